@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
+	grpclobby "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/password"
 	"github.com/joho/godotenv"
 )
 
@@ -16,6 +20,76 @@ type Config struct {
 	GinServerPort   string
 	DB_DSN          string
 	JWTSecret       string
+
+	// Argon2id parameters used to hash the passwords. The memory is expressed in KiB.
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+
+	// Deadlines of the lobby lifecycle, enforced by the server-side scheduler.
+	WaitingTimeout     time.Duration
+	ReadyCheckTimeout  time.Duration
+	GameDuration       time.Duration
+	ResultReportWindow time.Duration
+	// RematchWindow is how long the players of a finished game have to accept a rematch.
+	RematchWindow time.Duration
+	// MatchAcceptTimeout is how long the players of a match found by the matchmaker have to accept it.
+	MatchAcceptTimeout time.Duration
+
+	// A player that disconnects during the game has ReconnectGrace to come back, after which DisconnectPolicy is
+	// applied to the game. The watchdog looks for disconnections every WatchdogInterval.
+	ReconnectGrace   time.Duration
+	DisconnectPolicy grpclobby.DisconnectPolicy
+	WatchdogInterval time.Duration
+
+	// The players that dodge a ready check or a match, or abandon a game, wait Cooldown before they can create or
+	// join lobbies again. Every other infraction of the last InfractionDecay doubles it, up to MaxCooldown.
+	Cooldown        time.Duration
+	MaxCooldown     time.Duration
+	InfractionDecay time.Duration
+	// DeclineCooldown is the short cooldown of the players that decline a ready check or a match.
+	DeclineCooldown time.Duration
+
+	// QueueStatsWindow is how far back the queue statistics look at the waits of the matched players.
+	QueueStatsWindow time.Duration
+
+	// The reaper cancels the WAITING lobbies older than LobbyTTL, whose creator is idle for longer than
+	// CreatorIdleTimeout, or whose creator is disconnected for longer than CreatorDisconnectedTimeout.
+	LobbyTTL                   time.Duration
+	CreatorIdleTimeout         time.Duration
+	CreatorDisconnectedTimeout time.Duration
+	ReaperInterval             time.Duration
+
+	// MaxSpectators is how many users can watch a lobby at the same time.
+	MaxSpectators int
+	// KickBan is how long a player kicked by the host can not come back to the lobby.
+	KickBan time.Duration
+	// MaxPartySize is how many users a party can hold, its leader included.
+	MaxPartySize int
+
+	// A heartbeat keeps its user online for PresenceTimeout; the streams of presence changes check them every
+	// PresenceWatchInterval.
+	PresenceTimeout       time.Duration
+	PresenceWatchInterval time.Duration
+
+	// The chat of a lobby keeps its last ChatBacklog messages; a user can send at most ChatRateLimit messages
+	// within ChatRateWindow.
+	ChatBacklog    int
+	ChatRateLimit  int
+	ChatRateWindow time.Duration
+
+	// The players are also ranked within seasons of SeasonLength; the rotator checks every SeasonCheckInterval
+	// whether the current season ended.
+	SeasonLength        time.Duration
+	SeasonCheckInterval time.Duration
+}
+
+func getEnvSeconds(key string, defaultValue uint64) (time.Duration, error) {
+	seconds, err := getEnvUint(key, defaultValue, 32)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds) * time.Second, nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -25,6 +99,18 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvUint(key string, defaultValue uint64, bitSize int) (uint64, error) {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil || parsed == 0 {
+		return 0, fmt.Errorf("invalid %s: %s, must be a positive integer", key, value)
+	}
+	return parsed, nil
+}
+
 // LoadConfig checks for the existence of an .env file. If it doens't exist or if the variables are not sets,
 // it sets the Config struct with default values.
 //
@@ -55,6 +141,129 @@ func LoadConfig() (*Config, error) {
 	}
 	cfg.JWTSecret = jwtSecret
 
+	memory, err := getEnvUint("ARGON2_MEMORY", uint64(password.DefaultArgon2idParams.Memory), 32)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Argon2Memory = uint32(memory)
+
+	iterations, err := getEnvUint("ARGON2_ITERATIONS", uint64(password.DefaultArgon2idParams.Iterations), 32)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Argon2Iterations = uint32(iterations)
+
+	parallelism, err := getEnvUint("ARGON2_PARALLELISM", uint64(password.DefaultArgon2idParams.Parallelism), 8)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Argon2Parallelism = uint8(parallelism)
+
+	argon2Params := password.Argon2idParams{Memory: cfg.Argon2Memory, Iterations: cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism}
+	if err := argon2Params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.WaitingTimeout, err = getEnvSeconds("WAITING_TIMEOUT_SECONDS", 600); err != nil {
+		return nil, err
+	}
+	if cfg.ReadyCheckTimeout, err = getEnvSeconds("READY_CHECK_SECONDS", 15); err != nil {
+		return nil, err
+	}
+	if cfg.GameDuration, err = getEnvSeconds("GAME_DURATION_SECONDS", 10); err != nil {
+		return nil, err
+	}
+	if cfg.ResultReportWindow, err = getEnvSeconds("RESULT_REPORT_SECONDS", 10); err != nil {
+		return nil, err
+	}
+	if cfg.RematchWindow, err = getEnvSeconds("REMATCH_WINDOW_SECONDS", 30); err != nil {
+		return nil, err
+	}
+	if cfg.MatchAcceptTimeout, err = getEnvSeconds("MATCH_ACCEPT_SECONDS", 15); err != nil {
+		return nil, err
+	}
+	if cfg.KickBan, err = getEnvSeconds("KICK_BAN_SECONDS", 300); err != nil {
+		return nil, err
+	}
+	if cfg.ReconnectGrace, err = getEnvSeconds("RECONNECT_GRACE_SECONDS", 60); err != nil {
+		return nil, err
+	}
+	if cfg.DisconnectPolicy, err = grpclobby.ParseDisconnectPolicy(getEnv("DISCONNECT_POLICY", "FORFEIT")); err != nil {
+		return nil, err
+	}
+	if cfg.WatchdogInterval, err = getEnvSeconds("WATCHDOG_INTERVAL_SECONDS", 5); err != nil {
+		return nil, err
+	}
+	if cfg.Cooldown, err = getEnvSeconds("COOLDOWN_SECONDS", 60); err != nil {
+		return nil, err
+	}
+	if cfg.MaxCooldown, err = getEnvSeconds("MAX_COOLDOWN_SECONDS", 1800); err != nil {
+		return nil, err
+	}
+	if cfg.InfractionDecay, err = getEnvSeconds("INFRACTION_DECAY_SECONDS", 86400); err != nil {
+		return nil, err
+	}
+	if cfg.DeclineCooldown, err = getEnvSeconds("DECLINE_COOLDOWN_SECONDS", 30); err != nil {
+		return nil, err
+	}
+	if cfg.QueueStatsWindow, err = getEnvSeconds("QUEUE_STATS_WINDOW_SECONDS", 3600); err != nil {
+		return nil, err
+	}
+	if cfg.LobbyTTL, err = getEnvSeconds("LOBBY_TTL_SECONDS", 3600); err != nil {
+		return nil, err
+	}
+	if cfg.CreatorIdleTimeout, err = getEnvSeconds("CREATOR_IDLE_SECONDS", 900); err != nil {
+		return nil, err
+	}
+	if cfg.CreatorDisconnectedTimeout, err = getEnvSeconds("CREATOR_DISCONNECTED_SECONDS", 120); err != nil {
+		return nil, err
+	}
+	if cfg.ReaperInterval, err = getEnvSeconds("REAPER_INTERVAL_SECONDS", 60); err != nil {
+		return nil, err
+	}
+
+	maxSpectators, err := getEnvUint("MAX_SPECTATORS", 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MaxSpectators = int(maxSpectators)
+	maxPartySize, err := getEnvUint("MAX_PARTY_SIZE", 4, 16)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MaxPartySize = int(maxPartySize)
+
+	if cfg.PresenceTimeout, err = getEnvSeconds("PRESENCE_TIMEOUT_SECONDS", 90); err != nil {
+		return nil, err
+	}
+	if cfg.PresenceWatchInterval, err = getEnvSeconds("PRESENCE_WATCH_INTERVAL_SECONDS", 5); err != nil {
+		return nil, err
+	}
+
+	chatBacklog, err := getEnvUint("CHAT_BACKLOG_SIZE", 50, 16)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ChatBacklog = int(chatBacklog)
+	chatRateLimit, err := getEnvUint("CHAT_RATE_LIMIT", 5, 16)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ChatRateLimit = int(chatRateLimit)
+	if cfg.ChatRateWindow, err = getEnvSeconds("CHAT_RATE_WINDOW_SECONDS", 10); err != nil {
+		return nil, err
+	}
+
+	seasonDays, err := getEnvUint("SEASON_LENGTH_DAYS", 30, 16)
+	if err != nil {
+		return nil, err
+	}
+	cfg.SeasonLength = time.Duration(seasonDays) * 24 * time.Hour
+	if cfg.SeasonCheckInterval, err = getEnvSeconds("SEASON_CHECK_INTERVAL_SECONDS", 60); err != nil {
+		return nil, err
+	}
+
 	log.Printf("Configuration loaded for %s environment", cfg.GinMode)
 	return &cfg, nil
 }
@@ -4,48 +4,187 @@ import (
 	"fmt"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/auth"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/stats"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gamemode"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/activity"
 	grpcauth "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/auth"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	grpcchat "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/chat"
+	grpcfriend "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/friend"
+	grpcleaderboard "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/leaderboard"
 	grpclobby "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/lobby"
+	grpcparty "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/party"
+	grpcpresence "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/presence"
+	grpcstats "github.com/NicoPolazzi/multiplayer-queue/internal/grpc/stats"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/handlers"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/moderation"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/password"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/reaper"
+	chatrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/chat"
+	friendrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/friend"
+	infractionrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/infraction"
+	inviterepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/invite"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
 	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	partyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/party"
+	presencerepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/presence"
+	timerrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/timer"
 	usrRepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/routes"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/scheduler"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/season"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/watchdog"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
 // AppContainer holds all the dependencies useful for the application.
 type AppContainer struct {
-	RoutesManager *routes.RoutesManager
-	LobbyService  lobby.LobbyServiceServer
-	AuthService   auth.AuthServiceServer
+	RoutesManager      *routes.RoutesManager
+	LobbyService       lobby.LobbyServiceServer
+	AuthService        auth.AuthServiceServer
+	StatsService       stats.StatsServiceServer
+	LeaderboardService leaderboard.LeaderboardServiceServer
+	ChatService        chat.LobbyChatServiceServer
+	PartyService       party.PartyServiceServer
+	FriendService      friend.FriendServiceServer
+	PresenceService    presence.PresenceServiceServer
+	Scheduler          scheduler.Scheduler
+	Reaper             reaper.Reaper
+	Watchdog           watchdog.Watchdog
+	SeasonRotator      season.Rotator
+	// CallerInterceptor authenticates the callers of the gRPC services with the token forwarded by the gateway.
+	CallerInterceptor grpc.UnaryServerInterceptor
+	// CallerStreamInterceptor does the same for the streaming RPCs.
+	CallerStreamInterceptor grpc.StreamServerInterceptor
+	// ActivityInterceptor records when the callers of the gRPC services were last seen.
+	ActivityInterceptor grpc.UnaryServerInterceptor
 }
 
 // BuildContainer is responsible to inject all the dependencies needed by the application.
 func BuildContainer(db *gorm.DB, cfg *Config) *AppContainer {
 	userRepo := usrRepo.NewSQLUserRepository(db)
 	lobbyRepo := lobbyrepo.NewSQLLobbyRepository(db)
+	inviteRepo := inviterepo.NewSQLInviteRepository(db)
+	timerRepo := timerrepo.NewSQLTimerRepository(db)
+	leaderboardRepo := leaderboardrepo.NewSQLLeaderboardRepository(db)
+	chatRepo := chatrepo.NewSQLChatRepository(db)
+	partyRepo := partyrepo.NewSQLPartyRepository(db)
+	friendRepo := friendrepo.NewSQLFriendRepository(db)
+	presenceRepo := presencerepo.NewSQLPresenceRepository(db)
+	infractionRepo := infractionrepo.NewSQLInfractionRepository(db)
 
 	tokenManager := token.NewJWTTokenManager([]byte(cfg.JWTSecret))
 
+	argon2Params := password.DefaultArgon2idParams
+	argon2Params.Memory = cfg.Argon2Memory
+	argon2Params.Iterations = cfg.Argon2Iterations
+	argon2Params.Parallelism = cfg.Argon2Parallelism
+	// bcrypt is only kept to verify the passwords hashed before the switch to argon2id.
+	passwordHasher := password.NewPasswordHasher(
+		password.NewArgon2idHasher(argon2Params),
+		password.NewBcryptHasher(bcrypt.DefaultCost),
+	)
+
 	gatewayURL := fmt.Sprintf("http://%s:%s", cfg.Host, cfg.GRPCGatewayPort)
 	lobbyClient := gateway.NewLobbyGatewayClient(gatewayURL)
 	authClient := gateway.NewAuthGatewayClient(gatewayURL)
-	userHandler := handlers.NewUserHandler(authClient, lobbyClient)
+	statsClient := gateway.NewStatsGatewayClient(gatewayURL)
+	leaderboardClient := gateway.NewLeaderboardGatewayClient(gatewayURL)
+	chatClient := gateway.NewChatGatewayClient(gatewayURL)
+	partyClient := gateway.NewPartyGatewayClient(gatewayURL)
+	friendClient := gateway.NewFriendGatewayClient(gatewayURL)
+	presenceClient := gateway.NewPresenceGatewayClient(gatewayURL)
+	userHandler := handlers.NewUserHandler(authClient, lobbyClient, partyClient, friendClient, presenceClient)
 	lobbyHandler := handlers.NewLobbyHandler(lobbyClient)
+	statsHandler := handlers.NewStatsHandler(statsClient)
+	leaderboardHandler := handlers.NewLeaderboardHandler(leaderboardClient)
+	chatHandler := handlers.NewChatHandler(chatClient)
+	partyHandler := handlers.NewPartyHandler(partyClient)
+	friendHandler := handlers.NewFriendHandler(friendClient)
+	presenceHandler := handlers.NewPresenceHandler(presenceClient)
 	authMiddleware := middleware.NewAuthMiddleware(tokenManager)
 
-	routesManager := routes.NewRoutes(userHandler, lobbyHandler, authMiddleware)
+	routesManager := routes.NewRoutes(userHandler, lobbyHandler, statsHandler, leaderboardHandler, chatHandler,
+		partyHandler, friendHandler, presenceHandler, authMiddleware)
 
-	lobbyService := grpclobby.NewLobbyService(lobbyRepo, userRepo)
-	authService := grpcauth.NewAuthService(userRepo, tokenManager)
+	lobbyScheduler := scheduler.NewScheduler(timerRepo)
+	lobbyService := grpclobby.NewLobbyService(lobbyRepo, userRepo, inviteRepo, partyRepo, friendRepo, infractionRepo,
+		leaderboardRepo, passwordHasher, lobbyScheduler, gamemode.DefaultCatalog(), grpclobby.Config{
+			Timeouts: grpclobby.Timeouts{
+				Waiting:      cfg.WaitingTimeout,
+				ReadyCheck:   cfg.ReadyCheckTimeout,
+				Game:         cfg.GameDuration,
+				ResultReport: cfg.ResultReportWindow,
+				Rematch:      cfg.RematchWindow,
+				KickBan:      cfg.KickBan,
+				Reconnect:    cfg.ReconnectGrace,
+				MatchAccept:  cfg.MatchAcceptTimeout,
+			},
+			DisconnectPolicy: cfg.DisconnectPolicy,
+			Penalties: grpclobby.Penalties{
+				Cooldown:        cfg.Cooldown,
+				MaxCooldown:     cfg.MaxCooldown,
+				Decay:           cfg.InfractionDecay,
+				DeclineCooldown: cfg.DeclineCooldown,
+			},
+			QueueStatsWindow: cfg.QueueStatsWindow,
+			MaxSpectators:    cfg.MaxSpectators,
+		})
+	authService := grpcauth.NewAuthService(userRepo, tokenManager, passwordHasher)
+	statsService := grpcstats.NewStatsService(lobbyRepo, userRepo)
+	leaderboardService := grpcleaderboard.NewLeaderboardService(leaderboardRepo, userRepo)
+	chatService := grpcchat.NewChatService(chatRepo, lobbyRepo, userRepo,
+		moderation.NewWordListFilter(moderation.DefaultWordList), grpcchat.Config{
+			Backlog:    cfg.ChatBacklog,
+			RateLimit:  cfg.ChatRateLimit,
+			RateWindow: cfg.ChatRateWindow,
+		})
+	partyService := grpcparty.NewPartyService(partyRepo, userRepo, friendRepo, cfg.MaxPartySize)
+	friendService := grpcfriend.NewFriendService(friendRepo, userRepo, lobbyRepo, presenceRepo)
+	presenceService := grpcpresence.NewPresenceService(presenceRepo, userRepo, grpcpresence.Config{
+		Timeout:       cfg.PresenceTimeout,
+		WatchInterval: cfg.PresenceWatchInterval,
+	})
+	lobbyReaper := reaper.NewReaper(lobbyRepo, lobbyScheduler, reaper.Config{
+		TTL:                 cfg.LobbyTTL,
+		CreatorIdle:         cfg.CreatorIdleTimeout,
+		CreatorDisconnected: cfg.CreatorDisconnectedTimeout,
+		Interval:            cfg.ReaperInterval,
+	})
+	connectionWatchdog := watchdog.NewWatchdog(lobbyRepo, lobbyScheduler, watchdog.Config{Interval: cfg.WatchdogInterval})
+	seasonRotator := season.NewRotator(leaderboardRepo, season.Config{
+		Length:   cfg.SeasonLength,
+		Interval: cfg.SeasonCheckInterval,
+	})
 
 	return &AppContainer{
-		RoutesManager: routesManager,
-		LobbyService:  lobbyService,
-		AuthService:   authService,
+		RoutesManager:      routesManager,
+		LobbyService:       lobbyService,
+		AuthService:        authService,
+		StatsService:       statsService,
+		LeaderboardService: leaderboardService,
+		ChatService:        chatService,
+		PartyService:       partyService,
+		FriendService:      friendService,
+		PresenceService:    presenceService,
+		Scheduler:          lobbyScheduler,
+		Reaper:             lobbyReaper,
+		Watchdog:           connectionWatchdog,
+		SeasonRotator:      seasonRotator,
+
+		CallerInterceptor:       caller.UnaryServerInterceptor(tokenManager),
+		CallerStreamInterceptor: caller.StreamServerInterceptor(tokenManager),
+		ActivityInterceptor:     activity.UnaryServerInterceptor(userRepo),
 	}
 }
@@ -2,20 +2,39 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func NewDatabaseConnection(cfg *Config) (*gorm.DB, error) {
-	db, err := gorm.Open(sqlite.Open(cfg.DB_DSN), &gorm.Config{})
+	// Timestamps are stored in UTC, so that they compare correctly with the deadlines computed by the services.
+	db, err := gorm.Open(sqlite.Open(cfg.DB_DSN), &gorm.Config{NowFunc: func() time.Time { return time.Now().UTC() }})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	if err := db.AutoMigrate(&models.User{}, &models.Lobby{}); err != nil {
+	err = db.AutoMigrate(
+		&models.User{}, &models.Lobby{}, &models.LobbyPlayer{}, &models.LobbySpectator{}, &models.Invite{}, &models.LobbyTimer{},
+		&models.Season{}, &models.Standing{}, &models.ArchivedStanding{}, &models.ChatMessage{},
+		&models.Party{}, &models.PartyMember{}, &models.PartyInvite{}, &models.Friendship{}, &models.Block{},
+		&models.Presence{}, &models.Infraction{}, &models.PendingMatch{}, &models.MatchTicket{},
+	)
+	if err != nil {
 		return nil, fmt.Errorf("migration failed: %w", err)
 	}
+	if err := lobbyrepo.MigrateMemberships(db); err != nil {
+		return nil, fmt.Errorf("membership migration failed: %w", err)
+	}
+	if err := lobbyrepo.MigrateHosts(db); err != nil {
+		return nil, fmt.Errorf("host migration failed: %w", err)
+	}
+	if err := leaderboardrepo.BackfillStandings(db); err != nil {
+		return nil, fmt.Errorf("standings backfill failed: %w", err)
+	}
 	return db, nil
 }
@@ -13,7 +13,13 @@ import (
 	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/auth"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/stats"
 	"github.com/gin-gonic/gin"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
@@ -37,6 +43,14 @@ func main() {
 
 	container := BuildContainer(db, cfg)
 
+	// The handlers are registered by the services, so the persisted timers can be rearmed only now.
+	if err := container.Scheduler.Start(ctx); err != nil {
+		log.Fatalf("failed to start the lobby scheduler: %v", err)
+	}
+	container.Reaper.Start(ctx)
+	container.Watchdog.Start(ctx)
+	container.SeasonRotator.Start(ctx)
+
 	var wg sync.WaitGroup
 	errChan := make(chan error, 3)
 
@@ -89,9 +103,17 @@ func runGRPCServer(ctx context.Context, container *AppContainer, cfg *Config) er
 		return fmt.Errorf("failed to listen for gRPC on %s: %w", listenAddr, err)
 	}
 
-	s := grpc.NewServer()
+	// The caller is authenticated first, so that the activity is recorded for them.
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(container.CallerInterceptor, container.ActivityInterceptor),
+		grpc.ChainStreamInterceptor(container.CallerStreamInterceptor))
 	lobby.RegisterLobbyServiceServer(s, container.LobbyService)
 	auth.RegisterAuthServiceServer(s, container.AuthService)
+	stats.RegisterStatsServiceServer(s, container.StatsService)
+	leaderboard.RegisterLeaderboardServiceServer(s, container.LeaderboardService)
+	chat.RegisterLobbyChatServiceServer(s, container.ChatService)
+	party.RegisterPartyServiceServer(s, container.PartyService)
+	friend.RegisterFriendServiceServer(s, container.FriendService)
+	presence.RegisterPresenceServiceServer(s, container.PresenceService)
 
 	go func() {
 		<-ctx.Done()
@@ -114,6 +136,24 @@ func runGRPCGateway(ctx context.Context, cfg *Config) error {
 	if err := auth.RegisterAuthServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
 		return fmt.Errorf("failed to register Auth gRPC gateway: %w", err)
 	}
+	if err := stats.RegisterStatsServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return fmt.Errorf("failed to register Stats gRPC gateway: %w", err)
+	}
+	if err := leaderboard.RegisterLeaderboardServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return fmt.Errorf("failed to register Leaderboard gRPC gateway: %w", err)
+	}
+	if err := chat.RegisterLobbyChatServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return fmt.Errorf("failed to register Chat gRPC gateway: %w", err)
+	}
+	if err := party.RegisterPartyServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return fmt.Errorf("failed to register Party gRPC gateway: %w", err)
+	}
+	if err := friend.RegisterFriendServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return fmt.Errorf("failed to register Friend gRPC gateway: %w", err)
+	}
+	if err := presence.RegisterPresenceServiceHandlerFromEndpoint(ctx, mux, grpcEndpoint, opts); err != nil {
+		return fmt.Errorf("failed to register Presence gRPC gateway: %w", err)
+	}
 
 	listenAddr := fmt.Sprintf(":%s", cfg.GRPCGatewayPort)
 	srv := &http.Server{
@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v6.31.1
+// source: proto/chat.proto
+
+package chat
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// LobbyChatServiceClient is the client API for LobbyChatService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LobbyChatServiceClient interface {
+	// SendMessage posts a message to the chat of the lobby. The text goes through the content filter of the server,
+	// and every user can only send a limited number of messages in a row.
+	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*ChatMessage, error)
+	// StreamMessages sends the recent messages of the lobby, then every new message until the client goes away.
+	StreamMessages(ctx context.Context, in *StreamMessagesRequest, opts ...grpc.CallOption) (LobbyChatService_StreamMessagesClient, error)
+}
+
+type lobbyChatServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLobbyChatServiceClient(cc grpc.ClientConnInterface) LobbyChatServiceClient {
+	return &lobbyChatServiceClient{cc}
+}
+
+func (c *lobbyChatServiceClient) SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*ChatMessage, error) {
+	out := new(ChatMessage)
+	err := c.cc.Invoke(ctx, "/chat.LobbyChatService/SendMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyChatServiceClient) StreamMessages(ctx context.Context, in *StreamMessagesRequest, opts ...grpc.CallOption) (LobbyChatService_StreamMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LobbyChatService_ServiceDesc.Streams[0], "/chat.LobbyChatService/StreamMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &lobbyChatServiceStreamMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LobbyChatService_StreamMessagesClient interface {
+	Recv() (*ChatMessage, error)
+	grpc.ClientStream
+}
+
+type lobbyChatServiceStreamMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *lobbyChatServiceStreamMessagesClient) Recv() (*ChatMessage, error) {
+	m := new(ChatMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LobbyChatServiceServer is the server API for LobbyChatService service.
+// All implementations must embed UnimplementedLobbyChatServiceServer
+// for forward compatibility
+type LobbyChatServiceServer interface {
+	// SendMessage posts a message to the chat of the lobby. The text goes through the content filter of the server,
+	// and every user can only send a limited number of messages in a row.
+	SendMessage(context.Context, *SendMessageRequest) (*ChatMessage, error)
+	// StreamMessages sends the recent messages of the lobby, then every new message until the client goes away.
+	StreamMessages(*StreamMessagesRequest, LobbyChatService_StreamMessagesServer) error
+	mustEmbedUnimplementedLobbyChatServiceServer()
+}
+
+// UnimplementedLobbyChatServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedLobbyChatServiceServer struct {
+}
+
+func (UnimplementedLobbyChatServiceServer) SendMessage(context.Context, *SendMessageRequest) (*ChatMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
+}
+func (UnimplementedLobbyChatServiceServer) StreamMessages(*StreamMessagesRequest, LobbyChatService_StreamMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMessages not implemented")
+}
+func (UnimplementedLobbyChatServiceServer) mustEmbedUnimplementedLobbyChatServiceServer() {}
+
+// UnsafeLobbyChatServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LobbyChatServiceServer will
+// result in compilation errors.
+type UnsafeLobbyChatServiceServer interface {
+	mustEmbedUnimplementedLobbyChatServiceServer()
+}
+
+func RegisterLobbyChatServiceServer(s grpc.ServiceRegistrar, srv LobbyChatServiceServer) {
+	s.RegisterService(&LobbyChatService_ServiceDesc, srv)
+}
+
+func _LobbyChatService_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyChatServiceServer).SendMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/chat.LobbyChatService/SendMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyChatServiceServer).SendMessage(ctx, req.(*SendMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyChatService_StreamMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LobbyChatServiceServer).StreamMessages(m, &lobbyChatServiceStreamMessagesServer{stream})
+}
+
+type LobbyChatService_StreamMessagesServer interface {
+	Send(*ChatMessage) error
+	grpc.ServerStream
+}
+
+type lobbyChatServiceStreamMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *lobbyChatServiceStreamMessagesServer) Send(m *ChatMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// LobbyChatService_ServiceDesc is the grpc.ServiceDesc for LobbyChatService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LobbyChatService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chat.LobbyChatService",
+	HandlerType: (*LobbyChatServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendMessage",
+			Handler:    _LobbyChatService_SendMessage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMessages",
+			Handler:       _LobbyChatService_StreamMessages_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/chat.proto",
+}
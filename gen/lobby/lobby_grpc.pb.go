@@ -24,9 +24,72 @@ const _ = grpc.SupportPackageIsVersion7
 type LobbyServiceClient interface {
 	CreateLobby(ctx context.Context, in *CreateLobbyRequest, opts ...grpc.CallOption) (*Lobby, error)
 	GetLobby(ctx context.Context, in *GetLobbyRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// GetMyCurrentLobby returns the active lobby the user is in, if any.
+	GetMyCurrentLobby(ctx context.Context, in *GetMyCurrentLobbyRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// GetMyCooldown tells whether the user left, dodged or declined games too recently to create or join lobbies.
+	GetMyCooldown(ctx context.Context, in *GetMyCooldownRequest, opts ...grpc.CallOption) (*GetMyCooldownResponse, error)
 	JoinLobby(ctx context.Context, in *JoinLobbyRequest, opts ...grpc.CallOption) (*Lobby, error)
+	JoinLobbyByCode(ctx context.Context, in *JoinLobbyByCodeRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// SpectateLobby lets the user watch an active lobby without taking a seat. Spectators do not count toward the
+	// capacity of the lobby and can not report the result of the game.
+	SpectateLobby(ctx context.Context, in *SpectateLobbyRequest, opts ...grpc.CallOption) (*Lobby, error)
+	SetReady(ctx context.Context, in *SetReadyRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// DeclineReadyCheck takes the caller out of the lobby during its ready check. The other players go back to
+	// waiting in the lobby, keeping their seats, while the caller is put on a short cooldown that does not escalate
+	// like the one of the players that let the ready check expire.
+	DeclineReadyCheck(ctx context.Context, in *DeclineReadyCheckRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// LeaveLobby takes the caller out of their lobby. The host role passes to another player, and a lobby left empty
+	// is closed. Leaving during the ready check or the game puts the caller on a cooldown, and a game left counts as
+	// abandoned by the caller.
+	LeaveLobby(ctx context.Context, in *LeaveLobbyRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// SwitchTeam moves the user to another team of their lobby, while the lobby is WAITING.
+	SwitchTeam(ctx context.Context, in *SwitchTeamRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// SwapSeat moves the user to another seat of their lobby, while the lobby is WAITING. The player sitting there,
+	// if any, takes the seat and the team of the user.
+	SwapSeat(ctx context.Context, in *SwapSeatRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// FinishGame reports the result of the game. Only the players of the lobby can report it.
 	FinishGame(ctx context.Context, in *FinishGameRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// RequestRematch opens a vote for a rematch of the FINISHED game, accepted by the caller. The other players have
+	// until the REMATCH deadline to accept it.
+	RequestRematch(ctx context.Context, in *RequestRematchRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// RespondRematch accepts or declines the rematch vote. The last acceptance creates the rematch lobby, with the
+	// same settings and players, and a decline ends the vote.
+	RespondRematch(ctx context.Context, in *RespondRematchRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// ListAvailableLobbies pages through the public lobbies waiting for players that match the filters.
+	// KickPlayer removes a player from the WAITING lobby, and bans them from joining it again for a while. Only the
+	// host of the lobby can kick.
+	KickPlayer(ctx context.Context, in *KickPlayerRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// TransferHost hands over the host role to another player of the lobby. Like the other host actions, it is
+	// authorised against the authenticated caller.
+	TransferHost(ctx context.Context, in *TransferHostRequest, opts ...grpc.CallOption) (*Lobby, error)
+	// SetLobbyLocked closes the lobby to new players and spectators, or opens it again.
+	SetLobbyLocked(ctx context.Context, in *SetLobbyLockedRequest, opts ...grpc.CallOption) (*Lobby, error)
+	RenameLobby(ctx context.Context, in *RenameLobbyRequest, opts ...grpc.CallOption) (*Lobby, error)
 	ListAvailableLobbies(ctx context.Context, in *ListAvailableLobbiesRequest, opts ...grpc.CallOption) (*ListAvailableLobbiesResponse, error)
+	// GetQueueStats tells how many players are waiting for their lobby to fill up, where, and how long the players of
+	// the game mode and region of the request waited for it recently. An empty game mode or region means any.
+	GetQueueStats(ctx context.Context, in *GetQueueStatsRequest, opts ...grpc.CallOption) (*GetQueueStatsResponse, error)
+	// ListGameModes returns the game modes, with their settings, and the regions the lobbies can be created with.
+	ListGameModes(ctx context.Context, in *ListGameModesRequest, opts ...grpc.CallOption) (*ListGameModesResponse, error)
+	// ListMyMatches pages through the finished games of the user, the most recent first.
+	ListMyMatches(ctx context.Context, in *ListMyMatchesRequest, opts ...grpc.CallOption) (*ListMyMatchesResponse, error)
+	InviteToLobby(ctx context.Context, in *InviteToLobbyRequest, opts ...grpc.CallOption) (*Invite, error)
+	ListMyInvites(ctx context.Context, in *ListMyInvitesRequest, opts ...grpc.CallOption) (*ListMyInvitesResponse, error)
+	AcceptInvite(ctx context.Context, in *RespondInviteRequest, opts ...grpc.CallOption) (*Lobby, error)
+	DeclineInvite(ctx context.Context, in *RespondInviteRequest, opts ...grpc.CallOption) (*Invite, error)
+	// SearchMatch puts the caller in the matchmaking queue of a game mode and region. The matchmaker pairs the
+	// players that have been searching the longest into a pending match, which every one of them has to accept
+	// before its lobby is created.
+	SearchMatch(ctx context.Context, in *SearchMatchRequest, opts ...grpc.CallOption) (*MatchSearch, error)
+	// GetMySearch returns the search of the caller, if they are searching for a match.
+	GetMySearch(ctx context.Context, in *GetMySearchRequest, opts ...grpc.CallOption) (*MatchSearch, error)
+	// CancelSearch takes the caller out of the matchmaking queue. Once a match is found, it has to be declined instead.
+	CancelSearch(ctx context.Context, in *CancelSearchRequest, opts ...grpc.CallOption) (*CancelSearchResponse, error)
+	// AcceptMatch accepts the pending match of the caller. The last acceptance creates the lobby and starts the game.
+	AcceptMatch(ctx context.Context, in *RespondMatchRequest, opts ...grpc.CallOption) (*MatchSearch, error)
+	// DeclineMatch calls off the pending match of the caller, who leaves the queue and is put on the short decline
+	// cooldown. The other players go back to searching, ahead of the players that started searching after them.
+	DeclineMatch(ctx context.Context, in *RespondMatchRequest, opts ...grpc.CallOption) (*DeclineMatchResponse, error)
 }
 
 type lobbyServiceClient struct {
@@ -55,6 +118,24 @@ func (c *lobbyServiceClient) GetLobby(ctx context.Context, in *GetLobbyRequest,
 	return out, nil
 }
 
+func (c *lobbyServiceClient) GetMyCurrentLobby(ctx context.Context, in *GetMyCurrentLobbyRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/GetMyCurrentLobby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) GetMyCooldown(ctx context.Context, in *GetMyCooldownRequest, opts ...grpc.CallOption) (*GetMyCooldownResponse, error) {
+	out := new(GetMyCooldownResponse)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/GetMyCooldown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lobbyServiceClient) JoinLobby(ctx context.Context, in *JoinLobbyRequest, opts ...grpc.CallOption) (*Lobby, error) {
 	out := new(Lobby)
 	err := c.cc.Invoke(ctx, "/lobby.LobbyService/JoinLobby", in, out, opts...)
@@ -64,6 +145,69 @@ func (c *lobbyServiceClient) JoinLobby(ctx context.Context, in *JoinLobbyRequest
 	return out, nil
 }
 
+func (c *lobbyServiceClient) JoinLobbyByCode(ctx context.Context, in *JoinLobbyByCodeRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/JoinLobbyByCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) SpectateLobby(ctx context.Context, in *SpectateLobbyRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/SpectateLobby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) SetReady(ctx context.Context, in *SetReadyRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/SetReady", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) DeclineReadyCheck(ctx context.Context, in *DeclineReadyCheckRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/DeclineReadyCheck", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) LeaveLobby(ctx context.Context, in *LeaveLobbyRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/LeaveLobby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) SwitchTeam(ctx context.Context, in *SwitchTeamRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/SwitchTeam", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) SwapSeat(ctx context.Context, in *SwapSeatRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/SwapSeat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lobbyServiceClient) FinishGame(ctx context.Context, in *FinishGameRequest, opts ...grpc.CallOption) (*Lobby, error) {
 	out := new(Lobby)
 	err := c.cc.Invoke(ctx, "/lobby.LobbyService/FinishGame", in, out, opts...)
@@ -73,6 +217,60 @@ func (c *lobbyServiceClient) FinishGame(ctx context.Context, in *FinishGameReque
 	return out, nil
 }
 
+func (c *lobbyServiceClient) RequestRematch(ctx context.Context, in *RequestRematchRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/RequestRematch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) RespondRematch(ctx context.Context, in *RespondRematchRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/RespondRematch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) KickPlayer(ctx context.Context, in *KickPlayerRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/KickPlayer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) TransferHost(ctx context.Context, in *TransferHostRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/TransferHost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) SetLobbyLocked(ctx context.Context, in *SetLobbyLockedRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/SetLobbyLocked", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) RenameLobby(ctx context.Context, in *RenameLobbyRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/RenameLobby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *lobbyServiceClient) ListAvailableLobbies(ctx context.Context, in *ListAvailableLobbiesRequest, opts ...grpc.CallOption) (*ListAvailableLobbiesResponse, error) {
 	out := new(ListAvailableLobbiesResponse)
 	err := c.cc.Invoke(ctx, "/lobby.LobbyService/ListAvailableLobbies", in, out, opts...)
@@ -82,15 +280,186 @@ func (c *lobbyServiceClient) ListAvailableLobbies(ctx context.Context, in *ListA
 	return out, nil
 }
 
+func (c *lobbyServiceClient) GetQueueStats(ctx context.Context, in *GetQueueStatsRequest, opts ...grpc.CallOption) (*GetQueueStatsResponse, error) {
+	out := new(GetQueueStatsResponse)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/GetQueueStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) ListGameModes(ctx context.Context, in *ListGameModesRequest, opts ...grpc.CallOption) (*ListGameModesResponse, error) {
+	out := new(ListGameModesResponse)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/ListGameModes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) ListMyMatches(ctx context.Context, in *ListMyMatchesRequest, opts ...grpc.CallOption) (*ListMyMatchesResponse, error) {
+	out := new(ListMyMatchesResponse)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/ListMyMatches", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) InviteToLobby(ctx context.Context, in *InviteToLobbyRequest, opts ...grpc.CallOption) (*Invite, error) {
+	out := new(Invite)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/InviteToLobby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) ListMyInvites(ctx context.Context, in *ListMyInvitesRequest, opts ...grpc.CallOption) (*ListMyInvitesResponse, error) {
+	out := new(ListMyInvitesResponse)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/ListMyInvites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) AcceptInvite(ctx context.Context, in *RespondInviteRequest, opts ...grpc.CallOption) (*Lobby, error) {
+	out := new(Lobby)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/AcceptInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) DeclineInvite(ctx context.Context, in *RespondInviteRequest, opts ...grpc.CallOption) (*Invite, error) {
+	out := new(Invite)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/DeclineInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) SearchMatch(ctx context.Context, in *SearchMatchRequest, opts ...grpc.CallOption) (*MatchSearch, error) {
+	out := new(MatchSearch)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/SearchMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) GetMySearch(ctx context.Context, in *GetMySearchRequest, opts ...grpc.CallOption) (*MatchSearch, error) {
+	out := new(MatchSearch)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/GetMySearch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) CancelSearch(ctx context.Context, in *CancelSearchRequest, opts ...grpc.CallOption) (*CancelSearchResponse, error) {
+	out := new(CancelSearchResponse)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/CancelSearch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) AcceptMatch(ctx context.Context, in *RespondMatchRequest, opts ...grpc.CallOption) (*MatchSearch, error) {
+	out := new(MatchSearch)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/AcceptMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *lobbyServiceClient) DeclineMatch(ctx context.Context, in *RespondMatchRequest, opts ...grpc.CallOption) (*DeclineMatchResponse, error) {
+	out := new(DeclineMatchResponse)
+	err := c.cc.Invoke(ctx, "/lobby.LobbyService/DeclineMatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LobbyServiceServer is the server API for LobbyService service.
 // All implementations must embed UnimplementedLobbyServiceServer
 // for forward compatibility
 type LobbyServiceServer interface {
 	CreateLobby(context.Context, *CreateLobbyRequest) (*Lobby, error)
 	GetLobby(context.Context, *GetLobbyRequest) (*Lobby, error)
+	// GetMyCurrentLobby returns the active lobby the user is in, if any.
+	GetMyCurrentLobby(context.Context, *GetMyCurrentLobbyRequest) (*Lobby, error)
+	// GetMyCooldown tells whether the user left, dodged or declined games too recently to create or join lobbies.
+	GetMyCooldown(context.Context, *GetMyCooldownRequest) (*GetMyCooldownResponse, error)
 	JoinLobby(context.Context, *JoinLobbyRequest) (*Lobby, error)
+	JoinLobbyByCode(context.Context, *JoinLobbyByCodeRequest) (*Lobby, error)
+	// SpectateLobby lets the user watch an active lobby without taking a seat. Spectators do not count toward the
+	// capacity of the lobby and can not report the result of the game.
+	SpectateLobby(context.Context, *SpectateLobbyRequest) (*Lobby, error)
+	SetReady(context.Context, *SetReadyRequest) (*Lobby, error)
+	// DeclineReadyCheck takes the caller out of the lobby during its ready check. The other players go back to
+	// waiting in the lobby, keeping their seats, while the caller is put on a short cooldown that does not escalate
+	// like the one of the players that let the ready check expire.
+	DeclineReadyCheck(context.Context, *DeclineReadyCheckRequest) (*Lobby, error)
+	// LeaveLobby takes the caller out of their lobby. The host role passes to another player, and a lobby left empty
+	// is closed. Leaving during the ready check or the game puts the caller on a cooldown, and a game left counts as
+	// abandoned by the caller.
+	LeaveLobby(context.Context, *LeaveLobbyRequest) (*Lobby, error)
+	// SwitchTeam moves the user to another team of their lobby, while the lobby is WAITING.
+	SwitchTeam(context.Context, *SwitchTeamRequest) (*Lobby, error)
+	// SwapSeat moves the user to another seat of their lobby, while the lobby is WAITING. The player sitting there,
+	// if any, takes the seat and the team of the user.
+	SwapSeat(context.Context, *SwapSeatRequest) (*Lobby, error)
+	// FinishGame reports the result of the game. Only the players of the lobby can report it.
 	FinishGame(context.Context, *FinishGameRequest) (*Lobby, error)
+	// RequestRematch opens a vote for a rematch of the FINISHED game, accepted by the caller. The other players have
+	// until the REMATCH deadline to accept it.
+	RequestRematch(context.Context, *RequestRematchRequest) (*Lobby, error)
+	// RespondRematch accepts or declines the rematch vote. The last acceptance creates the rematch lobby, with the
+	// same settings and players, and a decline ends the vote.
+	RespondRematch(context.Context, *RespondRematchRequest) (*Lobby, error)
+	// ListAvailableLobbies pages through the public lobbies waiting for players that match the filters.
+	// KickPlayer removes a player from the WAITING lobby, and bans them from joining it again for a while. Only the
+	// host of the lobby can kick.
+	KickPlayer(context.Context, *KickPlayerRequest) (*Lobby, error)
+	// TransferHost hands over the host role to another player of the lobby. Like the other host actions, it is
+	// authorised against the authenticated caller.
+	TransferHost(context.Context, *TransferHostRequest) (*Lobby, error)
+	// SetLobbyLocked closes the lobby to new players and spectators, or opens it again.
+	SetLobbyLocked(context.Context, *SetLobbyLockedRequest) (*Lobby, error)
+	RenameLobby(context.Context, *RenameLobbyRequest) (*Lobby, error)
 	ListAvailableLobbies(context.Context, *ListAvailableLobbiesRequest) (*ListAvailableLobbiesResponse, error)
+	// GetQueueStats tells how many players are waiting for their lobby to fill up, where, and how long the players of
+	// the game mode and region of the request waited for it recently. An empty game mode or region means any.
+	GetQueueStats(context.Context, *GetQueueStatsRequest) (*GetQueueStatsResponse, error)
+	// ListGameModes returns the game modes, with their settings, and the regions the lobbies can be created with.
+	ListGameModes(context.Context, *ListGameModesRequest) (*ListGameModesResponse, error)
+	// ListMyMatches pages through the finished games of the user, the most recent first.
+	ListMyMatches(context.Context, *ListMyMatchesRequest) (*ListMyMatchesResponse, error)
+	InviteToLobby(context.Context, *InviteToLobbyRequest) (*Invite, error)
+	ListMyInvites(context.Context, *ListMyInvitesRequest) (*ListMyInvitesResponse, error)
+	AcceptInvite(context.Context, *RespondInviteRequest) (*Lobby, error)
+	DeclineInvite(context.Context, *RespondInviteRequest) (*Invite, error)
+	// SearchMatch puts the caller in the matchmaking queue of a game mode and region. The matchmaker pairs the
+	// players that have been searching the longest into a pending match, which every one of them has to accept
+	// before its lobby is created.
+	SearchMatch(context.Context, *SearchMatchRequest) (*MatchSearch, error)
+	// GetMySearch returns the search of the caller, if they are searching for a match.
+	GetMySearch(context.Context, *GetMySearchRequest) (*MatchSearch, error)
+	// CancelSearch takes the caller out of the matchmaking queue. Once a match is found, it has to be declined instead.
+	CancelSearch(context.Context, *CancelSearchRequest) (*CancelSearchResponse, error)
+	// AcceptMatch accepts the pending match of the caller. The last acceptance creates the lobby and starts the game.
+	AcceptMatch(context.Context, *RespondMatchRequest) (*MatchSearch, error)
+	// DeclineMatch calls off the pending match of the caller, who leaves the queue and is put on the short decline
+	// cooldown. The other players go back to searching, ahead of the players that started searching after them.
+	DeclineMatch(context.Context, *RespondMatchRequest) (*DeclineMatchResponse, error)
 	mustEmbedUnimplementedLobbyServiceServer()
 }
 
@@ -104,15 +473,96 @@ func (UnimplementedLobbyServiceServer) CreateLobby(context.Context, *CreateLobby
 func (UnimplementedLobbyServiceServer) GetLobby(context.Context, *GetLobbyRequest) (*Lobby, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLobby not implemented")
 }
+func (UnimplementedLobbyServiceServer) GetMyCurrentLobby(context.Context, *GetMyCurrentLobbyRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyCurrentLobby not implemented")
+}
+func (UnimplementedLobbyServiceServer) GetMyCooldown(context.Context, *GetMyCooldownRequest) (*GetMyCooldownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyCooldown not implemented")
+}
 func (UnimplementedLobbyServiceServer) JoinLobby(context.Context, *JoinLobbyRequest) (*Lobby, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JoinLobby not implemented")
 }
+func (UnimplementedLobbyServiceServer) JoinLobbyByCode(context.Context, *JoinLobbyByCodeRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinLobbyByCode not implemented")
+}
+func (UnimplementedLobbyServiceServer) SpectateLobby(context.Context, *SpectateLobbyRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SpectateLobby not implemented")
+}
+func (UnimplementedLobbyServiceServer) SetReady(context.Context, *SetReadyRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReady not implemented")
+}
+func (UnimplementedLobbyServiceServer) DeclineReadyCheck(context.Context, *DeclineReadyCheckRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineReadyCheck not implemented")
+}
+func (UnimplementedLobbyServiceServer) LeaveLobby(context.Context, *LeaveLobbyRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveLobby not implemented")
+}
+func (UnimplementedLobbyServiceServer) SwitchTeam(context.Context, *SwitchTeamRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwitchTeam not implemented")
+}
+func (UnimplementedLobbyServiceServer) SwapSeat(context.Context, *SwapSeatRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SwapSeat not implemented")
+}
 func (UnimplementedLobbyServiceServer) FinishGame(context.Context, *FinishGameRequest) (*Lobby, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FinishGame not implemented")
 }
+func (UnimplementedLobbyServiceServer) RequestRematch(context.Context, *RequestRematchRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestRematch not implemented")
+}
+func (UnimplementedLobbyServiceServer) RespondRematch(context.Context, *RespondRematchRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondRematch not implemented")
+}
+func (UnimplementedLobbyServiceServer) KickPlayer(context.Context, *KickPlayerRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KickPlayer not implemented")
+}
+func (UnimplementedLobbyServiceServer) TransferHost(context.Context, *TransferHostRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferHost not implemented")
+}
+func (UnimplementedLobbyServiceServer) SetLobbyLocked(context.Context, *SetLobbyLockedRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLobbyLocked not implemented")
+}
+func (UnimplementedLobbyServiceServer) RenameLobby(context.Context, *RenameLobbyRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameLobby not implemented")
+}
 func (UnimplementedLobbyServiceServer) ListAvailableLobbies(context.Context, *ListAvailableLobbiesRequest) (*ListAvailableLobbiesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAvailableLobbies not implemented")
 }
+func (UnimplementedLobbyServiceServer) GetQueueStats(context.Context, *GetQueueStatsRequest) (*GetQueueStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueueStats not implemented")
+}
+func (UnimplementedLobbyServiceServer) ListGameModes(context.Context, *ListGameModesRequest) (*ListGameModesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGameModes not implemented")
+}
+func (UnimplementedLobbyServiceServer) ListMyMatches(context.Context, *ListMyMatchesRequest) (*ListMyMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyMatches not implemented")
+}
+func (UnimplementedLobbyServiceServer) InviteToLobby(context.Context, *InviteToLobbyRequest) (*Invite, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteToLobby not implemented")
+}
+func (UnimplementedLobbyServiceServer) ListMyInvites(context.Context, *ListMyInvitesRequest) (*ListMyInvitesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyInvites not implemented")
+}
+func (UnimplementedLobbyServiceServer) AcceptInvite(context.Context, *RespondInviteRequest) (*Lobby, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptInvite not implemented")
+}
+func (UnimplementedLobbyServiceServer) DeclineInvite(context.Context, *RespondInviteRequest) (*Invite, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineInvite not implemented")
+}
+func (UnimplementedLobbyServiceServer) SearchMatch(context.Context, *SearchMatchRequest) (*MatchSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchMatch not implemented")
+}
+func (UnimplementedLobbyServiceServer) GetMySearch(context.Context, *GetMySearchRequest) (*MatchSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMySearch not implemented")
+}
+func (UnimplementedLobbyServiceServer) CancelSearch(context.Context, *CancelSearchRequest) (*CancelSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSearch not implemented")
+}
+func (UnimplementedLobbyServiceServer) AcceptMatch(context.Context, *RespondMatchRequest) (*MatchSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptMatch not implemented")
+}
+func (UnimplementedLobbyServiceServer) DeclineMatch(context.Context, *RespondMatchRequest) (*DeclineMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineMatch not implemented")
+}
 func (UnimplementedLobbyServiceServer) mustEmbedUnimplementedLobbyServiceServer() {}
 
 // UnsafeLobbyServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -162,6 +612,42 @@ func _LobbyService_GetLobby_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LobbyService_GetMyCurrentLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyCurrentLobbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).GetMyCurrentLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/GetMyCurrentLobby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).GetMyCurrentLobby(ctx, req.(*GetMyCurrentLobbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_GetMyCooldown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyCooldownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).GetMyCooldown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/GetMyCooldown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).GetMyCooldown(ctx, req.(*GetMyCooldownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LobbyService_JoinLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(JoinLobbyRequest)
 	if err := dec(in); err != nil {
@@ -180,69 +666,627 @@ func _LobbyService_JoinLobby_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LobbyService_FinishGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FinishGameRequest)
+func _LobbyService_JoinLobbyByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinLobbyByCodeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LobbyServiceServer).FinishGame(ctx, in)
+		return srv.(LobbyServiceServer).JoinLobbyByCode(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lobby.LobbyService/FinishGame",
+		FullMethod: "/lobby.LobbyService/JoinLobbyByCode",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LobbyServiceServer).FinishGame(ctx, req.(*FinishGameRequest))
+		return srv.(LobbyServiceServer).JoinLobbyByCode(ctx, req.(*JoinLobbyByCodeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _LobbyService_ListAvailableLobbies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListAvailableLobbiesRequest)
+func _LobbyService_SpectateLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpectateLobbyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(LobbyServiceServer).ListAvailableLobbies(ctx, in)
+		return srv.(LobbyServiceServer).SpectateLobby(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/lobby.LobbyService/ListAvailableLobbies",
+		FullMethod: "/lobby.LobbyService/SpectateLobby",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(LobbyServiceServer).ListAvailableLobbies(ctx, req.(*ListAvailableLobbiesRequest))
+		return srv.(LobbyServiceServer).SpectateLobby(ctx, req.(*SpectateLobbyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// LobbyService_ServiceDesc is the grpc.ServiceDesc for LobbyService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var LobbyService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "lobby.LobbyService",
-	HandlerType: (*LobbyServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "CreateLobby",
-			Handler:    _LobbyService_CreateLobby_Handler,
-		},
-		{
-			MethodName: "GetLobby",
-			Handler:    _LobbyService_GetLobby_Handler,
-		},
-		{
+func _LobbyService_SetReady_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReadyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).SetReady(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/SetReady",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).SetReady(ctx, req.(*SetReadyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_DeclineReadyCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclineReadyCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).DeclineReadyCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/DeclineReadyCheck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).DeclineReadyCheck(ctx, req.(*DeclineReadyCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_LeaveLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveLobbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).LeaveLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/LeaveLobby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).LeaveLobby(ctx, req.(*LeaveLobbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_SwitchTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).SwitchTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/SwitchTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).SwitchTeam(ctx, req.(*SwitchTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_SwapSeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapSeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).SwapSeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/SwapSeat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).SwapSeat(ctx, req.(*SwapSeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_FinishGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).FinishGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/FinishGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).FinishGame(ctx, req.(*FinishGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_RequestRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRematchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).RequestRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/RequestRematch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).RequestRematch(ctx, req.(*RequestRematchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_RespondRematch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondRematchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).RespondRematch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/RespondRematch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).RespondRematch(ctx, req.(*RespondRematchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_KickPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickPlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).KickPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/KickPlayer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).KickPlayer(ctx, req.(*KickPlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_TransferHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).TransferHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/TransferHost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).TransferHost(ctx, req.(*TransferHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_SetLobbyLocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLobbyLockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).SetLobbyLocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/SetLobbyLocked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).SetLobbyLocked(ctx, req.(*SetLobbyLockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_RenameLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameLobbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).RenameLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/RenameLobby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).RenameLobby(ctx, req.(*RenameLobbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_ListAvailableLobbies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAvailableLobbiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).ListAvailableLobbies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/ListAvailableLobbies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).ListAvailableLobbies(ctx, req.(*ListAvailableLobbiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_GetQueueStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueueStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).GetQueueStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/GetQueueStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).GetQueueStats(ctx, req.(*GetQueueStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_ListGameModes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGameModesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).ListGameModes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/ListGameModes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).ListGameModes(ctx, req.(*ListGameModesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_ListMyMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).ListMyMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/ListMyMatches",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).ListMyMatches(ctx, req.(*ListMyMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_InviteToLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteToLobbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).InviteToLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/InviteToLobby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).InviteToLobby(ctx, req.(*InviteToLobbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_ListMyInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).ListMyInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/ListMyInvites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).ListMyInvites(ctx, req.(*ListMyInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_AcceptInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).AcceptInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/AcceptInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).AcceptInvite(ctx, req.(*RespondInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_DeclineInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).DeclineInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/DeclineInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).DeclineInvite(ctx, req.(*RespondInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_SearchMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).SearchMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/SearchMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).SearchMatch(ctx, req.(*SearchMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_GetMySearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMySearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).GetMySearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/GetMySearch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).GetMySearch(ctx, req.(*GetMySearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_CancelSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).CancelSearch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/CancelSearch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).CancelSearch(ctx, req.(*CancelSearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_AcceptMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).AcceptMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/AcceptMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).AcceptMatch(ctx, req.(*RespondMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LobbyService_DeclineMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LobbyServiceServer).DeclineMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/lobby.LobbyService/DeclineMatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LobbyServiceServer).DeclineMatch(ctx, req.(*RespondMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// LobbyService_ServiceDesc is the grpc.ServiceDesc for LobbyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var LobbyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lobby.LobbyService",
+	HandlerType: (*LobbyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateLobby",
+			Handler:    _LobbyService_CreateLobby_Handler,
+		},
+		{
+			MethodName: "GetLobby",
+			Handler:    _LobbyService_GetLobby_Handler,
+		},
+		{
+			MethodName: "GetMyCurrentLobby",
+			Handler:    _LobbyService_GetMyCurrentLobby_Handler,
+		},
+		{
+			MethodName: "GetMyCooldown",
+			Handler:    _LobbyService_GetMyCooldown_Handler,
+		},
+		{
 			MethodName: "JoinLobby",
 			Handler:    _LobbyService_JoinLobby_Handler,
 		},
+		{
+			MethodName: "JoinLobbyByCode",
+			Handler:    _LobbyService_JoinLobbyByCode_Handler,
+		},
+		{
+			MethodName: "SpectateLobby",
+			Handler:    _LobbyService_SpectateLobby_Handler,
+		},
+		{
+			MethodName: "SetReady",
+			Handler:    _LobbyService_SetReady_Handler,
+		},
+		{
+			MethodName: "DeclineReadyCheck",
+			Handler:    _LobbyService_DeclineReadyCheck_Handler,
+		},
+		{
+			MethodName: "LeaveLobby",
+			Handler:    _LobbyService_LeaveLobby_Handler,
+		},
+		{
+			MethodName: "SwitchTeam",
+			Handler:    _LobbyService_SwitchTeam_Handler,
+		},
+		{
+			MethodName: "SwapSeat",
+			Handler:    _LobbyService_SwapSeat_Handler,
+		},
 		{
 			MethodName: "FinishGame",
 			Handler:    _LobbyService_FinishGame_Handler,
 		},
+		{
+			MethodName: "RequestRematch",
+			Handler:    _LobbyService_RequestRematch_Handler,
+		},
+		{
+			MethodName: "RespondRematch",
+			Handler:    _LobbyService_RespondRematch_Handler,
+		},
+		{
+			MethodName: "KickPlayer",
+			Handler:    _LobbyService_KickPlayer_Handler,
+		},
+		{
+			MethodName: "TransferHost",
+			Handler:    _LobbyService_TransferHost_Handler,
+		},
+		{
+			MethodName: "SetLobbyLocked",
+			Handler:    _LobbyService_SetLobbyLocked_Handler,
+		},
+		{
+			MethodName: "RenameLobby",
+			Handler:    _LobbyService_RenameLobby_Handler,
+		},
 		{
 			MethodName: "ListAvailableLobbies",
 			Handler:    _LobbyService_ListAvailableLobbies_Handler,
 		},
+		{
+			MethodName: "GetQueueStats",
+			Handler:    _LobbyService_GetQueueStats_Handler,
+		},
+		{
+			MethodName: "ListGameModes",
+			Handler:    _LobbyService_ListGameModes_Handler,
+		},
+		{
+			MethodName: "ListMyMatches",
+			Handler:    _LobbyService_ListMyMatches_Handler,
+		},
+		{
+			MethodName: "InviteToLobby",
+			Handler:    _LobbyService_InviteToLobby_Handler,
+		},
+		{
+			MethodName: "ListMyInvites",
+			Handler:    _LobbyService_ListMyInvites_Handler,
+		},
+		{
+			MethodName: "AcceptInvite",
+			Handler:    _LobbyService_AcceptInvite_Handler,
+		},
+		{
+			MethodName: "DeclineInvite",
+			Handler:    _LobbyService_DeclineInvite_Handler,
+		},
+		{
+			MethodName: "SearchMatch",
+			Handler:    _LobbyService_SearchMatch_Handler,
+		},
+		{
+			MethodName: "GetMySearch",
+			Handler:    _LobbyService_GetMySearch_Handler,
+		},
+		{
+			MethodName: "CancelSearch",
+			Handler:    _LobbyService_CancelSearch_Handler,
+		},
+		{
+			MethodName: "AcceptMatch",
+			Handler:    _LobbyService_AcceptMatch_Handler,
+		},
+		{
+			MethodName: "DeclineMatch",
+			Handler:    _LobbyService_DeclineMatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/lobby.proto",
@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v6.31.1
+// source: proto/party.proto
+
+package party
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PartyServiceClient is the client API for PartyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PartyServiceClient interface {
+	// CreateParty creates a party led by the user, who must not be in another party.
+	CreateParty(ctx context.Context, in *CreatePartyRequest, opts ...grpc.CallOption) (*Party, error)
+	// GetMyParty returns the party the user is in, if any.
+	GetMyParty(ctx context.Context, in *GetMyPartyRequest, opts ...grpc.CallOption) (*Party, error)
+	// InviteToParty lets the leader of the party invite another user by username.
+	InviteToParty(ctx context.Context, in *InviteToPartyRequest, opts ...grpc.CallOption) (*PartyInvite, error)
+	ListMyPartyInvites(ctx context.Context, in *ListMyPartyInvitesRequest, opts ...grpc.CallOption) (*ListMyPartyInvitesResponse, error)
+	AcceptPartyInvite(ctx context.Context, in *RespondPartyInviteRequest, opts ...grpc.CallOption) (*Party, error)
+	DeclinePartyInvite(ctx context.Context, in *RespondPartyInviteRequest, opts ...grpc.CallOption) (*PartyInvite, error)
+	// LeaveParty removes the user from the party. When the leader leaves, the member that joined first after them
+	// leads the party, and the last member that leaves disbands it.
+	LeaveParty(ctx context.Context, in *LeavePartyRequest, opts ...grpc.CallOption) (*LeavePartyResponse, error)
+}
+
+type partyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPartyServiceClient(cc grpc.ClientConnInterface) PartyServiceClient {
+	return &partyServiceClient{cc}
+}
+
+func (c *partyServiceClient) CreateParty(ctx context.Context, in *CreatePartyRequest, opts ...grpc.CallOption) (*Party, error) {
+	out := new(Party)
+	err := c.cc.Invoke(ctx, "/party.PartyService/CreateParty", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) GetMyParty(ctx context.Context, in *GetMyPartyRequest, opts ...grpc.CallOption) (*Party, error) {
+	out := new(Party)
+	err := c.cc.Invoke(ctx, "/party.PartyService/GetMyParty", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) InviteToParty(ctx context.Context, in *InviteToPartyRequest, opts ...grpc.CallOption) (*PartyInvite, error) {
+	out := new(PartyInvite)
+	err := c.cc.Invoke(ctx, "/party.PartyService/InviteToParty", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) ListMyPartyInvites(ctx context.Context, in *ListMyPartyInvitesRequest, opts ...grpc.CallOption) (*ListMyPartyInvitesResponse, error) {
+	out := new(ListMyPartyInvitesResponse)
+	err := c.cc.Invoke(ctx, "/party.PartyService/ListMyPartyInvites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) AcceptPartyInvite(ctx context.Context, in *RespondPartyInviteRequest, opts ...grpc.CallOption) (*Party, error) {
+	out := new(Party)
+	err := c.cc.Invoke(ctx, "/party.PartyService/AcceptPartyInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) DeclinePartyInvite(ctx context.Context, in *RespondPartyInviteRequest, opts ...grpc.CallOption) (*PartyInvite, error) {
+	out := new(PartyInvite)
+	err := c.cc.Invoke(ctx, "/party.PartyService/DeclinePartyInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *partyServiceClient) LeaveParty(ctx context.Context, in *LeavePartyRequest, opts ...grpc.CallOption) (*LeavePartyResponse, error) {
+	out := new(LeavePartyResponse)
+	err := c.cc.Invoke(ctx, "/party.PartyService/LeaveParty", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PartyServiceServer is the server API for PartyService service.
+// All implementations must embed UnimplementedPartyServiceServer
+// for forward compatibility
+type PartyServiceServer interface {
+	// CreateParty creates a party led by the user, who must not be in another party.
+	CreateParty(context.Context, *CreatePartyRequest) (*Party, error)
+	// GetMyParty returns the party the user is in, if any.
+	GetMyParty(context.Context, *GetMyPartyRequest) (*Party, error)
+	// InviteToParty lets the leader of the party invite another user by username.
+	InviteToParty(context.Context, *InviteToPartyRequest) (*PartyInvite, error)
+	ListMyPartyInvites(context.Context, *ListMyPartyInvitesRequest) (*ListMyPartyInvitesResponse, error)
+	AcceptPartyInvite(context.Context, *RespondPartyInviteRequest) (*Party, error)
+	DeclinePartyInvite(context.Context, *RespondPartyInviteRequest) (*PartyInvite, error)
+	// LeaveParty removes the user from the party. When the leader leaves, the member that joined first after them
+	// leads the party, and the last member that leaves disbands it.
+	LeaveParty(context.Context, *LeavePartyRequest) (*LeavePartyResponse, error)
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+// UnimplementedPartyServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPartyServiceServer struct {
+}
+
+func (UnimplementedPartyServiceServer) CreateParty(context.Context, *CreatePartyRequest) (*Party, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateParty not implemented")
+}
+func (UnimplementedPartyServiceServer) GetMyParty(context.Context, *GetMyPartyRequest) (*Party, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyParty not implemented")
+}
+func (UnimplementedPartyServiceServer) InviteToParty(context.Context, *InviteToPartyRequest) (*PartyInvite, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteToParty not implemented")
+}
+func (UnimplementedPartyServiceServer) ListMyPartyInvites(context.Context, *ListMyPartyInvitesRequest) (*ListMyPartyInvitesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyPartyInvites not implemented")
+}
+func (UnimplementedPartyServiceServer) AcceptPartyInvite(context.Context, *RespondPartyInviteRequest) (*Party, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptPartyInvite not implemented")
+}
+func (UnimplementedPartyServiceServer) DeclinePartyInvite(context.Context, *RespondPartyInviteRequest) (*PartyInvite, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclinePartyInvite not implemented")
+}
+func (UnimplementedPartyServiceServer) LeaveParty(context.Context, *LeavePartyRequest) (*LeavePartyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveParty not implemented")
+}
+func (UnimplementedPartyServiceServer) mustEmbedUnimplementedPartyServiceServer() {}
+
+// UnsafePartyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PartyServiceServer will
+// result in compilation errors.
+type UnsafePartyServiceServer interface {
+	mustEmbedUnimplementedPartyServiceServer()
+}
+
+func RegisterPartyServiceServer(s grpc.ServiceRegistrar, srv PartyServiceServer) {
+	s.RegisterService(&PartyService_ServiceDesc, srv)
+}
+
+func _PartyService_CreateParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).CreateParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/party.PartyService/CreateParty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).CreateParty(ctx, req.(*CreatePartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_GetMyParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyPartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).GetMyParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/party.PartyService/GetMyParty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).GetMyParty(ctx, req.(*GetMyPartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_InviteToParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteToPartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).InviteToParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/party.PartyService/InviteToParty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).InviteToParty(ctx, req.(*InviteToPartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_ListMyPartyInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyPartyInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).ListMyPartyInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/party.PartyService/ListMyPartyInvites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).ListMyPartyInvites(ctx, req.(*ListMyPartyInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_AcceptPartyInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondPartyInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).AcceptPartyInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/party.PartyService/AcceptPartyInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).AcceptPartyInvite(ctx, req.(*RespondPartyInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_DeclinePartyInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondPartyInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).DeclinePartyInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/party.PartyService/DeclinePartyInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).DeclinePartyInvite(ctx, req.(*RespondPartyInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PartyService_LeaveParty_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeavePartyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PartyServiceServer).LeaveParty(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/party.PartyService/LeaveParty",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PartyServiceServer).LeaveParty(ctx, req.(*LeavePartyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PartyService_ServiceDesc is the grpc.ServiceDesc for PartyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PartyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "party.PartyService",
+	HandlerType: (*PartyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateParty",
+			Handler:    _PartyService_CreateParty_Handler,
+		},
+		{
+			MethodName: "GetMyParty",
+			Handler:    _PartyService_GetMyParty_Handler,
+		},
+		{
+			MethodName: "InviteToParty",
+			Handler:    _PartyService_InviteToParty_Handler,
+		},
+		{
+			MethodName: "ListMyPartyInvites",
+			Handler:    _PartyService_ListMyPartyInvites_Handler,
+		},
+		{
+			MethodName: "AcceptPartyInvite",
+			Handler:    _PartyService_AcceptPartyInvite_Handler,
+		},
+		{
+			MethodName: "DeclinePartyInvite",
+			Handler:    _PartyService_DeclinePartyInvite_Handler,
+		},
+		{
+			MethodName: "LeaveParty",
+			Handler:    _PartyService_LeaveParty_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/party.proto",
+}
@@ -0,0 +1,143 @@
+package gamemode
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrUnknownMode         = errors.New("unknown game mode")
+	ErrUnknownRegion       = errors.New("unknown region")
+	ErrUnknownSetting      = errors.New("unknown setting")
+	ErrInvalidSettingValue = errors.New("invalid setting value")
+)
+
+// Setting is a setting of a game mode, whose value the creator of a lobby picks among the allowed ones.
+type Setting struct {
+	Name    string
+	Values  []string
+	Default string
+}
+
+// Mode is a way to play the game: it decides how many players fill a lobby and which settings the lobby has.
+type Mode struct {
+	Name        string
+	DisplayName string
+	Capacity    int
+	// Teams is the number of teams the players are split into, 0 when everybody plays for themselves. The capacity
+	// is a multiple of it.
+	Teams    int
+	Settings []Setting
+}
+
+// AutoBalance is the setting of the team modes that, when ON, splits the players into teams by rating as the game
+// starts.
+const AutoBalance = "auto_balance"
+
+// Catalog lists the game modes and the regions the lobbies can be created with. The first mode and the first region
+// are the defaults.
+type Catalog struct {
+	Modes   []Mode
+	Regions []string
+}
+
+var maps = []string{"ARENA", "CANYON", "RUINS"}
+
+// DefaultCatalog returns the game modes and the regions offered by the server.
+func DefaultCatalog() *Catalog {
+	return &Catalog{
+		Modes: []Mode{
+			{
+				Name:        "DUEL",
+				DisplayName: "Duel",
+				Capacity:    2,
+				Settings: []Setting{
+					{Name: "map", Values: maps, Default: "ARENA"},
+					{Name: "time_limit", Values: []string{"5", "10", "15"}, Default: "10"},
+				},
+			},
+			{
+				Name:        "FREE_FOR_ALL",
+				DisplayName: "Free for all",
+				Capacity:    4,
+				Settings: []Setting{
+					{Name: "map", Values: maps, Default: "ARENA"},
+					{Name: "time_limit", Values: []string{"10", "15", "20"}, Default: "15"},
+					{Name: "score_limit", Values: []string{"10", "20", "30"}, Default: "20"},
+				},
+			},
+			{
+				Name:        "TEAM_DEATHMATCH",
+				DisplayName: "Team deathmatch",
+				Capacity:    4,
+				Teams:       2,
+				Settings: []Setting{
+					{Name: "map", Values: maps, Default: "ARENA"},
+					{Name: "time_limit", Values: []string{"10", "15", "20"}, Default: "15"},
+					{Name: AutoBalance, Values: []string{"ON", "OFF"}, Default: "ON"},
+				},
+			},
+		},
+		Regions: []string{"EU", "NA", "ASIA"},
+	}
+}
+
+// Mode returns the game mode with the given name, ignoring the case. The empty name is the default mode.
+func (c *Catalog) Mode(name string) (Mode, error) {
+	if name == "" {
+		return c.Modes[0], nil
+	}
+	for _, mode := range c.Modes {
+		if strings.EqualFold(mode.Name, name) {
+			return mode, nil
+		}
+	}
+	return Mode{}, fmt.Errorf("%w: %q", ErrUnknownMode, name)
+}
+
+// Region returns the region with the given name, ignoring the case. The empty name is the default region.
+func (c *Catalog) Region(name string) (string, error) {
+	if name == "" {
+		return c.Regions[0], nil
+	}
+	for _, region := range c.Regions {
+		if strings.EqualFold(region, name) {
+			return region, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownRegion, name)
+}
+
+// TeamSize is how many players fill a team of the mode, 0 when the mode has no teams.
+func (m Mode) TeamSize() int {
+	if m.Teams == 0 {
+		return 0
+	}
+	return m.Capacity / m.Teams
+}
+
+// Resolve checks the settings chosen for a lobby of the mode, and returns every setting of the mode: the ones that
+// were not chosen take their default value.
+func (m Mode) Resolve(settings map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(m.Settings))
+	for _, setting := range m.Settings {
+		resolved[setting.Name] = setting.Default
+	}
+
+	for name, value := range settings {
+		index := slices.IndexFunc(m.Settings, func(setting Setting) bool { return setting.Name == name })
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %q is not a setting of %s", ErrUnknownSetting, name, m.DisplayName)
+		}
+		if value == "" {
+			continue
+		}
+		if !slices.Contains(m.Settings[index].Values, value) {
+			return nil, fmt.Errorf("%w: %q is not a valid %s", ErrInvalidSettingValue, value, name)
+		}
+		resolved[name] = value
+	}
+	return resolved, nil
+}
@@ -0,0 +1,89 @@
+package gamemode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CatalogTestSuite struct {
+	suite.Suite
+	catalog *Catalog
+}
+
+func (s *CatalogTestSuite) SetupTest() {
+	s.catalog = DefaultCatalog()
+}
+
+func (s *CatalogTestSuite) TestModeDefaultsToTheFirstMode() {
+	mode, err := s.catalog.Mode("")
+
+	s.NoError(err)
+	s.Equal("DUEL", mode.Name)
+	s.Equal(2, mode.Capacity)
+}
+
+func (s *CatalogTestSuite) TestModeIgnoresTheCase() {
+	mode, err := s.catalog.Mode("free_for_all")
+
+	s.NoError(err)
+	s.Equal("FREE_FOR_ALL", mode.Name)
+	s.Equal(4, mode.Capacity)
+}
+
+func (s *CatalogTestSuite) TestModeFailsWhenUnknown() {
+	_, err := s.catalog.Mode("BATTLE_ROYALE")
+
+	s.ErrorIs(err, ErrUnknownMode)
+}
+
+func (s *CatalogTestSuite) TestTeamSize() {
+	duel, _ := s.catalog.Mode("DUEL")
+	teamDeathmatch, _ := s.catalog.Mode("TEAM_DEATHMATCH")
+
+	s.Equal(0, duel.TeamSize())
+	s.Equal(2, teamDeathmatch.TeamSize())
+}
+
+func (s *CatalogTestSuite) TestRegion() {
+	region, err := s.catalog.Region("")
+	s.NoError(err)
+	s.Equal("EU", region)
+
+	region, err = s.catalog.Region("na")
+	s.NoError(err)
+	s.Equal("NA", region)
+
+	_, err = s.catalog.Region("MOON")
+	s.ErrorIs(err, ErrUnknownRegion)
+}
+
+func (s *CatalogTestSuite) TestResolveFillsInTheDefaults() {
+	mode, _ := s.catalog.Mode("FREE_FOR_ALL")
+
+	settings, err := mode.Resolve(map[string]string{"map": "RUINS", "time_limit": ""})
+
+	s.NoError(err)
+	s.Equal(map[string]string{"map": "RUINS", "time_limit": "15", "score_limit": "20"}, settings)
+}
+
+func (s *CatalogTestSuite) TestResolveRejectsTheSettingsOfOtherModes() {
+	mode, _ := s.catalog.Mode("DUEL")
+
+	_, err := mode.Resolve(map[string]string{"score_limit": "10"})
+
+	s.ErrorIs(err, ErrUnknownSetting)
+}
+
+func (s *CatalogTestSuite) TestResolveRejectsTheValuesThatAreNotAllowed() {
+	mode, _ := s.catalog.Mode("DUEL")
+
+	_, err := mode.Resolve(map[string]string{"time_limit": "20"})
+
+	s.ErrorIs(err, ErrInvalidSettingValue)
+	s.ErrorContains(err, `"20" is not a valid time_limit`)
+}
+
+func TestCatalog(t *testing.T) {
+	suite.Run(t, new(CatalogTestSuite))
+}
@@ -0,0 +1,102 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type ChatGatewayClient struct {
+	*baseClient
+}
+
+func NewChatGatewayClient(baseURL string) *ChatGatewayClient {
+	return &ChatGatewayClient{
+		&baseClient{
+			baseURL:    baseURL,
+			httpClient: &http.Client{},
+		},
+	}
+}
+
+func (c *ChatGatewayClient) SendMessage(ctx context.Context, req *chat.SendMessageRequest) (*chat.ChatMessage, error) {
+	var message chat.ChatMessage
+	path := fmt.Sprintf("/api/v1/lobbies/%s/messages", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPost, path, req, &message)
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// streamChunk is a line of a streaming response of the gateway: it holds either a message or the error that ended
+// the stream.
+type streamChunk struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// StreamMessages calls onMessage with every message of the lobby chat, until the context is done or the stream
+// ends. The error of onMessage ends the stream too.
+func (c *ChatGatewayClient) StreamMessages(ctx context.Context, lobbyID string,
+	onMessage func(*chat.ChatMessage) error) error {
+	path := fmt.Sprintf("/api/v1/lobbies/%s/messages", lobbyID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create http request: %w", err)
+	}
+	if tokenString, ok := token.FromContext(ctx); ok {
+		httpReq.Header.Set("Authorization", "Bearer "+tokenString)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Error closing response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			Message:    "An unexpected error occurred",
+		}
+	}
+
+	// The gateway writes the stream as one JSON object per line, and reports the errors that happen once the
+	// stream started with the status code already sent.
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var chunk streamChunk
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return fmt.Errorf("failed to unmarshal stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return &APIError{StatusCode: http.StatusInternalServerError, Message: chunk.Error.Message}
+		}
+
+		var message chat.ChatMessage
+		if err := protojson.Unmarshal(chunk.Result, &message); err != nil {
+			return fmt.Errorf("failed to unmarshal message: %w", err)
+		}
+		if err := onMessage(&message); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
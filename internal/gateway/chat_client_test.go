@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestChatGatewayClientSendMessage(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/messages", r.URL.Path)
+			var req chat.SendMessageRequest
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, protojson.Unmarshal(body, &req))
+			assert.Equal(t, "gg", req.Text)
+			assert.Equal(t, "Bearer player-token", r.Header.Get("Authorization"))
+			body, _ = protojson.Marshal(&chat.ChatMessage{Id: 1, Username: "player1", Text: req.Text})
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewChatGatewayClient(server.URL)
+		message, err := client.SendMessage(token.NewContext(context.Background(), "player-token"),
+			&chat.SendMessageRequest{LobbyId: "lobby-abc", Text: "gg"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "player1", message.Username)
+	})
+
+	t.Run("Failure - Rate limited", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := NewChatGatewayClient(server.URL)
+		_, err := client.SendMessage(context.Background(), &chat.SendMessageRequest{LobbyId: "lobby-abc", Text: "gg"})
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusTooManyRequests, apiErr.StatusCode)
+	})
+}
+
+func TestChatGatewayClientStreamMessages(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/messages", r.URL.Path)
+			assert.Equal(t, "Bearer player-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"result":{"id":1,"username":"player1","text":"first"}}` + "\n"))
+			_, _ = w.Write([]byte(`{"result":{"id":2,"username":"player2","text":"second"}}` + "\n"))
+		}))
+		defer server.Close()
+
+		var texts []string
+		client := NewChatGatewayClient(server.URL)
+		ctx := token.NewContext(context.Background(), "player-token")
+		err := client.StreamMessages(ctx, "lobby-abc", func(message *chat.ChatMessage) error {
+			texts = append(texts, message.Text)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, texts)
+	})
+
+	t.Run("Failure - Error inside the stream", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"error":{"code":7,"message":"only the players and the spectators of the lobby can chat"}}` + "\n"))
+		}))
+		defer server.Close()
+
+		client := NewChatGatewayClient(server.URL)
+		err := client.StreamMessages(context.Background(), "lobby-abc", func(*chat.ChatMessage) error {
+			t.Fatal("No message should be delivered")
+			return nil
+		})
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Contains(t, apiErr.Message, "only the players")
+	})
+
+	t.Run("Failure - Callback error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"result":{"id":1,"text":"first"}}` + "\n"))
+		}))
+		defer server.Close()
+
+		callbackErr := errors.New("client gone")
+		client := NewChatGatewayClient(server.URL)
+		err := client.StreamMessages(context.Background(), "lobby-abc", func(*chat.ChatMessage) error {
+			return callbackErr
+		})
+
+		assert.ErrorIs(t, err, callbackErr)
+	})
+}
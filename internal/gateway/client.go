@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -44,6 +45,9 @@ func (c *baseClient) doProtoRequest(ctx context.Context, method, path string, re
 		return fmt.Errorf("failed to create http request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	if tokenString, ok := token.FromContext(ctx); ok {
+		httpReq.Header.Set("Authorization", "Bearer "+tokenString)
+	}
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+)
+
+type FriendGatewayClient struct {
+	*baseClient
+}
+
+func NewFriendGatewayClient(baseURL string) *FriendGatewayClient {
+	return &FriendGatewayClient{
+		&baseClient{
+			baseURL:    baseURL,
+			httpClient: &http.Client{},
+		},
+	}
+}
+
+func (c *FriendGatewayClient) SendFriendRequest(ctx context.Context, req *friend.SendFriendRequestRequest) (*friend.FriendRequest, error) {
+	var request friend.FriendRequest
+	err := c.doProtoRequest(ctx, http.MethodPost, "/api/v1/friends/requests", req, &request)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (c *FriendGatewayClient) AcceptFriendRequest(ctx context.Context, req *friend.AcceptFriendRequestRequest) (*friend.Friend, error) {
+	var newFriend friend.Friend
+	path := fmt.Sprintf("/api/v1/friends/requests/%d/accept", req.RequestId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &newFriend)
+	if err != nil {
+		return nil, err
+	}
+	return &newFriend, nil
+}
+
+func (c *FriendGatewayClient) RemoveFriend(ctx context.Context, req *friend.RemoveFriendRequest) error {
+	path := fmt.Sprintf("/api/v1/friends/%s/remove", url.PathEscape(req.FriendUsername))
+	return c.doProtoRequest(ctx, http.MethodPut, path, req, nil)
+}
+
+func (c *FriendGatewayClient) BlockUser(ctx context.Context, req *friend.BlockUserRequest) error {
+	return c.doProtoRequest(ctx, http.MethodPost, "/api/v1/blocks", req, nil)
+}
+
+func (c *FriendGatewayClient) ListFriends(ctx context.Context, username string) (*friend.ListFriendsResponse, error) {
+	var friendsResponse friend.ListFriendsResponse
+	path := "/api/v1/friends?username=" + url.QueryEscape(username)
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &friendsResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &friendsResponse, nil
+}
@@ -0,0 +1,152 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestFriendGatewayClientSendFriendRequest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &friend.FriendRequest{RequestId: 5, FromUsername: "alice", ToUsername: "bob"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/friends/requests", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewFriendGatewayClient(server.URL)
+		res, err := client.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+			Username:       "alice",
+			FriendUsername: "bob",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(5), res.RequestId)
+		assert.Equal(t, "bob", res.ToUsername)
+	})
+
+	t.Run("Failure - Blocked", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewFriendGatewayClient(server.URL)
+		_, err := client.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+			Username:       "alice",
+			FriendUsername: "bob",
+		})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	})
+}
+
+func TestFriendGatewayClientAcceptFriendRequest(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &friend.Friend{Username: "alice", Presence: "ONLINE"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/friends/requests/5/accept", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewFriendGatewayClient(server.URL)
+		res, err := client.AcceptFriendRequest(context.Background(), &friend.AcceptFriendRequestRequest{RequestId: 5, Username: "bob"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice", res.Username)
+		assert.Equal(t, "ONLINE", res.Presence)
+	})
+
+	t.Run("Failure - Not Addressed To The User", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewFriendGatewayClient(server.URL)
+		_, err := client.AcceptFriendRequest(context.Background(), &friend.AcceptFriendRequestRequest{RequestId: 5, Username: "carol"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	})
+}
+
+func TestFriendGatewayClientRemoveFriend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/friends/bob/remove", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewFriendGatewayClient(server.URL)
+	err := client.RemoveFriend(context.Background(), &friend.RemoveFriendRequest{FriendUsername: "bob", Username: "alice"})
+
+	require.NoError(t, err)
+}
+
+func TestFriendGatewayClientBlockUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/blocks", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewFriendGatewayClient(server.URL)
+	err := client.BlockUser(context.Background(), &friend.BlockUserRequest{Username: "alice", BlockedUsername: "bob"})
+
+	require.NoError(t, err)
+}
+
+func TestFriendGatewayClientListFriends(t *testing.T) {
+	mockResponse := &friend.ListFriendsResponse{
+		Friends:          []*friend.Friend{{Username: "bob", Presence: "IN_GAME"}},
+		IncomingRequests: []*friend.FriendRequest{{RequestId: 9, FromUsername: "carol"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/friends", r.URL.Path)
+		assert.Equal(t, "alice", r.URL.Query().Get("username"))
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(mockResponse)
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewFriendGatewayClient(server.URL)
+	res, err := client.ListFriends(context.Background(), "alice")
+
+	require.NoError(t, err)
+	require.Len(t, res.Friends, 1)
+	assert.Equal(t, "IN_GAME", res.Friends[0].Presence)
+	require.Len(t, res.IncomingRequests, 1)
+	assert.Equal(t, "carol", res.IncomingRequests[0].FromUsername)
+}
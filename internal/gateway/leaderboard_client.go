@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
+)
+
+type LeaderboardGatewayClient struct {
+	*baseClient
+}
+
+func NewLeaderboardGatewayClient(baseURL string) *LeaderboardGatewayClient {
+	return &LeaderboardGatewayClient{
+		&baseClient{
+			baseURL:    baseURL,
+			httpClient: &http.Client{},
+		},
+	}
+}
+
+func (c *LeaderboardGatewayClient) GetLeaderboard(ctx context.Context, metric string, seasonID uint32, pageToken string) (*leaderboard.GetLeaderboardResponse, error) {
+	var leaderboardResponse leaderboard.GetLeaderboardResponse
+	query := rankingQuery(metric, seasonID)
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/leaderboard?"+query.Encode(), nil, &leaderboardResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &leaderboardResponse, nil
+}
+
+func (c *LeaderboardGatewayClient) GetMyRank(ctx context.Context, username, metric string, seasonID uint32) (*leaderboard.LeaderboardEntry, error) {
+	var entry leaderboard.LeaderboardEntry
+	query := rankingQuery(metric, seasonID)
+	query.Set("username", username)
+	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/leaderboard/me?"+query.Encode(), nil, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *LeaderboardGatewayClient) ListSeasons(ctx context.Context) ([]*leaderboard.Season, error) {
+	var seasonsResponse leaderboard.ListSeasonsResponse
+	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/seasons", nil, &seasonsResponse)
+	if err != nil {
+		return nil, err
+	}
+	return seasonsResponse.Seasons, nil
+}
+
+// rankingQuery selects the ranking: the empty metric and the season 0 are left to the defaults of the service.
+func rankingQuery(metric string, seasonID uint32) url.Values {
+	query := url.Values{}
+	if metric != "" {
+		query.Set("metric", metric)
+	}
+	if seasonID != 0 {
+		query.Set("season_id", strconv.FormatUint(uint64(seasonID), 10))
+	}
+	return query
+}
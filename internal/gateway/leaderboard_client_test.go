@@ -0,0 +1,149 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestLeaderboardGatewayClientGetLeaderboard(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &leaderboard.GetLeaderboardResponse{
+			Entries:       []*leaderboard.LeaderboardEntry{{Rank: 1, Username: "player1", Wins: 5}},
+			NextPageToken: "20",
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/leaderboard", r.URL.Path)
+			assert.Equal(t, "RATING", r.URL.Query().Get("metric"))
+			assert.Equal(t, "3", r.URL.Query().Get("season_id"))
+			assert.Equal(t, "10", r.URL.Query().Get("page_token"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLeaderboardGatewayClient(server.URL)
+		resp, err := client.GetLeaderboard(context.Background(), "RATING", 3, "10")
+
+		require.NoError(t, err)
+		require.Len(t, resp.Entries, 1)
+		assert.Equal(t, "player1", resp.Entries[0].Username)
+		assert.Equal(t, "20", resp.NextPageToken)
+	})
+
+	t.Run("AllTimeDefaults", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.RawQuery)
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte("{}"))
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLeaderboardGatewayClient(server.URL)
+		_, err := client.GetLeaderboard(context.Background(), "", 0, "")
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewLeaderboardGatewayClient(server.URL)
+		_, err := client.GetLeaderboard(context.Background(), "", 9, "")
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestLeaderboardGatewayClientGetMyRank(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &leaderboard.LeaderboardEntry{Rank: 42, Username: "player1"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/leaderboard/me", r.URL.Path)
+			assert.Equal(t, "player1", r.URL.Query().Get("username"))
+			assert.Equal(t, "WINS", r.URL.Query().Get("metric"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLeaderboardGatewayClient(server.URL)
+		entry, err := client.GetMyRank(context.Background(), "player1", "WINS", 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(42), entry.Rank)
+	})
+
+	t.Run("NotRanked", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewLeaderboardGatewayClient(server.URL)
+		_, err := client.GetMyRank(context.Background(), "player1", "", 0)
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestLeaderboardGatewayClientListSeasons(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &leaderboard.ListSeasonsResponse{Seasons: []*leaderboard.Season{{Id: 2, Number: 2}, {Id: 1, Number: 1}}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/seasons", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLeaderboardGatewayClient(server.URL)
+		seasons, err := client.ListSeasons(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, seasons, 2)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := NewLeaderboardGatewayClient(server.URL)
+		_, err := client.ListSeasons(context.Background())
+
+		require.Error(t, err)
+	})
+}
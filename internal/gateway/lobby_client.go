@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
 )
@@ -35,6 +38,75 @@ func (c *LobbyGatewayClient) JoinLobby(ctx context.Context, req *lobby.JoinLobby
 	return c.doProtoRequest(ctx, http.MethodPut, path, req, nil)
 }
 
+func (c *LobbyGatewayClient) JoinLobbyByCode(ctx context.Context, req *lobby.JoinLobbyByCodeRequest) (*lobby.Lobby, error) {
+	var joinedLobby lobby.Lobby
+	err := c.doProtoRequest(ctx, http.MethodPut, "/api/v1/lobbies/join-by-code", req, &joinedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &joinedLobby, nil
+}
+
+func (c *LobbyGatewayClient) SetReady(ctx context.Context, req *lobby.SetReadyRequest) (*lobby.Lobby, error) {
+	var readyLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/ready", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &readyLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &readyLobby, nil
+}
+
+func (c *LobbyGatewayClient) DeclineReadyCheck(ctx context.Context, req *lobby.DeclineReadyCheckRequest) (*lobby.Lobby, error) {
+	var reopenedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/decline", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &reopenedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &reopenedLobby, nil
+}
+
+func (c *LobbyGatewayClient) LeaveLobby(ctx context.Context, req *lobby.LeaveLobbyRequest) (*lobby.Lobby, error) {
+	var leftLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/leave", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &leftLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &leftLobby, nil
+}
+
+func (c *LobbyGatewayClient) SpectateLobby(ctx context.Context, req *lobby.SpectateLobbyRequest) (*lobby.Lobby, error) {
+	var watchedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/spectate", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &watchedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &watchedLobby, nil
+}
+
+func (c *LobbyGatewayClient) SwitchTeam(ctx context.Context, req *lobby.SwitchTeamRequest) (*lobby.Lobby, error) {
+	var switchedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/team", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &switchedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &switchedLobby, nil
+}
+
+func (c *LobbyGatewayClient) SwapSeat(ctx context.Context, req *lobby.SwapSeatRequest) (*lobby.Lobby, error) {
+	var swappedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/seat", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &swappedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &swappedLobby, nil
+}
+
 func (c *LobbyGatewayClient) GetLobby(ctx context.Context, lobbyID string) (*lobby.Lobby, error) {
 	var foundLobby lobby.Lobby
 	path := fmt.Sprintf("/api/v1/lobbies/%s", lobbyID)
@@ -45,21 +117,253 @@ func (c *LobbyGatewayClient) GetLobby(ctx context.Context, lobbyID string) (*lob
 	return &foundLobby, nil
 }
 
-func (c *LobbyGatewayClient) FinishLobby(ctx context.Context, lobbyID string) (*lobby.Lobby, error) {
+func (c *LobbyGatewayClient) GetMyCurrentLobby(ctx context.Context, username string) (*lobby.Lobby, error) {
+	var currentLobby lobby.Lobby
+	path := "/api/v1/lobbies/current?username=" + url.QueryEscape(username)
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &currentLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &currentLobby, nil
+}
+
+func (c *LobbyGatewayClient) GetMyCooldown(ctx context.Context, username string) (*lobby.GetMyCooldownResponse, error) {
+	var cooldownResponse lobby.GetMyCooldownResponse
+	path := "/api/v1/cooldown?username=" + url.QueryEscape(username)
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &cooldownResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &cooldownResponse, nil
+}
+
+func (c *LobbyGatewayClient) FinishLobby(ctx context.Context, lobbyID, username string) (*lobby.Lobby, error) {
 	var finishedLobby lobby.Lobby
 	path := fmt.Sprintf("/api/v1/lobbies/%s/finish", lobbyID)
-	err := c.doProtoRequest(ctx, http.MethodPut, path, nil, &finishedLobby)
+	req := &lobby.FinishGameRequest{LobbyId: lobbyID, Username: username}
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &finishedLobby)
 	if err != nil {
 		return nil, err
 	}
 	return &finishedLobby, nil
 }
 
-func (c *LobbyGatewayClient) ListAvailableLobbies(ctx context.Context) ([]*lobby.Lobby, error) {
+func (c *LobbyGatewayClient) RequestRematch(ctx context.Context, req *lobby.RequestRematchRequest) (*lobby.Lobby, error) {
+	var finishedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/rematch", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPost, path, req, &finishedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &finishedLobby, nil
+}
+
+func (c *LobbyGatewayClient) RespondRematch(ctx context.Context, req *lobby.RespondRematchRequest) (*lobby.Lobby, error) {
+	var finishedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/rematch", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &finishedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &finishedLobby, nil
+}
+
+func (c *LobbyGatewayClient) KickPlayer(ctx context.Context, req *lobby.KickPlayerRequest) (*lobby.Lobby, error) {
+	var hostedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/kick", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &hostedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &hostedLobby, nil
+}
+
+func (c *LobbyGatewayClient) TransferHost(ctx context.Context, req *lobby.TransferHostRequest) (*lobby.Lobby, error) {
+	var hostedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/host", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &hostedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &hostedLobby, nil
+}
+
+func (c *LobbyGatewayClient) SetLobbyLocked(ctx context.Context, req *lobby.SetLobbyLockedRequest) (*lobby.Lobby, error) {
+	var hostedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/lock", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &hostedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &hostedLobby, nil
+}
+
+func (c *LobbyGatewayClient) RenameLobby(ctx context.Context, req *lobby.RenameLobbyRequest) (*lobby.Lobby, error) {
+	var renamedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/lobbies/%s/name", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &renamedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &renamedLobby, nil
+}
+
+func (c *LobbyGatewayClient) ListAvailableLobbies(ctx context.Context, req *lobby.ListAvailableLobbiesRequest) (*lobby.ListAvailableLobbiesResponse, error) {
 	var lobbyListResponse lobby.ListAvailableLobbiesResponse
-	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/lobbies/available", nil, &lobbyListResponse)
+	path := "/api/v1/lobbies/available"
+	if query := availableLobbiesQuery(req); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &lobbyListResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &lobbyListResponse, nil
+}
+
+// availableLobbiesQuery leaves out the zero values of the request, so that the service applies its defaults.
+func availableLobbiesQuery(req *lobby.ListAvailableLobbiesRequest) url.Values {
+	query := url.Values{}
+	if req.GetPageSize() != 0 {
+		query.Set("page_size", strconv.Itoa(int(req.GetPageSize())))
+	}
+	if req.GetPageToken() != "" {
+		query.Set("page_token", req.GetPageToken())
+	}
+	if req.GetNameQuery() != "" {
+		query.Set("name_query", req.GetNameQuery())
+	}
+	if req.GetMinFreeSlots() != 0 {
+		query.Set("min_free_slots", strconv.Itoa(int(req.GetMinFreeSlots())))
+	}
+	if req.GetCreatedAfter() != nil {
+		query.Set("created_after", req.GetCreatedAfter().AsTime().Format(time.RFC3339Nano))
+	}
+	if req.GetCreatedBefore() != nil {
+		query.Set("created_before", req.GetCreatedBefore().AsTime().Format(time.RFC3339Nano))
+	}
+	if req.GetSort() != "" {
+		query.Set("sort", req.GetSort())
+	}
+	if req.GetGameMode() != "" {
+		query.Set("game_mode", req.GetGameMode())
+	}
+	if req.GetRegion() != "" {
+		query.Set("region", req.GetRegion())
+	}
+	return query
+}
+
+// GetQueueStats leaves out the empty game mode and region, which the service takes as any.
+func (c *LobbyGatewayClient) GetQueueStats(ctx context.Context, req *lobby.GetQueueStatsRequest) (*lobby.GetQueueStatsResponse, error) {
+	var queueStatsResponse lobby.GetQueueStatsResponse
+	query := url.Values{}
+	if req.GetGameMode() != "" {
+		query.Set("game_mode", req.GetGameMode())
+	}
+	if req.GetRegion() != "" {
+		query.Set("region", req.GetRegion())
+	}
+	path := "/api/v1/queue-stats"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &queueStatsResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &queueStatsResponse, nil
+}
+
+func (c *LobbyGatewayClient) ListGameModes(ctx context.Context) (*lobby.ListGameModesResponse, error) {
+	var gameModesResponse lobby.ListGameModesResponse
+	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/game-modes", nil, &gameModesResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &gameModesResponse, nil
+}
+
+func (c *LobbyGatewayClient) InviteToLobby(ctx context.Context, req *lobby.InviteToLobbyRequest) (*lobby.Invite, error) {
+	var invite lobby.Invite
+	path := fmt.Sprintf("/api/v1/lobbies/%s/invites", req.LobbyId)
+	err := c.doProtoRequest(ctx, http.MethodPost, path, req, &invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+func (c *LobbyGatewayClient) ListMyInvites(ctx context.Context) ([]*lobby.Invite, error) {
+	var invitesResponse lobby.ListMyInvitesResponse
+	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/invites", nil, &invitesResponse)
+	if err != nil {
+		return nil, err
+	}
+	return invitesResponse.Invites, nil
+}
+
+func (c *LobbyGatewayClient) ListMyMatches(ctx context.Context, username, pageToken string) (*lobby.ListMyMatchesResponse, error) {
+	var matchesResponse lobby.ListMyMatchesResponse
+	query := url.Values{"username": {username}}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/matches?"+query.Encode(), nil, &matchesResponse)
 	if err != nil {
 		return nil, err
 	}
-	return lobbyListResponse.Lobbies, nil
+	return &matchesResponse, nil
+}
+
+func (c *LobbyGatewayClient) AcceptInvite(ctx context.Context, req *lobby.RespondInviteRequest) (*lobby.Lobby, error) {
+	var joinedLobby lobby.Lobby
+	path := fmt.Sprintf("/api/v1/invites/%d/accept", req.InviteId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &joinedLobby)
+	if err != nil {
+		return nil, err
+	}
+	return &joinedLobby, nil
+}
+
+func (c *LobbyGatewayClient) DeclineInvite(ctx context.Context, req *lobby.RespondInviteRequest) error {
+	path := fmt.Sprintf("/api/v1/invites/%d/decline", req.InviteId)
+	return c.doProtoRequest(ctx, http.MethodPut, path, req, nil)
+}
+
+func (c *LobbyGatewayClient) SearchMatch(ctx context.Context, req *lobby.SearchMatchRequest) (*lobby.MatchSearch, error) {
+	var search lobby.MatchSearch
+	err := c.doProtoRequest(ctx, http.MethodPost, "/api/v1/matchmaking", req, &search)
+	if err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (c *LobbyGatewayClient) GetMySearch(ctx context.Context) (*lobby.MatchSearch, error) {
+	var search lobby.MatchSearch
+	err := c.doProtoRequest(ctx, http.MethodGet, "/api/v1/matchmaking", nil, &search)
+	if err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (c *LobbyGatewayClient) CancelSearch(ctx context.Context) error {
+	return c.doProtoRequest(ctx, http.MethodPut, "/api/v1/matchmaking/cancel", &lobby.CancelSearchRequest{}, nil)
+}
+
+func (c *LobbyGatewayClient) AcceptMatch(ctx context.Context, req *lobby.RespondMatchRequest) (*lobby.MatchSearch, error) {
+	var search lobby.MatchSearch
+	path := fmt.Sprintf("/api/v1/matchmaking/%s/accept", req.MatchId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &search)
+	if err != nil {
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (c *LobbyGatewayClient) DeclineMatch(ctx context.Context, req *lobby.RespondMatchRequest) error {
+	path := fmt.Sprintf("/api/v1/matchmaking/%s/decline", req.MatchId)
+	return c.doProtoRequest(ctx, http.MethodPut, path, req, nil)
 }
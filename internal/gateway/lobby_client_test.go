@@ -2,14 +2,18 @@ package gateway
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestLobbyGatewayClientCreateLobby(t *testing.T) {
@@ -80,13 +84,471 @@ func TestLobbyGatewayClientJoinLobby(t *testing.T) {
 	})
 }
 
+func TestLobbyGatewayClientJoinLobbyByCode(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", JoinCode: "ABC234"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/join-by-code", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		req := &lobby.JoinLobbyByCodeRequest{JoinCode: "ABC234", Username: "player2"}
+		res, err := client.JoinLobbyByCode(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, "lobby-abc", res.LobbyId)
+	})
+
+	t.Run("Failure - Unknown Code", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		req := &lobby.JoinLobbyByCodeRequest{JoinCode: "ZZZZZZ", Username: "player2"}
+		_, err := client.JoinLobbyByCode(context.Background(), req)
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientSetReady(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Status: "IN_PROGRESS"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/ready", r.URL.Path)
+			assert.Equal(t, "Bearer player-token", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		ctx := token.NewContext(context.Background(), "player-token")
+		res, err := client.SetReady(ctx, &lobby.SetReadyRequest{LobbyId: "lobby-abc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "IN_PROGRESS", res.Status)
+	})
+
+	t.Run("Failure - Expired", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.SetReady(context.Background(), &lobby.SetReadyRequest{LobbyId: "lobby-abc"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientDeclineReadyCheck(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Status: "WAITING"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/decline", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.DeclineReadyCheck(context.Background(), &lobby.DeclineReadyCheckRequest{LobbyId: "lobby-abc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "WAITING", res.Status)
+	})
+
+	t.Run("Failure - Not A Player", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.DeclineReadyCheck(context.Background(), &lobby.DeclineReadyCheckRequest{LobbyId: "lobby-abc"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientLeaveLobby(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Status: "WAITING"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/leave", r.URL.Path)
+			assert.Equal(t, "Bearer player-token", r.Header.Get("Authorization"))
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		ctx := token.NewContext(context.Background(), "player-token")
+		res, err := client.LeaveLobby(ctx, &lobby.LeaveLobbyRequest{LobbyId: "lobby-abc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "WAITING", res.Status)
+	})
+
+	t.Run("Failure - Lobby Over", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.LeaveLobby(context.Background(), &lobby.LeaveLobbyRequest{LobbyId: "lobby-abc"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientRequestRematch(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Players: []*lobby.Player{{Username: "player1", RematchAccepted: true}}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/rematch", r.URL.Path)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.RequestRematch(context.Background(), &lobby.RequestRematchRequest{LobbyId: "lobby-abc"})
+
+		require.NoError(t, err)
+		assert.True(t, res.Players[0].RematchAccepted)
+	})
+
+	t.Run("Failure - Vote running", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.RequestRematch(context.Background(), &lobby.RequestRematchRequest{LobbyId: "lobby-abc"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientRespondRematch(t *testing.T) {
+	rematchLobbyID := "lobby-def"
+	mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", RematchLobbyId: &rematchLobbyID}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/lobbies/lobby-abc/rematch", r.URL.Path)
+		var req lobby.RespondRematchRequest
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, protojson.Unmarshal(body, &req))
+		assert.True(t, req.Accept)
+		body, _ = protojson.Marshal(mockResponse)
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewLobbyGatewayClient(server.URL)
+	res, err := client.RespondRematch(context.Background(), &lobby.RespondRematchRequest{
+		LobbyId: "lobby-abc",
+		Accept:  true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "lobby-def", res.GetRematchLobbyId())
+}
+
+func TestLobbyGatewayClientKickPlayer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Players: []*lobby.Player{{Username: "host"}}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/kick", r.URL.Path)
+			assert.Equal(t, "Bearer host-token", r.Header.Get("Authorization"))
+			var req lobby.KickPlayerRequest
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, protojson.Unmarshal(body, &req))
+			assert.Equal(t, "troll", req.PlayerUsername)
+			body, _ = protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.KickPlayer(token.NewContext(context.Background(), "host-token"), &lobby.KickPlayerRequest{
+			LobbyId:        "lobby-abc",
+			PlayerUsername: "troll",
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, res.Players, 1)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.KickPlayer(context.Background(), &lobby.KickPlayerRequest{LobbyId: "lobby-abc"})
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientTransferHost(t *testing.T) {
+	hostUsername := "player2"
+	mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", HostUsername: &hostUsername}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/lobbies/lobby-abc/host", r.URL.Path)
+		var req lobby.TransferHostRequest
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, protojson.Unmarshal(body, &req))
+		assert.Equal(t, "player2", req.NewHostUsername)
+		body, _ = protojson.Marshal(mockResponse)
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewLobbyGatewayClient(server.URL)
+	res, err := client.TransferHost(context.Background(), &lobby.TransferHostRequest{
+		LobbyId:         "lobby-abc",
+		NewHostUsername: "player2",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "player2", res.GetHostUsername())
+}
+
+func TestLobbyGatewayClientSetLobbyLocked(t *testing.T) {
+	mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Locked: true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/lobbies/lobby-abc/lock", r.URL.Path)
+		var req lobby.SetLobbyLockedRequest
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, protojson.Unmarshal(body, &req))
+		assert.True(t, req.Locked)
+		body, _ = protojson.Marshal(mockResponse)
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewLobbyGatewayClient(server.URL)
+	res, err := client.SetLobbyLocked(context.Background(), &lobby.SetLobbyLockedRequest{
+		LobbyId: "lobby-abc",
+		Locked:  true,
+	})
+
+	require.NoError(t, err)
+	assert.True(t, res.Locked)
+}
+
+func TestLobbyGatewayClientRenameLobby(t *testing.T) {
+	mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Name: "Finals"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/lobbies/lobby-abc/name", r.URL.Path)
+		var req lobby.RenameLobbyRequest
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(t, protojson.Unmarshal(body, &req))
+		assert.Equal(t, "Finals", req.Name)
+		body, _ = protojson.Marshal(mockResponse)
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewLobbyGatewayClient(server.URL)
+	res, err := client.RenameLobby(context.Background(), &lobby.RenameLobbyRequest{
+		LobbyId: "lobby-abc",
+		Name:    "Finals",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Finals", res.Name)
+}
+
+func TestLobbyGatewayClientSpectateLobby(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Spectators: []*lobby.Spectator{{Username: "spectator"}}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/spectate", r.URL.Path)
+			var req lobby.SpectateLobbyRequest
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, protojson.Unmarshal(body, &req))
+			assert.Equal(t, "spectator", req.Username)
+			w.WriteHeader(http.StatusOK)
+			body, _ = protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{LobbyId: "lobby-abc", Username: "spectator"})
+
+		require.NoError(t, err)
+		require.Len(t, res.Spectators, 1)
+		assert.Equal(t, "spectator", res.Spectators[0].Username)
+	})
+
+	t.Run("Failure - No room left", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{LobbyId: "lobby-abc", Username: "spectator"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientSwitchTeam(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc", Players: []*lobby.Player{{Username: "player2", Team: 2}}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/team", r.URL.Path)
+			var req lobby.SwitchTeamRequest
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, protojson.Unmarshal(body, &req))
+			assert.Equal(t, int32(2), req.Team)
+			w.WriteHeader(http.StatusOK)
+			body, _ = protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.SwitchTeam(context.Background(), &lobby.SwitchTeamRequest{LobbyId: "lobby-abc", Username: "player2", Team: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), res.Players[0].Team)
+	})
+
+	t.Run("Failure - Team full", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.SwitchTeam(context.Background(), &lobby.SwitchTeamRequest{LobbyId: "lobby-abc", Username: "player2", Team: 2})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientSwapSeat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/lobbies/lobby-abc/seat", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-abc"})
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewLobbyGatewayClient(server.URL)
+	res, err := client.SwapSeat(context.Background(), &lobby.SwapSeatRequest{LobbyId: "lobby-abc", Username: "player2", Seat: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "lobby-abc", res.LobbyId)
+}
+
 func TestLobbyGatewayClientFinishLobby(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		winnerId := uint32(1)
 		mockResponse := &lobby.Lobby{LobbyId: "lobby-xyz", Status: "Finished", WinnerId: &winnerId}
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/lobbies/lobby-xyz/finish", r.URL.Path)
+			var req lobby.FinishGameRequest
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, protojson.Unmarshal(body, &req))
+			assert.Equal(t, "player1", req.Username)
 			w.WriteHeader(http.StatusOK)
-			body, _ := protojson.Marshal(mockResponse)
+			body, _ = protojson.Marshal(mockResponse)
 			_, err := w.Write(body)
 			if err != nil {
 				t.Fatalf("Failed to write response: %v", err)
@@ -95,7 +557,7 @@ func TestLobbyGatewayClientFinishLobby(t *testing.T) {
 		defer server.Close()
 
 		client := NewLobbyGatewayClient(server.URL)
-		res, err := client.FinishLobby(context.Background(), "lobby-xyz")
+		res, err := client.FinishLobby(context.Background(), "lobby-xyz", "player1")
 
 		require.NoError(t, err)
 		assert.Equal(t, "Finished", res.Status)
@@ -109,7 +571,7 @@ func TestLobbyGatewayClientFinishLobby(t *testing.T) {
 		defer server.Close()
 
 		client := NewLobbyGatewayClient(server.URL)
-		_, err := client.FinishLobby(context.Background(), "lobby-xyz")
+		_, err := client.FinishLobby(context.Background(), "lobby-xyz", "player1")
 
 		require.Error(t, err)
 		apiErr, ok := err.(*APIError)
@@ -163,6 +625,7 @@ func TestLobbyGatewayClientListAvailableLobbies(t *testing.T) {
 			},
 		}
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.RawQuery)
 			w.WriteHeader(http.StatusOK)
 			body, _ := protojson.Marshal(mockResponse)
 			_, err := w.Write(body)
@@ -173,25 +636,559 @@ func TestLobbyGatewayClientListAvailableLobbies(t *testing.T) {
 		defer server.Close()
 
 		client := NewLobbyGatewayClient(server.URL)
-		lobbies, err := client.ListAvailableLobbies(context.Background())
+		resp, err := client.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{})
 
 		require.NoError(t, err)
-		assert.Len(t, lobbies, 2)
-		assert.Equal(t, "Lobby One", lobbies[0].Name)
+		assert.Len(t, resp.Lobbies, 2)
+		assert.Equal(t, "Lobby One", resp.Lobbies[0].Name)
 	})
 
-	t.Run("Failure", func(t *testing.T) {
+	t.Run("SendsTheFilters", func(t *testing.T) {
+		createdAfter := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusInternalServerError)
+			query := r.URL.Query()
+			assert.Equal(t, "next", query.Get("page_token"))
+			assert.Equal(t, "friday night", query.Get("name_query"))
+			assert.Equal(t, "1", query.Get("min_free_slots"))
+			assert.Equal(t, "2025-01-01T12:00:00Z", query.Get("created_after"))
+			assert.Equal(t, "NAME", query.Get("sort"))
+			assert.Equal(t, "FREE_FOR_ALL", query.Get("game_mode"))
+			assert.Equal(t, "NA", query.Get("region"))
+			body, _ := protojson.Marshal(&lobby.ListAvailableLobbiesResponse{NextPageToken: "after-next"})
+			_, _ = w.Write(body)
 		}))
 		defer server.Close()
 
 		client := NewLobbyGatewayClient(server.URL)
-		_, err := client.ListAvailableLobbies(context.Background())
+		resp, err := client.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{
+			PageToken:    "next",
+			NameQuery:    "friday night",
+			MinFreeSlots: 1,
+			CreatedAfter: timestamppb.New(createdAfter),
+			Sort:         "NAME",
+			GameMode:     "FREE_FOR_ALL",
+			Region:       "NA",
+		})
 
-		require.Error(t, err)
-		apiErr, ok := err.(*APIError)
-		require.True(t, ok)
-		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+		require.NoError(t, err)
+		assert.Equal(t, "after-next", resp.NextPageToken)
 	})
+
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientInviteToLobby(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Invite{InviteId: 7, LobbyId: "lobby-abc", InviteeUsername: "friend"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/lobby-abc/invites", r.URL.Path)
+			assert.Equal(t, "Bearer host-token", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		req := &lobby.InviteToLobbyRequest{LobbyId: "lobby-abc", InviteeUsername: "friend"}
+		res, err := client.InviteToLobby(token.NewContext(context.Background(), "host-token"), req)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(7), res.InviteId)
+	})
+
+	t.Run("Failure - Already Invited", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		req := &lobby.InviteToLobbyRequest{LobbyId: "lobby-abc", InviteeUsername: "friend"}
+		_, err := client.InviteToLobby(context.Background(), req)
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientGetMyCurrentLobby(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-123", Name: "My Lobby"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/lobbies/current", r.URL.Path)
+			assert.Equal(t, "player1", r.URL.Query().Get("username"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		currentLobby, err := client.GetMyCurrentLobby(context.Background(), "player1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "lobby-123", currentLobby.LobbyId)
+	})
+
+	t.Run("NotInALobby", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.GetMyCurrentLobby(context.Background(), "player1")
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientGetMyCooldown(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.GetMyCooldownResponse{CooldownUntil: timestamppb.New(time.Unix(1700000000, 0)), Infractions: 2}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/cooldown", r.URL.Path)
+			assert.Equal(t, "player1", r.URL.Query().Get("username"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		cooldown, err := client.GetMyCooldown(context.Background(), "player1")
+
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, cooldown.GetInfractions())
+		assert.Equal(t, int64(1700000000), cooldown.GetCooldownUntil().GetSeconds())
+	})
+
+	t.Run("ServerError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.GetMyCooldown(context.Background(), "player1")
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientGetQueueStats(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		median := uint32(20)
+		mockResponse := &lobby.GetQueueStatsResponse{
+			SearchingPlayers:  3,
+			OpenLobbies:       []*lobby.OpenLobbies{{GameMode: "DUEL", Region: "EU", Lobbies: 2, Players: 3}},
+			MedianWaitSeconds: &median,
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/queue-stats", r.URL.Path)
+			assert.Equal(t, "DUEL", r.URL.Query().Get("game_mode"))
+			assert.False(t, r.URL.Query().Has("region"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		stats, err := client.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{GameMode: "DUEL"})
+
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, stats.GetSearchingPlayers())
+		require.Len(t, stats.GetOpenLobbies(), 1)
+		assert.EqualValues(t, 20, stats.GetMedianWaitSeconds())
+		assert.Nil(t, stats.EstimatedWaitSeconds)
+	})
+
+	t.Run("InvalidGameMode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{GameMode: "UNKNOWN"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientListMyMatches(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.ListMyMatchesResponse{
+			Matches:       []*lobby.Match{{Lobby: &lobby.Lobby{LobbyId: "lobby-123"}, Placement: 1}},
+			NextPageToken: "20",
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/matches", r.URL.Path)
+			assert.Equal(t, "player1", r.URL.Query().Get("username"))
+			assert.Equal(t, "10", r.URL.Query().Get("page_token"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		matches, err := client.ListMyMatches(context.Background(), "player1", "10")
+
+		require.NoError(t, err)
+		require.Len(t, matches.Matches, 1)
+		assert.Equal(t, int32(1), matches.Matches[0].Placement)
+		assert.Equal(t, "20", matches.NextPageToken)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.ListMyMatches(context.Background(), "player1", "bad")
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientListMyInvites(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.ListMyInvitesResponse{
+			Invites: []*lobby.Invite{{InviteId: 7, LobbyName: "Lobby One"}},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/invites", r.URL.Path)
+			assert.Equal(t, "Bearer friend-token", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		invites, err := client.ListMyInvites(token.NewContext(context.Background(), "friend-token"))
+
+		require.NoError(t, err)
+		assert.Len(t, invites, 1)
+		assert.Equal(t, "Lobby One", invites[0].LobbyName)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.ListMyInvites(context.Background())
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientAcceptInvite(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.Lobby{LobbyId: "lobby-abc"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/invites/7/accept", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.AcceptInvite(context.Background(), &lobby.RespondInviteRequest{InviteId: 7})
+
+		require.NoError(t, err)
+		assert.Equal(t, "lobby-abc", res.LobbyId)
+	})
+
+	t.Run("Failure - Expired", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.AcceptInvite(context.Background(), &lobby.RespondInviteRequest{InviteId: 7})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientDeclineInvite(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/invites/7/decline", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		err := client.DeclineInvite(context.Background(), &lobby.RespondInviteRequest{InviteId: 7})
+		require.NoError(t, err)
+	})
+
+	t.Run("Failure - Not Found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		err := client.DeclineInvite(context.Background(), &lobby.RespondInviteRequest{InviteId: 7})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientListGameModes(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.ListGameModesResponse{
+			Modes:   []*lobby.GameMode{{Name: "DUEL", DisplayName: "Duel", Capacity: 2}},
+			Regions: []string{"EU", "NA"},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/game-modes", r.URL.Path)
+			body, _ := protojson.Marshal(mockResponse)
+			_, _ = w.Write(body)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		resp, err := client.ListGameModes(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, resp.Modes, 1)
+		assert.Equal(t, "Duel", resp.Modes[0].DisplayName)
+		assert.Equal(t, []string{"EU", "NA"}, resp.Regions)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.ListGameModes(context.Background())
+
+		require.Error(t, err)
+	})
+}
+
+func TestLobbyGatewayClientSearchMatch(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.MatchSearch{Status: "SEARCHING", GameMode: "DUEL", Region: "EU"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/matchmaking", r.URL.Path)
+			var searchReq lobby.SearchMatchRequest
+			body, _ := io.ReadAll(r.Body)
+			require.NoError(t, protojson.Unmarshal(body, &searchReq))
+			assert.Equal(t, "DUEL", searchReq.GameMode)
+			w.WriteHeader(http.StatusOK)
+			body, _ = protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.SearchMatch(context.Background(), &lobby.SearchMatchRequest{GameMode: "DUEL", Region: "EU"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "SEARCHING", res.Status)
+	})
+
+	t.Run("Failure - On Cooldown", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.SearchMatch(context.Background(), &lobby.SearchMatchRequest{})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientGetMySearch(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.MatchSearch{Status: "MATCH_FOUND", MatchId: "match-1"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/matchmaking", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.GetMySearch(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "match-1", res.MatchId)
+	})
+
+	t.Run("Failure - Not Searching", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.GetMySearch(context.Background())
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientCancelSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/matchmaking/cancel", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewLobbyGatewayClient(server.URL)
+	err := client.CancelSearch(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestLobbyGatewayClientAcceptMatch(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &lobby.MatchSearch{Status: "MATCHED", MatchId: "match-1", LobbyId: "lobby-abc"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/matchmaking/match-1/accept", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		res, err := client.AcceptMatch(context.Background(), &lobby.RespondMatchRequest{MatchId: "match-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "lobby-abc", res.LobbyId)
+	})
+
+	t.Run("Failure - Match Over", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewLobbyGatewayClient(server.URL)
+		_, err := client.AcceptMatch(context.Background(), &lobby.RespondMatchRequest{MatchId: "match-1"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestLobbyGatewayClientDeclineMatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/matchmaking/match-1/decline", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewLobbyGatewayClient(server.URL)
+	err := client.DeclineMatch(context.Background(), &lobby.RespondMatchRequest{MatchId: "match-1"})
+
+	require.NoError(t, err)
 }
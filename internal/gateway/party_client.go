@@ -0,0 +1,83 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+)
+
+type PartyGatewayClient struct {
+	*baseClient
+}
+
+func NewPartyGatewayClient(baseURL string) *PartyGatewayClient {
+	return &PartyGatewayClient{
+		&baseClient{
+			baseURL:    baseURL,
+			httpClient: &http.Client{},
+		},
+	}
+}
+
+func (c *PartyGatewayClient) CreateParty(ctx context.Context, req *party.CreatePartyRequest) (*party.Party, error) {
+	var createdParty party.Party
+	err := c.doProtoRequest(ctx, http.MethodPost, "/api/v1/parties", req, &createdParty)
+	if err != nil {
+		return nil, err
+	}
+	return &createdParty, nil
+}
+
+// GetMyParty returns the party of the user. It fails with a 404 APIError if the user is not in a party.
+func (c *PartyGatewayClient) GetMyParty(ctx context.Context, username string) (*party.Party, error) {
+	var myParty party.Party
+	path := "/api/v1/parties/current?username=" + url.QueryEscape(username)
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &myParty)
+	if err != nil {
+		return nil, err
+	}
+	return &myParty, nil
+}
+
+func (c *PartyGatewayClient) InviteToParty(ctx context.Context, req *party.InviteToPartyRequest) (*party.PartyInvite, error) {
+	var invite party.PartyInvite
+	path := fmt.Sprintf("/api/v1/parties/%d/invites", req.PartyId)
+	err := c.doProtoRequest(ctx, http.MethodPost, path, req, &invite)
+	if err != nil {
+		return nil, err
+	}
+	return &invite, nil
+}
+
+func (c *PartyGatewayClient) ListMyPartyInvites(ctx context.Context, username string) ([]*party.PartyInvite, error) {
+	var invitesResponse party.ListMyPartyInvitesResponse
+	path := "/api/v1/party-invites?username=" + url.QueryEscape(username)
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &invitesResponse)
+	if err != nil {
+		return nil, err
+	}
+	return invitesResponse.Invites, nil
+}
+
+func (c *PartyGatewayClient) AcceptPartyInvite(ctx context.Context, req *party.RespondPartyInviteRequest) (*party.Party, error) {
+	var joinedParty party.Party
+	path := fmt.Sprintf("/api/v1/party-invites/%d/accept", req.InviteId)
+	err := c.doProtoRequest(ctx, http.MethodPut, path, req, &joinedParty)
+	if err != nil {
+		return nil, err
+	}
+	return &joinedParty, nil
+}
+
+func (c *PartyGatewayClient) DeclinePartyInvite(ctx context.Context, req *party.RespondPartyInviteRequest) error {
+	path := fmt.Sprintf("/api/v1/party-invites/%d/decline", req.InviteId)
+	return c.doProtoRequest(ctx, http.MethodPut, path, req, nil)
+}
+
+func (c *PartyGatewayClient) LeaveParty(ctx context.Context, req *party.LeavePartyRequest) error {
+	path := fmt.Sprintf("/api/v1/parties/%d/leave", req.PartyId)
+	return c.doProtoRequest(ctx, http.MethodPut, path, req, nil)
+}
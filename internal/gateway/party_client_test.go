@@ -0,0 +1,223 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestPartyGatewayClientCreateParty(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &party.Party{PartyId: 3, LeaderUsername: "leader"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/parties", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		res, err := client.CreateParty(context.Background(), &party.CreatePartyRequest{Username: "leader"})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(3), res.PartyId)
+		assert.Equal(t, "leader", res.LeaderUsername)
+	})
+
+	t.Run("Failure - Already In A Party", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		_, err := client.CreateParty(context.Background(), &party.CreatePartyRequest{Username: "leader"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestPartyGatewayClientGetMyParty(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &party.Party{PartyId: 3, Members: []*party.PartyMember{{Id: 1, Username: "leader"}}}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/parties/current", r.URL.Path)
+			assert.Equal(t, "leader", r.URL.Query().Get("username"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		res, err := client.GetMyParty(context.Background(), "leader")
+
+		require.NoError(t, err)
+		require.Len(t, res.Members, 1)
+		assert.Equal(t, "leader", res.Members[0].Username)
+	})
+
+	t.Run("Failure - Not In A Party", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		_, err := client.GetMyParty(context.Background(), "leader")
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestPartyGatewayClientInviteToParty(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &party.PartyInvite{InviteId: 7, InviteeUsername: "friend"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/parties/3/invites", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		res, err := client.InviteToParty(context.Background(), &party.InviteToPartyRequest{
+			PartyId:         3,
+			Username:        "leader",
+			InviteeUsername: "friend",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(7), res.InviteId)
+	})
+
+	t.Run("Failure - Not The Leader", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		_, err := client.InviteToParty(context.Background(), &party.InviteToPartyRequest{PartyId: 3, Username: "friend"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	})
+}
+
+func TestPartyGatewayClientListMyPartyInvites(t *testing.T) {
+	mockResponse := &party.ListMyPartyInvitesResponse{
+		Invites: []*party.PartyInvite{{InviteId: 7, InviterUsername: "leader"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/party-invites", r.URL.Path)
+		assert.Equal(t, "friend", r.URL.Query().Get("username"))
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(mockResponse)
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewPartyGatewayClient(server.URL)
+	invites, err := client.ListMyPartyInvites(context.Background(), "friend")
+
+	require.NoError(t, err)
+	require.Len(t, invites, 1)
+	assert.Equal(t, "leader", invites[0].InviterUsername)
+}
+
+func TestPartyGatewayClientAcceptPartyInvite(t *testing.T) {
+	mockResponse := &party.Party{PartyId: 3}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/party-invites/7/accept", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(mockResponse)
+		_, err := w.Write(body)
+		if err != nil {
+			t.Fatalf("Failed to write response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := NewPartyGatewayClient(server.URL)
+	res, err := client.AcceptPartyInvite(context.Background(), &party.RespondPartyInviteRequest{InviteId: 7, Username: "friend"})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), res.PartyId)
+}
+
+func TestPartyGatewayClientDeclinePartyInvite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/api/v1/party-invites/7/decline", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewPartyGatewayClient(server.URL)
+	err := client.DeclinePartyInvite(context.Background(), &party.RespondPartyInviteRequest{InviteId: 7, Username: "friend"})
+
+	require.NoError(t, err)
+}
+
+func TestPartyGatewayClientLeaveParty(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+			assert.Equal(t, "/api/v1/parties/3/leave", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		err := client.LeaveParty(context.Background(), &party.LeavePartyRequest{PartyId: 3, Username: "friend"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("Failure - Not In The Party", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewPartyGatewayClient(server.URL)
+		err := client.LeaveParty(context.Background(), &party.LeavePartyRequest{PartyId: 3, Username: "friend"})
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
+)
+
+type PresenceGatewayClient struct {
+	*baseClient
+}
+
+func NewPresenceGatewayClient(baseURL string) *PresenceGatewayClient {
+	return &PresenceGatewayClient{
+		&baseClient{
+			baseURL:    baseURL,
+			httpClient: &http.Client{},
+		},
+	}
+}
+
+func (c *PresenceGatewayClient) Heartbeat(ctx context.Context) (*presence.HeartbeatResponse, error) {
+	var heartbeat presence.HeartbeatResponse
+	req := &presence.HeartbeatRequest{}
+	err := c.doProtoRequest(ctx, http.MethodPost, "/api/v1/presence/heartbeat", req, &heartbeat)
+	if err != nil {
+		return nil, err
+	}
+	return &heartbeat, nil
+}
+
+// GetPresence returns the presence of the given users. Without users it only counts the users online.
+func (c *PresenceGatewayClient) GetPresence(ctx context.Context, usernames ...string) (*presence.GetPresenceResponse, error) {
+	var presenceResponse presence.GetPresenceResponse
+	path := "/api/v1/presence"
+	if len(usernames) > 0 {
+		path += "?" + url.Values{"usernames": usernames}.Encode()
+	}
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &presenceResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &presenceResponse, nil
+}
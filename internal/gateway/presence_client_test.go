@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestPresenceGatewayClientHeartbeat(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &presence.HeartbeatResponse{IntervalSeconds: 30}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/presence/heartbeat", r.URL.Path)
+			assert.Equal(t, "Bearer alice-token", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewPresenceGatewayClient(server.URL)
+		res, err := client.Heartbeat(token.NewContext(context.Background(), "alice-token"))
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(30), res.IntervalSeconds)
+	})
+
+	t.Run("Failure - Server Error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		client := NewPresenceGatewayClient(server.URL)
+		_, err := client.Heartbeat(context.Background())
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	})
+}
+
+func TestPresenceGatewayClientGetPresence(t *testing.T) {
+	t.Run("Of Some Users", func(t *testing.T) {
+		mockResponse := &presence.GetPresenceResponse{
+			Presences:   []*presence.UserPresence{{Username: "alice", Online: true}, {Username: "bob"}},
+			OnlineCount: 4,
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/presence", r.URL.Path)
+			assert.Equal(t, []string{"alice", "bob"}, r.URL.Query()["usernames"])
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewPresenceGatewayClient(server.URL)
+		res, err := client.GetPresence(context.Background(), "alice", "bob")
+
+		require.NoError(t, err)
+		require.Len(t, res.Presences, 2)
+		assert.True(t, res.Presences[0].Online)
+		assert.Equal(t, uint32(4), res.OnlineCount)
+	})
+
+	t.Run("Online Count Only", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Empty(t, r.URL.RawQuery)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"onlineCount": 2}`))
+		}))
+		defer server.Close()
+
+		client := NewPresenceGatewayClient(server.URL)
+		res, err := client.GetPresence(context.Background())
+
+		require.NoError(t, err)
+		assert.Empty(t, res.Presences)
+		assert.Equal(t, uint32(2), res.OnlineCount)
+	})
+}
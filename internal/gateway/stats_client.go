@@ -0,0 +1,45 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/stats"
+)
+
+type StatsGatewayClient struct {
+	*baseClient
+}
+
+func NewStatsGatewayClient(baseURL string) *StatsGatewayClient {
+	return &StatsGatewayClient{
+		&baseClient{
+			baseURL:    baseURL,
+			httpClient: &http.Client{},
+		},
+	}
+}
+
+func (c *StatsGatewayClient) GetPlayerStats(ctx context.Context, player string) (*stats.PlayerStats, error) {
+	var playerStats stats.PlayerStats
+	path := "/api/v1/players/" + url.PathEscape(player) + "/stats"
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &playerStats)
+	if err != nil {
+		return nil, err
+	}
+	return &playerStats, nil
+}
+
+func (c *StatsGatewayClient) ListRecentGames(ctx context.Context, player, pageToken string) (*stats.ListRecentGamesResponse, error) {
+	var gamesResponse stats.ListRecentGamesResponse
+	path := "/api/v1/players/" + url.PathEscape(player) + "/games"
+	if pageToken != "" {
+		path += "?" + url.Values{"page_token": {pageToken}}.Encode()
+	}
+	err := c.doProtoRequest(ctx, http.MethodGet, path, nil, &gamesResponse)
+	if err != nil {
+		return nil, err
+	}
+	return &gamesResponse, nil
+}
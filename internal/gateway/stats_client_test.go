@@ -0,0 +1,96 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestStatsGatewayClientGetPlayerStats(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &stats.PlayerStats{Username: "player1", GamesPlayed: 4, Wins: 3, Losses: 1, WinRate: 0.75}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/players/player1/stats", r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewStatsGatewayClient(server.URL)
+		playerStats, err := client.GetPlayerStats(context.Background(), "player1")
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), playerStats.Wins)
+		assert.Equal(t, 0.75, playerStats.WinRate)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := NewStatsGatewayClient(server.URL)
+		_, err := client.GetPlayerStats(context.Background(), "ghost")
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
+
+func TestStatsGatewayClientListRecentGames(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockResponse := &stats.ListRecentGamesResponse{
+			Games:         []*stats.Game{{LobbyId: "lobby-123", Opponents: []string{"player2"}, Won: true}},
+			NextPageToken: "20",
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/players/player1/games", r.URL.Path)
+			assert.Equal(t, "10", r.URL.Query().Get("page_token"))
+			w.WriteHeader(http.StatusOK)
+			body, _ := protojson.Marshal(mockResponse)
+			_, err := w.Write(body)
+			if err != nil {
+				t.Fatalf("Failed to write response: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		client := NewStatsGatewayClient(server.URL)
+		games, err := client.ListRecentGames(context.Background(), "player1", "10")
+
+		require.NoError(t, err)
+		require.Len(t, games.Games, 1)
+		assert.True(t, games.Games[0].Won)
+		assert.Equal(t, "20", games.NextPageToken)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		client := NewStatsGatewayClient(server.URL)
+		_, err := client.ListRecentGames(context.Background(), "player1", "bad")
+
+		require.Error(t, err)
+		apiErr, ok := err.(*APIError)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
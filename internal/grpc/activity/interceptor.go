@@ -0,0 +1,42 @@
+package activity
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"google.golang.org/grpc"
+)
+
+// callerRequest is implemented by the requests that carry the username of the caller.
+type callerRequest interface {
+	GetUsername() string
+}
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+// UnaryServerInterceptor records when the caller of a successful request was last seen, so that the lobbies of
+// inactive users can be told apart. The authenticated caller, when there is one, wins over the username of the
+// request.
+func UnaryServerInterceptor(userRepo usrrepo.UserRepository) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		username, ok := caller.FromContext(ctx)
+		if request, isCallerRequest := req.(callerRequest); !ok && isCallerRequest {
+			username = request.GetUsername()
+		}
+		if username != "" {
+			if err := userRepo.UpdateLastSeen(username, now()); err != nil {
+				log.Printf("Failed to record the activity of %s: %v", username, err)
+			}
+		}
+		return resp, nil
+	}
+}
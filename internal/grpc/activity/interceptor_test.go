@@ -0,0 +1,132 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(user *models.User, hashedPassword string) error {
+	args := m.Called(user, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLastSeen(username string, seenAt time.Time) error {
+	args := m.Called(username, seenAt)
+	return args.Error(0)
+}
+
+type InterceptorTestSuite struct {
+	suite.Suite
+	userRepo    *MockUserRepository
+	interceptor grpc.UnaryServerInterceptor
+	originalNow func() time.Time
+}
+
+func (s *InterceptorTestSuite) SetupTest() {
+	s.userRepo = new(MockUserRepository)
+	s.interceptor = UnaryServerInterceptor(s.userRepo)
+	s.originalNow = now
+	now = func() time.Time { return fixtureNow }
+}
+
+func (s *InterceptorTestSuite) TearDownTest() {
+	now = s.originalNow
+}
+
+func (s *InterceptorTestSuite) intercept(req any, handlerErr error) (any, error) {
+	return s.interceptWithContext(context.Background(), req, handlerErr)
+}
+
+func (s *InterceptorTestSuite) interceptWithContext(ctx context.Context, req any, handlerErr error) (any, error) {
+	handler := func(ctx context.Context, req any) (any, error) {
+		if handlerErr != nil {
+			return nil, handlerErr
+		}
+		return &lobby.Lobby{LobbyId: "lobby-123"}, nil
+	}
+	return s.interceptor(ctx, req, &grpc.UnaryServerInfo{}, handler)
+}
+
+func (s *InterceptorTestSuite) TestRecordsTheActivityOfTheCaller() {
+	s.userRepo.On("UpdateLastSeen", "player1", fixtureNow).Return(nil)
+
+	resp, err := s.intercept(&lobby.JoinLobbyRequest{LobbyId: "lobby-123", Username: "player1"}, nil)
+
+	s.NoError(err)
+	s.Equal("lobby-123", resp.(*lobby.Lobby).LobbyId)
+	s.userRepo.AssertExpectations(s.T())
+}
+
+func (s *InterceptorTestSuite) TestRecordsTheActivityOfTheAuthenticatedCaller() {
+	s.userRepo.On("UpdateLastSeen", "host", fixtureNow).Return(nil)
+
+	_, err := s.interceptWithContext(caller.NewContext(context.Background(), "host"),
+		&lobby.KickPlayerRequest{LobbyId: "lobby-123", PlayerUsername: "troll"}, nil)
+
+	s.NoError(err)
+	s.userRepo.AssertExpectations(s.T())
+}
+
+func (s *InterceptorTestSuite) TestIgnoresTheFailedRequests() {
+	handlerErr := errors.New("lobby is full")
+
+	_, err := s.intercept(&lobby.JoinLobbyRequest{LobbyId: "lobby-123", Username: "player1"}, handlerErr)
+
+	s.ErrorIs(err, handlerErr)
+	s.userRepo.AssertNotCalled(s.T(), "UpdateLastSeen", mock.Anything, mock.Anything)
+}
+
+func (s *InterceptorTestSuite) TestIgnoresTheRequestsWithoutACaller() {
+	_, err := s.intercept(&lobby.GetLobbyRequest{LobbyId: "lobby-123"}, nil)
+
+	s.NoError(err)
+	s.userRepo.AssertNotCalled(s.T(), "UpdateLastSeen", mock.Anything, mock.Anything)
+}
+
+func (s *InterceptorTestSuite) TestDoesNotFailTheRequestWhenTheActivityCannotBeRecorded() {
+	s.userRepo.On("UpdateLastSeen", "player1", fixtureNow).Return(errors.New("db error"))
+
+	_, err := s.intercept(&lobby.JoinLobbyRequest{LobbyId: "lobby-123", Username: "player1"}, nil)
+
+	s.NoError(err)
+}
+
+func TestInterceptor(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
@@ -3,13 +3,14 @@ package auth
 import (
 	"context"
 	"errors"
+	"log"
 	"strings"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/auth"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/password"
 	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
-	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -19,12 +20,14 @@ type AuthService struct {
 	auth.UnimplementedAuthServiceServer
 	userRepository usrrepo.UserRepository
 	jwtManager     token.TokenManager
+	hasher         password.PasswordHasher
 }
 
-func NewAuthService(repo usrrepo.UserRepository, manager token.TokenManager) auth.AuthServiceServer {
+func NewAuthService(repo usrrepo.UserRepository, manager token.TokenManager, hasher password.PasswordHasher) auth.AuthServiceServer {
 	return &AuthService{
 		userRepository: repo,
 		jwtManager:     manager,
+		hasher:         hasher,
 	}
 }
 
@@ -39,14 +42,14 @@ func (s *AuthService) RegisterUser(ctx context.Context, req *auth.RegisterUserRe
 		return nil, status.Errorf(codes.AlreadyExists, "username is already taken")
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.GetPassword()), bcrypt.DefaultCost)
+	hashedPassword, err := s.hasher.Hash(req.GetPassword())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
 	}
 
 	userModel := &models.User{
 		Username: username,
-		Password: string(hashedPassword),
+		Password: hashedPassword,
 	}
 
 	if err := s.userRepository.Create(userModel); err != nil {
@@ -56,7 +59,8 @@ func (s *AuthService) RegisterUser(ctx context.Context, req *auth.RegisterUserRe
 	return toProtoUser(userModel), nil
 }
 
-// It checks for the credentials and returns the computed JWT to the caller
+// It checks for the credentials and returns the computed JWT to the caller.
+// If the stored hash was made by an older algorithm or with weaker parameters, the password is rehashed and saved.
 func (s *AuthService) LoginUser(ctx context.Context, req *auth.LoginUserRequest) (*auth.LoginUserResponse, error) {
 	user, err := s.userRepository.FindByUsername(req.GetUsername())
 	if err != nil {
@@ -66,11 +70,15 @@ func (s *AuthService) LoginUser(ctx context.Context, req *auth.LoginUserRequest)
 		return nil, status.Errorf(codes.Internal, "failed to retrieve user: %v", err)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.GetPassword()))
+	err = s.hasher.Verify(user.Password, req.GetPassword())
 	if err != nil {
 		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials")
 	}
 
+	if s.hasher.NeedsRehash(user.Password) {
+		s.rehashPassword(user, req.GetPassword())
+	}
+
 	token, err := s.jwtManager.Create(user.Username)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create token: %v", err)
@@ -82,6 +90,22 @@ func (s *AuthService) LoginUser(ctx context.Context, req *auth.LoginUserRequest)
 	}, nil
 }
 
+// rehashPassword upgrades the stored hash. A failure here must not prevent the login, since the password was
+// already verified: the upgrade will simply be tried again at the next login.
+func (s *AuthService) rehashPassword(user *models.User, plainPassword string) {
+	hashedPassword, err := s.hasher.Hash(plainPassword)
+	if err != nil {
+		log.Printf("failed to rehash password for user %s: %v", user.Username, err)
+		return
+	}
+
+	if err := s.userRepository.UpdatePassword(user, hashedPassword); err != nil {
+		log.Printf("failed to save rehashed password for user %s: %v", user.Username, err)
+		return
+	}
+	user.Password = hashedPassword
+}
+
 func toProtoUser(user *models.User) *auth.User {
 	return &auth.User{
 		Id:       uint32(user.ID),
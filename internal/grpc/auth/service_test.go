@@ -3,10 +3,13 @@ package auth
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/NicoPolazzi/multiplayer-queue/gen/auth"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/password"
 	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -33,6 +36,15 @@ func (m *MockUserRepository) FindByUsername(username string) (*models.User, erro
 func (m *MockUserRepository) FindByID(id uint) (*models.User, error) {
 	return nil, nil
 }
+func (m *MockUserRepository) UpdatePassword(user *models.User, hashedPassword string) error {
+	args := m.Called(user, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLastSeen(username string, seenAt time.Time) error {
+	args := m.Called(username, seenAt)
+	return args.Error(0)
+}
 
 type MockTokenManager struct {
 	mock.Mock
@@ -46,17 +58,54 @@ func (m *MockTokenManager) Validate(token string) (string, error) {
 	return "", nil
 }
 
+type MockPasswordHasher struct {
+	mock.Mock
+}
+
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	args := m.Called(password)
+	return args.String(0), args.Error(1)
+}
+func (m *MockPasswordHasher) Verify(hash, password string) error {
+	args := m.Called(hash, password)
+	return args.Error(0)
+}
+func (m *MockPasswordHasher) NeedsRehash(hash string) bool {
+	args := m.Called(hash)
+	return args.Bool(0)
+}
+
+// Cheap argon2id parameters, so that the suite stays fast.
+var fixtureArgon2idParams = password.Argon2idParams{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
 type AuthServerTestSuite struct {
 	suite.Suite
 	usrRepo    *MockUserRepository
 	jwtManager *MockTokenManager
+	hasher     password.PasswordHasher
 	server     pb.AuthServiceServer
 }
 
 func (s *AuthServerTestSuite) SetupTest() {
 	s.usrRepo = new(MockUserRepository)
 	s.jwtManager = new(MockTokenManager)
-	s.server = NewAuthService(s.usrRepo, s.jwtManager)
+	s.hasher = password.NewPasswordHasher(
+		password.NewArgon2idHasher(fixtureArgon2idParams),
+		password.NewBcryptHasher(bcrypt.DefaultCost),
+	)
+	s.server = NewAuthService(s.usrRepo, s.jwtManager, s.hasher)
+}
+
+func (s *AuthServerTestSuite) currentHash(plainPassword string) string {
+	hash, err := s.hasher.Hash(plainPassword)
+	s.Require().NoError(err)
+	return hash
 }
 
 func (s *AuthServerTestSuite) TestRegisterUserSuccess() {
@@ -118,13 +167,27 @@ func (s *AuthServerTestSuite) TestRegisterUserWhenCreateFails() {
 	s.usrRepo.AssertExpectations(s.T())
 }
 
+func (s *AuthServerTestSuite) TestRegisterUserStoresAnArgon2idHash() {
+	req := &pb.RegisterUserRequest{Username: "newuser", Password: "password123"}
+	s.usrRepo.On("FindByUsername", "newuser").Return(nil, usrrepo.ErrUserNotFound)
+	s.usrRepo.On("Create", mock.MatchedBy(func(user *models.User) bool {
+		return strings.HasPrefix(user.Password, "$argon2id$") && s.hasher.Verify(user.Password, "password123") == nil
+	})).Return(nil)
+
+	_, err := s.server.RegisterUser(context.Background(), req)
+
+	s.NoError(err)
+	s.usrRepo.AssertExpectations(s.T())
+}
+
 func (s *AuthServerTestSuite) TestRegisterUserWhenPasswordHashingFails() {
-	// bcrypt fails for passwords longer than 72 bytes.
-	longPassword := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
-	req := &pb.RegisterUserRequest{Username: "newuser", Password: longPassword}
+	hasher := new(MockPasswordHasher)
+	server := NewAuthService(s.usrRepo, s.jwtManager, hasher)
+	req := &pb.RegisterUserRequest{Username: "newuser", Password: "password123"}
 	s.usrRepo.On("FindByUsername", "newuser").Return(nil, usrrepo.ErrUserNotFound)
+	hasher.On("Hash", "password123").Return("", errors.New("hashing error"))
 
-	resp, err := s.server.RegisterUser(context.Background(), req)
+	resp, err := server.RegisterUser(context.Background(), req)
 
 	s.Empty(resp)
 	st, ok := status.FromError(err)
@@ -136,11 +199,10 @@ func (s *AuthServerTestSuite) TestRegisterUserWhenPasswordHashingFails() {
 }
 
 func (s *AuthServerTestSuite) TestLoginUserSuccess() {
-	password := "password123"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	mockUser := &models.User{Username: "testuser", Password: string(hashedPassword)}
+	plainPassword := "password123"
+	mockUser := &models.User{Username: "testuser", Password: s.currentHash(plainPassword)}
 	mockUser.ID = 1
-	req := &pb.LoginUserRequest{Username: "testuser", Password: password}
+	req := &pb.LoginUserRequest{Username: "testuser", Password: plainPassword}
 	s.usrRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
 	s.jwtManager.On("Create", "testuser").Return("mock-jwt-token", nil)
 
@@ -152,9 +214,84 @@ func (s *AuthServerTestSuite) TestLoginUserSuccess() {
 	s.Equal(uint32(1), resp.User.Id)
 	s.Equal("testuser", resp.User.Username)
 	s.usrRepo.AssertExpectations(s.T())
+	s.usrRepo.AssertNotCalled(s.T(), "UpdatePassword", mock.Anything, mock.Anything)
 	s.jwtManager.AssertExpectations(s.T())
 }
 
+func (s *AuthServerTestSuite) TestLoginUserRehashesLegacyBcryptHash() {
+	plainPassword := "password123"
+	legacyHash, _ := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.MinCost)
+	mockUser := &models.User{Username: "testuser", Password: string(legacyHash)}
+	req := &pb.LoginUserRequest{Username: "testuser", Password: plainPassword}
+	s.usrRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.usrRepo.On("UpdatePassword", mockUser, mock.MatchedBy(func(hash string) bool {
+		return strings.HasPrefix(hash, "$argon2id$") && s.hasher.Verify(hash, plainPassword) == nil
+	})).Return(nil)
+	s.jwtManager.On("Create", "testuser").Return("mock-jwt-token", nil)
+
+	resp, err := s.server.LoginUser(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal("mock-jwt-token", resp.Token)
+	s.False(s.hasher.NeedsRehash(mockUser.Password))
+	s.usrRepo.AssertExpectations(s.T())
+}
+
+func (s *AuthServerTestSuite) TestLoginUserRehashesWhenArgon2idParamsAreWeaker() {
+	plainPassword := "password123"
+	weakParams := fixtureArgon2idParams
+	weakParams.Memory = 32
+	weakHash, err := password.NewArgon2idHasher(weakParams).Hash(plainPassword)
+	s.Require().NoError(err)
+	mockUser := &models.User{Username: "testuser", Password: weakHash}
+	req := &pb.LoginUserRequest{Username: "testuser", Password: plainPassword}
+	s.usrRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.usrRepo.On("UpdatePassword", mockUser, mock.MatchedBy(func(hash string) bool {
+		return strings.HasPrefix(hash, "$argon2id$v=19$m=64,")
+	})).Return(nil)
+	s.jwtManager.On("Create", "testuser").Return("mock-jwt-token", nil)
+
+	_, err = s.server.LoginUser(context.Background(), req)
+
+	s.NoError(err)
+	s.usrRepo.AssertExpectations(s.T())
+}
+
+func (s *AuthServerTestSuite) TestLoginUserSucceedsWhenRehashCanNotBeSaved() {
+	plainPassword := "password123"
+	legacyHash, _ := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.MinCost)
+	mockUser := &models.User{Username: "testuser", Password: string(legacyHash)}
+	req := &pb.LoginUserRequest{Username: "testuser", Password: plainPassword}
+	s.usrRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.usrRepo.On("UpdatePassword", mockUser, mock.AnythingOfType("string")).Return(errors.New("db error"))
+	s.jwtManager.On("Create", "testuser").Return("mock-jwt-token", nil)
+
+	resp, err := s.server.LoginUser(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal("mock-jwt-token", resp.Token)
+	s.Equal(string(legacyHash), mockUser.Password)
+}
+
+func (s *AuthServerTestSuite) TestLoginUserSucceedsWhenRehashFails() {
+	hasher := new(MockPasswordHasher)
+	server := NewAuthService(s.usrRepo, s.jwtManager, hasher)
+	mockUser := &models.User{Username: "testuser", Password: "old-hash"}
+	req := &pb.LoginUserRequest{Username: "testuser", Password: "password123"}
+	s.usrRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	hasher.On("Verify", "old-hash", "password123").Return(nil)
+	hasher.On("NeedsRehash", "old-hash").Return(true)
+	hasher.On("Hash", "password123").Return("", errors.New("hashing error"))
+	s.jwtManager.On("Create", "testuser").Return("mock-jwt-token", nil)
+
+	resp, err := server.LoginUser(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal("mock-jwt-token", resp.Token)
+	s.usrRepo.AssertNotCalled(s.T(), "UpdatePassword", mock.Anything, mock.Anything)
+	hasher.AssertExpectations(s.T())
+}
+
 func (s *AuthServerTestSuite) TestLoginUserWhenUserNotFound() {
 	req := &pb.LoginUserRequest{Username: "unknown", Password: "password123"}
 	s.usrRepo.On("FindByUsername", "unknown").Return(nil, usrrepo.ErrUserNotFound)
@@ -170,9 +307,7 @@ func (s *AuthServerTestSuite) TestLoginUserWhenUserNotFound() {
 }
 
 func (s *AuthServerTestSuite) TestLoginUserWithWrongPassword() {
-	password := "password123"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	mockUser := &models.User{Username: "testuser", Password: string(hashedPassword)}
+	mockUser := &models.User{Username: "testuser", Password: s.currentHash("password123")}
 	req := &pb.LoginUserRequest{Username: "testuser", Password: "wrongpassword"}
 	s.usrRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
 
@@ -186,10 +321,9 @@ func (s *AuthServerTestSuite) TestLoginUserWithWrongPassword() {
 }
 
 func (s *AuthServerTestSuite) TestLoginUserWhenTokenCreationFails() {
-	password := "password123"
-	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	mockUser := &models.User{Username: "testuser", Password: string(hashedPassword)}
-	req := &pb.LoginUserRequest{Username: "testuser", Password: password}
+	plainPassword := "password123"
+	mockUser := &models.User{Username: "testuser", Password: s.currentHash(plainPassword)}
+	req := &pb.LoginUserRequest{Username: "testuser", Password: plainPassword}
 	tokenError := errors.New("jwt error")
 	s.usrRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
 	s.jwtManager.On("Create", "testuser").Return("", tokenError)
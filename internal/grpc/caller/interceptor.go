@@ -0,0 +1,83 @@
+package caller
+
+import (
+	"context"
+	"strings"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorizationKey is the metadata the gateway forwards the Authorization header of the HTTP request as.
+const authorizationKey = "authorization"
+
+type callerKey struct{}
+
+// NewContext returns a copy of the context that carries the username of the authenticated caller.
+func NewContext(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, callerKey{}, username)
+}
+
+// FromContext returns the username of the authenticated caller, if the request carried a valid token.
+func FromContext(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(callerKey{}).(string)
+	return username, ok
+}
+
+// UnaryServerInterceptor authenticates the caller with the bearer token of the request, so that the services can
+// authorise against who is calling rather than against a username in the request. A request without a token goes on
+// without a caller, while a request with an invalid token is rejected.
+func UnaryServerInterceptor(tokenManager token.TokenManager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, tokenManager)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates the caller of a streaming RPC like UnaryServerInterceptor does for the
+// others.
+func StreamServerInterceptor(tokenManager token.TokenManager) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), tokenManager)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &callerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate returns the context of the caller of the bearer token the metadata carry, or the context as is when
+// they carry none.
+func authenticate(ctx context.Context, tokenManager token.TokenManager) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	tokenString, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "the authorization must be a bearer token")
+	}
+	username, err := tokenManager.Validate(tokenString)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+	return NewContext(ctx, username), nil
+}
+
+// callerStream is a server stream whose context carries the authenticated caller.
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
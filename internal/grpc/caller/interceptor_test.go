@@ -0,0 +1,135 @@
+package caller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/token"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type InterceptorTestSuite struct {
+	suite.Suite
+	tokenManager      token.TokenManager
+	interceptor       grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+}
+
+func (s *InterceptorTestSuite) SetupTest() {
+	s.tokenManager = token.NewJWTTokenManager([]byte("test-secret"))
+	s.interceptor = UnaryServerInterceptor(s.tokenManager)
+	s.streamInterceptor = StreamServerInterceptor(s.tokenManager)
+}
+
+// intercept runs the interceptor with the authorization metadata, if any, and returns the caller seen by the handler.
+func (s *InterceptorTestSuite) intercept(authorization ...string) (string, bool, error) {
+	ctx := context.Background()
+	if len(authorization) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationKey, authorization[0]))
+	}
+
+	var username string
+	var found, called bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		username, found = FromContext(ctx)
+		return nil, nil
+	}
+	_, err := s.interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	s.Equal(err == nil, called)
+	return username, found, err
+}
+
+func (s *InterceptorTestSuite) TestAuthenticatesTheCallerOfTheToken() {
+	tokenString, err := s.tokenManager.Create("host")
+	s.Require().NoError(err)
+
+	username, found, err := s.intercept("Bearer " + tokenString)
+
+	s.NoError(err)
+	s.True(found)
+	s.Equal("host", username)
+}
+
+func (s *InterceptorTestSuite) TestLetsTheRequestsWithoutATokenThroughWithoutACaller() {
+	_, found, err := s.intercept()
+
+	s.NoError(err)
+	s.False(found)
+}
+
+func (s *InterceptorTestSuite) TestRejectsAnInvalidToken() {
+	_, _, err := s.intercept("Bearer forged")
+
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (s *InterceptorTestSuite) TestRejectsAnAuthorizationThatIsNotABearerToken() {
+	tokenString, err := s.tokenManager.Create("host")
+	s.Require().NoError(err)
+
+	_, _, err = s.intercept(tokenString)
+
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+// serverStream is the stream of a streaming RPC whose context carries the authorization metadata, if any.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// interceptStream runs the stream interceptor like intercept runs the unary one.
+func (s *InterceptorTestSuite) interceptStream(authorization ...string) (string, bool, error) {
+	ctx := context.Background()
+	if len(authorization) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationKey, authorization[0]))
+	}
+
+	var username string
+	var found, called bool
+	handler := func(srv any, stream grpc.ServerStream) error {
+		called = true
+		username, found = FromContext(stream.Context())
+		return nil
+	}
+	err := s.streamInterceptor(nil, &serverStream{ctx: ctx}, &grpc.StreamServerInfo{}, handler)
+	s.Equal(err == nil, called)
+	return username, found, err
+}
+
+func (s *InterceptorTestSuite) TestAuthenticatesTheCallerOfAStream() {
+	tokenString, err := s.tokenManager.Create("player")
+	s.Require().NoError(err)
+
+	username, found, err := s.interceptStream("Bearer " + tokenString)
+
+	s.NoError(err)
+	s.True(found)
+	s.Equal("player", username)
+}
+
+func (s *InterceptorTestSuite) TestLetsTheStreamsWithoutATokenThroughWithoutACaller() {
+	_, found, err := s.interceptStream()
+
+	s.NoError(err)
+	s.False(found)
+}
+
+func (s *InterceptorTestSuite) TestRejectsAStreamWithAnInvalidToken() {
+	_, _, err := s.interceptStream("Bearer forged")
+
+	s.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func TestInterceptor(t *testing.T) {
+	suite.Run(t, new(InterceptorTestSuite))
+}
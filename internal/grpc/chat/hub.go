@@ -0,0 +1,55 @@
+package chat
+
+import (
+	"log"
+	"sync"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+)
+
+// subscriberBuffer is how many messages a stream can fall behind before it starts missing them.
+const subscriberBuffer = 32
+
+// hub delivers the new messages of a lobby to the streams open on it.
+type hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *chat.ChatMessage]struct{}
+}
+
+func newHub() *hub {
+	return &hub{subscribers: make(map[string]map[chan *chat.ChatMessage]struct{})}
+}
+
+// subscribe returns the channel of the new messages of the lobby, and the function that closes the subscription.
+func (h *hub) subscribe(lobbyID string) (<-chan *chat.ChatMessage, func()) {
+	messages := make(chan *chat.ChatMessage, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[lobbyID] == nil {
+		h.subscribers[lobbyID] = make(map[chan *chat.ChatMessage]struct{})
+	}
+	h.subscribers[lobbyID][messages] = struct{}{}
+
+	return messages, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[lobbyID], messages)
+		if len(h.subscribers[lobbyID]) == 0 {
+			delete(h.subscribers, lobbyID)
+		}
+	}
+}
+
+// publish never blocks: a stream whose buffer is full misses the message.
+func (h *hub) publish(lobbyID string, message *chat.ChatMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for subscriber := range h.subscribers[lobbyID] {
+		select {
+		case subscriber <- message:
+		default:
+			log.Printf("Dropped chat message %d of lobby %s for a slow stream", message.Id, lobbyID)
+		}
+	}
+}
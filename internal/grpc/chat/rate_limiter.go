@@ -0,0 +1,54 @@
+package chat
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiter allows each user at most limit messages within any window of time.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	sent   map[string][]time.Time
+	// swept is when the users that stopped chatting were last forgotten.
+	swept time.Time
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{limit: limit, window: window, sent: make(map[string][]time.Time)}
+}
+
+// allow records the message of the user sent at the given time, unless it goes over the limit.
+func (l *rateLimiter) allow(username string, at time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(at)
+
+	recent := l.sent[username][:0]
+	for _, sentAt := range l.sent[username] {
+		if at.Sub(sentAt) < l.window {
+			recent = append(recent, sentAt)
+		}
+	}
+	if len(recent) >= l.limit {
+		l.sent[username] = recent
+		return false
+	}
+	l.sent[username] = append(recent, at)
+	return true
+}
+
+// sweep forgets the users whose last message is out of the window, at most once per window, so that the users who
+// stopped chatting do not stay in memory.
+func (l *rateLimiter) sweep(at time.Time) {
+	if at.Sub(l.swept) < l.window {
+		return
+	}
+	l.swept = at
+	for username, sent := range l.sent {
+		if len(sent) == 0 || at.Sub(sent[len(sent)-1]) >= l.window {
+			delete(l.sent, username)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterForgetsTheUsersThatStoppedChatting(t *testing.T) {
+	limiter := newRateLimiter(2, time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, limiter.allow("idle", start))
+	assert.True(t, limiter.allow("active", start.Add(30*time.Second)))
+	assert.True(t, limiter.allow("active", start.Add(70*time.Second)))
+
+	assert.NotContains(t, limiter.sent, "idle")
+	assert.Len(t, limiter.sent["active"], 2)
+}
+
+func TestRateLimiterStillLimitsAfterASweep(t *testing.T) {
+	limiter := newRateLimiter(1, time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, limiter.allow("player", start))
+	assert.True(t, limiter.allow("player", start.Add(time.Minute)))
+	assert.False(t, limiter.allow("player", start.Add(90*time.Second)))
+}
@@ -0,0 +1,194 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/moderation"
+	chatrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/chat"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxMessageLength is the maximum number of characters of a message.
+const maxMessageLength = 500
+
+// Config bounds the chat of every lobby.
+type Config struct {
+	// Backlog is how many messages of a lobby are kept, and sent to the users that open the chat.
+	Backlog int
+	// RateLimit is how many messages a user can send within RateWindow.
+	RateLimit  int
+	RateWindow time.Duration
+}
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+// ChatService implements the gRPC chat service of the lobbies. The messages are stored before being delivered to the
+// streams open on this server.
+type ChatService struct {
+	chat.UnimplementedLobbyChatServiceServer
+	chatRepo  chatrepo.ChatRepository
+	lobbyRepo lobbyrepo.LobbyRepository
+	userRepo  usrrepo.UserRepository
+	filter    moderation.ContentFilter
+	limiter   *rateLimiter
+	hub       *hub
+	backlog   int
+}
+
+func NewChatService(chatRepo chatrepo.ChatRepository, lobbyRepo lobbyrepo.LobbyRepository,
+	userRepo usrrepo.UserRepository, filter moderation.ContentFilter, cfg Config) chat.LobbyChatServiceServer {
+	return &ChatService{
+		chatRepo:  chatRepo,
+		lobbyRepo: lobbyRepo,
+		userRepo:  userRepo,
+		filter:    filter,
+		limiter:   newRateLimiter(cfg.RateLimit, cfg.RateWindow),
+		hub:       newHub(),
+		backlog:   cfg.Backlog,
+	}
+}
+
+func (s *ChatService) SendMessage(ctx context.Context, req *chat.SendMessageRequest) (*chat.ChatMessage, error) {
+	text := strings.TrimSpace(req.GetText())
+	if text == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "message cannot be empty")
+	}
+	if utf8.RuneCountInString(text) > maxMessageLength {
+		return nil, status.Errorf(codes.InvalidArgument, "message cannot be longer than %d characters", maxMessageLength)
+	}
+
+	author, err := s.memberOf(ctx, req.GetLobbyId(), "chat")
+	if err != nil {
+		return nil, err
+	}
+
+	sentAt := now()
+	if !s.limiter.allow(author.Username, sentAt) {
+		return nil, status.Errorf(codes.ResourceExhausted, "too many messages, slow down")
+	}
+
+	text, err = s.filter.Filter(text)
+	if errors.Is(err, moderation.ErrMessageRejected) {
+		return nil, status.Errorf(codes.InvalidArgument, "message rejected by the content filter")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Content filter error: %v", err)
+	}
+
+	message := &models.ChatMessage{LobbyID: req.GetLobbyId(), UserID: author.ID, Text: text, SentAt: sentAt}
+	if err := s.chatRepo.Create(message, s.backlog); err != nil {
+		return nil, status.Errorf(codes.Internal, "Chat DB error: %v", err)
+	}
+	message.User = *author
+
+	pMessage := toProtoMessage(message)
+	s.hub.publish(message.LobbyID, pMessage)
+	return pMessage, nil
+}
+
+// StreamMessages subscribes to the lobby before reading its backlog, so that no message is lost in between: the
+// messages already sent with the backlog are skipped. The stream ends as soon as a new message finds that the user is
+// no longer in the lobby.
+func (s *ChatService) StreamMessages(req *chat.StreamMessagesRequest, stream chat.LobbyChatService_StreamMessagesServer) error {
+	member, err := s.memberOf(stream.Context(), req.GetLobbyId(), "read the chat")
+	if err != nil {
+		return err
+	}
+
+	messages, unsubscribe := s.hub.subscribe(req.GetLobbyId())
+	defer unsubscribe()
+
+	backlog, err := s.chatRepo.ListRecent(req.GetLobbyId(), s.backlog)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Chat DB error: %v", err)
+	}
+	var lastID uint32
+	for _, message := range backlog {
+		pMessage := toProtoMessage(message)
+		if err := stream.Send(pMessage); err != nil {
+			return err
+		}
+		lastID = pMessage.Id
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case message := <-messages:
+			if message.Id <= lastID {
+				continue
+			}
+			// The user may have left the lobby, or been kicked from it, since the stream was opened.
+			if err := s.checkMember(req.GetLobbyId(), member); err != nil {
+				return err
+			}
+			if err := stream.Send(message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// memberOf returns the authenticated caller when they are a player or a spectator of the lobby. The action completes
+// the message returned to the anonymous callers.
+func (s *ChatService) memberOf(ctx context.Context, lobbyID, action string) (*models.User, error) {
+	username, ok := caller.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "sign in to %s", action)
+	}
+
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+	if err := s.checkMember(lobbyID, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkMember reads the lobby again, and fails unless the user is one of its players or spectators.
+func (s *ChatService) checkMember(lobbyID string, user *models.User) error {
+	chatLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	for _, player := range chatLobby.Players {
+		if player.UserID == user.ID {
+			return nil
+		}
+	}
+	for _, spectator := range chatLobby.Spectators {
+		if spectator.UserID == user.ID {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "only the players and the spectators of the lobby can chat")
+}
+
+func toProtoMessage(m *models.ChatMessage) *chat.ChatMessage {
+	return &chat.ChatMessage{
+		Id:       uint32(m.ID),
+		LobbyId:  m.LobbyID,
+		Username: m.User.Username,
+		Text:     m.Text,
+		SentAt:   timestamppb.New(m.SentAt),
+	}
+}
@@ -0,0 +1,302 @@
+package chat
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/moderation"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const fixtureLobbyID = "lobby-123"
+
+var (
+	fixtureNow    = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	fixtureConfig = Config{Backlog: 20, RateLimit: 2, RateWindow: 10 * time.Second}
+)
+
+// MockUserRepository implements only the methods the chat service calls: the embedded interface is nil.
+type MockUserRepository struct {
+	mock.Mock
+	usrrepo.UserRepository
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+// MockLobbyRepository implements only the methods the chat service calls: the embedded interface is nil.
+type MockLobbyRepository struct {
+	mock.Mock
+	lobbyrepo.LobbyRepository
+}
+
+func (m *MockLobbyRepository) FindByID(lobbyID string) (*models.Lobby, error) {
+	args := m.Called(lobbyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Lobby), args.Error(1)
+}
+
+type MockChatRepository struct {
+	mock.Mock
+}
+
+func (m *MockChatRepository) Create(message *models.ChatMessage, backlog int) error {
+	args := m.Called(message, backlog)
+	if args.Error(0) == nil {
+		message.ID = uint(len(m.Calls))
+	}
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) ListRecent(lobbyID string, limit int) ([]*models.ChatMessage, error) {
+	args := m.Called(lobbyID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.ChatMessage), args.Error(1)
+}
+
+// fakeStream collects the messages sent to the client until its context is cancelled.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *chat.ChatMessage
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStream) Send(message *chat.ChatMessage) error {
+	f.sent <- message
+	return nil
+}
+
+type ChatServiceTestSuite struct {
+	suite.Suite
+	chatRepo  *MockChatRepository
+	lobbyRepo *MockLobbyRepository
+	userRepo  *MockUserRepository
+	service   chat.LobbyChatServiceServer
+	player    *models.User
+	spectator *models.User
+	outsider  *models.User
+}
+
+func (s *ChatServiceTestSuite) SetupTest() {
+	s.chatRepo = new(MockChatRepository)
+	s.lobbyRepo = new(MockLobbyRepository)
+	s.userRepo = new(MockUserRepository)
+	s.service = NewChatService(s.chatRepo, s.lobbyRepo, s.userRepo, moderation.NewWordListFilter([]string{"darn"}),
+		fixtureConfig)
+
+	s.player = &models.User{Username: "player"}
+	s.player.ID = 1
+	s.spectator = &models.User{Username: "spectator"}
+	s.spectator.ID = 2
+	s.outsider = &models.User{Username: "outsider"}
+	s.outsider.ID = 3
+	for _, user := range []*models.User{s.player, s.spectator, s.outsider} {
+		s.userRepo.On("FindByUsername", user.Username).Return(user, nil)
+	}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Players:    []models.LobbyPlayer{{UserID: s.player.ID, User: *s.player}},
+		Spectators: []models.LobbySpectator{{UserID: s.spectator.ID, User: *s.spectator}},
+	}, nil)
+}
+
+func (s *ChatServiceTestSuite) stubNow() func() {
+	original := now
+	now = func() time.Time { return fixtureNow }
+	return func() { now = original }
+}
+
+// callerContext returns the context of a request authenticated as the user.
+func callerContext(username string) context.Context {
+	return caller.NewContext(context.Background(), username)
+}
+
+func (s *ChatServiceTestSuite) assertGrpcError(err error, code codes.Code) {
+	s.Require().Error(err)
+	st, ok := status.FromError(err)
+	s.Require().True(ok)
+	s.Equal(code, st.Code())
+}
+
+func (s *ChatServiceTestSuite) TestSendMessageFiltersAndStoresTheMessage() {
+	defer s.stubNow()()
+	s.chatRepo.On("Create", mock.AnythingOfType("*models.ChatMessage"), fixtureConfig.Backlog).Return(nil)
+
+	resp, err := s.service.SendMessage(callerContext("spectator"), &chat.SendMessageRequest{
+		LobbyId: fixtureLobbyID,
+		Text:    "  darn, good game  ",
+	})
+
+	s.NoError(err)
+	s.Equal("****, good game", resp.Text)
+	s.Equal("spectator", resp.Username)
+	s.Equal(fixtureNow, resp.SentAt.AsTime())
+	stored := s.chatRepo.Calls[0].Arguments.Get(0).(*models.ChatMessage)
+	s.Equal("****, good game", stored.Text)
+	s.Equal(s.spectator.ID, stored.UserID)
+}
+
+func (s *ChatServiceTestSuite) TestSendMessageFailsForAUserOutsideTheLobby() {
+	_, err := s.service.SendMessage(callerContext("outsider"), &chat.SendMessageRequest{
+		LobbyId: fixtureLobbyID,
+		Text:    "hello",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied)
+	s.chatRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *ChatServiceTestSuite) TestSendMessageFailsWhenUnauthenticated() {
+	_, err := s.service.SendMessage(context.Background(), &chat.SendMessageRequest{
+		LobbyId: fixtureLobbyID,
+		Text:    "hello",
+	})
+
+	s.assertGrpcError(err, codes.Unauthenticated)
+	s.chatRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *ChatServiceTestSuite) TestSendMessageFailsWithAnInvalidText() {
+	for _, text := range []string{"   ", string(make([]rune, maxMessageLength+1))} {
+		_, err := s.service.SendMessage(callerContext("player"), &chat.SendMessageRequest{
+			LobbyId: fixtureLobbyID,
+			Text:    text,
+		})
+
+		s.assertGrpcError(err, codes.InvalidArgument)
+	}
+	s.chatRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *ChatServiceTestSuite) TestSendMessageIsRateLimitedPerUser() {
+	defer s.stubNow()()
+	s.chatRepo.On("Create", mock.AnythingOfType("*models.ChatMessage"), fixtureConfig.Backlog).Return(nil)
+	send := func(username string) error {
+		_, err := s.service.SendMessage(callerContext(username), &chat.SendMessageRequest{
+			LobbyId: fixtureLobbyID,
+			Text:    "hello",
+		})
+		return err
+	}
+
+	s.NoError(send("player"))
+	s.NoError(send("player"))
+	s.assertGrpcError(send("player"), codes.ResourceExhausted)
+	s.NoError(send("spectator"))
+
+	now = func() time.Time { return fixtureNow.Add(fixtureConfig.RateWindow) }
+	s.NoError(send("player"))
+}
+
+func (s *ChatServiceTestSuite) TestSendMessageFailsWhenTheLobbyDoesNotExist() {
+	s.lobbyRepo.On("FindByID", "missing").Return(nil, lobbyrepo.ErrLobbyNotFound)
+
+	_, err := s.service.SendMessage(callerContext("player"), &chat.SendMessageRequest{
+		LobbyId: "missing",
+		Text:    "hello",
+	})
+
+	s.assertGrpcError(err, codes.NotFound)
+}
+
+func (s *ChatServiceTestSuite) TestStreamMessagesSendsTheBacklogThenTheNewMessages() {
+	backlog := []*models.ChatMessage{
+		{ID: 1, LobbyID: fixtureLobbyID, User: *s.player, Text: "first", SentAt: fixtureNow},
+	}
+	s.chatRepo.On("ListRecent", fixtureLobbyID, fixtureConfig.Backlog).Return(backlog, nil)
+	s.chatRepo.On("Create", mock.AnythingOfType("*models.ChatMessage"), fixtureConfig.Backlog).Return(nil)
+	ctx, cancel := context.WithCancel(callerContext("spectator"))
+	stream := &fakeStream{ctx: ctx, sent: make(chan *chat.ChatMessage, 2)}
+	done := make(chan error)
+
+	go func() {
+		done <- s.service.StreamMessages(&chat.StreamMessagesRequest{LobbyId: fixtureLobbyID}, stream)
+	}()
+	s.Equal("first", (<-stream.sent).Text)
+	_, err := s.service.SendMessage(callerContext("player"), &chat.SendMessageRequest{
+		LobbyId: fixtureLobbyID,
+		Text:    "second",
+	})
+	s.Require().NoError(err)
+	s.Equal("second", (<-stream.sent).Text)
+
+	cancel()
+	s.NoError(<-done)
+}
+
+func (s *ChatServiceTestSuite) TestStreamMessagesFailsForAUserOutsideTheLobby() {
+	stream := &fakeStream{ctx: callerContext("outsider"), sent: make(chan *chat.ChatMessage, 1)}
+
+	err := s.service.StreamMessages(&chat.StreamMessagesRequest{LobbyId: fixtureLobbyID}, stream)
+
+	s.assertGrpcError(err, codes.PermissionDenied)
+	s.chatRepo.AssertNotCalled(s.T(), "ListRecent", mock.Anything, mock.Anything)
+}
+
+func (s *ChatServiceTestSuite) TestStreamMessagesFailsWhenUnauthenticated() {
+	stream := &fakeStream{ctx: context.Background(), sent: make(chan *chat.ChatMessage, 1)}
+
+	err := s.service.StreamMessages(&chat.StreamMessagesRequest{LobbyId: fixtureLobbyID}, stream)
+
+	s.assertGrpcError(err, codes.Unauthenticated)
+	s.chatRepo.AssertNotCalled(s.T(), "ListRecent", mock.Anything, mock.Anything)
+}
+
+func (s *ChatServiceTestSuite) TestStreamMessagesEndsOnceTheUserLeftTheLobby() {
+	full := &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Players:    []models.LobbyPlayer{{UserID: s.player.ID, User: *s.player}},
+		Spectators: []models.LobbySpectator{{UserID: s.spectator.ID, User: *s.spectator}},
+	}
+	left := &models.Lobby{LobbyID: fixtureLobbyID, Players: full.Players}
+	s.lobbyRepo.ExpectedCalls = nil
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(full, nil).Twice()
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(left, nil).Once()
+	backlog := []*models.ChatMessage{
+		{ID: 1, LobbyID: fixtureLobbyID, User: *s.player, Text: "first", SentAt: fixtureNow},
+	}
+	s.chatRepo.On("ListRecent", fixtureLobbyID, fixtureConfig.Backlog).Return(backlog, nil)
+	s.chatRepo.On("Create", mock.AnythingOfType("*models.ChatMessage"), fixtureConfig.Backlog).Return(nil)
+	stream := &fakeStream{ctx: callerContext("spectator"), sent: make(chan *chat.ChatMessage, 1)}
+	done := make(chan error)
+
+	go func() {
+		done <- s.service.StreamMessages(&chat.StreamMessagesRequest{LobbyId: fixtureLobbyID}, stream)
+	}()
+	s.Equal("first", (<-stream.sent).Text)
+	_, err := s.service.SendMessage(callerContext("player"), &chat.SendMessageRequest{
+		LobbyId: fixtureLobbyID,
+		Text:    "hello",
+	})
+	s.Require().NoError(err)
+
+	s.assertGrpcError(<-done, codes.PermissionDenied)
+	s.Empty(stream.sent)
+}
+
+func TestChatService(t *testing.T) {
+	suite.Run(t, new(ChatServiceTestSuite))
+}
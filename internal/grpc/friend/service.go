@@ -0,0 +1,263 @@
+package friend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	friendrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/friend"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	presencerepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/presence"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The presence of a friend, from the most to the least busy.
+const (
+	presenceInGame  = "IN_GAME"
+	presenceInLobby = "IN_LOBBY"
+	presenceOnline  = "ONLINE"
+	presenceOffline = "OFFLINE"
+)
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+// FriendService implements the gRPC friend service. The blocks it stores are enforced by the lobby and party services
+// as well, which read them from the same repository.
+type FriendService struct {
+	friend.UnimplementedFriendServiceServer
+	friendRepo friendrepo.FriendRepository
+	userRepo   usrrepo.UserRepository
+	// lobbyRepo tells whether the friends are in a lobby or in game.
+	lobbyRepo lobbyrepo.LobbyRepository
+	// presenceRepo tells whether the friends that are not in a lobby are connected.
+	presenceRepo presencerepo.PresenceRepository
+}
+
+func NewFriendService(friendRepo friendrepo.FriendRepository, userRepo usrrepo.UserRepository,
+	lobbyRepo lobbyrepo.LobbyRepository, presenceRepo presencerepo.PresenceRepository) friend.FriendServiceServer {
+	return &FriendService{
+		friendRepo:   friendRepo,
+		userRepo:     userRepo,
+		lobbyRepo:    lobbyRepo,
+		presenceRepo: presenceRepo,
+	}
+}
+
+func (s *FriendService) SendFriendRequest(ctx context.Context, req *friend.SendFriendRequestRequest) (*friend.FriendRequest, error) {
+	requester, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid requester: %v", err)
+	}
+
+	if req.GetFriendUsername() == requester.Username {
+		return nil, status.Errorf(codes.InvalidArgument, "you cannot befriend yourself")
+	}
+
+	addressee, err := s.userRepo.FindByUsername(req.GetFriendUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	if err := s.checkNotBlocked(requester, addressee); err != nil {
+		return nil, err
+	}
+
+	request := &models.Friendship{
+		RequesterID: requester.ID,
+		Requester:   *requester,
+		AddresseeID: addressee.ID,
+		Addressee:   *addressee,
+		Status:      models.FriendshipStatusPending,
+	}
+	err = s.friendRepo.CreateRequest(request)
+	if errors.Is(err, friendrepo.ErrFriendshipExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "you are already friends, or a friend request is pending between you")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	return toProtoRequest(request), nil
+}
+
+// checkNotBlocked refuses the friend request when either user blocked the other.
+func (s *FriendService) checkNotBlocked(requester, addressee *models.User) error {
+	blocked, err := s.friendRepo.HasBlocked([]uint{addressee.ID}, requester.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	if blocked {
+		return status.Errorf(codes.PermissionDenied, "you cannot send a friend request to this user")
+	}
+
+	blocked, err = s.friendRepo.HasBlocked([]uint{requester.ID}, addressee.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	if blocked {
+		return status.Errorf(codes.FailedPrecondition, "you have blocked this user")
+	}
+	return nil
+}
+
+func (s *FriendService) AcceptFriendRequest(ctx context.Context, req *friend.AcceptFriendRequestRequest) (*friend.Friend, error) {
+	addressee, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	request, err := s.friendRepo.FindByID(uint(req.GetRequestId()))
+	if errors.Is(err, friendrepo.ErrFriendshipNotFound) {
+		return nil, status.Errorf(codes.NotFound, "friend request not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+
+	if request.AddresseeID != addressee.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "the friend request is not addressed to you")
+	}
+	if request.Status != models.FriendshipStatusPending {
+		return nil, status.Errorf(codes.FailedPrecondition, "the friend request has already been accepted")
+	}
+
+	err = s.friendRepo.Accept(request, now())
+	if errors.Is(err, friendrepo.ErrFriendshipNotFound) {
+		return nil, status.Errorf(codes.FailedPrecondition, "the friend request is no longer pending")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	return s.toProtoFriend(request, addressee.ID)
+}
+
+func (s *FriendService) RemoveFriend(ctx context.Context, req *friend.RemoveFriendRequest) (*friend.RemoveFriendResponse, error) {
+	user, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	other, err := s.userRepo.FindByUsername(req.GetFriendUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	friendship, err := s.friendRepo.FindBetween(user.ID, other.ID)
+	if errors.Is(err, friendrepo.ErrFriendshipNotFound) {
+		return nil, status.Errorf(codes.NotFound, "you are not friends with this user")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+
+	// Removing the friendship twice at the same time is not an error: it is gone either way.
+	err = s.friendRepo.Delete(friendship)
+	if err != nil && !errors.Is(err, friendrepo.ErrFriendshipNotFound) {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	return &friend.RemoveFriendResponse{}, nil
+}
+
+func (s *FriendService) BlockUser(ctx context.Context, req *friend.BlockUserRequest) (*friend.BlockUserResponse, error) {
+	blocker, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	if req.GetBlockedUsername() == blocker.Username {
+		return nil, status.Errorf(codes.InvalidArgument, "you cannot block yourself")
+	}
+
+	blocked, err := s.userRepo.FindByUsername(req.GetBlockedUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	if err := s.friendRepo.Block(blocker.ID, blocked.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	return &friend.BlockUserResponse{}, nil
+}
+
+func (s *FriendService) ListFriends(ctx context.Context, req *friend.ListFriendsRequest) (*friend.ListFriendsResponse, error) {
+	user, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	friendships, err := s.friendRepo.ListFriends(user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	requests, err := s.friendRepo.ListIncomingRequests(user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+
+	resp := &friend.ListFriendsResponse{
+		Friends:          make([]*friend.Friend, 0, len(friendships)),
+		IncomingRequests: make([]*friend.FriendRequest, 0, len(requests)),
+	}
+	for _, friendship := range friendships {
+		protoFriend, err := s.toProtoFriend(friendship, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp.Friends = append(resp.Friends, protoFriend)
+	}
+	for _, request := range requests {
+		resp.IncomingRequests = append(resp.IncomingRequests, toProtoRequest(request))
+	}
+	return resp, nil
+}
+
+// presenceOf tells what the user is doing: the lobby they are in comes first, then the heartbeats of their clients.
+func (s *FriendService) presenceOf(user *models.User) (string, error) {
+	currentLobby, err := s.lobbyRepo.FindActiveByPlayer(user.ID)
+	switch {
+	case err == nil && currentLobby.Status == models.LobbyStatusInProgress:
+		return presenceInGame, nil
+	case err == nil:
+		return presenceInLobby, nil
+	case !errors.Is(err, lobbyrepo.ErrLobbyNotFound):
+		return "", status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	presences, err := s.presenceRepo.FindByUsers([]uint{user.ID})
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "Presence DB error: %v", err)
+	}
+	if len(presences) > 0 && presences[0].Online(now()) {
+		return presenceOnline, nil
+	}
+	return presenceOffline, nil
+}
+
+// toProtoFriend converts the friendship into the entry of the friend of the given user.
+func (s *FriendService) toProtoFriend(friendship *models.Friendship, userID uint) (*friend.Friend, error) {
+	other := friendship.Other(userID)
+	presence, err := s.presenceOf(&other)
+	if err != nil {
+		return nil, err
+	}
+
+	protoFriend := &friend.Friend{Username: other.Username, Presence: presence}
+	if friendship.AcceptedAt != nil {
+		protoFriend.FriendsSince = timestamppb.New(*friendship.AcceptedAt)
+	}
+	return protoFriend, nil
+}
+
+func toProtoRequest(request *models.Friendship) *friend.FriendRequest {
+	return &friend.FriendRequest{
+		RequestId:    uint32(request.ID),
+		FromUsername: request.Requester.Username,
+		ToUsername:   request.Addressee.Username,
+		CreatedAt:    timestamppb.New(request.CreatedAt),
+	}
+}
@@ -0,0 +1,416 @@
+package friend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	friendrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/friend"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	presencerepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/presence"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// MockUserRepository implements only the methods the friend service calls: the embedded interface is nil.
+type MockUserRepository struct {
+	mock.Mock
+	usrrepo.UserRepository
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+// MockLobbyRepository implements only the methods the friend service calls: the embedded interface is nil.
+type MockLobbyRepository struct {
+	mock.Mock
+	lobbyrepo.LobbyRepository
+}
+
+func (m *MockLobbyRepository) FindActiveByPlayer(userID uint) (*models.Lobby, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Lobby), args.Error(1)
+}
+
+// MockPresenceRepository implements only the methods the friend service calls: the embedded interface is nil.
+type MockPresenceRepository struct {
+	mock.Mock
+	presencerepo.PresenceRepository
+}
+
+func (m *MockPresenceRepository) FindByUsers(userIDs []uint) ([]*models.Presence, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Presence), args.Error(1)
+}
+
+type MockFriendRepository struct {
+	mock.Mock
+}
+
+func (m *MockFriendRepository) CreateRequest(friendship *models.Friendship) error {
+	args := m.Called(friendship)
+	if args.Error(0) == nil {
+		friendship.ID = 5
+		friendship.CreatedAt = fixtureNow
+	}
+	return args.Error(0)
+}
+
+func (m *MockFriendRepository) FindByID(friendshipID uint) (*models.Friendship, error) {
+	args := m.Called(friendshipID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Friendship), args.Error(1)
+}
+
+func (m *MockFriendRepository) FindBetween(userID, otherID uint) (*models.Friendship, error) {
+	args := m.Called(userID, otherID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Friendship), args.Error(1)
+}
+
+func (m *MockFriendRepository) Accept(friendship *models.Friendship, acceptedAt time.Time) error {
+	args := m.Called(friendship, acceptedAt)
+	if args.Error(0) == nil {
+		friendship.Status = models.FriendshipStatusAccepted
+		friendship.AcceptedAt = &acceptedAt
+	}
+	return args.Error(0)
+}
+
+func (m *MockFriendRepository) Delete(friendship *models.Friendship) error {
+	args := m.Called(friendship)
+	return args.Error(0)
+}
+
+func (m *MockFriendRepository) ListFriends(userID uint) ([]*models.Friendship, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Friendship), args.Error(1)
+}
+
+func (m *MockFriendRepository) ListIncomingRequests(userID uint) ([]*models.Friendship, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Friendship), args.Error(1)
+}
+
+func (m *MockFriendRepository) Block(blockerID, blockedID uint) error {
+	args := m.Called(blockerID, blockedID)
+	return args.Error(0)
+}
+
+func (m *MockFriendRepository) HasBlocked(blockerIDs []uint, blockedID uint) (bool, error) {
+	args := m.Called(blockerIDs, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+type FriendServiceTestSuite struct {
+	suite.Suite
+	friendRepo   *MockFriendRepository
+	userRepo     *MockUserRepository
+	lobbyRepo    *MockLobbyRepository
+	presenceRepo *MockPresenceRepository
+	service      friend.FriendServiceServer
+	alice        *models.User
+	bob          *models.User
+}
+
+func (s *FriendServiceTestSuite) SetupTest() {
+	s.friendRepo = new(MockFriendRepository)
+	s.userRepo = new(MockUserRepository)
+	s.lobbyRepo = new(MockLobbyRepository)
+	s.presenceRepo = new(MockPresenceRepository)
+	s.service = NewFriendService(s.friendRepo, s.userRepo, s.lobbyRepo, s.presenceRepo)
+
+	s.alice = &models.User{Username: "alice"}
+	s.alice.ID = 1
+	s.bob = &models.User{Username: "bob"}
+	s.bob.ID = 2
+	for _, user := range []*models.User{s.alice, s.bob} {
+		s.userRepo.On("FindByUsername", user.Username).Return(user, nil)
+	}
+}
+
+func (s *FriendServiceTestSuite) stubNow() func() {
+	original := now
+	now = func() time.Time { return fixtureNow }
+	return func() { now = original }
+}
+
+func (s *FriendServiceTestSuite) assertGrpcError(err error, code codes.Code, msgContains string) {
+	s.Require().Error(err)
+	st, ok := status.FromError(err)
+	s.Require().True(ok)
+	s.Equal(code, st.Code())
+	s.Contains(st.Message(), msgContains)
+}
+
+// requestFixture returns the pending request from alice to bob.
+func (s *FriendServiceTestSuite) requestFixture() *models.Friendship {
+	return &models.Friendship{
+		ID:          5,
+		RequesterID: s.alice.ID,
+		Requester:   *s.alice,
+		AddresseeID: s.bob.ID,
+		Addressee:   *s.bob,
+		Status:      models.FriendshipStatusPending,
+		CreatedAt:   fixtureNow.Add(-time.Hour),
+	}
+}
+
+func (s *FriendServiceTestSuite) acceptedFixture() *models.Friendship {
+	friendship := s.requestFixture()
+	acceptedAt := fixtureNow.Add(-time.Minute)
+	friendship.Status = models.FriendshipStatusAccepted
+	friendship.AcceptedAt = &acceptedAt
+	return friendship
+}
+
+func (s *FriendServiceTestSuite) expectNoBlocks() {
+	s.friendRepo.On("HasBlocked", mock.Anything, mock.AnythingOfType("uint")).Return(false, nil)
+}
+
+func (s *FriendServiceTestSuite) TestSendFriendRequestSuccess() {
+	s.expectNoBlocks()
+	s.friendRepo.On("CreateRequest", mock.MatchedBy(func(f *models.Friendship) bool {
+		return f.RequesterID == s.alice.ID && f.AddresseeID == s.bob.ID && f.Status == models.FriendshipStatusPending
+	})).Return(nil)
+
+	resp, err := s.service.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+		Username:       "alice",
+		FriendUsername: "bob",
+	})
+
+	s.NoError(err)
+	s.Equal(uint32(5), resp.RequestId)
+	s.Equal("alice", resp.FromUsername)
+	s.Equal("bob", resp.ToUsername)
+}
+
+func (s *FriendServiceTestSuite) TestSendFriendRequestToYourself() {
+	_, err := s.service.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+		Username:       "alice",
+		FriendUsername: "alice",
+	})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "yourself")
+}
+
+func (s *FriendServiceTestSuite) TestSendFriendRequestToAnUnknownUser() {
+	s.userRepo.On("FindByUsername", "ghost").Return(nil, usrrepo.ErrUserNotFound)
+
+	_, err := s.service.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+		Username:       "alice",
+		FriendUsername: "ghost",
+	})
+
+	s.assertGrpcError(err, codes.NotFound, "user not found")
+}
+
+func (s *FriendServiceTestSuite) TestSendFriendRequestWhenBlocked() {
+	s.friendRepo.On("HasBlocked", []uint{s.bob.ID}, s.alice.ID).Return(true, nil)
+
+	_, err := s.service.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+		Username:       "alice",
+		FriendUsername: "bob",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "cannot send a friend request")
+	s.friendRepo.AssertNotCalled(s.T(), "CreateRequest", mock.Anything)
+}
+
+func (s *FriendServiceTestSuite) TestSendFriendRequestToABlockedUser() {
+	s.friendRepo.On("HasBlocked", []uint{s.bob.ID}, s.alice.ID).Return(false, nil)
+	s.friendRepo.On("HasBlocked", []uint{s.alice.ID}, s.bob.ID).Return(true, nil)
+
+	_, err := s.service.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+		Username:       "alice",
+		FriendUsername: "bob",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "you have blocked this user")
+}
+
+func (s *FriendServiceTestSuite) TestSendFriendRequestTwice() {
+	s.expectNoBlocks()
+	s.friendRepo.On("CreateRequest", mock.AnythingOfType("*models.Friendship")).Return(friendrepo.ErrFriendshipExists)
+
+	_, err := s.service.SendFriendRequest(context.Background(), &friend.SendFriendRequestRequest{
+		Username:       "alice",
+		FriendUsername: "bob",
+	})
+
+	s.assertGrpcError(err, codes.AlreadyExists, "already friends")
+}
+
+func (s *FriendServiceTestSuite) TestAcceptFriendRequestSuccess() {
+	defer s.stubNow()()
+	request := s.requestFixture()
+	s.friendRepo.On("FindByID", uint(5)).Return(request, nil)
+	s.friendRepo.On("Accept", request, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FindActiveByPlayer", s.alice.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.presenceRepo.On("FindByUsers", []uint{s.alice.ID}).Return([]*models.Presence{}, nil)
+
+	resp, err := s.service.AcceptFriendRequest(context.Background(), &friend.AcceptFriendRequestRequest{
+		RequestId: 5,
+		Username:  "bob",
+	})
+
+	s.NoError(err)
+	s.Equal("alice", resp.Username)
+	s.Equal(presenceOffline, resp.Presence)
+	s.Equal(fixtureNow, resp.FriendsSince.AsTime())
+	s.friendRepo.AssertExpectations(s.T())
+}
+
+func (s *FriendServiceTestSuite) TestAcceptFriendRequestNotAddressedToTheUser() {
+	s.friendRepo.On("FindByID", uint(5)).Return(s.requestFixture(), nil)
+
+	_, err := s.service.AcceptFriendRequest(context.Background(), &friend.AcceptFriendRequestRequest{
+		RequestId: 5,
+		Username:  "alice",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "not addressed to you")
+	s.friendRepo.AssertNotCalled(s.T(), "Accept", mock.Anything, mock.Anything)
+}
+
+func (s *FriendServiceTestSuite) TestAcceptFriendRequestAlreadyAccepted() {
+	s.friendRepo.On("FindByID", uint(5)).Return(s.acceptedFixture(), nil)
+
+	_, err := s.service.AcceptFriendRequest(context.Background(), &friend.AcceptFriendRequestRequest{
+		RequestId: 5,
+		Username:  "bob",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already been accepted")
+}
+
+func (s *FriendServiceTestSuite) TestAcceptFriendRequestNotFound() {
+	s.friendRepo.On("FindByID", uint(5)).Return(nil, friendrepo.ErrFriendshipNotFound)
+
+	_, err := s.service.AcceptFriendRequest(context.Background(), &friend.AcceptFriendRequestRequest{
+		RequestId: 5,
+		Username:  "bob",
+	})
+
+	s.assertGrpcError(err, codes.NotFound, "friend request not found")
+}
+
+func (s *FriendServiceTestSuite) TestRemoveFriendSuccess() {
+	friendship := s.acceptedFixture()
+	s.friendRepo.On("FindBetween", s.bob.ID, s.alice.ID).Return(friendship, nil)
+	s.friendRepo.On("Delete", friendship).Return(nil)
+
+	_, err := s.service.RemoveFriend(context.Background(), &friend.RemoveFriendRequest{
+		Username:       "bob",
+		FriendUsername: "alice",
+	})
+
+	s.NoError(err)
+	s.friendRepo.AssertExpectations(s.T())
+}
+
+func (s *FriendServiceTestSuite) TestRemoveFriendWhenNotFriends() {
+	s.friendRepo.On("FindBetween", s.bob.ID, s.alice.ID).Return(nil, friendrepo.ErrFriendshipNotFound)
+
+	_, err := s.service.RemoveFriend(context.Background(), &friend.RemoveFriendRequest{
+		Username:       "bob",
+		FriendUsername: "alice",
+	})
+
+	s.assertGrpcError(err, codes.NotFound, "not friends")
+}
+
+func (s *FriendServiceTestSuite) TestBlockUserSuccess() {
+	s.friendRepo.On("Block", s.alice.ID, s.bob.ID).Return(nil)
+
+	_, err := s.service.BlockUser(context.Background(), &friend.BlockUserRequest{
+		Username:        "alice",
+		BlockedUsername: "bob",
+	})
+
+	s.NoError(err)
+	s.friendRepo.AssertExpectations(s.T())
+}
+
+func (s *FriendServiceTestSuite) TestBlockYourself() {
+	_, err := s.service.BlockUser(context.Background(), &friend.BlockUserRequest{
+		Username:        "alice",
+		BlockedUsername: "alice",
+	})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "yourself")
+}
+
+func (s *FriendServiceTestSuite) TestListFriendsWithTheirPresence() {
+	defer s.stubNow()()
+	carol := &models.User{Username: "carol"}
+	carol.ID = 3
+	dave := &models.User{Username: "dave"}
+	dave.ID = 4
+	erin := &models.User{Username: "erin"}
+	erin.ID = 5
+	friendships := []*models.Friendship{
+		{RequesterID: s.alice.ID, Requester: *s.alice, AddresseeID: s.bob.ID, Addressee: *s.bob},
+		{RequesterID: carol.ID, Requester: *carol, AddresseeID: s.alice.ID, Addressee: *s.alice},
+		{RequesterID: s.alice.ID, Requester: *s.alice, AddresseeID: dave.ID, Addressee: *dave},
+		{RequesterID: s.alice.ID, Requester: *s.alice, AddresseeID: erin.ID, Addressee: *erin},
+	}
+	request := &models.Friendship{ID: 9, RequesterID: 6, Requester: models.User{Username: "frank"}, AddresseeID: s.alice.ID, Addressee: *s.alice}
+	s.friendRepo.On("ListFriends", s.alice.ID).Return(friendships, nil)
+	s.friendRepo.On("ListIncomingRequests", s.alice.ID).Return([]*models.Friendship{request}, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", s.bob.ID).Return(&models.Lobby{Status: models.LobbyStatusInProgress}, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", erin.ID).Return(&models.Lobby{Status: models.LobbyStatusWaiting}, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mock.AnythingOfType("uint")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.presenceRepo.On("FindByUsers", []uint{carol.ID}).Return([]*models.Presence{
+		{UserID: carol.ID, ExpiresAt: fixtureNow.Add(time.Minute)},
+	}, nil)
+	s.presenceRepo.On("FindByUsers", []uint{dave.ID}).Return([]*models.Presence{
+		{UserID: dave.ID, ExpiresAt: fixtureNow.Add(-time.Minute)},
+	}, nil)
+
+	resp, err := s.service.ListFriends(context.Background(), &friend.ListFriendsRequest{Username: "alice"})
+
+	s.NoError(err)
+	s.Require().Len(resp.Friends, 4)
+	s.Equal("bob", resp.Friends[0].Username)
+	s.Equal(presenceInGame, resp.Friends[0].Presence)
+	s.Equal("carol", resp.Friends[1].Username)
+	s.Equal(presenceOnline, resp.Friends[1].Presence)
+	s.Equal(presenceOffline, resp.Friends[2].Presence)
+	s.Equal(presenceInLobby, resp.Friends[3].Presence)
+	s.Require().Len(resp.IncomingRequests, 1)
+	s.Equal(uint32(9), resp.IncomingRequests[0].RequestId)
+	s.Equal("frank", resp.IncomingRequests[0].FromUsername)
+}
+
+func TestFriendService(t *testing.T) {
+	suite.Run(t, new(FriendServiceTestSuite))
+}
@@ -0,0 +1,171 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultLeaderboardPageSize = 10
+	maxLeaderboardPageSize     = 50
+)
+
+// LeaderboardService implements the gRPC leaderboard service server. The standings are kept up to date by the lobby
+// service as the games finish, and archived by the season rotator.
+type LeaderboardService struct {
+	leaderboard.UnimplementedLeaderboardServiceServer
+	leaderboardRepo leaderboardrepo.LeaderboardRepository
+	userRepo        usrrepo.UserRepository
+}
+
+func NewLeaderboardService(leaderboardRepo leaderboardrepo.LeaderboardRepository,
+	userRepo usrrepo.UserRepository) leaderboard.LeaderboardServiceServer {
+	return &LeaderboardService{leaderboardRepo: leaderboardRepo, userRepo: userRepo}
+}
+
+// GetLeaderboard pages through the ranking. The page token is the number of entries already returned: the live
+// standings move as the games finish, so a page may repeat or miss a player that changed position meanwhile.
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context, req *leaderboard.GetLeaderboardRequest) (*leaderboard.GetLeaderboardResponse, error) {
+	metric, err := toRankingMetric(req.GetMetric())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page size: it can not be negative")
+	case pageSize == 0:
+		pageSize = defaultLeaderboardPageSize
+	case pageSize > maxLeaderboardPageSize:
+		pageSize = maxLeaderboardPageSize
+	}
+
+	offset := 0
+	if req.GetPageToken() != "" {
+		offset, err = strconv.Atoi(req.GetPageToken())
+		if err != nil || offset < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	season, err := s.findSeason(req.GetSeasonId())
+	if err != nil {
+		return nil, err
+	}
+
+	// One more entry than requested tells whether there is a next page.
+	entries, err := s.leaderboardRepo.ListEntries(season, metric, offset, pageSize+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Leaderboard DB error: %v", err)
+	}
+
+	resp := &leaderboard.GetLeaderboardResponse{}
+	if season != nil {
+		resp.Season = toProtoSeason(season)
+	}
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		resp.NextPageToken = strconv.Itoa(offset + pageSize)
+	}
+	resp.Entries = make([]*leaderboard.LeaderboardEntry, len(entries))
+	for i := range entries {
+		resp.Entries[i] = toProtoEntry(&entries[i])
+	}
+	return resp, nil
+}
+
+func (s *LeaderboardService) GetMyRank(ctx context.Context, req *leaderboard.GetMyRankRequest) (*leaderboard.LeaderboardEntry, error) {
+	metric, err := toRankingMetric(req.GetMetric())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	season, err := s.findSeason(req.GetSeasonId())
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.leaderboardRepo.FindEntry(season, metric, user.ID)
+	if errors.Is(err, leaderboardrepo.ErrStandingNotFound) {
+		return nil, status.Errorf(codes.NotFound, "user %s is not ranked: no finished game", req.GetUsername())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Leaderboard DB error: %v", err)
+	}
+	return toProtoEntry(entry), nil
+}
+
+func (s *LeaderboardService) ListSeasons(ctx context.Context, req *leaderboard.ListSeasonsRequest) (*leaderboard.ListSeasonsResponse, error) {
+	seasons, err := s.leaderboardRepo.ListSeasons()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Leaderboard DB error: %v", err)
+	}
+
+	resp := &leaderboard.ListSeasonsResponse{Seasons: make([]*leaderboard.Season, len(seasons))}
+	for i, season := range seasons {
+		resp.Seasons[i] = toProtoSeason(season)
+	}
+	return resp, nil
+}
+
+// findSeason returns nil for the all-time leaderboard.
+func (s *LeaderboardService) findSeason(seasonID uint32) (*models.Season, error) {
+	if uint(seasonID) == models.AllTime {
+		return nil, nil
+	}
+
+	season, err := s.leaderboardRepo.FindSeason(uint(seasonID))
+	if errors.Is(err, leaderboardrepo.ErrSeasonNotFound) {
+		return nil, status.Errorf(codes.NotFound, "season %d not found", seasonID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Leaderboard DB error: %v", err)
+	}
+	return season, nil
+}
+
+func toRankingMetric(metric string) (models.RankingMetric, error) {
+	switch models.RankingMetric(metric) {
+	case "", models.RankingByWins:
+		return models.RankingByWins, nil
+	case models.RankingByRating:
+		return models.RankingByRating, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid metric %q: it must be WINS or RATING", metric)
+	}
+}
+
+func toProtoEntry(entry *leaderboardrepo.Entry) *leaderboard.LeaderboardEntry {
+	return &leaderboard.LeaderboardEntry{
+		Rank:     int32(entry.Rank),
+		Username: entry.Username,
+		Wins:     int32(entry.Wins),
+		Losses:   int32(entry.Losses),
+		Rating:   int32(entry.Rating),
+	}
+}
+
+func toProtoSeason(season *models.Season) *leaderboard.Season {
+	return &leaderboard.Season{
+		Id:       uint32(season.ID),
+		Number:   int32(season.Number),
+		StartsAt: timestamppb.New(season.StartsAt),
+		EndsAt:   timestamppb.New(season.EndsAt),
+		Archived: season.ArchivedAt != nil,
+	}
+}
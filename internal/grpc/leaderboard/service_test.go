@@ -0,0 +1,263 @@
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(user *models.User, hashedPassword string) error {
+	args := m.Called(user, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLastSeen(username string, seenAt time.Time) error {
+	args := m.Called(username, seenAt)
+	return args.Error(0)
+}
+
+type MockLeaderboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardRepository) RecordGame(winnerIDs, loserIDs []uint, finishedAt time.Time) error {
+	args := m.Called(winnerIDs, loserIDs, finishedAt)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardRepository) ListEntries(season *models.Season, metric models.RankingMetric, offset, limit int) ([]leaderboardrepo.Entry, error) {
+	args := m.Called(season, metric, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]leaderboardrepo.Entry), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) FindEntry(season *models.Season, metric models.RankingMetric, userID uint) (*leaderboardrepo.Entry, error) {
+	args := m.Called(season, metric, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardrepo.Entry), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) CreateSeason(season *models.Season) error {
+	args := m.Called(season)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardRepository) FindSeason(seasonID uint) (*models.Season, error) {
+	args := m.Called(seasonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) FindSeasonAt(at time.Time) (*models.Season, error) {
+	args := m.Called(at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) LatestSeason() (*models.Season, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) ListSeasons() ([]*models.Season, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Season), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) ListUnarchivedSeasons(endedBefore time.Time) ([]*models.Season, error) {
+	args := m.Called(endedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Season), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) ArchiveSeason(seasonID uint, archivedAt time.Time) error {
+	args := m.Called(seasonID, archivedAt)
+	return args.Error(0)
+}
+
+type LeaderboardServiceTestSuite struct {
+	suite.Suite
+	leaderboardRepo *MockLeaderboardRepository
+	userRepo        *MockUserRepository
+	service         leaderboard.LeaderboardServiceServer
+	season          *models.Season
+}
+
+func (s *LeaderboardServiceTestSuite) SetupTest() {
+	s.leaderboardRepo = new(MockLeaderboardRepository)
+	s.userRepo = new(MockUserRepository)
+	s.service = NewLeaderboardService(s.leaderboardRepo, s.userRepo)
+
+	archivedAt := time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)
+	s.season = &models.Season{
+		ID:         3,
+		Number:     1,
+		StartsAt:   time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:     archivedAt,
+		ArchivedAt: &archivedAt,
+	}
+}
+
+func (s *LeaderboardServiceTestSuite) assertGrpcError(err error, code codes.Code) {
+	s.Require().Error(err)
+	st, ok := status.FromError(err)
+	s.Require().True(ok)
+	s.Equal(code, st.Code())
+}
+
+func (s *LeaderboardServiceTestSuite) TestGetLeaderboardAllTimeByWins() {
+	s.leaderboardRepo.On("ListEntries", (*models.Season)(nil), models.RankingByWins, 0, defaultLeaderboardPageSize+1).
+		Return([]leaderboardrepo.Entry{
+			{Rank: 1, Username: "first", Wins: 3, Rating: 1040},
+			{Rank: 1, Username: "second", Wins: 3, Rating: 1010},
+		}, nil)
+
+	resp, err := s.service.GetLeaderboard(context.Background(), &leaderboard.GetLeaderboardRequest{})
+
+	s.NoError(err)
+	s.Nil(resp.Season)
+	s.Require().Len(resp.Entries, 2)
+	s.Equal("second", resp.Entries[1].Username)
+	s.Equal(int32(1), resp.Entries[1].Rank)
+	s.Empty(resp.NextPageToken)
+}
+
+func (s *LeaderboardServiceTestSuite) TestGetLeaderboardOfASeasonByRating() {
+	s.leaderboardRepo.On("FindSeason", uint(3)).Return(s.season, nil)
+	s.leaderboardRepo.On("ListEntries", s.season, models.RankingByRating, 4, 3).
+		Return([]leaderboardrepo.Entry{{Rank: 5}, {Rank: 6}, {Rank: 7}}, nil)
+
+	req := &leaderboard.GetLeaderboardRequest{Metric: "RATING", SeasonId: 3, PageSize: 2, PageToken: "4"}
+	resp, err := s.service.GetLeaderboard(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(int32(1), resp.Season.Number)
+	s.True(resp.Season.Archived)
+	s.Len(resp.Entries, 2)
+	s.Equal("6", resp.NextPageToken)
+}
+
+func (s *LeaderboardServiceTestSuite) TestGetLeaderboardFailsWithAnInvalidRequest() {
+	for _, req := range []*leaderboard.GetLeaderboardRequest{
+		{Metric: "LOSSES"},
+		{PageSize: -1},
+		{PageToken: "not-a-number"},
+	} {
+		_, err := s.service.GetLeaderboard(context.Background(), req)
+
+		s.assertGrpcError(err, codes.InvalidArgument)
+	}
+	s.leaderboardRepo.AssertNotCalled(s.T(), "ListEntries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LeaderboardServiceTestSuite) TestGetLeaderboardFailsForAnUnknownSeason() {
+	s.leaderboardRepo.On("FindSeason", uint(9)).Return(nil, leaderboardrepo.ErrSeasonNotFound)
+
+	_, err := s.service.GetLeaderboard(context.Background(), &leaderboard.GetLeaderboardRequest{SeasonId: 9})
+
+	s.assertGrpcError(err, codes.NotFound)
+}
+
+func (s *LeaderboardServiceTestSuite) TestGetLeaderboardFailsOnRepositoryError() {
+	s.leaderboardRepo.On("ListEntries", (*models.Season)(nil), models.RankingByWins, 0, defaultLeaderboardPageSize+1).
+		Return(nil, errors.New("db error"))
+
+	_, err := s.service.GetLeaderboard(context.Background(), &leaderboard.GetLeaderboardRequest{})
+
+	s.assertGrpcError(err, codes.Internal)
+}
+
+func (s *LeaderboardServiceTestSuite) TestGetMyRankSuccess() {
+	user := &models.User{Username: "player"}
+	user.ID = 7
+	s.userRepo.On("FindByUsername", "player").Return(user, nil)
+	s.leaderboardRepo.On("FindSeason", uint(3)).Return(s.season, nil)
+	s.leaderboardRepo.On("FindEntry", s.season, models.RankingByRating, uint(7)).
+		Return(&leaderboardrepo.Entry{Rank: 42, Username: "player", Rating: 1100}, nil)
+
+	req := &leaderboard.GetMyRankRequest{Username: "player", Metric: "RATING", SeasonId: 3}
+	entry, err := s.service.GetMyRank(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(int32(42), entry.Rank)
+	s.Equal(int32(1100), entry.Rating)
+}
+
+func (s *LeaderboardServiceTestSuite) TestGetMyRankFailsForAnUnrankedUser() {
+	user := &models.User{Username: "player"}
+	user.ID = 7
+	s.userRepo.On("FindByUsername", "player").Return(user, nil)
+	s.leaderboardRepo.On("FindEntry", (*models.Season)(nil), models.RankingByWins, uint(7)).
+		Return(nil, leaderboardrepo.ErrStandingNotFound)
+
+	_, err := s.service.GetMyRank(context.Background(), &leaderboard.GetMyRankRequest{Username: "player"})
+
+	s.assertGrpcError(err, codes.NotFound)
+}
+
+func (s *LeaderboardServiceTestSuite) TestListSeasons() {
+	running := &models.Season{ID: 4, Number: 2, StartsAt: s.season.EndsAt, EndsAt: s.season.EndsAt.AddDate(0, 1, 0)}
+	s.leaderboardRepo.On("ListSeasons").Return([]*models.Season{running, s.season}, nil)
+
+	resp, err := s.service.ListSeasons(context.Background(), &leaderboard.ListSeasonsRequest{})
+
+	s.NoError(err)
+	s.Require().Len(resp.Seasons, 2)
+	s.Equal(uint32(4), resp.Seasons[0].Id)
+	s.False(resp.Seasons[0].Archived)
+	s.True(resp.Seasons[1].Archived)
+}
+
+func TestLeaderboardService(t *testing.T) {
+	suite.Run(t, new(LeaderboardServiceTestSuite))
+}
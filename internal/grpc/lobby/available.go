@@ -0,0 +1,138 @@
+package lobby
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAvailablePageSize = 10
+	maxAvailablePageSize     = 50
+)
+
+// availablePageToken is the position of the last lobby of a page. Lobbies created or filled in the meantime never
+// make a page repeat or skip the lobbies that were already there.
+type availablePageToken struct {
+	Sort      lobbyrepo.AvailableSort `json:"s"`
+	CreatedAt time.Time               `json:"c"`
+	Name      string                  `json:"n"`
+	LobbyID   string                  `json:"l"`
+}
+
+// ListAvailableLobbies pages through the public lobbies waiting for players that match the filters of the request.
+func (s *LobbyService) ListAvailableLobbies(ctx context.Context, req *lobby.ListAvailableLobbiesRequest) (*lobby.ListAvailableLobbiesResponse, error) {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize < 0:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page size: it can not be negative")
+	case pageSize == 0:
+		pageSize = defaultAvailablePageSize
+	case pageSize > maxAvailablePageSize:
+		pageSize = maxAvailablePageSize
+	}
+
+	sort, err := parseAvailableSort(req.GetSort())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetMinFreeSlots() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid minimum of free slots: it can not be negative")
+	}
+
+	filter := lobbyrepo.AvailableFilter{
+		NameContains: strings.TrimSpace(req.GetNameQuery()),
+		MinFreeSlots: int(req.GetMinFreeSlots()),
+		Sort:         sort,
+		// Nobody is sent to a lobby whose host left without closing it.
+		OnlineAt: now(),
+		// One more lobby than requested tells whether there is a next page.
+		Limit: pageSize + 1,
+	}
+	// Unlike when creating a lobby, an empty game mode or region does not mean the default one but any.
+	if req.GetGameMode() != "" {
+		mode, err := s.catalog.Mode(req.GetGameMode())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		filter.GameMode = mode.Name
+	}
+	if req.GetRegion() != "" {
+		region, err := s.catalog.Region(req.GetRegion())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		filter.Region = region
+	}
+	if req.GetCreatedAfter() != nil {
+		filter.CreatedAfter = req.GetCreatedAfter().AsTime()
+	}
+	if req.GetCreatedBefore() != nil {
+		filter.CreatedBefore = req.GetCreatedBefore().AsTime()
+	}
+	if req.GetPageToken() != "" {
+		token, err := decodeAvailablePageToken(req.GetPageToken())
+		if err != nil || token.Sort != sort {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token")
+		}
+		filter.After = &lobbyrepo.AvailableCursor{CreatedAt: token.CreatedAt, Name: token.Name, LobbyID: token.LobbyID}
+	}
+
+	lobbies, err := s.lobbyRepo.ListAvailable(filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	resp := &lobby.ListAvailableLobbiesResponse{}
+	if len(lobbies) > pageSize {
+		lobbies = lobbies[:pageSize]
+		last := lobbies[pageSize-1]
+		resp.NextPageToken = encodeAvailablePageToken(availablePageToken{
+			Sort:      sort,
+			CreatedAt: last.CreatedAt,
+			Name:      last.Name,
+			LobbyID:   last.LobbyID,
+		})
+	}
+	resp.Lobbies = make([]*lobby.Lobby, len(lobbies))
+	for i, l := range lobbies {
+		resp.Lobbies[i] = toProtoLobby(l)
+	}
+	return resp, nil
+}
+
+func parseAvailableSort(sort string) (lobbyrepo.AvailableSort, error) {
+	switch lobbyrepo.AvailableSort(strings.ToUpper(sort)) {
+	case "", lobbyrepo.SortNewest:
+		return lobbyrepo.SortNewest, nil
+	case lobbyrepo.SortOldest:
+		return lobbyrepo.SortOldest, nil
+	case lobbyrepo.SortByName:
+		return lobbyrepo.SortByName, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid sort: %q", sort)
+	}
+}
+
+func encodeAvailablePageToken(token availablePageToken) string {
+	// Marshalling a struct of strings and a time never fails.
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeAvailablePageToken(encoded string) (availablePageToken, error) {
+	var token availablePageToken
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return token, err
+	}
+	err = json.Unmarshal(data, &token)
+	return token, err
+}
@@ -0,0 +1,130 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func availableLobbyFixture(lobbyID string, minutesAgo int) *models.Lobby {
+	return &models.Lobby{
+		LobbyID:   lobbyID,
+		Name:      lobbyID,
+		Status:    models.LobbyStatusWaiting,
+		CreatedAt: fixtureNow.Add(-time.Duration(minutesAgo) * time.Minute),
+	}
+}
+
+func (s *LobbyServiceTestSuite) TestListAvailableLobbiesPassesTheFiltersToTheRepository() {
+	defer s.stubNow()()
+	createdAfter := fixtureNow.Add(-time.Hour)
+	expected := lobbyrepo.AvailableFilter{
+		NameContains: "friday",
+		MinFreeSlots: 1,
+		GameMode:     "FREE_FOR_ALL",
+		Region:       "NA",
+		CreatedAfter: createdAfter,
+		Sort:         lobbyrepo.SortByName,
+		OnlineAt:     fixtureNow,
+		Limit:        6,
+	}
+	s.lobbyRepo.On("ListAvailable", expected).Return([]*models.Lobby{}, nil)
+
+	_, err := s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{
+		PageSize:     5,
+		NameQuery:    " friday ",
+		MinFreeSlots: 1,
+		GameMode:     "free_for_all",
+		Region:       "na",
+		CreatedAfter: timestamppb.New(createdAfter),
+		Sort:         "name",
+	})
+
+	s.NoError(err)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestListAvailableLobbiesPagesFromTheLastLobby() {
+	s.lobbyRepo.On("ListAvailable", mock.MatchedBy(func(f lobbyrepo.AvailableFilter) bool {
+		return f.After == nil && f.Limit == 3
+	})).Return([]*models.Lobby{
+		availableLobbyFixture("newest", 1),
+		availableLobbyFixture("middle", 2),
+		availableLobbyFixture("oldest", 3),
+	}, nil)
+
+	firstPage, err := s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{PageSize: 2})
+	s.Require().NoError(err)
+	s.Require().Len(firstPage.Lobbies, 2)
+	s.Equal("middle", firstPage.Lobbies[1].LobbyId)
+	s.Equal(fixtureNow.Add(-2*time.Minute), firstPage.Lobbies[1].CreatedAt.AsTime())
+	s.NotEmpty(firstPage.NextPageToken)
+
+	s.lobbyRepo.On("ListAvailable", mock.MatchedBy(func(f lobbyrepo.AvailableFilter) bool {
+		return f.After != nil && f.After.LobbyID == "middle" && f.After.CreatedAt.Equal(fixtureNow.Add(-2*time.Minute))
+	})).Return([]*models.Lobby{availableLobbyFixture("oldest", 3)}, nil)
+
+	secondPage, err := s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{
+		PageSize:  2,
+		PageToken: firstPage.NextPageToken,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(secondPage.Lobbies, 1)
+	s.Equal("oldest", secondPage.Lobbies[0].LobbyId)
+	s.Empty(secondPage.NextPageToken)
+}
+
+func (s *LobbyServiceTestSuite) TestListAvailableLobbiesRejectsATokenOfAnotherSort() {
+	s.lobbyRepo.On("ListAvailable", mock.Anything).Return([]*models.Lobby{
+		availableLobbyFixture("newest", 1),
+		availableLobbyFixture("oldest", 2),
+	}, nil).Once()
+	firstPage, err := s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{PageSize: 1})
+	s.Require().NoError(err)
+
+	_, err = s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{
+		PageToken: firstPage.NextPageToken,
+		Sort:      "OLDEST",
+	})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "invalid page token")
+}
+
+func (s *LobbyServiceTestSuite) TestListAvailableLobbiesFailsWithInvalidArguments() {
+	testCases := []struct {
+		name    string
+		req     *lobby.ListAvailableLobbiesRequest
+		message string
+	}{
+		{"negative page size", &lobby.ListAvailableLobbiesRequest{PageSize: -1}, "invalid page size: it can not be negative"},
+		{"unknown sort", &lobby.ListAvailableLobbiesRequest{Sort: "POPULAR"}, `invalid sort: "POPULAR"`},
+		{"negative free slots", &lobby.ListAvailableLobbiesRequest{MinFreeSlots: -1}, "invalid minimum of free slots: it can not be negative"},
+		{"malformed page token", &lobby.ListAvailableLobbiesRequest{PageToken: "not a token"}, "invalid page token"},
+		{"unknown game mode", &lobby.ListAvailableLobbiesRequest{GameMode: "BATTLE_ROYALE"}, `unknown game mode: "BATTLE_ROYALE"`},
+		{"unknown region", &lobby.ListAvailableLobbiesRequest{Region: "MOON"}, `unknown region: "MOON"`},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := s.service.ListAvailableLobbies(context.Background(), tc.req)
+
+			s.assertGrpcError(err, codes.InvalidArgument, tc.message)
+		})
+	}
+	s.lobbyRepo.AssertNotCalled(s.T(), "ListAvailable", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestListAvailableLobbiesFailsWhenTheRepositoryFails() {
+	s.lobbyRepo.On("ListAvailable", mock.Anything).Return(nil, errors.New("db error"))
+
+	_, err := s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{})
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error: db error")
+}
@@ -0,0 +1,166 @@
+package lobby
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DisconnectPolicy tells what happens to a game once one of its players stayed disconnected past the reconnect grace
+// period.
+type DisconnectPolicy string
+
+const (
+	DisconnectForfeit DisconnectPolicy = "FORFEIT" // The players that abandoned the game lose it
+	DisconnectAbandon DisconnectPolicy = "ABANDON" // The game stops without a result
+	DisconnectWait    DisconnectPolicy = "WAIT"    // The game goes on, in case the players come back
+)
+
+func ParseDisconnectPolicy(policy string) (DisconnectPolicy, error) {
+	switch parsed := DisconnectPolicy(strings.ToUpper(policy)); parsed {
+	case DisconnectForfeit, DisconnectAbandon, DisconnectWait:
+		return parsed, nil
+	default:
+		return "", fmt.Errorf("invalid disconnect policy: %s, must be one of FORFEIT, ABANDON or WAIT", policy)
+	}
+}
+
+// expireReconnect runs every time the connection of a player of the game changes, and at the end of every grace
+// period. The players whose grace period is over abandon the game and the disconnect policy is applied, then the
+// timer is armed again for the players that are still within theirs.
+func (s *LobbyService) expireReconnect(lobbyID string) {
+	gameLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if err != nil {
+		log.Printf("Failed to check the disconnected players of lobby %s: %v", lobbyID, err)
+		return
+	}
+
+	if gameLobby.Status != models.LobbyStatusInProgress {
+		return
+	}
+
+	expiredAt := now()
+	var abandonedIDs []uint
+	var nextDeadline time.Time
+	for _, player := range gameLobby.Players {
+		if player.DisconnectedAt == nil || player.AbandonedAt != nil {
+			continue
+		}
+		deadline := player.DisconnectedAt.Add(s.cfg.Timeouts.Reconnect)
+		if deadline.After(expiredAt) {
+			if nextDeadline.IsZero() || deadline.Before(nextDeadline) {
+				nextDeadline = deadline
+			}
+			continue
+		}
+		abandonedIDs = append(abandonedIDs, player.UserID)
+	}
+
+	if !nextDeadline.IsZero() {
+		if err := s.scheduler.Schedule(lobbyID, models.LobbyTimerReconnect, nextDeadline); err != nil {
+			log.Printf("Failed to schedule the reconnect grace period of lobby %s: %v", lobbyID, err)
+		}
+	}
+	if len(abandonedIDs) == 0 {
+		return
+	}
+
+	if err := s.lobbyRepo.AbandonPlayers(gameLobby, abandonedIDs, expiredAt); err != nil {
+		log.Printf("Failed to record the players that abandoned lobby %s: %v", lobbyID, err)
+		return
+	}
+	s.recordInfractions(lobbyID, models.InfractionAbandon, abandonedIDs)
+	for i, player := range gameLobby.Players {
+		if player.AbandonedAt == nil && slices.Contains(abandonedIDs, player.UserID) {
+			gameLobby.Players[i].AbandonedAt = &expiredAt
+		}
+	}
+
+	if err := s.applyDisconnectPolicy(gameLobby); err != nil {
+		log.Printf("Failed to apply the %s disconnect policy to lobby %s: %v", s.cfg.DisconnectPolicy, lobbyID, err)
+	}
+}
+
+// applyDisconnectPolicy ends the game once some of its players abandoned it, unless the policy is to wait for them. A
+// forfeited game goes on while more than one side is left in it, is won by the last side that nobody abandoned, and is
+// stopped like an abandoned one when no such side is left.
+func (s *LobbyService) applyDisconnectPolicy(gameLobby *models.Lobby) error {
+	if s.cfg.DisconnectPolicy == DisconnectWait {
+		return nil
+	}
+
+	players, teams := standingSides(gameLobby)
+	if s.cfg.DisconnectPolicy == DisconnectForfeit && len(players)+len(teams) > 1 {
+		// The others play on, and the players that abandoned the game can not win it anymore.
+		return nil
+	}
+
+	if s.cfg.DisconnectPolicy == DisconnectForfeit && len(players)+len(teams) == 1 {
+		var winner *models.User
+		var team int
+		if len(teams) == 1 {
+			team = teams[0]
+		} else {
+			winner = players[0]
+		}
+		err := s.finishWith(gameLobby, winner, team)
+		if status.Code(err) == codes.FailedPrecondition {
+			// The game ended in the meantime, with the result it was given then.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		err := s.lobbyRepo.AbandonGame(gameLobby)
+		if errors.Is(err, lobbyrepo.ErrLobbyNotInProgress) {
+			// The game was finished in the meantime, and its standings already count: it must stay finished.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		gameLobby.Status = models.LobbyStatusAbandoned
+	}
+
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerGameEnd)
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerResultReport)
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerReconnect)
+	return nil
+}
+
+// standingSides returns the players in seat order that did not abandon the game, or the teams in order none of whose
+// players abandoned it when the lobby has teams. Only these sides can still win the game.
+func standingSides(gameLobby *models.Lobby) (players []*models.User, teams []int) {
+	if gameLobby.Teams == 0 {
+		for i, player := range gameLobby.Players {
+			if player.AbandonedAt == nil {
+				players = append(players, &gameLobby.Players[i].User)
+			}
+		}
+		return players, nil
+	}
+
+	abandoned := make(map[int]bool, gameLobby.Teams)
+	for _, player := range gameLobby.Players {
+		if player.Team != nil && player.AbandonedAt != nil {
+			abandoned[*player.Team] = true
+		}
+	}
+	for team := 1; team <= gameLobby.Teams; team++ {
+		if !abandoned[team] && slices.ContainsFunc(gameLobby.Players, func(player models.LobbyPlayer) bool {
+			return player.Team != nil && *player.Team == team
+		}) {
+			teams = append(teams, team)
+		}
+	}
+	return nil, teams
+}
@@ -0,0 +1,331 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+)
+
+// disconnectedGameFixture returns a game in progress between stayer and leaver, where leaver disconnected
+// disconnectedFor ago.
+func disconnectedGameFixture(stayer, leaver *models.User, disconnectedFor time.Duration) *models.Lobby {
+	disconnectedAt := fixtureNow.Add(-disconnectedFor)
+	players := seated(stayer, leaver)
+	players[1].DisconnectedAt = &disconnectedAt
+	return &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: players}
+}
+
+func (s *LobbyServiceTestSuite) expectGameStopped() {
+	s.expectCancelled(models.LobbyTimerGameEnd)
+	s.expectCancelled(models.LobbyTimerResultReport)
+	s.expectCancelled(models.LobbyTimerReconnect)
+}
+
+func (s *LobbyServiceTestSuite) TestParseDisconnectPolicy() {
+	for input, expected := range map[string]DisconnectPolicy{
+		"FORFEIT": DisconnectForfeit,
+		"abandon": DisconnectAbandon,
+		"Wait":    DisconnectWait,
+	} {
+		policy, err := ParseDisconnectPolicy(input)
+
+		s.NoError(err)
+		s.Equal(expected, policy)
+	}
+
+	_, err := ParseDisconnectPolicy("SURRENDER")
+	s.Error(err)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerWaitsForThePlayersWithinTheirGracePeriod() {
+	defer s.stubNow()()
+	gameLobby := disconnectedGameFixture(newUser(1, "stayer"), newUser(2, "leaver"), 10*time.Second)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	deadline := gameLobby.Players[1].DisconnectedAt.Add(fixtureReconnectGrace)
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerReconnect, deadline).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.scheduler.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "AbandonPlayers", mock.Anything, mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusInProgress, gameLobby.Status)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerDoesNothingOnceThePlayersReconnected() {
+	defer s.stubNow()()
+	gameLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress,
+		Players: seated(newUser(1, "stayer"), newUser(2, "leaver"))}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.scheduler.AssertNotCalled(s.T(), "Schedule", mock.Anything, mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AbandonPlayers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerIgnoresAGameThatIsOver() {
+	gameLobby := disconnectedGameFixture(newUser(1, "stayer"), newUser(2, "leaver"), time.Hour)
+	gameLobby.Status = models.LobbyStatusFinished
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "AbandonPlayers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerForfeitsTheGameOfThePlayersThatAbandonedIt() {
+	defer s.stubNow()()
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, 2*fixtureReconnectGrace)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{leaver.ID}, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, stayer.ID).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{stayer.ID}, []uint{leaver.ID}, fixtureNow).Return(nil)
+	s.expectGameStopped()
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.leaderboardRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+	s.Equal(models.LobbyStatusFinished, gameLobby.Status)
+	s.Equal(stayer.ID, *gameLobby.WinnerID)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerForfeitsTheGameOfTheTeamThatAbandonedIt() {
+	defer s.stubNow()()
+	players := []*models.User{newUser(1, "a1"), newUser(2, "a2"), newUser(3, "b1"), newUser(4, "b2")}
+	gameLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Teams: 2,
+		Players: seated(players...)}
+	for i := range gameLobby.Players {
+		team := i/2 + 1
+		gameLobby.Players[i].Team = &team
+	}
+	disconnectedAt := fixtureNow.Add(-2 * fixtureReconnectGrace)
+	gameLobby.Players[0].DisconnectedAt = &disconnectedAt
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{1}, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FinishWithWinningTeam", gameLobby, 2).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{3, 4}, []uint{1, 2}, fixtureNow).Return(nil)
+	s.expectGameStopped()
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.Equal(2, *gameLobby.WinningTeam)
+}
+
+func (s *LobbyServiceTestSuite) TestStandingSidesLeaveOutTheSidesThatAbandonedTheGame() {
+	abandonedAt := fixtureNow
+	teamGame := func(teams int, abandoned ...int) *models.Lobby {
+		gameLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Teams: teams,
+			Players: seated(newUser(1, "a1"), newUser(2, "b1"), newUser(3, "c1"), newUser(4, "a2"), newUser(5, "b2"), newUser(6, "c2"))}
+		for i := range gameLobby.Players {
+			team := i%teams + 1
+			gameLobby.Players[i].Team = &team
+		}
+		for _, i := range abandoned {
+			gameLobby.Players[i].AbandonedAt = &abandonedAt
+		}
+		return gameLobby
+	}
+
+	_, teams := standingSides(teamGame(3, 0))
+	s.Equal([]int{2, 3}, teams)
+	_, teams = standingSides(teamGame(3, 1, 3))
+	s.Equal([]int{3}, teams)
+	_, teams = standingSides(teamGame(3, 3, 4, 5))
+	s.Empty(teams)
+
+	freeForAll := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress,
+		Players: seated(newUser(1, "first"), newUser(2, "second"), newUser(3, "third"))}
+	freeForAll.Players[0].AbandonedAt = &abandonedAt
+	players, teams := standingSides(freeForAll)
+	s.Nil(teams)
+	s.Len(players, 2)
+	s.Equal("second", players[0].Username)
+	s.Equal("third", players[1].Username)
+}
+
+// freeForAllFixture returns a free-for-all game in progress between users, where the players at the abandoned seats
+// have been disconnected for longer than their grace period.
+func freeForAllFixture(users []*models.User, abandoned ...int) *models.Lobby {
+	disconnectedAt := fixtureNow.Add(-2 * fixtureReconnectGrace)
+	gameLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, GameMode: "FREE_FOR_ALL",
+		MaxPlayers: len(users), Players: seated(users...)}
+	for _, i := range abandoned {
+		gameLobby.Players[i].DisconnectedAt = &disconnectedAt
+	}
+	return gameLobby
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerLetsAFreeForAllGoOnWhenOnePlayerAbandonsIt() {
+	defer s.stubNow()()
+	users := []*models.User{newUser(1, "first"), newUser(2, "second"), newUser(3, "third"), newUser(4, "fourth")}
+	gameLobby := freeForAllFixture(users, 0)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{1}, fixtureNow).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AbandonGame", mock.Anything)
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+	s.leaderboardRepo.AssertNotCalled(s.T(), "RecordGame", mock.Anything, mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusInProgress, gameLobby.Status)
+	s.NotNil(gameLobby.Players[0].AbandonedAt)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerForfeitsAFreeForAllToTheLastPlayerLeft() {
+	defer s.stubNow()()
+	users := []*models.User{newUser(1, "first"), newUser(2, "second"), newUser(3, "third"), newUser(4, "fourth")}
+	gameLobby := freeForAllFixture(users, 2)
+	abandonedAt := fixtureNow.Add(-time.Minute)
+	gameLobby.Players[0].AbandonedAt = &abandonedAt
+	gameLobby.Players[3].AbandonedAt = &abandonedAt
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{3}, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, uint(2)).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{2}, []uint{1, 3, 4}, fixtureNow).Return(nil)
+	s.expectGameStopped()
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.leaderboardRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+	s.Equal(uint(2), *gameLobby.WinnerID)
+}
+
+func (s *LobbyServiceTestSuite) TestResultReportTimeoutDoesNotLetAnAbandonedPlayerWinAFreeForAll() {
+	users := []*models.User{newUser(1, "first"), newUser(2, "second"), newUser(3, "third")}
+	gameLobby := freeForAllFixture(users)
+	abandonedAt := fixtureNow
+	gameLobby.Players[0].AbandonedAt = &abandonedAt
+	gameLobby.Players[2].AbandonedAt = &abandonedAt
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, uint(2)).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{2}, []uint{1, 3}, mock.AnythingOfType("time.Time")).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerResultReport](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.Equal(uint(2), *gameLobby.WinnerID)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerAbandonsTheGameWhenEverybodyLeft() {
+	defer s.stubNow()()
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, 2*fixtureReconnectGrace)
+	gameLobby.Players[0].DisconnectedAt = gameLobby.Players[1].DisconnectedAt
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{stayer.ID, leaver.ID}, fixtureNow).Return(nil)
+	s.lobbyRepo.On("AbandonGame", gameLobby).Return(nil)
+	s.expectGameStopped()
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.leaderboardRepo.AssertNotCalled(s.T(), "RecordGame", mock.Anything, mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusAbandoned, gameLobby.Status)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerWithTheAbandonPolicyStopsTheGameWithoutAResult() {
+	defer s.stubNow()()
+	s.useDisconnectPolicy(DisconnectAbandon)
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, fixtureReconnectGrace)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{leaver.ID}, fixtureNow).Return(nil)
+	s.lobbyRepo.On("AbandonGame", gameLobby).Return(nil)
+	s.expectGameStopped()
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
+	s.leaderboardRepo.AssertNotCalled(s.T(), "RecordGame", mock.Anything, mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusAbandoned, gameLobby.Status)
+	s.NotNil(gameLobby.Players[1].AbandonedAt)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerDoesNotAbandonAGameFinishedConcurrently() {
+	defer s.stubNow()()
+	s.useDisconnectPolicy(DisconnectAbandon)
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, fixtureReconnectGrace)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{leaver.ID}, fixtureNow).Return(nil)
+	s.lobbyRepo.On("AbandonGame", gameLobby).Return(lobbyrepo.ErrLobbyNotInProgress)
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusInProgress, gameLobby.Status)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerWithTheWaitPolicyOnlyRecordsTheAbandonment() {
+	defer s.stubNow()()
+	s.useDisconnectPolicy(DisconnectWait)
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, 2*fixtureReconnectGrace)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{leaver.ID}, fixtureNow).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "AbandonGame", mock.Anything)
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusInProgress, gameLobby.Status)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerDoesNotAbandonAPlayerTwice() {
+	defer s.stubNow()()
+	s.useDisconnectPolicy(DisconnectWait)
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, 2*fixtureReconnectGrace)
+	abandonedAt := fixtureNow.Add(-fixtureReconnectGrace)
+	gameLobby.Players[1].AbandonedAt = &abandonedAt
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "AbandonPlayers", mock.Anything, mock.Anything, mock.Anything)
+	s.scheduler.AssertNotCalled(s.T(), "Schedule", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerKeepsTheGameGoingOnRepositoryError() {
+	defer s.stubNow()()
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, 2*fixtureReconnectGrace)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{leaver.ID}, fixtureNow).Return(errors.New("db error"))
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusInProgress, gameLobby.Status)
+}
+
+func (s *LobbyServiceTestSuite) TestGetLobbyShowsTheDisconnectedPlayers() {
+	gameLobby := disconnectedGameFixture(newUser(1, "stayer"), newUser(2, "leaver"), time.Minute)
+	abandonedAt := fixtureNow
+	gameLobby.Players[1].AbandonedAt = &abandonedAt
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+
+	resp, err := s.service.GetLobby(context.Background(), &lobby.GetLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Nil(resp.Players[0].DisconnectedAt)
+	s.False(resp.Players[0].Abandoned)
+	s.True(resp.Players[1].DisconnectedAt.AsTime().Equal(*gameLobby.Players[1].DisconnectedAt))
+	s.True(resp.Players[1].Abandoned)
+}
@@ -0,0 +1,31 @@
+package lobby
+
+import (
+	"context"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+)
+
+func (s *LobbyService) ListGameModes(ctx context.Context, req *lobby.ListGameModesRequest) (*lobby.ListGameModesResponse, error) {
+	resp := &lobby.ListGameModesResponse{
+		Modes:   make([]*lobby.GameMode, len(s.catalog.Modes)),
+		Regions: s.catalog.Regions,
+	}
+	for i, mode := range s.catalog.Modes {
+		resp.Modes[i] = &lobby.GameMode{
+			Name:        mode.Name,
+			DisplayName: mode.DisplayName,
+			Capacity:    int32(mode.Capacity),
+			Settings:    make([]*lobby.GameSetting, len(mode.Settings)),
+			Teams:       int32(mode.Teams),
+		}
+		for j, setting := range mode.Settings {
+			resp.Modes[i].Settings[j] = &lobby.GameSetting{
+				Name:         setting.Name,
+				Values:       setting.Values,
+				DefaultValue: setting.Default,
+			}
+		}
+	}
+	return resp, nil
+}
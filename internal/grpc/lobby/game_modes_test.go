@@ -0,0 +1,120 @@
+package lobby
+
+import (
+	"context"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyUsesTheDefaultGameMode() {
+	s.expectNoParty()
+	mockUser := &models.User{Username: "testuser"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.AnythingOfType("*models.Lobby")).Return(nil)
+
+	resp, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser"})
+
+	s.NoError(err)
+	s.Equal("DUEL", resp.GameMode)
+	s.Equal("EU", resp.Region)
+	s.Equal(int32(2), resp.MaxPlayers)
+	s.Equal(map[string]string{"map": "ARENA", "time_limit": "10"}, resp.Settings)
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyWithAGameModeAndSettings() {
+	s.expectNoParty()
+	mockUser := &models.User{Username: "testuser"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.MatchedBy(func(l *models.Lobby) bool {
+		return l.GameMode == "FREE_FOR_ALL" && l.Region == "NA" && l.MaxPlayers == 4 && l.Settings["map"] == "RUINS"
+	})).Return(nil)
+
+	resp, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{
+		Name:     fixtureLobbyName,
+		Username: "testuser",
+		GameMode: "free_for_all",
+		Region:   "na",
+		Settings: map[string]string{"map": "RUINS"},
+	})
+
+	s.NoError(err)
+	s.Equal("FREE_FOR_ALL", resp.GameMode)
+	s.Equal(int32(4), resp.MaxPlayers)
+	s.Equal(map[string]string{"map": "RUINS", "time_limit": "15", "score_limit": "20"}, resp.Settings)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWithAnInvalidConfiguration() {
+	testCases := []struct {
+		name    string
+		req     *lobby.CreateLobbyRequest
+		message string
+	}{
+		{"unknown game mode", &lobby.CreateLobbyRequest{GameMode: "BATTLE_ROYALE"}, `unknown game mode: "BATTLE_ROYALE"`},
+		{"unknown region", &lobby.CreateLobbyRequest{Region: "MOON"}, `unknown region: "MOON"`},
+		{"setting of another mode", &lobby.CreateLobbyRequest{Settings: map[string]string{"score_limit": "10"}}, `"score_limit" is not a setting of Duel`},
+		{"value not allowed", &lobby.CreateLobbyRequest{Settings: map[string]string{"map": "MOON"}}, `"MOON" is not a valid map`},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			tc.req.Name = fixtureLobbyName
+			tc.req.Username = "testuser"
+
+			_, err := s.service.CreateLobby(context.Background(), tc.req)
+
+			s.assertGrpcError(err, codes.InvalidArgument, tc.message)
+		})
+	}
+	s.userRepo.AssertNotCalled(s.T(), "FindByUsername", mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyWaitsUntilTheGameModeCapacityIsReached() {
+	s.expectNoParty()
+	mockPlayer := newUser(3, "player3")
+	mockLobby := &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Status:     models.LobbyStatusWaiting,
+		GameMode:   "FREE_FOR_ALL",
+		MaxPlayers: 4,
+		Players:    seated(newUser(1, "creator"), newUser(2, "player2")),
+	}
+	s.userRepo.On("FindByUsername", "player3").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 4).Return(nil)
+
+	resp, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player3"})
+
+	s.NoError(err)
+	s.Len(resp.Players, 3)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.lobbyRepo.AssertNotCalled(s.T(), "StartReadyCheck", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestListGameModes() {
+	resp, err := s.service.ListGameModes(context.Background(), &lobby.ListGameModesRequest{})
+
+	s.NoError(err)
+	s.Require().Len(resp.Modes, 3)
+	s.Equal("DUEL", resp.Modes[0].Name)
+	s.Equal(int32(2), resp.Modes[0].Capacity)
+	s.Equal("FREE_FOR_ALL", resp.Modes[1].Name)
+	s.Equal("score_limit", resp.Modes[1].Settings[2].Name)
+	s.Equal("20", resp.Modes[1].Settings[2].DefaultValue)
+	s.Zero(resp.Modes[1].Teams)
+	s.Equal("TEAM_DEATHMATCH", resp.Modes[2].Name)
+	s.Equal(int32(2), resp.Modes[2].Teams)
+	s.Equal([]string{"EU", "NA", "ASIA"}, resp.Regions)
+}
@@ -0,0 +1,198 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// KickPlayer removes a player from the WAITING lobby of the host. The kicked player can not join or watch the lobby
+// again until their ban expires.
+func (s *LobbyService) KickPlayer(ctx context.Context, req *lobby.KickPlayerRequest) (*lobby.Lobby, error) {
+	host, hostedLobby, err := s.hostedLobbyOf(ctx, req.GetLobbyId(), "kick players")
+	if err != nil {
+		return nil, err
+	}
+
+	if hostedLobby.Status != models.LobbyStatusWaiting {
+		return nil, status.Errorf(codes.FailedPrecondition, "players can only be kicked while the lobby is waiting")
+	}
+
+	kicked, err := playerNamed(hostedLobby, req.GetPlayerUsername())
+	if err != nil {
+		return nil, err
+	}
+	if kicked.UserID == host.ID {
+		return nil, status.Errorf(codes.InvalidArgument, "you can not kick yourself")
+	}
+
+	err = s.lobbyRepo.KickPlayer(hostedLobby, kicked.UserID, now().Add(s.cfg.Timeouts.KickBan))
+	switch {
+	case errors.Is(err, lobbyrepo.ErrLobbyConflict):
+		return nil, status.Errorf(codes.Aborted, "the lobby changed at the same time, please retry")
+	case errors.Is(err, lobbyrepo.ErrLobbyNotWaiting):
+		return nil, status.Errorf(codes.FailedPrecondition, "players can only be kicked while the lobby is waiting")
+	case errors.Is(err, lobbyrepo.ErrPlayerNotInLobby):
+		return nil, status.Errorf(codes.NotFound, "the player is not in the lobby")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return toMemberProtoLobby(hostedLobby, host.ID), nil
+}
+
+// TransferHost hands over the host role to another player of the lobby.
+func (s *LobbyService) TransferHost(ctx context.Context, req *lobby.TransferHostRequest) (*lobby.Lobby, error) {
+	host, hostedLobby, err := s.hostedLobbyOf(ctx, req.GetLobbyId(), "hand over the role")
+	if err != nil {
+		return nil, err
+	}
+
+	newHost, err := playerNamed(hostedLobby, req.GetNewHostUsername())
+	if err != nil {
+		return nil, err
+	}
+	if newHost.UserID == host.ID {
+		return nil, status.Errorf(codes.InvalidArgument, "you are already the host of the lobby")
+	}
+
+	if err := hostUpdateFailure(s.lobbyRepo.UpdateHost(hostedLobby, newHost.UserID)); err != nil {
+		return nil, err
+	}
+	return toMemberProtoLobby(hostedLobby, host.ID), nil
+}
+
+// SetLobbyLocked closes the lobby to new players and spectators, or opens it again. Users already in the lobby are
+// not affected.
+func (s *LobbyService) SetLobbyLocked(ctx context.Context, req *lobby.SetLobbyLockedRequest) (*lobby.Lobby, error) {
+	host, hostedLobby, err := s.hostedLobbyOf(ctx, req.GetLobbyId(), "lock it")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hostUpdateFailure(s.lobbyRepo.SetLocked(hostedLobby, req.GetLocked())); err != nil {
+		return nil, err
+	}
+	return toMemberProtoLobby(hostedLobby, host.ID), nil
+}
+
+func (s *LobbyService) RenameLobby(ctx context.Context, req *lobby.RenameLobbyRequest) (*lobby.Lobby, error) {
+	name := req.GetName()
+	if strings.TrimSpace(name) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "lobby name cannot be empty")
+	}
+
+	host, hostedLobby, err := s.hostedLobbyOf(ctx, req.GetLobbyId(), "rename it")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hostUpdateFailure(s.lobbyRepo.Rename(hostedLobby, name)); err != nil {
+		return nil, err
+	}
+	return toMemberProtoLobby(hostedLobby, host.ID), nil
+}
+
+// hostedLobbyOf loads the authenticated caller and the lobby, checking that the lobby is still active and that the
+// caller is its host. The username in the request is never trusted for this. The action completes the message
+// returned to anybody else.
+func (s *LobbyService) hostedLobbyOf(ctx context.Context, lobbyID, action string) (*models.User, *models.Lobby, error) {
+	username, ok := caller.FromContext(ctx)
+	if !ok {
+		return nil, nil, status.Errorf(codes.Unauthenticated, "sign in to %s", action)
+	}
+
+	host, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Invalid host: %v", err)
+	}
+
+	hostedLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if !isHost(hostedLobby, host.ID) {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "only the host of the lobby can %s", action)
+	}
+	if !slices.Contains(models.ActiveLobbyStatuses, hostedLobby.Status) {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "lobby is over")
+	}
+	return host, hostedLobby, nil
+}
+
+// hostUpdateFailure turns the error of a change of the host to the lobby into the status returned to the host.
+func hostUpdateFailure(err error) error {
+	switch {
+	case errors.Is(err, lobbyrepo.ErrLobbyConflict):
+		return status.Errorf(codes.Aborted, "the lobby changed at the same time, please retry")
+	case err != nil:
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return nil
+}
+
+// playerNamed returns the current player of the lobby with the given username.
+func playerNamed(l *models.Lobby, username string) (*models.LobbyPlayer, error) {
+	for i := range l.Players {
+		if l.Players[i].User.Username == username {
+			return &l.Players[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "the player is not in the lobby")
+}
+
+// checkAdmission refuses the users that are kept out of the lobby: everybody while the host locked it, the players
+// the host kicked until their ban expires, and the users blocked by one of its players.
+func (s *LobbyService) checkAdmission(l *models.Lobby, user *models.User) error {
+	if l.Locked {
+		return status.Errorf(codes.FailedPrecondition, "lobby is locked")
+	}
+
+	bannedUntil, err := s.lobbyRepo.BannedUntil(l.LobbyID, user.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	if bannedUntil != nil && now().Before(*bannedUntil) {
+		return status.Errorf(codes.PermissionDenied, "you have been kicked from this lobby, try again later")
+	}
+
+	blocked, err := s.blockedByPlayers(l, user.ID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return status.Errorf(codes.PermissionDenied, "a player of this lobby has blocked you")
+	}
+	return nil
+}
+
+// blockedByPlayers reports whether another player of the lobby has blocked the user.
+func (s *LobbyService) blockedByPlayers(l *models.Lobby, userID uint) (bool, error) {
+	playerIDs := make([]uint, 0, len(l.Players))
+	for _, player := range l.Players {
+		if player.UserID != userID {
+			playerIDs = append(playerIDs, player.UserID)
+		}
+	}
+	blocked, err := s.friendRepo.HasBlocked(playerIDs, userID)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	return blocked, nil
+}
+
+// isHost reports whether the user is the host of the lobby.
+func isHost(l *models.Lobby, userID uint) bool {
+	return l.HostID != nil && *l.HostID == userID
+}
@@ -0,0 +1,354 @@
+package lobby
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+// hostedLobbyFixture returns a lobby of four seats hosted by the first of the users, seated in order.
+func hostedLobbyFixture(status models.LobbyStatus, users ...*models.User) *models.Lobby {
+	hostID := users[0].ID
+	return &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Name:       fixtureLobbyName,
+		Status:     status,
+		Visibility: models.LobbyVisibilityPublic,
+		MaxPlayers: 4,
+		HostID:     &hostID,
+		Players:    seated(users...),
+	}
+}
+
+// callerContext returns the context of a request authenticated as the user.
+func callerContext(username string) context.Context {
+	return caller.NewContext(context.Background(), username)
+}
+
+func (s *LobbyServiceTestSuite) TestKickPlayerRemovesAndBansThePlayer() {
+	defer s.stubNow()()
+	host, troll := newUser(1, "host"), newUser(2, "troll")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host, troll)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("KickPlayer", hostedLobby, troll.ID, fixtureNow.Add(fixtureKickBan)).Return(nil)
+
+	resp, err := s.service.KickPlayer(callerContext("host"), &lobby.KickPlayerRequest{
+		LobbyId:        fixtureLobbyID,
+		PlayerUsername: "troll",
+	})
+
+	s.NoError(err)
+	s.Require().Len(resp.Players, 1)
+	s.Equal("host", resp.Players[0].Username)
+	s.Equal("host", resp.GetHostUsername())
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestKickPlayerFailsWhenTheCallerIsNotTheHost() {
+	host, player := newUser(1, "host"), newUser(2, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, host, player), nil)
+
+	_, err := s.service.KickPlayer(callerContext("player"), &lobby.KickPlayerRequest{
+		LobbyId:        fixtureLobbyID,
+		PlayerUsername: "host",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the host of the lobby can kick players")
+	s.lobbyRepo.AssertNotCalled(s.T(), "KickPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestKickPlayerFailsWithoutAnAuthenticatedCaller() {
+	_, err := s.service.KickPlayer(context.Background(), &lobby.KickPlayerRequest{
+		LobbyId:        fixtureLobbyID,
+		PlayerUsername: "host",
+	})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to kick players")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FindByID", mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "KickPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestKickPlayerFailsWhenTheHostKicksThemselves() {
+	host := newUser(1, "host")
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, host), nil)
+
+	_, err := s.service.KickPlayer(callerContext("host"), &lobby.KickPlayerRequest{
+		LobbyId:        fixtureLobbyID,
+		PlayerUsername: "host",
+	})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "can not kick yourself")
+}
+
+func (s *LobbyServiceTestSuite) TestKickPlayerFailsWhenThePlayerIsNotInTheLobby() {
+	host := newUser(1, "host")
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, host), nil)
+
+	_, err := s.service.KickPlayer(callerContext("host"), &lobby.KickPlayerRequest{
+		LobbyId:        fixtureLobbyID,
+		PlayerUsername: "stranger",
+	})
+
+	s.assertGrpcError(err, codes.NotFound, "not in the lobby")
+}
+
+func (s *LobbyServiceTestSuite) TestKickPlayerFailsWhenTheLobbyIsNotWaiting() {
+	host, player := newUser(1, "host"), newUser(2, "player")
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusInProgress, host, player), nil)
+
+	_, err := s.service.KickPlayer(callerContext("host"), &lobby.KickPlayerRequest{
+		LobbyId:        fixtureLobbyID,
+		PlayerUsername: "player",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "only be kicked while the lobby is waiting")
+}
+
+func (s *LobbyServiceTestSuite) TestKickPlayerFailsWhenTheLobbyChangedAtTheSameTime() {
+	host, player := newUser(1, "host"), newUser(2, "player")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host, player)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("KickPlayer", hostedLobby, player.ID, mock.AnythingOfType("time.Time")).Return(lobbyrepo.ErrLobbyConflict)
+
+	_, err := s.service.KickPlayer(callerContext("host"), &lobby.KickPlayerRequest{
+		LobbyId:        fixtureLobbyID,
+		PlayerUsername: "player",
+	})
+
+	s.assertGrpcError(err, codes.Aborted, "please retry")
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhileThePlayerIsBanned() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	host, troll := newUser(1, "host"), newUser(2, "troll")
+	bannedUntil := fixtureNow.Add(time.Minute)
+	s.userRepo.On("FindByUsername", "troll").Return(troll, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, host), nil)
+	s.lobbyRepo.On("BannedUntil", fixtureLobbyID, troll.ID).Return(&bannedUntil, nil)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "troll"})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "kicked from this lobby")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbySucceedsOnceTheBanExpired() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	host, troll := newUser(1, "host"), newUser(2, "troll")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host)
+	bannedUntil := fixtureNow.Add(-time.Second)
+	s.userRepo.On("FindByUsername", "troll").Return(troll, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("BannedUntil", fixtureLobbyID, troll.ID).Return(&bannedUntil, nil)
+	s.expectNotBlocked()
+	s.lobbyRepo.On("AddPlayer", hostedLobby, troll, 4).Return(nil)
+
+	resp, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "troll"})
+
+	s.NoError(err)
+	s.Len(resp.Players, 2)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenAPlayerBlockedTheUser() {
+	s.expectNoParty()
+	host, player, troll := newUser(1, "host"), newUser(2, "player"), newUser(3, "troll")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host, player)
+	s.userRepo.On("FindByUsername", "troll").Return(troll, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("BannedUntil", fixtureLobbyID, troll.ID).Return(nil, nil)
+	s.friendRepo.On("HasBlocked", []uint{host.ID, player.ID}, troll.ID).Return(true, nil)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "troll"})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "has blocked you")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenTheLobbyIsLocked() {
+	s.expectNoParty()
+	host, player := newUser(1, "host"), newUser(2, "player")
+	lockedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host)
+	lockedLobby.Locked = true
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(lockedLobby, nil)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is locked")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenTheLobbyIsLockedAtTheSameTime() {
+	s.expectNoParty()
+	host, player := newUser(1, "host"), newUser(2, "player")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host)
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", hostedLobby, player, 4).Return(lobbyrepo.ErrLobbyLocked)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is locked")
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbyFailsWhenTheLobbyIsLocked() {
+	host, spectator := newUser(1, "host"), newUser(2, "spectator")
+	lockedLobby := hostedLobbyFixture(models.LobbyStatusInProgress, host)
+	lockedLobby.Locked = true
+	s.userRepo.On("FindByUsername", "spectator").Return(spectator, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(lockedLobby, nil)
+
+	_, err := s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "spectator",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is locked")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddSpectator", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestTransferHostSuccess() {
+	host, player := newUser(1, "host"), newUser(2, "player")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host, player)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("UpdateHost", hostedLobby, player.ID).Return(nil)
+
+	resp, err := s.service.TransferHost(callerContext("host"), &lobby.TransferHostRequest{
+		LobbyId:         fixtureLobbyID,
+		NewHostUsername: "player",
+	})
+
+	s.NoError(err)
+	s.Equal(uint32(player.ID), resp.GetHostId())
+	s.Equal("player", resp.GetHostUsername())
+}
+
+func (s *LobbyServiceTestSuite) TestTransferHostFailsWhenTheNewHostIsNotInTheLobby() {
+	host := newUser(1, "host")
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, host), nil)
+
+	_, err := s.service.TransferHost(callerContext("host"), &lobby.TransferHostRequest{
+		LobbyId:         fixtureLobbyID,
+		NewHostUsername: "stranger",
+	})
+
+	s.assertGrpcError(err, codes.NotFound, "not in the lobby")
+	s.lobbyRepo.AssertNotCalled(s.T(), "UpdateHost", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestTransferHostFailsWhenTheLobbyIsOver() {
+	host, player := newUser(1, "host"), newUser(2, "player")
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusFinished, host, player), nil)
+
+	_, err := s.service.TransferHost(callerContext("host"), &lobby.TransferHostRequest{
+		LobbyId:         fixtureLobbyID,
+		NewHostUsername: "player",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is over")
+}
+
+func (s *LobbyServiceTestSuite) TestTransferHostFailsWhenTheLobbyChangedConcurrently() {
+	host, player := newUser(1, "host"), newUser(2, "player")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host, player)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("UpdateHost", hostedLobby, player.ID).Return(lobbyrepo.ErrLobbyConflict)
+
+	_, err := s.service.TransferHost(callerContext("host"), &lobby.TransferHostRequest{
+		LobbyId:         fixtureLobbyID,
+		NewHostUsername: "player",
+	})
+
+	s.assertGrpcError(err, codes.Aborted, "please retry")
+	s.Equal(host.ID, *hostedLobby.HostID)
+}
+
+func (s *LobbyServiceTestSuite) TestSetLobbyLockedSuccess() {
+	host := newUser(1, "host")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("SetLocked", hostedLobby, true).Return(nil)
+
+	resp, err := s.service.SetLobbyLocked(callerContext("host"), &lobby.SetLobbyLockedRequest{
+		LobbyId: fixtureLobbyID,
+		Locked:  true,
+	})
+
+	s.NoError(err)
+	s.True(resp.Locked)
+}
+
+func (s *LobbyServiceTestSuite) TestSetLobbyLockedFailsWhenTheCallerIsNotTheHost() {
+	host, player := newUser(1, "host"), newUser(2, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, host, player), nil)
+
+	_, err := s.service.SetLobbyLocked(callerContext("player"), &lobby.SetLobbyLockedRequest{
+		LobbyId: fixtureLobbyID,
+		Locked:  true,
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the host of the lobby can lock it")
+	s.lobbyRepo.AssertNotCalled(s.T(), "SetLocked", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRenameLobbySuccess() {
+	host := newUser(1, "host")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("Rename", hostedLobby, "Finals").Return(nil)
+
+	resp, err := s.service.RenameLobby(callerContext("host"), &lobby.RenameLobbyRequest{
+		LobbyId: fixtureLobbyID,
+		Name:    "Finals",
+	})
+
+	s.NoError(err)
+	s.Equal("Finals", resp.Name)
+}
+
+func (s *LobbyServiceTestSuite) TestRenameLobbyFailsWhenTheLobbyChangedConcurrently() {
+	host := newUser(1, "host")
+	hostedLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobby, nil)
+	s.lobbyRepo.On("Rename", hostedLobby, "Finals").Return(lobbyrepo.ErrLobbyConflict)
+
+	_, err := s.service.RenameLobby(callerContext("host"), &lobby.RenameLobbyRequest{
+		LobbyId: fixtureLobbyID,
+		Name:    "Finals",
+	})
+
+	s.assertGrpcError(err, codes.Aborted, "please retry")
+}
+
+func (s *LobbyServiceTestSuite) TestRenameLobbyFailsWhenTheNameIsEmpty() {
+	_, err := s.service.RenameLobby(callerContext("host"), &lobby.RenameLobbyRequest{
+		LobbyId: fixtureLobbyID,
+		Name:    "   ",
+	})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "lobby name cannot be empty")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Rename", mock.Anything, mock.Anything)
+}
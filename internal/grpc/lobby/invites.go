@@ -0,0 +1,215 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	inviterepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/invite"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const inviteTTL = 15 * time.Minute
+
+// InviteToLobby lets the host of a waiting lobby invite another user by username. Like the other host actions, it is
+// authorised against the authenticated caller.
+func (s *LobbyService) InviteToLobby(ctx context.Context, req *lobby.InviteToLobbyRequest) (*lobby.Invite, error) {
+	username, ok := caller.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "sign in to send invites")
+	}
+
+	inviter, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid inviter: %v", err)
+	}
+
+	if req.GetInviteeUsername() == inviter.Username {
+		return nil, status.Errorf(codes.InvalidArgument, "you cannot invite yourself")
+	}
+
+	invitee, err := s.userRepo.FindByUsername(req.GetInviteeUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user to invite not found")
+	}
+
+	lobbyToJoin, err := s.lobbyRepo.FindByID(req.GetLobbyId())
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if lobbyToJoin.Status != models.LobbyStatusWaiting {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is not waiting for players")
+	}
+
+	if !isHost(lobbyToJoin, inviter.ID) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the host of the lobby can send invites")
+	}
+
+	if hasPlayer(lobbyToJoin, invitee.ID) {
+		return nil, status.Errorf(codes.FailedPrecondition, "user is already in the lobby")
+	}
+
+	blocked, err := s.friendRepo.HasBlocked([]uint{invitee.ID}, inviter.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	if blocked {
+		return nil, status.Errorf(codes.PermissionDenied, "you cannot invite this user")
+	}
+
+	currentTime := now()
+	_, err = s.inviteRepo.FindPending(lobbyToJoin.LobbyID, invitee.ID, currentTime)
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "user has already been invited")
+	}
+	if !errors.Is(err, inviterepo.ErrInviteNotFound) {
+		return nil, status.Errorf(codes.Internal, "Invite DB error: %v", err)
+	}
+
+	invite := &models.Invite{
+		LobbyID:   lobbyToJoin.LobbyID,
+		InviterID: inviter.ID,
+		Inviter:   *inviter,
+		InviteeID: invitee.ID,
+		Invitee:   *invitee,
+		Status:    models.InviteStatusPending,
+		ExpiresAt: currentTime.Add(inviteTTL),
+	}
+
+	if err := s.inviteRepo.Create(invite); err != nil {
+		return nil, status.Errorf(codes.Internal, "Invite DB error: %v", err)
+	}
+
+	return toProtoInvite(invite, lobbyToJoin), nil
+}
+
+func (s *LobbyService) ListMyInvites(ctx context.Context, req *lobby.ListMyInvitesRequest) (*lobby.ListMyInvitesResponse, error) {
+	invitee, err := s.callerPlayer(ctx, "see your invites")
+	if err != nil {
+		return nil, err
+	}
+
+	invites := s.inviteRepo.ListPending(invitee.ID, now())
+	protoInvites := make([]*lobby.Invite, 0, len(invites))
+	for _, invite := range invites {
+		// The lobby may have been deleted in the meantime: such invites can not be accepted anymore.
+		invitedLobby, err := s.lobbyRepo.FindByID(invite.LobbyID)
+		if err != nil {
+			continue
+		}
+		protoInvites = append(protoInvites, toProtoInvite(invite, invitedLobby))
+	}
+	return &lobby.ListMyInvitesResponse{Invites: protoInvites}, nil
+}
+
+// AcceptInvite joins the invited lobby. The invite replaces both the join code and the lobby password, but the
+// capacity is checked as in JoinLobby.
+func (s *LobbyService) AcceptInvite(ctx context.Context, req *lobby.RespondInviteRequest) (*lobby.Lobby, error) {
+	invite, player, err := s.pendingInvite(ctx, req.GetInviteId(), "accept the invite")
+	if err != nil {
+		return nil, err
+	}
+
+	invitedLobby, err := s.lobbyRepo.FindByID(invite.LobbyID)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	joinedLobby, err := s.addPlayer(invitedLobby, player)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.inviteRepo.UpdateStatus(invite, models.InviteStatusAccepted); err != nil {
+		return nil, status.Errorf(codes.Internal, "Invite DB error: %v", err)
+	}
+	return joinedLobby, nil
+}
+
+func (s *LobbyService) DeclineInvite(ctx context.Context, req *lobby.RespondInviteRequest) (*lobby.Invite, error) {
+	invite, _, err := s.pendingInvite(ctx, req.GetInviteId(), "decline the invite")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.inviteRepo.UpdateStatus(invite, models.InviteStatusDeclined); err != nil {
+		return nil, status.Errorf(codes.Internal, "Invite DB error: %v", err)
+	}
+	invite.Status = models.InviteStatusDeclined
+
+	invitedLobby, err := s.lobbyRepo.FindByID(invite.LobbyID)
+	if err != nil {
+		invitedLobby = &models.Lobby{LobbyID: invite.LobbyID}
+	}
+	return toProtoInvite(invite, invitedLobby), nil
+}
+
+// pendingInvite retrieves the invite, checking that it is addressed to the authenticated caller and that it can still
+// be answered. Invites found expired are marked as such. The action completes the message returned to the anonymous
+// callers.
+func (s *LobbyService) pendingInvite(ctx context.Context, inviteID uint32, action string) (*models.Invite, *models.User,
+	error) {
+	player, err := s.callerPlayer(ctx, action)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	invite, err := s.inviteRepo.FindByID(uint(inviteID))
+	if errors.Is(err, inviterepo.ErrInviteNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Invite DB error: %v", err)
+	}
+
+	if invite.InviteeID != player.ID {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "the invite is not addressed to you")
+	}
+
+	if invite.Status != models.InviteStatusPending {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "invite is no longer pending")
+	}
+
+	if !now().Before(invite.ExpiresAt) {
+		if err := s.inviteRepo.UpdateStatus(invite, models.InviteStatusExpired); err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "Invite DB error: %v", err)
+		}
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "invite has expired")
+	}
+
+	return invite, player, nil
+}
+
+func hasPlayer(l *models.Lobby, userID uint) bool {
+	for _, player := range l.Players {
+		if player.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func toProtoInvite(invite *models.Invite, invitedLobby *models.Lobby) *lobby.Invite {
+	return &lobby.Invite{
+		InviteId:        uint32(invite.ID),
+		LobbyId:         invite.LobbyID,
+		LobbyName:       invitedLobby.Name,
+		InviterUsername: invite.Inviter.Username,
+		InviteeUsername: invite.Invitee.Username,
+		Status:          string(invite.Status),
+		ExpiresAt:       timestamppb.New(invite.ExpiresAt),
+	}
+}
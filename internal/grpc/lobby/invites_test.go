@@ -0,0 +1,354 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	inviterepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/invite"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *LobbyServiceTestSuite) stubNow() func() {
+	originalNow := now
+	now = func() time.Time { return fixtureNow }
+	return func() { now = originalNow }
+}
+
+func newUser(id uint, username string) *models.User {
+	user := &models.User{Username: username}
+	user.ID = id
+	return user
+}
+
+func asPlayer(user *models.User) models.LobbyPlayer {
+	return models.LobbyPlayer{LobbyID: fixtureLobbyID, UserID: user.ID, User: *user}
+}
+
+// seated returns the memberships of the users, seated in the given order.
+func seated(users ...*models.User) []models.LobbyPlayer {
+	players := make([]models.LobbyPlayer, len(users))
+	for i, user := range users {
+		players[i] = asPlayer(user)
+		players[i].Seat = i
+	}
+	return players
+}
+
+func (s *LobbyServiceTestSuite) pendingInviteFixture(invitee *models.User) *models.Invite {
+	invite := &models.Invite{
+		LobbyID:   fixtureLobbyID,
+		InviterID: 1,
+		Inviter:   *newUser(1, "creator"),
+		InviteeID: invitee.ID,
+		Invitee:   *invitee,
+		Status:    models.InviteStatusPending,
+		ExpiresAt: fixtureNow.Add(time.Minute),
+	}
+	invite.ID = 7
+	return invite
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbySuccess() {
+	defer s.stubNow()()
+	creator := newUser(1, "creator")
+	friend := newUser(2, "friend")
+	mockLobby := hostedLobbyFixture(models.LobbyStatusWaiting, creator)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	s.userRepo.On("FindByUsername", "creator").Return(creator, nil)
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	s.friendRepo.On("HasBlocked", []uint{friend.ID}, creator.ID).Return(false, nil)
+	s.inviteRepo.On("FindPending", fixtureLobbyID, friend.ID, fixtureNow).Return(nil, inviterepo.ErrInviteNotFound)
+	s.inviteRepo.On("Create", mock.MatchedBy(func(i *models.Invite) bool {
+		return i.InviterID == creator.ID && i.InviteeID == friend.ID && i.ExpiresAt.Equal(fixtureNow.Add(inviteTTL))
+	})).Return(nil)
+
+	resp, err := s.service.InviteToLobby(callerContext("creator"), req)
+
+	s.NoError(err)
+	s.Equal(fixtureLobbyName, resp.LobbyName)
+	s.Equal("creator", resp.InviterUsername)
+	s.Equal("friend", resp.InviteeUsername)
+	s.Equal(string(models.InviteStatusPending), resp.Status)
+	s.Equal(fixtureNow.Add(inviteTTL), resp.ExpiresAt.AsTime())
+	s.inviteRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenInvitingYourself() {
+	s.userRepo.On("FindByUsername", "creator").Return(newUser(1, "creator"), nil)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "creator"}
+
+	_, err := s.service.InviteToLobby(callerContext("creator"), req)
+
+	s.assertGrpcError(err, codes.InvalidArgument, "cannot invite yourself")
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenInviteeNotFound() {
+	s.userRepo.On("FindByUsername", "creator").Return(newUser(1, "creator"), nil)
+	s.userRepo.On("FindByUsername", "ghost").Return(nil, usrrepo.ErrUserNotFound)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "ghost"}
+
+	_, err := s.service.InviteToLobby(callerContext("creator"), req)
+
+	s.assertGrpcError(err, codes.NotFound, "user to invite not found")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FindByID", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenLobbyNotFound() {
+	s.userRepo.On("FindByUsername", "creator").Return(newUser(1, "creator"), nil)
+	s.userRepo.On("FindByUsername", "friend").Return(newUser(2, "friend"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	_, err := s.service.InviteToLobby(callerContext("creator"), req)
+
+	s.assertGrpcError(err, codes.NotFound, "lobby not found")
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenLobbyIsNotWaiting() {
+	creator := newUser(1, "creator")
+	s.userRepo.On("FindByUsername", "creator").Return(creator, nil)
+	s.userRepo.On("FindByUsername", "friend").Return(newUser(2, "friend"), nil)
+	mockLobby := hostedLobbyFixture(models.LobbyStatusInProgress, creator)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	_, err := s.service.InviteToLobby(callerContext("creator"), req)
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not waiting")
+	s.inviteRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenInviterIsNotInTheLobby() {
+	s.userRepo.On("FindByUsername", "outsider").Return(newUser(3, "outsider"), nil)
+	s.userRepo.On("FindByUsername", "friend").Return(newUser(2, "friend"), nil)
+	mockLobby := hostedLobbyFixture(models.LobbyStatusWaiting, newUser(1, "creator"))
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	_, err := s.service.InviteToLobby(callerContext("outsider"), req)
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the host of the lobby can send invites")
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenTheInviterIsNotTheHost() {
+	creator, player := newUser(1, "creator"), newUser(3, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.userRepo.On("FindByUsername", "friend").Return(newUser(2, "friend"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, creator, player), nil)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	_, err := s.service.InviteToLobby(callerContext("player"), req)
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the host of the lobby can send invites")
+	s.inviteRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWithoutAnAuthenticatedCaller() {
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	_, err := s.service.InviteToLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to send invites")
+	s.inviteRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenAlreadyInvited() {
+	defer s.stubNow()()
+	creator := newUser(1, "creator")
+	friend := newUser(2, "friend")
+	s.userRepo.On("FindByUsername", "creator").Return(creator, nil)
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	mockLobby := hostedLobbyFixture(models.LobbyStatusWaiting, creator)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	s.expectNotBlocked()
+	s.inviteRepo.On("FindPending", fixtureLobbyID, friend.ID, fixtureNow).Return(s.pendingInviteFixture(friend), nil)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	_, err := s.service.InviteToLobby(callerContext("creator"), req)
+
+	s.assertGrpcError(err, codes.AlreadyExists, "already been invited")
+	s.inviteRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestInviteToLobbyFailsWhenTheInviteeBlockedTheInviter() {
+	creator := newUser(1, "creator")
+	friend := newUser(2, "friend")
+	s.userRepo.On("FindByUsername", "creator").Return(creator, nil)
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	mockLobby := hostedLobbyFixture(models.LobbyStatusWaiting, creator)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	s.friendRepo.On("HasBlocked", []uint{friend.ID}, creator.ID).Return(true, nil)
+	req := &lobby.InviteToLobbyRequest{LobbyId: fixtureLobbyID, InviteeUsername: "friend"}
+
+	_, err := s.service.InviteToLobby(callerContext("creator"), req)
+
+	s.assertGrpcError(err, codes.PermissionDenied, "cannot invite this user")
+	s.inviteRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestListMyInvitesSkipsDeletedLobbies() {
+	defer s.stubNow()()
+	friend := newUser(2, "friend")
+	stillOpen := s.pendingInviteFixture(friend)
+	deleted := s.pendingInviteFixture(friend)
+	deleted.LobbyID = "deleted-lobby"
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.inviteRepo.On("ListPending", friend.ID, fixtureNow).Return([]*models.Invite{stillOpen, deleted})
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Name: fixtureLobbyName}, nil)
+	s.lobbyRepo.On("FindByID", "deleted-lobby").Return(nil, lobbyrepo.ErrLobbyNotFound)
+
+	resp, err := s.service.ListMyInvites(callerContext("friend"), &lobby.ListMyInvitesRequest{})
+
+	s.NoError(err)
+	s.Len(resp.Invites, 1)
+	s.Equal(fixtureLobbyName, resp.Invites[0].LobbyName)
+}
+
+func (s *LobbyServiceTestSuite) TestListMyInvitesFailsWhenUnauthenticated() {
+	_, err := s.service.ListMyInvites(context.Background(), &lobby.ListMyInvitesRequest{})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to see your invites")
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptInviteBypassesPasswordAndPrivateVisibility() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	hash, err := s.hasher.Hash("secret")
+	s.Require().NoError(err)
+	friend := newUser(2, "friend")
+	invite := s.pendingInviteFixture(friend)
+	mockLobby := &models.Lobby{
+		LobbyID:      fixtureLobbyID,
+		Status:       models.LobbyStatusWaiting,
+		MaxPlayers:   2,
+		Visibility:   models.LobbyVisibilityPrivate,
+		PasswordHash: hash,
+		Players:      seated(newUser(1, "creator")),
+	}
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.inviteRepo.On("FindByID", invite.ID).Return(invite, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, friend, 2).Return(nil)
+	s.expectScheduled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("StartReadyCheck", mockLobby, fixtureNow.Add(fixtureReadyCheckTimeout), true).Return(nil)
+	s.expectCancelled(models.LobbyTimerWaiting)
+	s.inviteRepo.On("UpdateStatus", invite, models.InviteStatusAccepted).Return(nil)
+
+	resp, err := s.service.AcceptInvite(callerContext("friend"), &lobby.RespondInviteRequest{InviteId: uint32(invite.ID)})
+
+	s.NoError(err)
+	s.Len(resp.Players, 2)
+	s.inviteRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptInviteFailsWhenLobbyIsFull() {
+	defer s.stubNow()()
+	friend := newUser(2, "friend")
+	invite := s.pendingInviteFixture(friend)
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, MaxPlayers: 2, Players: seated(&models.User{}, &models.User{})}
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.inviteRepo.On("FindByID", invite.ID).Return(invite, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+
+	_, err := s.service.AcceptInvite(callerContext("friend"), &lobby.RespondInviteRequest{InviteId: uint32(invite.ID)})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is full")
+	s.inviteRepo.AssertNotCalled(s.T(), "UpdateStatus", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptInviteMarksExpiredInvites() {
+	defer s.stubNow()()
+	friend := newUser(2, "friend")
+	invite := s.pendingInviteFixture(friend)
+	invite.ExpiresAt = fixtureNow
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.inviteRepo.On("FindByID", invite.ID).Return(invite, nil)
+	s.inviteRepo.On("UpdateStatus", invite, models.InviteStatusExpired).Return(nil)
+
+	_, err := s.service.AcceptInvite(callerContext("friend"), &lobby.RespondInviteRequest{InviteId: uint32(invite.ID)})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "invite has expired")
+	s.inviteRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "FindByID", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptInviteFailsWhenNotTheInvitee() {
+	defer s.stubNow()()
+	invite := s.pendingInviteFixture(newUser(2, "friend"))
+	s.userRepo.On("FindByUsername", "intruder").Return(newUser(3, "intruder"), nil)
+	s.inviteRepo.On("FindByID", invite.ID).Return(invite, nil)
+
+	_, err := s.service.AcceptInvite(callerContext("intruder"), &lobby.RespondInviteRequest{InviteId: uint32(invite.ID)})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "not addressed to you")
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptInviteFailsWhenUnauthenticated() {
+	_, err := s.service.AcceptInvite(context.Background(), &lobby.RespondInviteRequest{InviteId: 7})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to accept the invite")
+	s.inviteRepo.AssertNotCalled(s.T(), "FindByID", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptInviteFailsWhenAlreadyAnswered() {
+	defer s.stubNow()()
+	friend := newUser(2, "friend")
+	invite := s.pendingInviteFixture(friend)
+	invite.Status = models.InviteStatusDeclined
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.inviteRepo.On("FindByID", invite.ID).Return(invite, nil)
+
+	_, err := s.service.AcceptInvite(callerContext("friend"), &lobby.RespondInviteRequest{InviteId: uint32(invite.ID)})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "no longer pending")
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptInviteFailsWhenInviteNotFound() {
+	s.userRepo.On("FindByUsername", "friend").Return(newUser(2, "friend"), nil)
+	s.inviteRepo.On("FindByID", uint(99)).Return(nil, inviterepo.ErrInviteNotFound)
+
+	_, err := s.service.AcceptInvite(callerContext("friend"), &lobby.RespondInviteRequest{InviteId: 99})
+
+	s.assertGrpcError(err, codes.NotFound, "invite not found")
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineInviteSuccess() {
+	defer s.stubNow()()
+	friend := newUser(2, "friend")
+	invite := s.pendingInviteFixture(friend)
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.inviteRepo.On("FindByID", invite.ID).Return(invite, nil)
+	s.inviteRepo.On("UpdateStatus", invite, models.InviteStatusDeclined).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Name: fixtureLobbyName}, nil)
+
+	resp, err := s.service.DeclineInvite(callerContext("friend"), &lobby.RespondInviteRequest{InviteId: uint32(invite.ID)})
+
+	s.NoError(err)
+	s.Equal(string(models.InviteStatusDeclined), resp.Status)
+	s.inviteRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineInviteFailsOnRepositoryError() {
+	defer s.stubNow()()
+	friend := newUser(2, "friend")
+	invite := s.pendingInviteFixture(friend)
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.inviteRepo.On("FindByID", invite.ID).Return(invite, nil)
+	s.inviteRepo.On("UpdateStatus", invite, models.InviteStatusDeclined).Return(errors.New("db error"))
+
+	_, err := s.service.DeclineInvite(callerContext("friend"), &lobby.RespondInviteRequest{InviteId: uint32(invite.ID)})
+
+	s.assertGrpcError(err, codes.Internal, "Invite DB error")
+}
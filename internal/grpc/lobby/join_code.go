@@ -0,0 +1,30 @@
+package lobby
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const (
+	// Ambiguous characters (0/O, 1/I/L) are left out, so that the code can be easily read aloud or copied by hand.
+	joinCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	joinCodeLength   = 6
+
+	maxJoinCodeAttempts = 5
+)
+
+// package-level variable used for test purpose only.
+var generateJoinCode = newJoinCode
+
+func newJoinCode() (string, error) {
+	code := make([]byte, joinCodeLength)
+	alphabetSize := big.NewInt(int64(len(joinCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", err
+		}
+		code[i] = joinCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,119 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LeaveLobby takes the caller out of their lobby. A waiting lobby just frees the seat, or closes once nobody is left
+// in it. Leaving during the ready check reopens the lobby like a decline, and leaving the game abandons it: both put
+// the caller on the escalating cooldown.
+func (s *LobbyService) LeaveLobby(ctx context.Context, req *lobby.LeaveLobbyRequest) (*lobby.Lobby, error) {
+	username, ok := caller.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "sign in to leave a lobby")
+	}
+
+	player, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid player: %v", err)
+	}
+
+	leftLobby, err := s.lobbyRepo.FindByID(req.GetLobbyId())
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if !hasPlayer(leftLobby, player.ID) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the lobby players can leave it")
+	}
+
+	switch leftLobby.Status {
+	case models.LobbyStatusWaiting:
+		err = s.leaveWaiting(leftLobby, player.ID)
+	case models.LobbyStatusReadyCheck:
+		err = s.withdrawFromReadyCheck(leftLobby, player.ID, models.InfractionLeave)
+	case models.LobbyStatusInProgress:
+		err = s.leaveGame(leftLobby, player.ID)
+	default:
+		err = status.Errorf(codes.FailedPrecondition, "lobby is over")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The lobby is read again, since the host may have been handed over to another player.
+	leftLobby, err = s.lobbyRepo.FindByID(req.GetLobbyId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return toProtoLobby(leftLobby), nil
+}
+
+func (s *LobbyService) leaveWaiting(waitingLobby *models.Lobby, playerID uint) error {
+	err := s.lobbyRepo.LeaveWaiting(waitingLobby, playerID, now())
+	switch {
+	case errors.Is(err, lobbyrepo.ErrLobbyConflict), errors.Is(err, lobbyrepo.ErrLobbyNotWaiting):
+		return status.Errorf(codes.Aborted, "the lobby changed at the same time, please retry")
+	case errors.Is(err, lobbyrepo.ErrPlayerNotInLobby):
+		return status.Errorf(codes.PermissionDenied, "only the lobby players can leave it")
+	case err != nil:
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if waitingLobby.Status == models.LobbyStatusCancelled {
+		s.cancelTimer(waitingLobby.LobbyID, models.LobbyTimerWaiting)
+	}
+	return nil
+}
+
+// leaveGame records that the player abandoned the game, and applies the disconnect policy as if the player had not
+// reconnected in time. A game nobody is left in is stopped without a result, whatever the policy.
+func (s *LobbyService) leaveGame(gameLobby *models.Lobby, playerID uint) error {
+	leftAt := now()
+	err := s.lobbyRepo.LeaveGame(gameLobby, playerID, leftAt)
+	switch {
+	case errors.Is(err, lobbyrepo.ErrLobbyNotInProgress):
+		return status.Errorf(codes.FailedPrecondition, "the game is over")
+	case errors.Is(err, lobbyrepo.ErrPlayerNotInLobby):
+		return status.Errorf(codes.PermissionDenied, "only the lobby players can leave it")
+	case err != nil:
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.recordInfractions(gameLobby.LobbyID, models.InfractionLeave, []uint{playerID})
+
+	// The player stays in the game read before, so that the result of a forfeit counts them among the losers.
+	for i, player := range gameLobby.Players {
+		if player.UserID == playerID && player.AbandonedAt == nil {
+			gameLobby.Players[i].AbandonedAt = &leftAt
+		}
+	}
+
+	if slices.ContainsFunc(gameLobby.Players, func(player models.LobbyPlayer) bool { return player.UserID != playerID }) {
+		if err := s.applyDisconnectPolicy(gameLobby); err != nil {
+			log.Printf("Failed to apply the %s disconnect policy to lobby %s: %v", s.cfg.DisconnectPolicy, gameLobby.LobbyID, err)
+		}
+		return nil
+	}
+
+	err = s.lobbyRepo.AbandonGame(gameLobby)
+	if err != nil && !errors.Is(err, lobbyrepo.ErrLobbyNotInProgress) {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerGameEnd)
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerResultReport)
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerReconnect)
+	return nil
+}
@@ -0,0 +1,150 @@
+package lobby
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyFreesTheSeatOfAWaitingLobby() {
+	defer s.stubNow()()
+	host, player := newUser(1, "host"), newUser(2, "player")
+	waitingLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host, player)
+	nextHostID := player.ID
+	after := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, HostID: &nextHostID,
+		Players: seated(player)}
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil).Once()
+	s.lobbyRepo.On("LeaveWaiting", waitingLobby, host.ID, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+
+	resp, err := s.service.LeaveLobby(callerContext("host"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.Require().Len(resp.Players, 1)
+	s.Equal("player", resp.GetHostUsername())
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyClosesTheWaitingLobbyOfTheLastPlayer() {
+	defer s.stubNow()()
+	player := newUser(1, "player")
+	waitingLobby := hostedLobbyFixture(models.LobbyStatusWaiting, player)
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.lobbyRepo.On("LeaveWaiting", waitingLobby, player.ID, fixtureNow).Return(nil)
+	s.expectCancelled(models.LobbyTimerWaiting)
+
+	resp, err := s.service.LeaveLobby(callerContext("player"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusCancelled), resp.Status)
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyDuringTheReadyCheckPutsTheLeaverOnACooldown() {
+	defer s.stubNow()()
+	player, leaver := newUser(1, "player"), newUser(2, "leaver")
+	readyLobby := readyCheckLobbyFixture(fixtureNow.Add(time.Second), readyPlayer(player), asPlayer(leaver))
+	after := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, Players: seated(player)}
+	s.userRepo.On("FindByUsername", "leaver").Return(leaver, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil).Once()
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerWaiting, fixtureNow.Add(fixtureWaitingTimeout)).Return(nil)
+	s.lobbyRepo.On("FailReadyCheck", readyLobby, []uint{leaver.ID}).Return(nil)
+	s.expectCancelled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+
+	resp, err := s.service.LeaveLobby(callerContext("leaver"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureLobbyID, models.InfractionLeave, []uint{leaver.ID}, fixtureNow)
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyDuringTheGameForfeitsIt() {
+	defer s.stubNow()()
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := hostedLobbyFixture(models.LobbyStatusInProgress, stayer, leaver)
+	s.userRepo.On("FindByUsername", "leaver").Return(leaver, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("LeaveGame", gameLobby, leaver.ID, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, stayer.ID).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{stayer.ID}, []uint{leaver.ID}, fixtureNow).Return(nil)
+	s.expectGameStopped()
+
+	resp, err := s.service.LeaveLobby(callerContext("leaver"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusFinished), resp.Status)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.leaderboardRepo.AssertExpectations(s.T())
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureLobbyID, models.InfractionLeave, []uint{leaver.ID}, fixtureNow)
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyStopsTheGameNobodyIsLeftIn() {
+	defer s.stubNow()()
+	s.useDisconnectPolicy(DisconnectWait)
+	leaver := newUser(1, "leaver")
+	gameLobby := hostedLobbyFixture(models.LobbyStatusInProgress, leaver)
+	s.userRepo.On("FindByUsername", "leaver").Return(leaver, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("LeaveGame", gameLobby, leaver.ID, fixtureNow).Return(nil)
+	s.lobbyRepo.On("AbandonGame", gameLobby).Return(nil)
+	s.expectGameStopped()
+
+	_, err := s.service.LeaveLobby(callerContext("leaver"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyFailsWhenTheLobbyChangedAtTheSameTime() {
+	defer s.stubNow()()
+	host, player := newUser(1, "host"), newUser(2, "player")
+	waitingLobby := hostedLobbyFixture(models.LobbyStatusWaiting, host, player)
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.lobbyRepo.On("LeaveWaiting", waitingLobby, player.ID, fixtureNow).Return(lobbyrepo.ErrLobbyConflict)
+
+	_, err := s.service.LeaveLobby(callerContext("player"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Aborted, "please retry")
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyFailsWhenTheCallerIsNotAPlayer() {
+	host, stranger := newUser(1, "host"), newUser(2, "stranger")
+	s.userRepo.On("FindByUsername", "stranger").Return(stranger, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusWaiting, host), nil)
+
+	_, err := s.service.LeaveLobby(callerContext("stranger"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the lobby players")
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyFailsOnceTheLobbyIsOver() {
+	player := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(hostedLobbyFixture(models.LobbyStatusFinished, player), nil)
+
+	_, err := s.service.LeaveLobby(callerContext("player"), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is over")
+}
+
+func (s *LobbyServiceTestSuite) TestLeaveLobbyFailsWithoutAnAuthenticatedCaller() {
+	_, err := s.service.LeaveLobby(context.Background(), &lobby.LeaveLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FindByID", mock.Anything)
+}
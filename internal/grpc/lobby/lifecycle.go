@@ -0,0 +1,81 @@
+package lobby
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expireWaitingLobby closes a lobby that nobody filled before the waiting timeout. Nothing happens if the lobby
+// is not waiting anymore.
+func (s *LobbyService) expireWaitingLobby(lobbyID string) {
+	err := s.lobbyRepo.CloseWaiting(lobbyID, models.LobbyCloseWaitingTimeout, now())
+	if err != nil && !errors.Is(err, lobbyrepo.ErrLobbyNotWaiting) {
+		log.Printf("Failed to close the waiting lobby %s: %v", lobbyID, err)
+	}
+}
+
+// endGame opens the result-report window once the game duration is over.
+func (s *LobbyService) endGame(lobbyID string) {
+	gameLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if err != nil {
+		log.Printf("Failed to end the game of lobby %s: %v", lobbyID, err)
+		return
+	}
+
+	if gameLobby.Status != models.LobbyStatusInProgress {
+		return
+	}
+
+	if err := s.scheduler.Schedule(lobbyID, models.LobbyTimerResultReport, now().Add(s.cfg.Timeouts.ResultReport)); err != nil {
+		log.Printf("Failed to schedule the result report of lobby %s: %v", lobbyID, err)
+	}
+}
+
+// expireResultReport finishes the game when nobody reported its result in time.
+func (s *LobbyService) expireResultReport(lobbyID string) {
+	gameLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if err != nil {
+		log.Printf("Failed to finish the game of lobby %s: %v", lobbyID, err)
+		return
+	}
+
+	if gameLobby.Status != models.LobbyStatusInProgress {
+		return
+	}
+
+	// A player may have reported the result, or the game may have been forfeited, since the lobby was read.
+	if err := s.finish(gameLobby); err != nil && status.Code(err) != codes.FailedPrecondition {
+		log.Printf("Failed to finish the game of lobby %s: %v", lobbyID, err)
+	}
+}
+
+// cancelTimer drops a timer that is not needed anymore. A failure is only logged, since the handler of the timer
+// finds the lobby in another state and does nothing.
+func (s *LobbyService) cancelTimer(lobbyID string, kind models.LobbyTimerKind) {
+	if err := s.scheduler.Cancel(lobbyID, kind); err != nil {
+		log.Printf("Failed to cancel the %s timer of lobby %s: %v", kind, lobbyID, err)
+	}
+}
+
+// setTimer and clearTimer keep the timers of the in-memory lobby in sync with the scheduler, so that the
+// responses show the current deadlines.
+func setTimer(l *models.Lobby, kind models.LobbyTimerKind, firesAt time.Time) {
+	clearTimer(l, kind)
+	l.Timers = append(l.Timers, models.LobbyTimer{LobbyID: l.LobbyID, Kind: kind, FiresAt: firesAt})
+}
+
+func clearTimer(l *models.Lobby, kind models.LobbyTimerKind) {
+	timers := l.Timers[:0]
+	for _, timer := range l.Timers {
+		if timer.Kind != kind {
+			timers = append(timers, timer)
+		}
+	}
+	l.Timers = timers
+}
@@ -0,0 +1,103 @@
+package lobby
+
+import (
+	"errors"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *LobbyServiceTestSuite) TestTheServiceRegistersAHandlerForEveryTimer() {
+	for _, kind := range []models.LobbyTimerKind{
+		models.LobbyTimerWaiting,
+		models.LobbyTimerReadyCheck,
+		models.LobbyTimerGameEnd,
+		models.LobbyTimerResultReport,
+		models.LobbyTimerRematch,
+		models.LobbyTimerReconnect,
+	} {
+		s.Contains(s.scheduler.handlers, kind)
+	}
+}
+
+func (s *LobbyServiceTestSuite) TestWaitingTimeoutClosesTheLobby() {
+	defer s.stubNow()()
+	s.lobbyRepo.On("CloseWaiting", fixtureLobbyID, models.LobbyCloseWaitingTimeout, fixtureNow).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerWaiting](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestWaitingTimeoutIgnoresALobbyThatIsNotWaiting() {
+	defer s.stubNow()()
+	s.lobbyRepo.On("CloseWaiting", fixtureLobbyID, models.LobbyCloseWaitingTimeout, fixtureNow).Return(lobbyrepo.ErrLobbyNotWaiting)
+
+	s.scheduler.handlers[models.LobbyTimerWaiting](fixtureLobbyID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "Delete", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestGameEndOpensTheResultReportWindow() {
+	defer s.stubNow()()
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress}, nil)
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerResultReport, fixtureNow.Add(fixtureResultReportWindow)).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerGameEnd](fixtureLobbyID)
+
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestGameEndIgnoresAFinishedGame() {
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusFinished}, nil)
+
+	s.scheduler.handlers[models.LobbyTimerGameEnd](fixtureLobbyID)
+
+	s.scheduler.AssertNotCalled(s.T(), "Schedule", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestResultReportTimeoutFinishesTheGame() {
+	player := newUser(1, "player1")
+	gameLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: seated(player)}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, player.ID).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{player.ID}, []uint(nil), mock.AnythingOfType("time.Time")).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerResultReport](fixtureLobbyID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.leaderboardRepo.AssertExpectations(s.T())
+	s.Equal(models.LobbyStatusFinished, gameLobby.Status)
+}
+
+func (s *LobbyServiceTestSuite) TestResultReportTimeoutIgnoresAReportedGame() {
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusFinished}, nil)
+
+	s.scheduler.handlers[models.LobbyTimerResultReport](fixtureLobbyID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestResultReportTimeoutKeepsGoingOnRepositoryError() {
+	player := newUser(1, "player1")
+	gameLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: seated(player)}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, player.ID).Return(errors.New("db error"))
+
+	s.scheduler.handlers[models.LobbyTimerResultReport](fixtureLobbyID)
+
+	s.leaderboardRepo.AssertNotCalled(s.T(), "RecordGame", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestResultReportTimeoutLosesTheRaceToAReport() {
+	player := newUser(1, "player1")
+	gameLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: seated(player)}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, player.ID).Return(lobbyrepo.ErrLobbyNotInProgress)
+
+	s.scheduler.handlers[models.LobbyTimerResultReport](fixtureLobbyID)
+
+	s.leaderboardRepo.AssertNotCalled(s.T(), "RecordGame", mock.Anything, mock.Anything, mock.Anything)
+	s.Equal(models.LobbyStatusInProgress, gameLobby.Status)
+}
@@ -0,0 +1,59 @@
+package lobby
+
+import (
+	"context"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/pagination"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultMatchesPageSize = 10
+	maxMatchesPageSize     = 50
+)
+
+// ListMyMatches pages through the finished games of the user. The page token is the number of matches already
+// returned, as for every pagination.OffsetPage.
+func (s *LobbyService) ListMyMatches(ctx context.Context, req *lobby.ListMyMatchesRequest) (*lobby.ListMyMatchesResponse, error) {
+	page, err := pagination.ParseOffsetPage(req.GetPageSize(), req.GetPageToken(), defaultMatchesPageSize,
+		maxMatchesPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	lobbies, err := s.lobbyRepo.ListFinishedByPlayer(player.ID, page.Offset, page.Limit())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	resp := &lobby.ListMyMatchesResponse{}
+	lobbies, resp.NextPageToken = pagination.Cut(page, lobbies)
+	resp.Matches = make([]*lobby.Match, len(lobbies))
+	for i, finishedLobby := range lobbies {
+		resp.Matches[i] = toProtoMatch(finishedLobby, player.ID)
+	}
+	return resp, nil
+}
+
+// toProtoMatch describes the finished lobby from the point of view of the given player.
+func toProtoMatch(m *models.Lobby, userID uint) *lobby.Match {
+	match := &lobby.Match{
+		Lobby:      toProtoLobby(m),
+		FinishedAt: timestamppb.New(m.UpdatedAt),
+	}
+	for _, player := range m.Players {
+		if player.UserID == userID && player.Placement != nil {
+			match.Placement = int32(*player.Placement)
+		}
+	}
+	return match
+}
@@ -0,0 +1,129 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+func finishedLobbyFixture(lobbyID string, winner, loser *models.User) *models.Lobby {
+	players := seated(winner, loser)
+	first, second := 1, 2
+	players[0].Placement = &first
+	players[1].Placement = &second
+	return &models.Lobby{
+		LobbyID:   lobbyID,
+		Status:    models.LobbyStatusFinished,
+		Players:   players,
+		WinnerID:  &winner.ID,
+		Winner:    winner,
+		UpdatedAt: fixtureNow,
+	}
+}
+
+func (s *LobbyServiceTestSuite) TestListMyMatchesReturnsThePlacementOfTheUser() {
+	player := newUser(1, "player1")
+	opponent := newUser(2, "player2")
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("ListFinishedByPlayer", player.ID, 0, defaultMatchesPageSize+1).Return([]*models.Lobby{
+		finishedLobbyFixture("won", player, opponent),
+		finishedLobbyFixture("lost", opponent, player),
+	}, nil)
+
+	resp, err := s.service.ListMyMatches(context.Background(), &lobby.ListMyMatchesRequest{Username: "player1"})
+
+	s.NoError(err)
+	s.Require().Len(resp.Matches, 2)
+	s.Equal("won", resp.Matches[0].Lobby.LobbyId)
+	s.Equal(int32(1), resp.Matches[0].Placement)
+	s.Equal(int32(2), resp.Matches[1].Placement)
+	s.True(fixtureNow.Equal(resp.Matches[0].FinishedAt.AsTime()))
+	s.Empty(resp.NextPageToken)
+}
+
+func (s *LobbyServiceTestSuite) TestListMyMatchesPagesThroughTheMatches() {
+	player := newUser(1, "player1")
+	opponent := newUser(2, "player2")
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("ListFinishedByPlayer", player.ID, 4, 3).Return([]*models.Lobby{
+		finishedLobbyFixture("first", player, opponent),
+		finishedLobbyFixture("second", player, opponent),
+		finishedLobbyFixture("third", player, opponent),
+	}, nil)
+
+	resp, err := s.service.ListMyMatches(context.Background(), &lobby.ListMyMatchesRequest{Username: "player1", PageSize: 2, PageToken: "4"})
+
+	s.NoError(err)
+	s.Len(resp.Matches, 2)
+	s.Equal("6", resp.NextPageToken)
+}
+
+func (s *LobbyServiceTestSuite) TestListMyMatchesCapsThePageSize() {
+	player := newUser(1, "player1")
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("ListFinishedByPlayer", player.ID, 0, maxMatchesPageSize+1).Return([]*models.Lobby{}, nil)
+
+	resp, err := s.service.ListMyMatches(context.Background(), &lobby.ListMyMatchesRequest{Username: "player1", PageSize: 1000})
+
+	s.NoError(err)
+	s.Empty(resp.Matches)
+}
+
+func (s *LobbyServiceTestSuite) TestListMyMatchesFailsWithAnInvalidPage() {
+	for _, req := range []*lobby.ListMyMatchesRequest{
+		{Username: "player1", PageSize: -1},
+		{Username: "player1", PageToken: "not-a-number"},
+		{Username: "player1", PageToken: "-10"},
+	} {
+		_, err := s.service.ListMyMatches(context.Background(), req)
+
+		s.assertGrpcError(err, codes.InvalidArgument, "invalid page")
+	}
+	s.lobbyRepo.AssertNotCalled(s.T(), "ListFinishedByPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestListMyMatchesFailsOnRepositoryError() {
+	player := newUser(1, "player1")
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("ListFinishedByPlayer", player.ID, 0, defaultMatchesPageSize+1).Return(nil, errors.New("db error"))
+
+	_, err := s.service.ListMyMatches(context.Background(), &lobby.ListMyMatchesRequest{Username: "player1"})
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
+}
+
+func (s *LobbyServiceTestSuite) TestFinishGameRecordsThePlacements() {
+	gameLobby := &models.Lobby{
+		LobbyID: fixtureLobbyID,
+		Status:  models.LobbyStatusInProgress,
+		Players: seated(newUser(1, "player1"), newUser(2, "player2")),
+	}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, mock.AnythingOfType("uint")).Return(nil)
+	s.leaderboardRepo.On("RecordGame", mock.AnythingOfType("[]uint"), mock.AnythingOfType("[]uint"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	s.expectCancelled(models.LobbyTimerGameEnd)
+	s.expectCancelled(models.LobbyTimerResultReport)
+	s.expectCancelled(models.LobbyTimerReconnect)
+
+	resp, err := s.service.FinishGame(context.Background(), &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "player1"})
+
+	s.NoError(err)
+	var placements []int32
+	for _, player := range resp.Players {
+		s.Require().NotNil(player.Placement)
+		placements = append(placements, *player.Placement)
+		if *player.Placement == 1 {
+			s.Equal(player.Username, resp.GetWinnerUsername())
+		}
+	}
+	s.ElementsMatch([]int32{1, 2}, placements)
+	recorded := s.leaderboardRepo.Calls[0].Arguments
+	s.Equal([]uint{uint(*resp.WinnerId)}, recorded.Get(0))
+	s.Len(recorded.Get(1), 1)
+	s.NotContains(recorded.Get(1), uint(*resp.WinnerId))
+}
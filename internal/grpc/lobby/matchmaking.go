@@ -0,0 +1,435 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gamemode"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The statuses of a search, as the players see it.
+const (
+	searchSearching  = "SEARCHING"
+	searchMatchFound = "MATCH_FOUND"
+	searchMatched    = "MATCHED"
+)
+
+// SearchMatch puts the caller in the queue of the game mode and region, then looks for a match right away: the
+// search that completes a match does not wait for another one.
+func (s *LobbyService) SearchMatch(ctx context.Context, req *lobby.SearchMatchRequest) (*lobby.MatchSearch, error) {
+	player, err := s.callerPlayer(ctx, "search for a match")
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := s.catalog.Mode(req.GetGameMode())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	region, err := s.catalog.Region(req.GetRegion())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	// The matchmaker pairs single players, so a party would be split up between different matches.
+	group, err := s.groupOf(player, "search for a match")
+	if err != nil {
+		return nil, err
+	}
+	if len(group) > 1 {
+		return nil, status.Errorf(codes.FailedPrecondition, "parties play together by creating or joining a lobby")
+	}
+
+	if err := s.checkCooldown(group); err != nil {
+		return nil, err
+	}
+	if err := s.checkNotInActiveLobby(player); err != nil {
+		return nil, err
+	}
+
+	ticket := &models.MatchTicket{UserID: player.ID, User: *player, GameMode: mode.Name, Region: region, QueuedAt: now()}
+	err = s.lobbyRepo.Enqueue(ticket)
+	if errors.Is(err, lobbyrepo.ErrAlreadySearching) {
+		return nil, status.Errorf(codes.FailedPrecondition, "you are already searching for a match")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if err := s.matchmake(mode, region); err != nil {
+		return nil, err
+	}
+	return s.searchOf(player.ID)
+}
+
+// GetMySearch returns the search of the caller, with the pending match it is part of, if any.
+func (s *LobbyService) GetMySearch(ctx context.Context, req *lobby.GetMySearchRequest) (*lobby.MatchSearch, error) {
+	player, err := s.callerPlayer(ctx, "see your search")
+	if err != nil {
+		return nil, err
+	}
+	return s.searchOf(player.ID)
+}
+
+// CancelSearch takes the caller out of the queue. A match that was found can only be declined, which tells the other
+// players at once.
+func (s *LobbyService) CancelSearch(ctx context.Context, req *lobby.CancelSearchRequest) (*lobby.CancelSearchResponse, error) {
+	player, err := s.callerPlayer(ctx, "cancel your search")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.lobbyRepo.Dequeue(player.ID)
+	if errors.Is(err, lobbyrepo.ErrTicketNotFound) {
+		return nil, status.Errorf(codes.NotFound, "you are not searching for a match")
+	}
+	if errors.Is(err, lobbyrepo.ErrTicketMatched) {
+		return nil, status.Errorf(codes.FailedPrecondition, "a match has been found: decline it instead")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return &lobby.CancelSearchResponse{}, nil
+}
+
+// AcceptMatch accepts the pending match for the caller. The last acceptance creates the lobby of the match.
+func (s *LobbyService) AcceptMatch(ctx context.Context, req *lobby.RespondMatchRequest) (*lobby.MatchSearch, error) {
+	match, ticket, err := s.pendingMatchOf(ctx, req.GetMatchId(), "accept the match")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.lobbyRepo.AcceptMatch(match, ticket.UserID, now())
+	if errors.Is(err, lobbyrepo.ErrMatchOver) {
+		return nil, status.Errorf(codes.FailedPrecondition, "the match is over")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	// The match is read again, so that two players accepting at the same time can not both miss the other one.
+	match, err = s.lobbyRepo.FindMatch(match.MatchID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if match.Status == models.PendingMatchPending && allAccepted(match) {
+		err := s.startMatch(match)
+		if errors.Is(err, lobbyrepo.ErrMatchOver) {
+			// Another acceptance created the lobby, or the match ended, since it was read.
+			if match, err = s.lobbyRepo.FindMatch(match.MatchID); err != nil {
+				return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	if match.Status != models.PendingMatchPending && match.Status != models.PendingMatchAccepted {
+		// The match fell through right after the acceptance: the caller is back to searching.
+		return s.searchOf(ticket.UserID)
+	}
+	return toProtoSearch(ticket, match), nil
+}
+
+// DeclineMatch calls off the pending match. The caller leaves the queue and is put on the short decline cooldown,
+// while the other players go back to searching with the time they started, ahead of the players that came after them.
+func (s *LobbyService) DeclineMatch(ctx context.Context, req *lobby.RespondMatchRequest) (*lobby.DeclineMatchResponse, error) {
+	match, ticket, err := s.pendingMatchOf(ctx, req.GetMatchId(), "decline the match")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.failMatch(match, models.PendingMatchDeclined, []uint{ticket.UserID})
+	if errors.Is(err, lobbyrepo.ErrMatchOver) {
+		return nil, status.Errorf(codes.FailedPrecondition, "the match is over")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.recordInfractions(match.MatchID, models.InfractionDecline, []uint{ticket.UserID})
+	return &lobby.DeclineMatchResponse{}, nil
+}
+
+// pendingMatchOf loads the match and the ticket the authenticated caller has in it, checking that the match still
+// waits for the answers of its players. The action completes the messages returned to anybody else.
+func (s *LobbyService) pendingMatchOf(ctx context.Context, matchID, action string) (*models.PendingMatch,
+	*models.MatchTicket, error) {
+	player, err := s.callerPlayer(ctx, action)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	match, err := s.lobbyRepo.FindMatch(matchID)
+	if errors.Is(err, lobbyrepo.ErrMatchNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "match not found")
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if match.Status != models.PendingMatchPending {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "the match is over")
+	}
+	ticket := ticketOf(match, player.ID)
+	if ticket == nil {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "only the players of the match can %s", action)
+	}
+	if !now().Before(match.Deadline) {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "the match has expired")
+	}
+	return match, ticket, nil
+}
+
+// matchmake forms a pending match out of the tickets of the game mode and region that have been searching the
+// longest, once there are enough of them to fill a lobby. Like the timers of the lobbies, the deadline of the match is
+// scheduled before the match is formed, and cancelled when there are not enough tickets.
+func (s *LobbyService) matchmake(mode gamemode.Mode, region string) error {
+	match := &models.PendingMatch{
+		MatchID:  uuid.New().String(),
+		GameMode: mode.Name,
+		Region:   region,
+		Status:   models.PendingMatchPending,
+		Deadline: now().Add(s.cfg.Timeouts.MatchAccept),
+	}
+	if err := s.scheduler.Schedule(match.MatchID, models.LobbyTimerMatchAccept, match.Deadline); err != nil {
+		return status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err := s.lobbyRepo.FormMatch(match, mode.Capacity)
+	if errors.Is(err, lobbyrepo.ErrNotEnoughTickets) {
+		s.cancelTimer(match.MatchID, models.LobbyTimerMatchAccept)
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return nil
+}
+
+// startMatch creates the lobby of the match that every player accepted, and starts its game at once: accepting the
+// match stands for the ready check of the lobbies the players fill themselves. The players are seated in queue order,
+// and their wait is counted from when they started searching. It returns lobbyrepo.ErrMatchOver as is when the match
+// already ended.
+func (s *LobbyService) startMatch(match *models.PendingMatch) error {
+	mode, err := s.catalog.Mode(match.GameMode)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Invalid game mode: %v", err)
+	}
+	settings, err := mode.Resolve(nil)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Invalid game mode: %v", err)
+	}
+
+	startedAt := now()
+	players := make([]models.LobbyPlayer, len(match.Tickets))
+	for i, ticket := range match.Tickets {
+		players[i] = models.LobbyPlayer{UserID: ticket.UserID, User: ticket.User, Seat: i, JoinedAt: ticket.QueuedAt}
+		if mode.Teams > 0 {
+			team := i%mode.Teams + 1
+			players[i].Team = &team
+		}
+	}
+	matchLobby := &models.Lobby{
+		LobbyID:    uuid.New().String(),
+		Name:       fmt.Sprintf("%s match", mode.DisplayName),
+		Players:    players,
+		Status:     models.LobbyStatusInProgress,
+		Visibility: models.LobbyVisibilityPublic,
+		HostID:     &match.Tickets[0].UserID,
+		GameMode:   mode.Name,
+		Region:     match.Region,
+		Settings:   settings,
+		MaxPlayers: mode.Capacity,
+		Teams:      mode.Teams,
+		MatchedAt:  &startedAt,
+	}
+
+	gameEnd := startedAt.Add(s.cfg.Timeouts.Game)
+	if err := s.scheduler.Schedule(matchLobby.LobbyID, models.LobbyTimerGameEnd, gameEnd); err != nil {
+		return status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err = s.lobbyRepo.CreateMatchLobby(match, matchLobby)
+	if err != nil {
+		s.cancelTimer(matchLobby.LobbyID, models.LobbyTimerGameEnd)
+	}
+	if errors.Is(err, lobbyrepo.ErrMatchOver) {
+		return err
+	}
+	if errors.Is(err, lobbyrepo.ErrPlayerInLobby) {
+		return s.cancelMatch(match)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.cancelTimer(match.MatchID, models.LobbyTimerMatchAccept)
+	s.balanceTeams(matchLobby)
+	return nil
+}
+
+// cancelMatch ends the match of the players that entered a lobby since they started searching. They leave the queue
+// without an infraction, since they are playing anyway, and the other players go back to it.
+func (s *LobbyService) cancelMatch(match *models.PendingMatch) error {
+	var busy []uint
+	for _, ticket := range match.Tickets {
+		_, err := s.lobbyRepo.FindActiveByPlayer(ticket.UserID)
+		if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+			continue
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+		}
+		busy = append(busy, ticket.UserID)
+	}
+	return s.failMatch(match, models.PendingMatchCancelled, busy)
+}
+
+// failMatch ends the match without a lobby: the tickets of the dropped players are deleted, and the other ones go back
+// to the queue, where the matchmaker tries to pair them again right away. It returns lobbyrepo.ErrMatchOver as is when
+// the match already ended.
+func (s *LobbyService) failMatch(match *models.PendingMatch, outcome models.PendingMatchStatus, droppedIDs []uint) error {
+	err := s.lobbyRepo.FailMatch(match, outcome, droppedIDs)
+	if errors.Is(err, lobbyrepo.ErrMatchOver) {
+		return err
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.cancelTimer(match.MatchID, models.LobbyTimerMatchAccept)
+
+	// The match is over whatever happens next: the tickets that are not paired now wait for the next search.
+	mode, err := s.catalog.Mode(match.GameMode)
+	if err == nil {
+		err = s.matchmake(mode, match.Region)
+	}
+	if err != nil {
+		log.Printf("Failed to pair again the players of match %s: %v", match.MatchID, err)
+	}
+	return nil
+}
+
+// expireMatch ends the match that not every player accepted in time. The players that did not answer leave the queue
+// with the same escalating cooldown as the ones that let a ready check expire, and the others go back to searching.
+func (s *LobbyService) expireMatch(matchID string) {
+	match, err := s.lobbyRepo.FindMatch(matchID)
+	if errors.Is(err, lobbyrepo.ErrMatchNotFound) {
+		// The timer of a search that did not form a match.
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to expire match %s: %v", matchID, err)
+		return
+	}
+
+	if match.Status != models.PendingMatchPending || now().Before(match.Deadline) {
+		return
+	}
+
+	var unanswered []uint
+	for _, ticket := range match.Tickets {
+		if ticket.AcceptedAt == nil {
+			unanswered = append(unanswered, ticket.UserID)
+		}
+	}
+
+	// Every player accepted, but the lobby was not created: the last acceptance failed along the way.
+	if len(unanswered) == 0 {
+		if err := s.startMatch(match); err != nil && !errors.Is(err, lobbyrepo.ErrMatchOver) {
+			log.Printf("Failed to start match %s: %v", matchID, err)
+		}
+		return
+	}
+
+	// An acceptance may have completed the match, or a decline may have ended it, since it was read.
+	err = s.failMatch(match, models.PendingMatchExpired, unanswered)
+	if errors.Is(err, lobbyrepo.ErrMatchOver) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to expire match %s: %v", matchID, err)
+		return
+	}
+	s.recordInfractions(matchID, models.InfractionDodge, unanswered)
+}
+
+// searchOf describes the search of the player, with its pending match when it has one.
+func (s *LobbyService) searchOf(playerID uint) (*lobby.MatchSearch, error) {
+	ticket, err := s.lobbyRepo.FindTicket(playerID)
+	if errors.Is(err, lobbyrepo.ErrTicketNotFound) {
+		return nil, status.Errorf(codes.NotFound, "you are not searching for a match")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	if ticket.PendingMatchID == nil {
+		return toProtoSearch(ticket, nil), nil
+	}
+
+	match, err := s.lobbyRepo.FindMatch(*ticket.PendingMatchID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return toProtoSearch(ticket, match), nil
+}
+
+func ticketOf(match *models.PendingMatch, userID uint) *models.MatchTicket {
+	for i := range match.Tickets {
+		if match.Tickets[i].UserID == userID {
+			return &match.Tickets[i]
+		}
+	}
+	return nil
+}
+
+func allAccepted(match *models.PendingMatch) bool {
+	for _, ticket := range match.Tickets {
+		if ticket.AcceptedAt == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// toProtoSearch converts the ticket, with its match when it is PENDING or ACCEPTED.
+func toProtoSearch(ticket *models.MatchTicket, match *models.PendingMatch) *lobby.MatchSearch {
+	search := &lobby.MatchSearch{
+		Status:   searchSearching,
+		GameMode: ticket.GameMode,
+		Region:   ticket.Region,
+		QueuedAt: timestamppb.New(ticket.QueuedAt),
+	}
+	if match == nil {
+		return search
+	}
+
+	search.MatchId = match.MatchID
+	if match.Status == models.PendingMatchAccepted {
+		search.Status = searchMatched
+		search.LobbyId = *match.LobbyID
+		return search
+	}
+
+	search.Status = searchMatchFound
+	search.AcceptDeadline = timestamppb.New(match.Deadline)
+	search.Players = int32(len(match.Tickets))
+	for _, matched := range match.Tickets {
+		if matched.AcceptedAt == nil {
+			continue
+		}
+		search.AcceptedPlayers++
+		if matched.UserID == ticket.UserID {
+			search.Accepted = true
+		}
+	}
+	return search
+}
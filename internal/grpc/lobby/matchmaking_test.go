@@ -0,0 +1,449 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+const fixtureMatchID = "match-123"
+
+// ticketFixture returns the DUEL ticket of the user in the EU queue, which started searching minutes ago.
+func ticketFixture(user *models.User, minutes int) models.MatchTicket {
+	return models.MatchTicket{
+		ID:       user.ID,
+		UserID:   user.ID,
+		User:     *user,
+		GameMode: "DUEL",
+		Region:   "EU",
+		QueuedAt: fixtureNow.Add(-time.Duration(minutes) * time.Minute),
+	}
+}
+
+// accepted marks the ticket as accepted a second before now.
+func accepted(ticket models.MatchTicket) models.MatchTicket {
+	acceptedAt := fixtureNow.Add(-time.Second)
+	ticket.AcceptedAt = &acceptedAt
+	return ticket
+}
+
+// pendingMatchFixture returns the PENDING match of the tickets, whose deadline is still ahead.
+func pendingMatchFixture(tickets ...models.MatchTicket) *models.PendingMatch {
+	match := &models.PendingMatch{
+		MatchID:  fixtureMatchID,
+		GameMode: "DUEL",
+		Region:   "EU",
+		Status:   models.PendingMatchPending,
+		Deadline: fixtureNow.Add(time.Second),
+	}
+	for _, ticket := range tickets {
+		ticket.PendingMatchID = &match.MatchID
+		match.Tickets = append(match.Tickets, ticket)
+	}
+	return match
+}
+
+// expectSearchAlone lets the matchmaker find no match for the tickets of the test.
+func (s *LobbyServiceTestSuite) expectSearchAlone() {
+	s.expectScheduled(models.LobbyTimerMatchAccept)
+	s.lobbyRepo.On("FormMatch", mock.AnythingOfType("*models.PendingMatch"), 2).Return(lobbyrepo.ErrNotEnoughTickets)
+	s.expectCancelled(models.LobbyTimerMatchAccept)
+}
+
+func (s *LobbyServiceTestSuite) TestSearchMatchQueuesTheCaller() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	player := newUser(1, "player")
+	ticket := ticketFixture(player, 0)
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", player.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("Enqueue", mock.MatchedBy(func(t *models.MatchTicket) bool {
+		return t.UserID == player.ID && t.GameMode == "DUEL" && t.Region == "EU" && t.QueuedAt.Equal(fixtureNow)
+	})).Return(nil)
+	s.expectSearchAlone()
+	s.lobbyRepo.On("FindTicket", player.ID).Return(&ticket, nil)
+
+	resp, err := s.service.SearchMatch(callerContext("player"), &lobby.SearchMatchRequest{})
+
+	s.NoError(err)
+	s.Equal(searchSearching, resp.Status)
+	s.Equal("DUEL", resp.GameMode)
+	s.Equal("EU", resp.Region)
+	s.Empty(resp.MatchId)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestSearchMatchFormsAMatchOnceTheQueueIsFull() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	waiting, player := newUser(1, "waiting"), newUser(2, "player")
+	match := pendingMatchFixture(ticketFixture(waiting, 3), ticketFixture(player, 0))
+	match.Deadline = fixtureNow.Add(fixtureMatchAcceptTimeout)
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", player.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("Enqueue", mock.AnythingOfType("*models.MatchTicket")).Return(nil)
+	s.scheduler.On("Schedule", mock.AnythingOfType("string"), models.LobbyTimerMatchAccept, match.Deadline).Return(nil)
+	s.lobbyRepo.On("FormMatch", mock.MatchedBy(func(m *models.PendingMatch) bool {
+		return m.GameMode == "DUEL" && m.Region == "EU" && m.Status == models.PendingMatchPending &&
+			m.Deadline.Equal(match.Deadline)
+	}), 2).Return(nil)
+	s.lobbyRepo.On("FindTicket", player.ID).Return(&match.Tickets[1], nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+
+	resp, err := s.service.SearchMatch(callerContext("player"), &lobby.SearchMatchRequest{GameMode: "DUEL", Region: "EU"})
+
+	s.NoError(err)
+	s.Equal(searchMatchFound, resp.Status)
+	s.Equal(fixtureMatchID, resp.MatchId)
+	s.Equal(match.Deadline, resp.AcceptDeadline.AsTime())
+	s.Equal(int32(2), resp.Players)
+	s.Zero(resp.AcceptedPlayers)
+	s.False(resp.Accepted)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSearchMatchFailsWhenUnauthenticated() {
+	_, err := s.service.SearchMatch(context.Background(), &lobby.SearchMatchRequest{})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to search for a match")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Enqueue", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSearchMatchFailsForAnUnknownGameMode() {
+	s.userRepo.On("FindByUsername", "player").Return(newUser(1, "player"), nil)
+
+	_, err := s.service.SearchMatch(callerContext("player"), &lobby.SearchMatchRequest{GameMode: "CHESS"})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Enqueue", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSearchMatchFailsForAParty() {
+	leader, friend := newUser(1, "leader"), newUser(2, "friend")
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+
+	_, err := s.service.SearchMatch(callerContext("leader"), &lobby.SearchMatchRequest{})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "parties play together")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Enqueue", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSearchMatchFailsInAnActiveLobby() {
+	s.expectNoParty()
+	player := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", player.ID).Return(&models.Lobby{LobbyID: fixtureLobbyID}, nil)
+
+	_, err := s.service.SearchMatch(callerContext("player"), &lobby.SearchMatchRequest{})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Enqueue", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSearchMatchFailsWhenAlreadySearching() {
+	s.expectNoParty()
+	player := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", player.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("Enqueue", mock.AnythingOfType("*models.MatchTicket")).Return(lobbyrepo.ErrAlreadySearching)
+
+	_, err := s.service.SearchMatch(callerContext("player"), &lobby.SearchMatchRequest{})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already searching")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FormMatch", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestGetMySearchFailsWhenNotSearching() {
+	player := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindTicket", player.ID).Return(nil, lobbyrepo.ErrTicketNotFound)
+
+	_, err := s.service.GetMySearch(callerContext("player"), &lobby.GetMySearchRequest{})
+
+	s.assertGrpcError(err, codes.NotFound, "not searching")
+}
+
+func (s *LobbyServiceTestSuite) TestCancelSearchLeavesTheQueue() {
+	player := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("Dequeue", player.ID).Return(nil)
+
+	_, err := s.service.CancelSearch(callerContext("player"), &lobby.CancelSearchRequest{})
+
+	s.NoError(err)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestCancelSearchFailsOnceAMatchIsFound() {
+	player := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("Dequeue", player.ID).Return(lobbyrepo.ErrTicketMatched)
+
+	_, err := s.service.CancelSearch(callerContext("player"), &lobby.CancelSearchRequest{})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "decline it instead")
+}
+
+func (s *LobbyServiceTestSuite) TestCancelSearchFailsWhenNotSearching() {
+	player := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("Dequeue", player.ID).Return(lobbyrepo.ErrTicketNotFound)
+
+	_, err := s.service.CancelSearch(callerContext("player"), &lobby.CancelSearchRequest{})
+
+	s.assertGrpcError(err, codes.NotFound, "not searching")
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchWaitsForTheOtherPlayers() {
+	defer s.stubNow()()
+	first, second := newUser(1, "first"), newUser(2, "second")
+	before := pendingMatchFixture(ticketFixture(first, 3), ticketFixture(second, 1))
+	after := pendingMatchFixture(accepted(ticketFixture(first, 3)), ticketFixture(second, 1))
+	s.userRepo.On("FindByUsername", "first").Return(first, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(before, nil).Once()
+	s.lobbyRepo.On("AcceptMatch", before, first.ID, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(after, nil).Once()
+
+	resp, err := s.service.AcceptMatch(callerContext("first"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.NoError(err)
+	s.Equal(searchMatchFound, resp.Status)
+	s.True(resp.Accepted)
+	s.Equal(int32(1), resp.AcceptedPlayers)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "CreateMatchLobby", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchByTheLastPlayerStartsTheGame() {
+	defer s.stubNow()()
+	first, second := newUser(1, "first"), newUser(2, "second")
+	before := pendingMatchFixture(accepted(ticketFixture(first, 3)), ticketFixture(second, 1))
+	after := pendingMatchFixture(accepted(ticketFixture(first, 3)), accepted(ticketFixture(second, 1)))
+	s.userRepo.On("FindByUsername", "second").Return(second, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(before, nil).Once()
+	s.lobbyRepo.On("AcceptMatch", before, second.ID, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(after, nil).Once()
+	s.scheduler.On("Schedule", mock.AnythingOfType("string"), models.LobbyTimerGameEnd, fixtureNow.Add(fixtureGameDuration)).Return(nil)
+	s.lobbyRepo.On("CreateMatchLobby", after, mock.MatchedBy(func(l *models.Lobby) bool {
+		// The players are seated in queue order, and their wait counts from when they started searching.
+		return l.Status == models.LobbyStatusInProgress && l.Visibility == models.LobbyVisibilityPublic &&
+			l.GameMode == "DUEL" && l.Region == "EU" && l.MaxPlayers == 2 && *l.HostID == first.ID &&
+			l.CreatorID == nil && l.JoinCode == nil && l.MatchedAt.Equal(fixtureNow) && l.Settings["map"] == "ARENA" &&
+			len(l.Players) == 2 && l.Players[0].UserID == first.ID && l.Players[1].UserID == second.ID &&
+			l.Players[0].JoinedAt.Equal(fixtureNow.Add(-3*time.Minute))
+	})).Return(nil)
+	s.scheduler.On("Cancel", fixtureMatchID, models.LobbyTimerMatchAccept).Return(nil)
+
+	resp, err := s.service.AcceptMatch(callerContext("second"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.NoError(err)
+	s.Equal(searchMatched, resp.Status)
+	s.NotEmpty(resp.LobbyId)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchCancelsTheMatchOfAPlayerThatEnteredALobby() {
+	defer s.stubNow()()
+	first, busy := newUser(1, "first"), newUser(2, "busy")
+	before := pendingMatchFixture(ticketFixture(first, 3), accepted(ticketFixture(busy, 1)))
+	after := pendingMatchFixture(accepted(ticketFixture(first, 3)), accepted(ticketFixture(busy, 1)))
+	requeued := ticketFixture(first, 3)
+	s.userRepo.On("FindByUsername", "first").Return(first, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(before, nil).Once()
+	s.lobbyRepo.On("AcceptMatch", before, first.ID, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(after, nil).Once()
+	s.expectScheduled(models.LobbyTimerGameEnd)
+	s.lobbyRepo.On("CreateMatchLobby", after, mock.AnythingOfType("*models.Lobby")).Return(lobbyrepo.ErrPlayerInLobby)
+	s.expectCancelled(models.LobbyTimerGameEnd)
+	s.lobbyRepo.On("FindActiveByPlayer", first.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindActiveByPlayer", busy.ID).Return(&models.Lobby{LobbyID: fixtureLobbyID}, nil)
+	s.lobbyRepo.On("FailMatch", after, models.PendingMatchCancelled, []uint{busy.ID}).Return(nil)
+	s.expectSearchAlone()
+	s.lobbyRepo.On("FindTicket", first.ID).Return(&requeued, nil)
+
+	resp, err := s.service.AcceptMatch(callerContext("first"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.NoError(err)
+	s.Equal(searchSearching, resp.Status)
+	s.Equal(requeued.QueuedAt, resp.QueuedAt.AsTime())
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchFailsWhenTheMatchIsOver() {
+	player := newUser(1, "player")
+	match := pendingMatchFixture()
+	match.Status = models.PendingMatchDeclined
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+
+	_, err := s.service.AcceptMatch(callerContext("player"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "the match is over")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AcceptMatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchFailsWhenTheMatchEndedConcurrently() {
+	defer s.stubNow()()
+	player := newUser(1, "player")
+	match := pendingMatchFixture(ticketFixture(player, 1), ticketFixture(newUser(2, "other"), 0))
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+	s.lobbyRepo.On("AcceptMatch", match, player.ID, fixtureNow).Return(lobbyrepo.ErrMatchOver)
+
+	_, err := s.service.AcceptMatch(callerContext("player"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "the match is over")
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchFailsForAnotherPlayer() {
+	defer s.stubNow()()
+	s.userRepo.On("FindByUsername", "outsider").Return(newUser(3, "outsider"), nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(pendingMatchFixture(ticketFixture(newUser(1, "first"), 1)), nil)
+
+	_, err := s.service.AcceptMatch(callerContext("outsider"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the players of the match")
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchFailsAfterTheDeadline() {
+	defer s.stubNow()()
+	player := newUser(1, "player")
+	match := pendingMatchFixture(ticketFixture(player, 1))
+	match.Deadline = fixtureNow
+	s.userRepo.On("FindByUsername", "player").Return(player, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+
+	_, err := s.service.AcceptMatch(callerContext("player"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "the match has expired")
+}
+
+func (s *LobbyServiceTestSuite) TestAcceptMatchFailsWhenMatchNotFound() {
+	s.userRepo.On("FindByUsername", "player").Return(newUser(1, "player"), nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(nil, lobbyrepo.ErrMatchNotFound)
+
+	_, err := s.service.AcceptMatch(callerContext("player"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.assertGrpcError(err, codes.NotFound, "match not found")
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineMatchPutsTheOtherPlayersBackInTheQueue() {
+	defer s.stubNow()()
+	waiting, decliner := newUser(1, "waiting"), newUser(2, "decliner")
+	match := pendingMatchFixture(accepted(ticketFixture(waiting, 3)), ticketFixture(decliner, 1))
+	s.userRepo.On("FindByUsername", "decliner").Return(decliner, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+	s.lobbyRepo.On("FailMatch", match, models.PendingMatchDeclined, []uint{decliner.ID}).Return(nil)
+	s.scheduler.On("Cancel", fixtureMatchID, models.LobbyTimerMatchAccept).Return(nil)
+	s.expectSearchAlone()
+
+	_, err := s.service.DeclineMatch(callerContext("decliner"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.NoError(err)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureMatchID, models.InfractionDecline, []uint{decliner.ID}, fixtureNow)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineMatchFailsWhenTheMatchEndedConcurrently() {
+	defer s.stubNow()()
+	decliner := newUser(2, "decliner")
+	match := pendingMatchFixture(ticketFixture(newUser(1, "waiting"), 3), ticketFixture(decliner, 1))
+	s.userRepo.On("FindByUsername", "decliner").Return(decliner, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+	s.lobbyRepo.On("FailMatch", match, models.PendingMatchDeclined, []uint{decliner.ID}).Return(lobbyrepo.ErrMatchOver)
+
+	_, err := s.service.DeclineMatch(callerContext("decliner"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "the match is over")
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineMatchFailsOnRepositoryError() {
+	defer s.stubNow()()
+	decliner := newUser(2, "decliner")
+	match := pendingMatchFixture(ticketFixture(newUser(1, "waiting"), 3), ticketFixture(decliner, 1))
+	s.userRepo.On("FindByUsername", "decliner").Return(decliner, nil)
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+	s.lobbyRepo.On("FailMatch", match, models.PendingMatchDeclined, []uint{decliner.ID}).Return(errors.New("db error"))
+
+	_, err := s.service.DeclineMatch(callerContext("decliner"), &lobby.RespondMatchRequest{MatchId: fixtureMatchID})
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
+}
+
+func (s *LobbyServiceTestSuite) TestExpireMatchDropsThePlayersThatDidNotAnswer() {
+	defer s.stubNow()()
+	waiting, dodger := newUser(1, "waiting"), newUser(2, "dodger")
+	match := pendingMatchFixture(accepted(ticketFixture(waiting, 3)), ticketFixture(dodger, 1))
+	match.Deadline = fixtureNow
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+	s.lobbyRepo.On("FailMatch", match, models.PendingMatchExpired, []uint{dodger.ID}).Return(nil)
+	s.scheduler.On("Cancel", fixtureMatchID, models.LobbyTimerMatchAccept).Return(nil)
+	s.expectSearchAlone()
+
+	s.scheduler.handlers[models.LobbyTimerMatchAccept](fixtureMatchID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureMatchID, models.InfractionDodge, []uint{dodger.ID}, fixtureNow)
+}
+
+func (s *LobbyServiceTestSuite) TestExpireMatchStartsTheGameWhenEveryoneAccepted() {
+	defer s.stubNow()()
+	match := pendingMatchFixture(accepted(ticketFixture(newUser(1, "first"), 3)), accepted(ticketFixture(newUser(2, "second"), 1)))
+	match.Deadline = fixtureNow
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+	s.expectScheduled(models.LobbyTimerGameEnd)
+	s.lobbyRepo.On("CreateMatchLobby", match, mock.AnythingOfType("*models.Lobby")).Return(nil)
+	s.scheduler.On("Cancel", fixtureMatchID, models.LobbyTimerMatchAccept).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerMatchAccept](fixtureMatchID)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailMatch", mock.Anything, mock.Anything, mock.Anything)
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestExpireMatchIgnoresAMatchThatEnded() {
+	defer s.stubNow()()
+	match := pendingMatchFixture()
+	match.Status = models.PendingMatchAccepted
+	match.Deadline = fixtureNow
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+
+	s.scheduler.handlers[models.LobbyTimerMatchAccept](fixtureMatchID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailMatch", mock.Anything, mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "CreateMatchLobby", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestExpireMatchIgnoresASearchWithoutMatch() {
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(nil, lobbyrepo.ErrMatchNotFound)
+
+	s.scheduler.handlers[models.LobbyTimerMatchAccept](fixtureMatchID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailMatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestExpireMatchSkipsTheInfractionsWhenTheMatchEndedConcurrently() {
+	defer s.stubNow()()
+	match := pendingMatchFixture(accepted(ticketFixture(newUser(1, "waiting"), 3)), ticketFixture(newUser(2, "dodger"), 1))
+	match.Deadline = fixtureNow
+	s.lobbyRepo.On("FindMatch", fixtureMatchID).Return(match, nil)
+	s.lobbyRepo.On("FailMatch", match, models.PendingMatchExpired, []uint{2}).Return(lobbyrepo.ErrMatchOver)
+
+	s.scheduler.handlers[models.LobbyTimerMatchAccept](fixtureMatchID)
+
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,38 @@
+package lobby
+
+import (
+	"errors"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	partyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/party"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// groupOf returns the users that enter a lobby along with the user: the whole party when the user leads one, or the
+// user alone. The members of a party can not create or join lobbies on their own, the action describes what they
+// tried to do.
+func (s *LobbyService) groupOf(user *models.User, action string) ([]*models.User, error) {
+	userParty, err := s.partyRepo.FindByMember(user.ID)
+	if errors.Is(err, partyrepo.ErrPartyNotFound) {
+		return []*models.User{user}, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+
+	if len(userParty.Members) <= 1 {
+		return []*models.User{user}, nil
+	}
+	if userParty.LeaderID != user.ID {
+		return nil, status.Errorf(codes.FailedPrecondition, "only the leader of your party can %s", action)
+	}
+
+	group := []*models.User{user}
+	for i := range userParty.Members {
+		if userParty.Members[i].UserID != user.ID {
+			group = append(group, &userParty.Members[i].User)
+		}
+	}
+	return group, nil
+}
@@ -0,0 +1,208 @@
+package lobby
+
+import (
+	"context"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+// partyFixture returns the party led by the first user, with the others as members.
+func partyFixture(users ...*models.User) *models.Party {
+	p := &models.Party{ID: 3, LeaderID: users[0].ID, Leader: *users[0]}
+	for _, user := range users {
+		p.Members = append(p.Members, models.PartyMember{PartyID: p.ID, UserID: user.ID, User: *user})
+	}
+	return p
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbySeatsTheWholeParty() {
+	leader, friend := newUser(2, "leader"), newUser(3, "friend")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 4, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayers", waitingLobby, mock.MatchedBy(func(players []*models.User) bool {
+		return len(players) == 2 && players[0].ID == leader.ID && players[1].ID == friend.ID
+	}), 4).Return(nil)
+
+	resp, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "leader"})
+
+	s.NoError(err)
+	s.Len(resp.Players, 3)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWithoutRoomForTheParty() {
+	leader, friend := newUser(3, "leader"), newUser(4, "friend")
+	waitingLobby := &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Status:     models.LobbyStatusWaiting,
+		MaxPlayers: 4,
+		Players:    seated(newUser(1, "creator"), newUser(2, "player2"), newUser(5, "player3")),
+	}
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "leader"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not have enough free slots for your party")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayers", mock.Anything, mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenThePartyNoLongerFits() {
+	leader, friend := newUser(2, "leader"), newUser(3, "friend")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 4, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayers", waitingLobby, mock.Anything, 4).Return(lobbyrepo.ErrLobbyFull)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "leader"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not have enough free slots for your party")
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenAPartyMemberIsBusy() {
+	leader, friend := newUser(2, "leader"), newUser(3, "friend")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 4, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayers", waitingLobby, mock.Anything, 4).Return(lobbyrepo.ErrPlayerInLobby)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "leader"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "a member of your party is already in an active lobby")
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsForAPartyMember() {
+	leader, friend := newUser(2, "leader"), newUser(3, "friend")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 4, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", friend.ID).Return(partyFixture(leader, friend), nil)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "friend"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "only the leader of your party can join lobbies")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenAPartyMemberWasKicked() {
+	defer s.stubNow()()
+	leader, friend := newUser(2, "leader"), newUser(3, "friend")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 4, Players: seated(newUser(1, "creator"))}
+	bannedUntil := fixtureNow.Add(fixtureKickBan)
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+	s.lobbyRepo.On("BannedUntil", fixtureLobbyID, leader.ID).Return(nil, nil)
+	s.lobbyRepo.On("BannedUntil", fixtureLobbyID, friend.ID).Return(&bannedUntil, nil)
+	s.expectNotBlocked()
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "leader"})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "kicked")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyAloneInAParty() {
+	leader := newUser(2, "leader")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 4, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader), nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", waitingLobby, leader, 4).Return(nil)
+
+	resp, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "leader"})
+
+	s.NoError(err)
+	s.Len(resp.Players, 2)
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbySeatsTheParty() {
+	leader, friend := newUser(1, "leader"), newUser(2, "friend")
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+	s.lobbyRepo.On("FindActiveByPlayer", leader.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.MatchedBy(func(l *models.Lobby) bool {
+		return len(l.Players) == 2 && l.Players[1].UserID == friend.ID && l.Players[1].Seat == 1 &&
+			*l.Players[0].Team == 1 && *l.Players[1].Team == 2
+	})).Return(nil)
+
+	resp, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{
+		Name:     fixtureLobbyName,
+		Username: "leader",
+		GameMode: "TEAM_DEATHMATCH",
+	})
+
+	s.NoError(err)
+	s.Len(resp.Players, 2)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFilledByThePartyStartsTheReadyCheck() {
+	leader, friend := newUser(1, "leader"), newUser(2, "friend")
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+	s.lobbyRepo.On("FindActiveByPlayer", leader.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.AnythingOfType("*models.Lobby")).Return(nil)
+	s.expectScheduled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("StartReadyCheck", mock.AnythingOfType("*models.Lobby"), mock.AnythingOfType("time.Time"), false).Return(nil)
+	s.expectCancelled(models.LobbyTimerWaiting)
+
+	resp, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{
+		Name:     fixtureLobbyName,
+		Username: "leader",
+		GameMode: "DUEL",
+	})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusReadyCheck), resp.Status)
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWhenThePartyDoesNotFit() {
+	leader := newUser(1, "leader")
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, newUser(2, "friend"), newUser(3, "other")), nil)
+
+	_, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{
+		Name:     fixtureLobbyName,
+		Username: "leader",
+		GameMode: "DUEL",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "your party does not fit")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsForAPartyMember() {
+	leader, friend := newUser(1, "leader"), newUser(2, "friend")
+	s.userRepo.On("FindByUsername", "friend").Return(friend, nil)
+	s.partyRepo.On("FindByMember", friend.ID).Return(partyFixture(leader, friend), nil)
+
+	_, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{
+		Name:     fixtureLobbyName,
+		Username: "friend",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "only the leader of your party can create lobbies")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
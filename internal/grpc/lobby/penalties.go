@@ -0,0 +1,134 @@
+package lobby
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Penalties tell how long the users that leave or dodge games have to wait before they can create or join lobbies
+// again.
+type Penalties struct {
+	// Cooldown follows the first infraction. Every other infraction that did not decay yet doubles it, up to
+	// MaxCooldown.
+	Cooldown    time.Duration
+	MaxCooldown time.Duration
+	// Decay is how long an infraction counts towards the next cooldowns.
+	Decay time.Duration
+	// DeclineCooldown follows a declined ready check or match. It is short and always the same: declining tells the other
+	// players at once, so the declines do not count towards the cooldowns of the other infractions.
+	DeclineCooldown time.Duration
+}
+
+// cooldownUntil returns the end of the cooldown the infractions put the user on, given the most recent one first,
+// and whether it is the cooldown of a declined ready check or match.
+func (p Penalties) cooldownUntil(infractions []*models.Infraction) (time.Time, bool) {
+	var declinedUntil time.Time
+	if i := slices.IndexFunc(infractions, isDecline); i >= 0 {
+		declinedUntil = infractions[i].CreatedAt.Add(p.DeclineCooldown)
+	}
+
+	counted := countedInfractions(infractions)
+	if len(counted) == 0 {
+		return declinedUntil, !declinedUntil.IsZero()
+	}
+	cooldown := p.Cooldown
+	for range counted[1:] {
+		cooldown *= 2
+		if cooldown >= p.MaxCooldown {
+			break
+		}
+	}
+	until := counted[0].CreatedAt.Add(min(cooldown, p.MaxCooldown))
+	if declinedUntil.After(until) {
+		return declinedUntil, true
+	}
+	return until, false
+}
+
+func isDecline(infraction *models.Infraction) bool {
+	return infraction.Kind == models.InfractionDecline
+}
+
+// countedInfractions leaves out the declines, which do not make the cooldowns longer.
+func countedInfractions(infractions []*models.Infraction) []*models.Infraction {
+	return slices.DeleteFunc(slices.Clone(infractions), isDecline)
+}
+
+func (s *LobbyService) GetMyCooldown(ctx context.Context, req *lobby.GetMyCooldownRequest) (*lobby.GetMyCooldownResponse, error) {
+	user, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	at := now()
+	infractions, err := s.infractionRepo.ListByUser(user.ID, at.Add(-s.cfg.Penalties.Decay))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Infraction DB error: %v", err)
+	}
+
+	resp := &lobby.GetMyCooldownResponse{Infractions: uint32(len(countedInfractions(infractions)))}
+	if until, _ := s.cfg.Penalties.cooldownUntil(infractions); until.After(at) {
+		resp.CooldownUntil = timestamppb.New(until)
+	}
+	return resp, nil
+}
+
+// checkCooldown rejects the group when one of its members is on a cooldown, telling how long is left.
+func (s *LobbyService) checkCooldown(group []*models.User) error {
+	for i, member := range group {
+		remaining, declined, err := s.cooldownLeft(member)
+		if err != nil {
+			return err
+		}
+		if remaining <= 0 {
+			continue
+		}
+		// The caller always comes first in the group.
+		if declined && i == 0 {
+			return status.Errorf(codes.FailedPrecondition,
+				"you declined a ready check or a match: you can create or join lobbies again in %s",
+				remaining.Round(time.Second))
+		}
+		if declined {
+			return status.Errorf(codes.FailedPrecondition,
+				"%s declined a ready check or a match: your party can create or join lobbies again in %s",
+				member.Username, remaining.Round(time.Second))
+		}
+		if i == 0 {
+			return status.Errorf(codes.FailedPrecondition,
+				"you left or dodged too many games recently: you can create or join lobbies again in %s",
+				remaining.Round(time.Second))
+		}
+		return status.Errorf(codes.FailedPrecondition,
+			"%s left or dodged too many games recently: your party can create or join lobbies again in %s",
+			member.Username, remaining.Round(time.Second))
+	}
+	return nil
+}
+
+// cooldownLeft returns how long the user is still on a cooldown, if at all, and whether it is the cooldown of a
+// declined ready check or match.
+func (s *LobbyService) cooldownLeft(user *models.User) (time.Duration, bool, error) {
+	at := now()
+	infractions, err := s.infractionRepo.ListByUser(user.ID, at.Add(-s.cfg.Penalties.Decay))
+	if err != nil {
+		return 0, false, status.Errorf(codes.Internal, "Infraction DB error: %v", err)
+	}
+	until, declined := s.cfg.Penalties.cooldownUntil(infractions)
+	return until.Sub(at), declined, nil
+}
+
+// recordInfractions is only logged when it fails: the players get away without a cooldown, but the lobby moves on.
+func (s *LobbyService) recordInfractions(lobbyID string, kind models.InfractionKind, userIDs []uint) {
+	if err := s.infractionRepo.Record(lobbyID, kind, userIDs, now()); err != nil {
+		log.Printf("Failed to record the %s infractions of lobby %s: %v", kind, lobbyID, err)
+	}
+}
@@ -0,0 +1,262 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+// infractionsFixture returns one infraction of the user per age, given the most recent one first.
+func infractionsFixture(user *models.User, ages ...time.Duration) []*models.Infraction {
+	return infractionsOfKind(user, models.InfractionDodge, ages...)
+}
+
+func declinesFixture(user *models.User, ages ...time.Duration) []*models.Infraction {
+	return infractionsOfKind(user, models.InfractionDecline, ages...)
+}
+
+func infractionsOfKind(user *models.User, kind models.InfractionKind, ages ...time.Duration) []*models.Infraction {
+	infractions := make([]*models.Infraction, 0, len(ages))
+	for _, age := range ages {
+		infractions = append(infractions, &models.Infraction{UserID: user.ID, Kind: kind, CreatedAt: fixtureNow.Add(-age)})
+	}
+	return infractions
+}
+
+// mostRecentFirst merges the infractions the way the repository lists them.
+func mostRecentFirst(infractions ...[]*models.Infraction) []*models.Infraction {
+	merged := slices.Concat(infractions...)
+	slices.SortFunc(merged, func(a, b *models.Infraction) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return merged
+}
+
+func (s *LobbyServiceTestSuite) expectNoInfractions() {
+	s.infractionRepo.On("ListByUser", mock.AnythingOfType("uint"), mock.AnythingOfType("time.Time")).
+		Return([]*models.Infraction{}, nil)
+	s.infractionRepo.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+}
+
+// expectInfractions makes the user's infractions win over the defaults set up by expectNoInfractions.
+func (s *LobbyServiceTestSuite) expectInfractions(user *models.User, infractions []*models.Infraction) {
+	s.infractionRepo.ExpectedCalls = nil
+	s.infractionRepo.On("ListByUser", user.ID, fixtureNow.Add(-fixtureInfractionDecay)).Return(infractions, nil)
+	s.expectNoInfractions()
+}
+
+func (s *LobbyServiceTestSuite) TestCooldownDoublesWithEveryInfractionUpToTheMaximum() {
+	penalties := Penalties{Cooldown: fixtureCooldown, MaxCooldown: fixtureMaxCooldown, Decay: fixtureInfractionDecay}
+	user := newUser(1, "dodger")
+	cooldownUntil := func(infractions []*models.Infraction) time.Time {
+		until, declined := penalties.cooldownUntil(infractions)
+		s.False(declined)
+		return until
+	}
+
+	s.True(cooldownUntil(nil).IsZero())
+	s.Equal(fixtureNow.Add(time.Minute), cooldownUntil(infractionsFixture(user, 0)))
+	s.Equal(fixtureNow.Add(2*time.Minute), cooldownUntil(infractionsFixture(user, 0, time.Hour)))
+	s.Equal(fixtureNow.Add(4*time.Minute), cooldownUntil(infractionsFixture(user, 0, time.Hour, 2*time.Hour)))
+	s.Equal(fixtureNow.Add(fixtureMaxCooldown), cooldownUntil(infractionsFixture(user, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)))
+	s.Equal(fixtureNow.Add(-time.Hour).Add(2*time.Minute), cooldownUntil(infractionsFixture(user, time.Hour, 2*time.Hour)))
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineCooldownIsShortAndDoesNotEscalate() {
+	penalties := Penalties{Cooldown: fixtureCooldown, MaxCooldown: fixtureMaxCooldown, Decay: fixtureInfractionDecay,
+		DeclineCooldown: fixtureDeclineCooldown}
+	user := newUser(1, "decliner")
+
+	until, declined := penalties.cooldownUntil(declinesFixture(user, 0, time.Minute, 2*time.Minute))
+	s.True(declined)
+	s.Equal(fixtureNow.Add(fixtureDeclineCooldown), until)
+
+	until, declined = penalties.cooldownUntil(mostRecentFirst(declinesFixture(user, 0), infractionsFixture(user, time.Hour)))
+	s.True(declined)
+	s.Equal(fixtureNow.Add(fixtureDeclineCooldown), until)
+
+	until, declined = penalties.cooldownUntil(mostRecentFirst(infractionsFixture(user, 0), declinesFixture(user, time.Minute, 2*time.Minute)))
+	s.False(declined)
+	s.Equal(fixtureNow.Add(fixtureCooldown), until)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsDuringADeclineCooldown() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	user := newUser(2, "decliner")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "decliner").Return(user, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.expectAdmitted()
+	s.expectInfractions(user, declinesFixture(user, 10*time.Second))
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "decliner"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "you declined a ready check or a match: you can create or join lobbies again in 20s")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsDuringACooldown() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	user := newUser(1, "dodger")
+	s.userRepo.On("FindByUsername", "dodger").Return(user, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", user.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectInfractions(user, infractionsFixture(user, 30*time.Second, time.Hour))
+
+	_, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "dodger"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "you can create or join lobbies again in 1m30s")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbySucceedsOnceTheCooldownIsOver() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	user := newUser(1, "dodger")
+	s.userRepo.On("FindByUsername", "dodger").Return(user, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", user.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.AnythingOfType("*models.Lobby")).Return(nil)
+	s.expectInfractions(user, infractionsFixture(user, 5*time.Minute, time.Hour))
+
+	_, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "dodger"})
+
+	s.NoError(err)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsDuringACooldown() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	user := newUser(2, "dodger")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "dodger").Return(user, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.expectAdmitted()
+	s.expectInfractions(user, infractionsFixture(user, 0))
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "dodger"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "you can create or join lobbies again in 1m0s")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenAPartyMemberIsOnACooldown() {
+	defer s.stubNow()()
+	leader, friend := newUser(2, "leader"), newUser(3, "friend")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 4, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "leader").Return(leader, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.partyRepo.On("FindByMember", leader.ID).Return(partyFixture(leader, friend), nil)
+	s.expectAdmitted()
+	s.expectInfractions(friend, infractionsFixture(friend, 0))
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "leader"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "friend left or dodged too many games recently")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenTheInfractionsCannotBeRead() {
+	defer s.stubNow()()
+	s.expectNoParty()
+	user := newUser(2, "player2")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "player2").Return(user, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.expectAdmitted()
+	s.infractionRepo.ExpectedCalls = nil
+	s.infractionRepo.On("ListByUser", user.ID, mock.AnythingOfType("time.Time")).
+		Return(([]*models.Infraction)(nil), errors.New("db error"))
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2"})
+
+	s.assertGrpcError(err, codes.Internal, "Infraction DB error")
+}
+
+func (s *LobbyServiceTestSuite) TestGetMyCooldown() {
+	defer s.stubNow()()
+	user := newUser(1, "dodger")
+	s.userRepo.On("FindByUsername", "dodger").Return(user, nil)
+	s.expectInfractions(user, infractionsFixture(user, 0, time.Hour))
+
+	resp, err := s.service.GetMyCooldown(context.Background(), &lobby.GetMyCooldownRequest{Username: "dodger"})
+
+	s.NoError(err)
+	s.EqualValues(2, resp.GetInfractions())
+	s.Require().NotNil(resp.GetCooldownUntil())
+	s.Equal(fixtureNow.Add(2*time.Minute), resp.GetCooldownUntil().AsTime())
+}
+
+func (s *LobbyServiceTestSuite) TestGetMyCooldownWithoutACooldown() {
+	defer s.stubNow()()
+	user := newUser(1, "player")
+	s.userRepo.On("FindByUsername", "player").Return(user, nil)
+	s.expectInfractions(user, infractionsFixture(user, time.Hour))
+
+	resp, err := s.service.GetMyCooldown(context.Background(), &lobby.GetMyCooldownRequest{Username: "player"})
+
+	s.NoError(err)
+	s.EqualValues(1, resp.GetInfractions())
+	s.Nil(resp.GetCooldownUntil())
+}
+
+func (s *LobbyServiceTestSuite) TestGetMyCooldownDoesNotCountTheDeclines() {
+	defer s.stubNow()()
+	user := newUser(1, "decliner")
+	s.userRepo.On("FindByUsername", "decliner").Return(user, nil)
+	s.expectInfractions(user, mostRecentFirst(declinesFixture(user, 0), infractionsFixture(user, time.Hour)))
+
+	resp, err := s.service.GetMyCooldown(context.Background(), &lobby.GetMyCooldownRequest{Username: "decliner"})
+
+	s.NoError(err)
+	s.EqualValues(1, resp.GetInfractions())
+	s.Require().NotNil(resp.GetCooldownUntil())
+	s.Equal(fixtureNow.Add(fixtureDeclineCooldown), resp.GetCooldownUntil().AsTime())
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationRecordsTheDodges() {
+	s.expectNoParty()
+	deadline := fixtureNow.Add(fixtureReadyCheckTimeout)
+	expiredLobby := readyCheckLobbyFixture(deadline, readyPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerWaiting, deadline.Add(fixtureWaitingTimeout)).Return(nil)
+	s.lobbyRepo.On("FailReadyCheck", expiredLobby, []uint{2}).Return(nil)
+
+	s.joinAndExpire(expiredLobby)
+
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureLobbyID, models.InfractionDodge, []uint{2}, deadline)
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationDoesNotRecordTheDodgesWhenItFails() {
+	s.expectNoParty()
+	deadline := fixtureNow.Add(fixtureReadyCheckTimeout)
+	expiredLobby := readyCheckLobbyFixture(deadline, readyPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerWaiting, deadline.Add(fixtureWaitingTimeout)).Return(nil)
+	s.lobbyRepo.On("FailReadyCheck", expiredLobby, []uint{2}).Return(errors.New("db error"))
+
+	s.joinAndExpire(expiredLobby)
+
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReconnectTimerRecordsTheAbandons() {
+	defer s.stubNow()()
+	stayer, leaver := newUser(1, "stayer"), newUser(2, "leaver")
+	gameLobby := disconnectedGameFixture(stayer, leaver, 2*fixtureReconnectGrace)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("AbandonPlayers", gameLobby, []uint{leaver.ID}, fixtureNow).Return(nil)
+	s.lobbyRepo.On("FinishWithWinner", gameLobby, stayer.ID).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{stayer.ID}, []uint{leaver.ID}, fixtureNow).Return(nil)
+	s.expectGameStopped()
+
+	s.scheduler.handlers[models.LobbyTimerReconnect](fixtureLobbyID)
+
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureLobbyID, models.InfractionAbandon, []uint{leaver.ID}, fixtureNow)
+}
@@ -0,0 +1,102 @@
+package lobby
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetQueueStats reports the lobbies waiting for players, and the waits of the players matched in the game mode and
+// region of the request during the last queueStatsWindow.
+func (s *LobbyService) GetQueueStats(ctx context.Context, req *lobby.GetQueueStatsRequest) (*lobby.GetQueueStatsResponse, error) {
+	// Like when listing the available lobbies, an empty game mode or region means any.
+	var gameMode, region string
+	if req.GetGameMode() != "" {
+		mode, err := s.catalog.Mode(req.GetGameMode())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		gameMode = mode.Name
+	}
+	if req.GetRegion() != "" {
+		var err error
+		if region, err = s.catalog.Region(req.GetRegion()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	matches := func(lobbyMode, lobbyRegion string) bool {
+		return (gameMode == "" || lobbyMode == gameMode) && (region == "" || lobbyRegion == region)
+	}
+
+	waiting, err := s.lobbyRepo.ListWaiting()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	waits, err := s.lobbyRepo.ListMatchWaits(now().Add(-s.cfg.QueueStatsWindow))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	resp := &lobby.GetQueueStatsResponse{WindowSeconds: uint32(s.cfg.QueueStatsWindow.Seconds())}
+	openLobbies := map[[2]string]*lobby.OpenLobbies{}
+	almostFull := false
+	for _, waitingLobby := range waiting {
+		resp.SearchingPlayers += uint32(waitingLobby.Players)
+
+		key := [2]string{waitingLobby.GameMode, waitingLobby.Region}
+		open, ok := openLobbies[key]
+		if !ok {
+			open = &lobby.OpenLobbies{GameMode: waitingLobby.GameMode, Region: waitingLobby.Region}
+			openLobbies[key] = open
+			resp.OpenLobbies = append(resp.OpenLobbies, open)
+		}
+		open.Lobbies++
+		open.Players += uint32(waitingLobby.Players)
+
+		if matches(waitingLobby.GameMode, waitingLobby.Region) && waitingLobby.MaxPlayers-waitingLobby.Players == 1 {
+			almostFull = true
+		}
+	}
+	slices.SortFunc(resp.OpenLobbies, func(a, b *lobby.OpenLobbies) int {
+		return cmp.Or(cmp.Compare(a.GameMode, b.GameMode), cmp.Compare(a.Region, b.Region))
+	})
+
+	var durations []time.Duration
+	for _, wait := range waits {
+		if matches(wait.GameMode, wait.Region) {
+			durations = append(durations, wait.Wait)
+		}
+	}
+	resp.MatchedPlayers = uint32(len(durations))
+
+	// The player who joins a lobby missing a single player fills it up right away.
+	if almostFull {
+		resp.EstimatedWaitSeconds = seconds(0)
+	}
+	if len(durations) == 0 {
+		return resp, nil
+	}
+	slices.Sort(durations)
+	resp.MedianWaitSeconds = seconds(percentile(durations, 50))
+	resp.P90WaitSeconds = seconds(percentile(durations, 90))
+	if !almostFull {
+		resp.EstimatedWaitSeconds = resp.MedianWaitSeconds
+	}
+	return resp, nil
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func seconds(d time.Duration) *uint32 {
+	rounded := uint32(d.Round(time.Second).Seconds())
+	return &rounded
+}
@@ -0,0 +1,133 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+)
+
+// duelWaits returns the match waits of duels in the EU region.
+func duelWaits(waits ...time.Duration) []lobbyrepo.MatchWait {
+	matchWaits := make([]lobbyrepo.MatchWait, len(waits))
+	for i, wait := range waits {
+		matchWaits[i] = lobbyrepo.MatchWait{GameMode: "DUEL", Region: "EU", Wait: wait}
+	}
+	return matchWaits
+}
+
+func (s *LobbyServiceTestSuite) TestPercentileUsesTheNearestRank() {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	s.Equal(time.Duration(5), percentile(sorted, 50))
+	s.Equal(time.Duration(9), percentile(sorted, 90))
+	s.Equal(time.Duration(1), percentile(sorted[:1], 50))
+	s.Equal(time.Duration(2), percentile(sorted[:3], 50))
+	s.Equal(time.Duration(3), percentile(sorted[:3], 90))
+}
+
+func (s *LobbyServiceTestSuite) TestGetQueueStatsReportsTheOpenLobbiesAndTheWaits() {
+	defer s.stubNow()()
+	s.lobbyRepo.On("ListWaiting").Return([]lobbyrepo.WaitingLobby{
+		{GameMode: "TEAM_DEATHMATCH", Region: "NA", Players: 2, MaxPlayers: 4},
+		{GameMode: "DUEL", Region: "EU", Players: 0, MaxPlayers: 2},
+		{GameMode: "TEAM_DEATHMATCH", Region: "NA", Players: 1, MaxPlayers: 4},
+		{GameMode: "DUEL", Region: "ASIA", Players: 1, MaxPlayers: 2},
+	}, nil)
+	s.lobbyRepo.On("ListMatchWaits", fixtureNow.Add(-fixtureQueueStatsWindow)).Return(append(
+		duelWaits(40*time.Second, 10*time.Second, 20*time.Second, 30*time.Second),
+		lobbyrepo.MatchWait{GameMode: "TEAM_DEATHMATCH", Region: "NA", Wait: time.Hour},
+	), nil)
+
+	resp, err := s.service.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{GameMode: "DUEL", Region: "EU"})
+
+	s.NoError(err)
+	s.EqualValues(4, resp.GetSearchingPlayers())
+	s.Require().Len(resp.GetOpenLobbies(), 3)
+	s.Equal("DUEL", resp.OpenLobbies[0].GameMode)
+	s.Equal("ASIA", resp.OpenLobbies[0].Region)
+	s.Equal("EU", resp.OpenLobbies[1].Region)
+	s.EqualValues(1, resp.OpenLobbies[1].Lobbies)
+	s.Equal("TEAM_DEATHMATCH", resp.OpenLobbies[2].GameMode)
+	s.EqualValues(2, resp.OpenLobbies[2].Lobbies)
+	s.EqualValues(3, resp.OpenLobbies[2].Players)
+	s.EqualValues(3600, resp.GetWindowSeconds())
+	s.EqualValues(4, resp.GetMatchedPlayers())
+	s.EqualValues(20, resp.GetMedianWaitSeconds())
+	s.EqualValues(40, resp.GetP90WaitSeconds())
+	s.Require().NotNil(resp.EstimatedWaitSeconds)
+	s.EqualValues(20, resp.GetEstimatedWaitSeconds())
+}
+
+func (s *LobbyServiceTestSuite) TestGetQueueStatsExpectsNoWaitWhenALobbyNeedsASinglePlayer() {
+	defer s.stubNow()()
+	s.lobbyRepo.On("ListWaiting").Return([]lobbyrepo.WaitingLobby{
+		{GameMode: "DUEL", Region: "EU", Players: 1, MaxPlayers: 2},
+	}, nil)
+	s.lobbyRepo.On("ListMatchWaits", fixtureNow.Add(-fixtureQueueStatsWindow)).Return(duelWaits(time.Minute), nil)
+
+	resp, err := s.service.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{GameMode: "DUEL"})
+
+	s.NoError(err)
+	s.EqualValues(60, resp.GetMedianWaitSeconds())
+	s.Require().NotNil(resp.EstimatedWaitSeconds)
+	s.Zero(resp.GetEstimatedWaitSeconds())
+}
+
+func (s *LobbyServiceTestSuite) TestGetQueueStatsWithoutRecentMatches() {
+	defer s.stubNow()()
+	s.lobbyRepo.On("ListWaiting").Return([]lobbyrepo.WaitingLobby{
+		{GameMode: "FREE_FOR_ALL", Region: "EU", Players: 1, MaxPlayers: 4},
+	}, nil)
+	s.lobbyRepo.On("ListMatchWaits", fixtureNow.Add(-fixtureQueueStatsWindow)).Return(duelWaits(time.Minute), nil)
+
+	resp, err := s.service.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{GameMode: "FREE_FOR_ALL"})
+
+	s.NoError(err)
+	s.EqualValues(1, resp.GetSearchingPlayers())
+	s.Zero(resp.GetMatchedPlayers())
+	s.Nil(resp.MedianWaitSeconds)
+	s.Nil(resp.P90WaitSeconds)
+	s.Nil(resp.EstimatedWaitSeconds)
+}
+
+func (s *LobbyServiceTestSuite) TestGetQueueStatsOfEveryGameMode() {
+	defer s.stubNow()()
+	s.lobbyRepo.On("ListWaiting").Return([]lobbyrepo.WaitingLobby{}, nil)
+	s.lobbyRepo.On("ListMatchWaits", fixtureNow.Add(-fixtureQueueStatsWindow)).Return(append(
+		duelWaits(10*time.Second),
+		lobbyrepo.MatchWait{GameMode: "TEAM_DEATHMATCH", Region: "NA", Wait: 30 * time.Second},
+	), nil)
+
+	resp, err := s.service.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{})
+
+	s.NoError(err)
+	s.Empty(resp.GetOpenLobbies())
+	s.EqualValues(2, resp.GetMatchedPlayers())
+	s.EqualValues(10, resp.GetMedianWaitSeconds())
+	s.EqualValues(30, resp.GetP90WaitSeconds())
+}
+
+func (s *LobbyServiceTestSuite) TestGetQueueStatsFailsWithAnUnknownGameMode() {
+	_, err := s.service.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{GameMode: "CAPTURE_THE_FLAG"})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "CAPTURE_THE_FLAG")
+	s.lobbyRepo.AssertNotCalled(s.T(), "ListWaiting")
+}
+
+func (s *LobbyServiceTestSuite) TestGetQueueStatsFailsWithAnUnknownRegion() {
+	_, err := s.service.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{Region: "MARS"})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "MARS")
+}
+
+func (s *LobbyServiceTestSuite) TestGetQueueStatsFailsOnRepositoryError() {
+	s.lobbyRepo.On("ListWaiting").Return(nil, errors.New("db error"))
+
+	_, err := s.service.GetQueueStats(context.Background(), &lobby.GetQueueStatsRequest{})
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
+}
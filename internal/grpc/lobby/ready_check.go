@@ -0,0 +1,252 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetReady confirms the caller for the ready check of the lobby. The last confirmation starts the game.
+func (s *LobbyService) SetReady(ctx context.Context, req *lobby.SetReadyRequest) (*lobby.Lobby, error) {
+	player, err := s.callerPlayer(ctx, "confirm the ready check")
+	if err != nil {
+		return nil, err
+	}
+
+	readyLobby, err := s.lobbyRepo.FindByID(req.GetLobbyId())
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if readyLobby.Status != models.LobbyStatusReadyCheck {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is not in the ready check")
+	}
+
+	if !hasPlayer(readyLobby, player.ID) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the lobby players can confirm the ready check")
+	}
+
+	if readyCheckExpired(readyLobby) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ready check has expired")
+	}
+
+	if err := s.lobbyRepo.SetPlayerReady(readyLobby, player); err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	// The lobby is read again, so that two players confirming at the same time can not both miss the other one.
+	readyLobby, err = s.lobbyRepo.FindByID(req.GetLobbyId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if readyLobby.Status == models.LobbyStatusReadyCheck && allReady(readyLobby) {
+		err := s.startGame(readyLobby)
+		if errors.Is(err, lobbyrepo.ErrReadyCheckOver) {
+			// Another confirmation started the game, or the ready check expired, since the lobby was read.
+			if readyLobby, err = s.lobbyRepo.FindByID(req.GetLobbyId()); err != nil {
+				return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return toMemberProtoLobby(readyLobby, player.ID), nil
+}
+
+// DeclineReadyCheck takes the caller out of the lobby and reopens it for the other players, who keep their seats. The
+// caller is put on the short decline cooldown, rather than on the escalating one of the players that let the ready
+// check expire.
+func (s *LobbyService) DeclineReadyCheck(ctx context.Context, req *lobby.DeclineReadyCheckRequest) (*lobby.Lobby, error) {
+	player, err := s.callerPlayer(ctx, "decline the ready check")
+	if err != nil {
+		return nil, err
+	}
+
+	readyLobby, err := s.lobbyRepo.FindByID(req.GetLobbyId())
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if readyLobby.Status != models.LobbyStatusReadyCheck {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is not in the ready check")
+	}
+
+	if !hasPlayer(readyLobby, player.ID) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the lobby players can decline the ready check")
+	}
+
+	if readyCheckExpired(readyLobby) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ready check has expired")
+	}
+
+	if err := s.withdrawFromReadyCheck(readyLobby, player.ID, models.InfractionDecline); err != nil {
+		return nil, err
+	}
+
+	// The lobby is read again, since the host may have been handed over to another player.
+	reopenedLobby, err := s.lobbyRepo.FindByID(req.GetLobbyId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return toProtoLobby(reopenedLobby), nil
+}
+
+// withdrawFromReadyCheck takes the player out of the lobby during its ready check, reopens the lobby for the other
+// players and records the infraction of the player.
+func (s *LobbyService) withdrawFromReadyCheck(readyLobby *models.Lobby, playerID uint, kind models.InfractionKind) error {
+	if err := s.scheduler.Schedule(readyLobby.LobbyID, models.LobbyTimerWaiting, now().Add(s.cfg.Timeouts.Waiting)); err != nil {
+		return status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err := s.lobbyRepo.FailReadyCheck(readyLobby, []uint{playerID})
+	if errors.Is(err, lobbyrepo.ErrReadyCheckOver) {
+		return status.Errorf(codes.FailedPrecondition, "lobby is not in the ready check")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.cancelTimer(readyLobby.LobbyID, models.LobbyTimerReadyCheck)
+	s.recordInfractions(readyLobby.LobbyID, kind, []uint{playerID})
+	return nil
+}
+
+// startReadyCheck gives the players of the full lobby the configured time to confirm. The expiration is checked
+// by the server, so that it does not depend on any client being connected. When the lobby changed since it was
+// filled, no ready check is started and readyLobby is read again. matched tells whether joins filled the lobby, which
+// is what the queue statistics measure.
+func (s *LobbyService) startReadyCheck(readyLobby *models.Lobby, matched bool) error {
+	deadline := now().Add(s.cfg.Timeouts.ReadyCheck)
+	if err := s.scheduler.Schedule(readyLobby.LobbyID, models.LobbyTimerReadyCheck, deadline); err != nil {
+		return status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err := s.lobbyRepo.StartReadyCheck(readyLobby, deadline, matched)
+	if errors.Is(err, lobbyrepo.ErrLobbyConflict) {
+		// A kick, a leave or the waiting timeout got in between: the scheduled timer finds no ready check to expire.
+		current, err := s.lobbyRepo.FindByID(readyLobby.LobbyID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+		}
+		*readyLobby = *current
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.cancelTimer(readyLobby.LobbyID, models.LobbyTimerWaiting)
+
+	readyLobby.Status = models.LobbyStatusReadyCheck
+	readyLobby.ReadyCheckDeadline = &deadline
+	for i := range readyLobby.Players {
+		readyLobby.Players[i].Ready = false
+	}
+	setTimer(readyLobby, models.LobbyTimerReadyCheck, deadline)
+	clearTimer(readyLobby, models.LobbyTimerWaiting)
+	return nil
+}
+
+// startGame closes the ready check once every player confirmed, balances the teams if the lobby asks for it, and
+// schedules the end of the game. It returns lobbyrepo.ErrReadyCheckOver as is when the ready check already ended.
+func (s *LobbyService) startGame(readyLobby *models.Lobby) error {
+	gameEnd := now().Add(s.cfg.Timeouts.Game)
+	if err := s.scheduler.Schedule(readyLobby.LobbyID, models.LobbyTimerGameEnd, gameEnd); err != nil {
+		return status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err := s.lobbyRepo.CompleteReadyCheck(readyLobby)
+	if errors.Is(err, lobbyrepo.ErrReadyCheckOver) {
+		return err
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.cancelTimer(readyLobby.LobbyID, models.LobbyTimerReadyCheck)
+	s.balanceTeams(readyLobby)
+
+	readyLobby.Status = models.LobbyStatusInProgress
+	readyLobby.ReadyCheckDeadline = nil
+	for i := range readyLobby.Players {
+		readyLobby.Players[i].Ready = false
+	}
+	setTimer(readyLobby, models.LobbyTimerGameEnd, gameEnd)
+	clearTimer(readyLobby, models.LobbyTimerReadyCheck)
+	return nil
+}
+
+// expireReadyCheck removes the players that did not confirm and reopens the lobby. If nobody confirmed, the lobby
+// is deleted since no player is left in it.
+func (s *LobbyService) expireReadyCheck(lobbyID string) {
+	readyLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if err != nil {
+		log.Printf("Failed to expire the ready check of lobby %s: %v", lobbyID, err)
+		return
+	}
+
+	// Either everyone confirmed in time, or the lobby has been filled again and a new ready check is running.
+	if readyLobby.Status != models.LobbyStatusReadyCheck || !readyCheckExpired(readyLobby) {
+		return
+	}
+
+	var unready []uint
+	for _, player := range readyLobby.Players {
+		if !player.Ready {
+			unready = append(unready, player.UserID)
+		}
+	}
+
+	// The last confirmation, a decline or a new ready check may have changed the lobby since it was read.
+	if len(unready) == len(readyLobby.Players) {
+		err := s.lobbyRepo.DeleteUnconfirmed(readyLobby)
+		if errors.Is(err, lobbyrepo.ErrReadyCheckOver) {
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to delete lobby %s after its ready check expired: %v", lobbyID, err)
+			return
+		}
+		s.recordInfractions(lobbyID, models.InfractionDodge, unready)
+		return
+	}
+
+	if err := s.scheduler.Schedule(lobbyID, models.LobbyTimerWaiting, now().Add(s.cfg.Timeouts.Waiting)); err != nil {
+		log.Printf("Failed to schedule the waiting timeout of lobby %s: %v", lobbyID, err)
+		return
+	}
+
+	err = s.lobbyRepo.FailReadyCheck(readyLobby, unready)
+	if errors.Is(err, lobbyrepo.ErrReadyCheckOver) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to expire the ready check of lobby %s: %v", lobbyID, err)
+		return
+	}
+	s.recordInfractions(lobbyID, models.InfractionDodge, unready)
+}
+
+func readyCheckExpired(l *models.Lobby) bool {
+	return l.ReadyCheckDeadline == nil || !now().Before(*l.ReadyCheckDeadline)
+}
+
+func allReady(l *models.Lobby) bool {
+	for _, player := range l.Players {
+		if !player.Ready {
+			return false
+		}
+	}
+	return true
+}
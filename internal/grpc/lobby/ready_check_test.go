@@ -0,0 +1,422 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+func readyCheckLobbyFixture(deadline time.Time, players ...models.LobbyPlayer) *models.Lobby {
+	for i := range players {
+		players[i].Seat = i
+	}
+	return &models.Lobby{
+		LobbyID:            fixtureLobbyID,
+		Status:             models.LobbyStatusReadyCheck,
+		ReadyCheckDeadline: &deadline,
+		Players:            players,
+	}
+}
+
+func readyPlayer(user *models.User) models.LobbyPlayer {
+	ready := asPlayer(user)
+	ready.Ready = true
+	return ready
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyWaitsWhenTheLobbyIsNotFull() {
+	s.expectNoParty()
+	mockPlayer := newUser(2, "player2")
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2}
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(nil)
+
+	resp, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2"})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.lobbyRepo.AssertNotCalled(s.T(), "StartReadyCheck", mock.Anything, mock.Anything, mock.Anything)
+	s.scheduler.AssertNotCalled(s.T(), "Schedule", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyWaitsForTheOtherPlayers() {
+	defer s.stubNow()()
+	creator := newUser(1, "creator")
+	player := newUser(2, "player2")
+	deadline := fixtureNow.Add(time.Second)
+	before := readyCheckLobbyFixture(deadline, asPlayer(creator), asPlayer(player))
+	after := readyCheckLobbyFixture(deadline, asPlayer(creator), readyPlayer(player))
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.lobbyRepo.On("SetPlayerReady", before, player).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+
+	resp, err := s.service.SetReady(callerContext("player2"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusReadyCheck), resp.Status)
+	s.True(resp.Players[1].Ready)
+	s.lobbyRepo.AssertNotCalled(s.T(), "CompleteReadyCheck", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyStartsTheGameWhenEveryoneConfirmed() {
+	defer s.stubNow()()
+	creator := newUser(1, "creator")
+	player := newUser(2, "player2")
+	deadline := fixtureNow.Add(time.Second)
+	before := readyCheckLobbyFixture(deadline, readyPlayer(creator), asPlayer(player))
+	after := readyCheckLobbyFixture(deadline, readyPlayer(creator), readyPlayer(player))
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.lobbyRepo.On("SetPlayerReady", before, player).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerGameEnd, fixtureNow.Add(fixtureGameDuration)).Return(nil)
+	s.lobbyRepo.On("CompleteReadyCheck", after).Return(nil)
+	s.expectCancelled(models.LobbyTimerReadyCheck)
+
+	resp, err := s.service.SetReady(callerContext("player2"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusInProgress), resp.Status)
+	s.Nil(resp.ReadyCheckDeadline)
+	s.Equal(fixtureNow.Add(fixtureGameDuration), resp.Deadlines[string(models.LobbyTimerGameEnd)].AsTime())
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyReturnsTheLobbyWhenAnotherConfirmationStartedTheGame() {
+	defer s.stubNow()()
+	creator := newUser(1, "creator")
+	player := newUser(2, "player2")
+	deadline := fixtureNow.Add(time.Second)
+	before := readyCheckLobbyFixture(deadline, asPlayer(creator), asPlayer(player))
+	after := readyCheckLobbyFixture(deadline, readyPlayer(creator), readyPlayer(player))
+	started := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: seated(creator, player)}
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.lobbyRepo.On("SetPlayerReady", before, player).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+	s.expectScheduled(models.LobbyTimerGameEnd)
+	s.lobbyRepo.On("CompleteReadyCheck", after).Return(lobbyrepo.ErrReadyCheckOver)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(started, nil).Once()
+
+	resp, err := s.service.SetReady(callerContext("player2"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusInProgress), resp.Status)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AssignTeams", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyFailsWhenLobbyIsNotInReadyCheck() {
+	player := newUser(2, "player2")
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting}, nil)
+
+	_, err := s.service.SetReady(callerContext("player2"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not in the ready check")
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyFailsWhenCallerIsNotAPlayer() {
+	defer s.stubNow()()
+	s.userRepo.On("FindByUsername", "outsider").Return(newUser(3, "outsider"), nil)
+	readyLobby := readyCheckLobbyFixture(fixtureNow.Add(time.Second), asPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil)
+
+	_, err := s.service.SetReady(callerContext("outsider"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the lobby players")
+	s.lobbyRepo.AssertNotCalled(s.T(), "SetPlayerReady", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyFailsWhenUnauthenticated() {
+	_, err := s.service.SetReady(context.Background(), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to confirm the ready check")
+	s.lobbyRepo.AssertNotCalled(s.T(), "SetPlayerReady", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyFailsAfterTheDeadline() {
+	defer s.stubNow()()
+	player := newUser(2, "player2")
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyCheckLobbyFixture(fixtureNow, asPlayer(newUser(1, "creator")), asPlayer(player)), nil)
+
+	_, err := s.service.SetReady(callerContext("player2"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "ready check has expired")
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyFailsWhenLobbyNotFound() {
+	s.userRepo.On("FindByUsername", "player2").Return(newUser(2, "player2"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+
+	_, err := s.service.SetReady(callerContext("player2"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.NotFound, "lobby not found")
+}
+
+func (s *LobbyServiceTestSuite) TestSetReadyFailsOnRepositoryError() {
+	defer s.stubNow()()
+	player := newUser(2, "player2")
+	readyLobby := readyCheckLobbyFixture(fixtureNow.Add(time.Second), asPlayer(newUser(1, "creator")), asPlayer(player))
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil)
+	s.lobbyRepo.On("SetPlayerReady", readyLobby, player).Return(errors.New("db error"))
+
+	_, err := s.service.SetReady(callerContext("player2"), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckReopensTheLobbyForTheOtherPlayers() {
+	defer s.stubNow()()
+	creator, player, decliner := newUser(1, "creator"), newUser(2, "player2"), newUser(3, "decliner")
+	deadline := fixtureNow.Add(time.Second)
+	before := readyCheckLobbyFixture(deadline, readyPlayer(creator), asPlayer(player), asPlayer(decliner))
+	after := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, Players: seated(creator, player)}
+	s.userRepo.On("FindByUsername", "decliner").Return(decliner, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerWaiting, fixtureNow.Add(fixtureWaitingTimeout)).Return(nil)
+	s.lobbyRepo.On("FailReadyCheck", before, []uint{decliner.ID}).Return(nil)
+	s.expectCancelled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+
+	resp, err := s.service.DeclineReadyCheck(callerContext("decliner"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.Len(resp.Players, 2)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureLobbyID, models.InfractionDecline, []uint{decliner.ID}, fixtureNow)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckAfterConfirming() {
+	defer s.stubNow()()
+	creator, decliner := newUser(1, "creator"), newUser(2, "decliner")
+	before := readyCheckLobbyFixture(fixtureNow.Add(time.Second), asPlayer(creator), readyPlayer(decliner))
+	s.userRepo.On("FindByUsername", "decliner").Return(decliner, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("FailReadyCheck", before, []uint{decliner.ID}).Return(nil)
+	s.expectCancelled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).
+		Return(&models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, Players: seated(creator)}, nil).Once()
+
+	resp, err := s.service.DeclineReadyCheck(callerContext("decliner"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.infractionRepo.AssertCalled(s.T(), "Record", fixtureLobbyID, models.InfractionDecline, []uint{decliner.ID}, fixtureNow)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsWhenUnauthenticated() {
+	_, err := s.service.DeclineReadyCheck(context.Background(), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to decline the ready check")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsWhenLobbyIsNotInReadyCheck() {
+	s.userRepo.On("FindByUsername", "player2").Return(newUser(2, "player2"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress}, nil)
+
+	_, err := s.service.DeclineReadyCheck(callerContext("player2"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not in the ready check")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsWhenCallerIsNotAPlayer() {
+	defer s.stubNow()()
+	s.userRepo.On("FindByUsername", "outsider").Return(newUser(3, "outsider"), nil)
+	readyLobby := readyCheckLobbyFixture(fixtureNow.Add(time.Second), asPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil)
+
+	_, err := s.service.DeclineReadyCheck(callerContext("outsider"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the lobby players")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsAfterTheDeadline() {
+	defer s.stubNow()()
+	player := newUser(2, "player2")
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyCheckLobbyFixture(fixtureNow, asPlayer(newUser(1, "creator")), asPlayer(player)), nil)
+
+	_, err := s.service.DeclineReadyCheck(callerContext("player2"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "ready check has expired")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsWhenLobbyNotFound() {
+	s.userRepo.On("FindByUsername", "player2").Return(newUser(2, "player2"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+
+	_, err := s.service.DeclineReadyCheck(callerContext("player2"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.NotFound, "lobby not found")
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsWhenTheWaitingTimeoutCannotBeScheduled() {
+	defer s.stubNow()()
+	player := newUser(2, "player2")
+	readyLobby := readyCheckLobbyFixture(fixtureNow.Add(time.Second), asPlayer(newUser(1, "creator")), asPlayer(player))
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil)
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerWaiting, mock.AnythingOfType("time.Time")).Return(errors.New("db error"))
+
+	_, err := s.service.DeclineReadyCheck(callerContext("player2"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Internal, "Scheduler error")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsOnRepositoryError() {
+	defer s.stubNow()()
+	player := newUser(2, "player2")
+	readyLobby := readyCheckLobbyFixture(fixtureNow.Add(time.Second), asPlayer(newUser(1, "creator")), asPlayer(player))
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("FailReadyCheck", readyLobby, []uint{player.ID}).Return(errors.New("db error"))
+
+	_, err := s.service.DeclineReadyCheck(callerContext("player2"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestDeclineReadyCheckFailsWhenTheReadyCheckEndedConcurrently() {
+	defer s.stubNow()()
+	player := newUser(2, "player2")
+	readyLobby := readyCheckLobbyFixture(fixtureNow.Add(time.Second), asPlayer(newUser(1, "creator")), asPlayer(player))
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("FailReadyCheck", readyLobby, []uint{player.ID}).Return(lobbyrepo.ErrReadyCheckOver)
+
+	_, err := s.service.DeclineReadyCheck(callerContext("player2"), &lobby.DeclineReadyCheckRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is not in the ready check")
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationIgnoresADeclinedReadyCheck() {
+	s.expectNoParty()
+	declinedLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, Players: seated(newUser(1, "creator"))}
+
+	s.joinAndExpire(declinedLobby)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "DeleteUnconfirmed", mock.Anything)
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// joinAndExpire fills a lobby, then fires the timer scheduled by the ready check once the deadline has passed.
+func (s *LobbyServiceTestSuite) joinAndExpire(expiredLobby *models.Lobby) {
+	restoreNow := s.stubNow()
+	defer restoreNow()
+	player := newUser(2, "player2")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(newUser(1, "creator"))}
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil).Once()
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", waitingLobby, player, 2).Return(nil)
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerReadyCheck, fixtureNow.Add(fixtureReadyCheckTimeout)).Return(nil)
+	s.lobbyRepo.On("StartReadyCheck", waitingLobby, fixtureNow.Add(fixtureReadyCheckTimeout), true).Return(nil)
+	s.expectCancelled(models.LobbyTimerWaiting)
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2"})
+	s.Require().NoError(err)
+	s.Require().Contains(s.scheduler.handlers, models.LobbyTimerReadyCheck)
+
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(expiredLobby, nil).Once()
+	now = func() time.Time { return fixtureNow.Add(fixtureReadyCheckTimeout) }
+	s.scheduler.handlers[models.LobbyTimerReadyCheck](fixtureLobbyID)
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationRemovesUnreadyPlayers() {
+	s.expectNoParty()
+	deadline := fixtureNow.Add(fixtureReadyCheckTimeout)
+	expiredLobby := readyCheckLobbyFixture(deadline, readyPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerWaiting, deadline.Add(fixtureWaitingTimeout)).Return(nil)
+	s.lobbyRepo.On("FailReadyCheck", expiredLobby, []uint{2}).Return(nil)
+
+	s.joinAndExpire(expiredLobby)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationDeletesTheLobbyWhenNobodyConfirmed() {
+	s.expectNoParty()
+	deadline := fixtureNow.Add(fixtureReadyCheckTimeout)
+	expiredLobby := readyCheckLobbyFixture(deadline, asPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.lobbyRepo.On("DeleteUnconfirmed", expiredLobby).Return(nil)
+	s.infractionRepo.On("Record", fixtureLobbyID, models.InfractionDodge, []uint{1, 2}, mock.Anything).Return(nil)
+
+	s.joinAndExpire(expiredLobby)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.infractionRepo.AssertExpectations(s.T())
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationKeepsTheLobbyConfirmedMeanwhile() {
+	s.expectNoParty()
+	deadline := fixtureNow.Add(fixtureReadyCheckTimeout)
+	expiredLobby := readyCheckLobbyFixture(deadline, asPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.lobbyRepo.On("DeleteUnconfirmed", expiredLobby).Return(lobbyrepo.ErrReadyCheckOver)
+
+	s.joinAndExpire(expiredLobby)
+
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationIgnoresStartedGames() {
+	s.expectNoParty()
+	startedLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress}
+
+	s.joinAndExpire(startedLobby)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "DeleteUnconfirmed", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationIgnoresANewerReadyCheck() {
+	s.expectNoParty()
+	newerLobby := readyCheckLobbyFixture(fixtureNow.Add(2*fixtureReadyCheckTimeout), asPlayer(newUser(1, "creator")), asPlayer(newUser(3, "player3")))
+
+	s.joinAndExpire(newerLobby)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "FailReadyCheck", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "DeleteUnconfirmed", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestReadyCheckExpirationLosesTheRaceToTheLastConfirmation() {
+	s.expectNoParty()
+	deadline := fixtureNow.Add(fixtureReadyCheckTimeout)
+	expiredLobby := readyCheckLobbyFixture(deadline, readyPlayer(newUser(1, "creator")), asPlayer(newUser(2, "player2")))
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerWaiting, deadline.Add(fixtureWaitingTimeout)).Return(nil)
+	s.lobbyRepo.On("FailReadyCheck", expiredLobby, []uint{2}).Return(lobbyrepo.ErrReadyCheckOver)
+
+	s.joinAndExpire(expiredLobby)
+
+	s.infractionRepo.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,243 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"log"
+	"maps"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequestRematch opens the rematch vote of the finished game, accepted by the caller. The other players have the
+// configured time to accept it as well.
+func (s *LobbyService) RequestRematch(ctx context.Context, req *lobby.RequestRematchRequest) (*lobby.Lobby, error) {
+	player, finishedLobby, err := s.findRematchVoter(ctx, req.GetLobbyId(), "ask for a rematch")
+	if err != nil {
+		return nil, err
+	}
+
+	// This check only avoids moving the deadline of the running vote: the repository checks it again atomically.
+	if rematchVoteRunning(finishedLobby) {
+		return nil, status.Errorf(codes.FailedPrecondition, "a rematch vote is already running")
+	}
+
+	deadline := now().Add(s.cfg.Timeouts.Rematch)
+	if err := s.scheduler.Schedule(finishedLobby.LobbyID, models.LobbyTimerRematch, deadline); err != nil {
+		return nil, status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err = s.lobbyRepo.StartRematchVote(finishedLobby, player.ID, now(), deadline)
+	switch {
+	case errors.Is(err, lobbyrepo.ErrRematchVoteRunning):
+		return nil, status.Errorf(codes.FailedPrecondition, "a rematch vote is already running")
+	case errors.Is(err, lobbyrepo.ErrRematchCreated):
+		return nil, status.Errorf(codes.FailedPrecondition, "the rematch has already been created")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	setTimer(finishedLobby, models.LobbyTimerRematch, deadline)
+
+	return toProtoLobby(finishedLobby), nil
+}
+
+// RespondRematch records the answer of the caller to the rematch vote. A decline ends the vote, while the last
+// acceptance creates the rematch.
+func (s *LobbyService) RespondRematch(ctx context.Context, req *lobby.RespondRematchRequest) (*lobby.Lobby, error) {
+	player, finishedLobby, err := s.findRematchVoter(ctx, req.GetLobbyId(), "answer the rematch vote")
+	if err != nil {
+		return nil, err
+	}
+
+	if !rematchVoteRunning(finishedLobby) {
+		return nil, status.Errorf(codes.FailedPrecondition, "no rematch vote is running")
+	}
+
+	if !req.GetAccept() {
+		if err := s.lobbyRepo.EndRematchVote(finishedLobby); err != nil {
+			return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+		}
+		s.cancelTimer(finishedLobby.LobbyID, models.LobbyTimerRematch)
+
+		finishedLobby.RematchDeadline = nil
+		for i := range finishedLobby.Players {
+			finishedLobby.Players[i].RematchAccepted = false
+		}
+		clearTimer(finishedLobby, models.LobbyTimerRematch)
+		return toProtoLobby(finishedLobby), nil
+	}
+
+	if err := s.lobbyRepo.SetRematchAccepted(finishedLobby, player.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	// The lobby is read again, so that two players accepting at the same time can not both miss the other one.
+	finishedLobby, err = s.lobbyRepo.FindByID(req.GetLobbyId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if finishedLobby.RematchLobbyID == nil && rematchVoteRunning(finishedLobby) && allAcceptedRematch(finishedLobby) {
+		if err := s.createRematch(finishedLobby); err != nil {
+			return nil, err
+		}
+	}
+
+	return toProtoLobby(finishedLobby), nil
+}
+
+// findRematchVoter loads the authenticated caller and the finished lobby, and checks that the caller played in it and
+// that the rematch does not exist yet. The action completes the message returned to the anonymous callers.
+func (s *LobbyService) findRematchVoter(ctx context.Context, lobbyID, action string) (*models.User, *models.Lobby,
+	error) {
+	player, err := s.callerPlayer(ctx, action)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finishedLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if finishedLobby.Status != models.LobbyStatusFinished {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "the game of the lobby is not finished")
+	}
+
+	if !hasPlayer(finishedLobby, player.ID) {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "only the lobby players can vote for a rematch")
+	}
+
+	if finishedLobby.RematchLobbyID != nil {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "the rematch has already been created")
+	}
+	return player, finishedLobby, nil
+}
+
+// createRematch creates a lobby with the settings and the players of the finished one. Since the new lobby is full,
+// its ready check starts right away.
+func (s *LobbyService) createRematch(finishedLobby *models.Lobby) error {
+	if err := s.checkRematchPlayers(finishedLobby); err != nil {
+		return err
+	}
+
+	joinCode, err := s.uniqueJoinCode()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to generate join code: %v", err)
+	}
+
+	rematch := &models.Lobby{
+		LobbyID:      uuid.New().String(),
+		Name:         finishedLobby.Name,
+		Status:       models.LobbyStatusWaiting,
+		Visibility:   finishedLobby.Visibility,
+		JoinCode:     &joinCode,
+		PasswordHash: finishedLobby.PasswordHash,
+		CreatorID:    finishedLobby.CreatorID,
+		HostID:       finishedLobby.HostID,
+		GameMode:     finishedLobby.GameMode,
+		Region:       finishedLobby.Region,
+		Settings:     maps.Clone(finishedLobby.Settings),
+		MaxPlayers:   finishedLobby.MaxPlayers,
+		Teams:        finishedLobby.Teams,
+	}
+	for _, player := range finishedLobby.Players {
+		rematch.Players = append(rematch.Players, models.LobbyPlayer{
+			UserID: player.UserID,
+			User:   player.User,
+			Seat:   player.Seat,
+			Team:   player.Team,
+		})
+	}
+
+	if err := s.scheduler.Schedule(rematch.LobbyID, models.LobbyTimerWaiting, now().Add(s.cfg.Timeouts.Waiting)); err != nil {
+		return status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err = s.lobbyRepo.CreateRematch(finishedLobby, rematch)
+	switch {
+	case errors.Is(err, lobbyrepo.ErrRematchCreated):
+		// Another player accepted last at the same time, and created the rematch first.
+		current, err := s.lobbyRepo.FindByID(finishedLobby.LobbyID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+		}
+		*finishedLobby = *current
+		return nil
+	case errors.Is(err, lobbyrepo.ErrPlayerInLobby):
+		return status.Errorf(codes.FailedPrecondition, "a player is already in another active lobby")
+	case err != nil:
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	s.cancelTimer(finishedLobby.LobbyID, models.LobbyTimerRematch)
+	clearTimer(finishedLobby, models.LobbyTimerRematch)
+
+	if len(rematch.Players) == rematch.MaxPlayers {
+		return s.startReadyCheck(rematch, false)
+	}
+	return nil
+}
+
+// checkRematchPlayers holds the players of the rematch to the checks of the players that create or join a lobby: none
+// of them may be on a cooldown, nor be blocked by another one since the game.
+func (s *LobbyService) checkRematchPlayers(finishedLobby *models.Lobby) error {
+	for _, player := range finishedLobby.Players {
+		remaining, _, err := s.cooldownLeft(&player.User)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return status.Errorf(codes.FailedPrecondition, "%s is on a cooldown: the rematch can be created in %s",
+				player.User.Username, remaining.Round(time.Second))
+		}
+
+		blocked, err := s.blockedByPlayers(finishedLobby, player.UserID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return status.Errorf(codes.PermissionDenied, "%s has been blocked by another player", player.User.Username)
+		}
+	}
+	return nil
+}
+
+// expireRematch ends the rematch vote that not every player accepted in time.
+func (s *LobbyService) expireRematch(lobbyID string) {
+	finishedLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if err != nil {
+		log.Printf("Failed to end the rematch vote of lobby %s: %v", lobbyID, err)
+		return
+	}
+
+	// Either the rematch has been created, or a new vote has been opened after this one.
+	if finishedLobby.RematchDeadline == nil || finishedLobby.RematchLobbyID != nil || rematchVoteRunning(finishedLobby) {
+		return
+	}
+
+	if err := s.lobbyRepo.EndRematchVote(finishedLobby); err != nil {
+		log.Printf("Failed to end the rematch vote of lobby %s: %v", lobbyID, err)
+	}
+}
+
+func rematchVoteRunning(l *models.Lobby) bool {
+	return l.RematchDeadline != nil && now().Before(*l.RematchDeadline)
+}
+
+func allAcceptedRematch(l *models.Lobby) bool {
+	for _, player := range l.Players {
+		if !player.RematchAccepted {
+			return false
+		}
+	}
+	return true
+}
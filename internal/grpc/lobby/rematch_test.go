@@ -0,0 +1,317 @@
+package lobby
+
+import (
+	"context"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+func rematchLobbyFixture(players ...models.LobbyPlayer) *models.Lobby {
+	for i := range players {
+		players[i].Seat = i
+	}
+	return &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Name:       fixtureLobbyName,
+		Status:     models.LobbyStatusFinished,
+		Visibility: models.LobbyVisibilityUnlisted,
+		GameMode:   "DUEL",
+		Region:     "NA",
+		Settings:   map[string]string{"map": "RUINS"},
+		MaxPlayers: len(players),
+		Players:    players,
+	}
+}
+
+// inRematchVote opens a rematch vote on the lobby, accepted by the given players.
+func inRematchVote(l *models.Lobby, deadline time.Time, acceptedBy ...uint) *models.Lobby {
+	l.RematchDeadline = &deadline
+	for i := range l.Players {
+		for _, userID := range acceptedBy {
+			if l.Players[i].UserID == userID {
+				l.Players[i].RematchAccepted = true
+			}
+		}
+	}
+	return l
+}
+
+func (s *LobbyServiceTestSuite) TestRequestRematchOpensTheVote() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	player2 := newUser(2, "player2")
+	finishedLobby := rematchLobbyFixture(asPlayer(player1), asPlayer(player2))
+	deadline := fixtureNow.Add(fixtureRematchWindow)
+	s.userRepo.On("FindByUsername", "player1").Return(player1, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(finishedLobby, nil)
+	s.scheduler.On("Schedule", fixtureLobbyID, models.LobbyTimerRematch, deadline).Return(nil)
+	s.lobbyRepo.On("StartRematchVote", finishedLobby, player1.ID, fixtureNow, deadline).Return(nil)
+
+	resp, err := s.service.RequestRematch(callerContext("player1"), &lobby.RequestRematchRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal(deadline, resp.Deadlines[string(models.LobbyTimerRematch)].AsTime())
+	s.True(resp.Players[0].RematchAccepted)
+	s.False(resp.Players[1].RematchAccepted)
+}
+
+func (s *LobbyServiceTestSuite) TestRequestRematchBeforeTheEndOfTheGame() {
+	player1 := newUser(1, "player1")
+	gameLobby := rematchLobbyFixture(asPlayer(player1))
+	gameLobby.Status = models.LobbyStatusInProgress
+	s.userRepo.On("FindByUsername", "player1").Return(player1, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+
+	_, err := s.service.RequestRematch(callerContext("player1"), &lobby.RequestRematchRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not finished")
+}
+
+func (s *LobbyServiceTestSuite) TestRequestRematchFromAUserThatDidNotPlay() {
+	player1 := newUser(1, "player1")
+	outsider := newUser(3, "outsider")
+	s.userRepo.On("FindByUsername", "outsider").Return(outsider, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(rematchLobbyFixture(asPlayer(player1)), nil)
+
+	_, err := s.service.RequestRematch(callerContext("outsider"), &lobby.RequestRematchRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the lobby players")
+}
+
+func (s *LobbyServiceTestSuite) TestRequestRematchWhenUnauthenticated() {
+	_, err := s.service.RequestRematch(context.Background(), &lobby.RequestRematchRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.Unauthenticated, "sign in to ask for a rematch")
+	s.lobbyRepo.AssertNotCalled(s.T(), "StartRematchVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRequestRematchWhileAVoteIsRunning() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	player2 := newUser(2, "player2")
+	finishedLobby := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)),
+		fixtureNow.Add(time.Second), player1.ID)
+	s.userRepo.On("FindByUsername", "player2").Return(player2, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(finishedLobby, nil)
+
+	_, err := s.service.RequestRematch(callerContext("player2"), &lobby.RequestRematchRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already running")
+	s.scheduler.AssertNotCalled(s.T(), "Schedule", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRequestRematchWhenTheRematchExists() {
+	player1 := newUser(1, "player1")
+	rematchLobbyID := "rematch-456"
+	finishedLobby := rematchLobbyFixture(asPlayer(player1))
+	finishedLobby.RematchLobbyID = &rematchLobbyID
+	s.userRepo.On("FindByUsername", "player1").Return(player1, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(finishedLobby, nil)
+
+	_, err := s.service.RequestRematch(callerContext("player1"), &lobby.RequestRematchRequest{LobbyId: fixtureLobbyID})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already been created")
+}
+
+func (s *LobbyServiceTestSuite) TestRespondRematchDeclineEndsTheVote() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	player2 := newUser(2, "player2")
+	finishedLobby := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)),
+		fixtureNow.Add(time.Second), player1.ID)
+	s.userRepo.On("FindByUsername", "player2").Return(player2, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(finishedLobby, nil)
+	s.lobbyRepo.On("EndRematchVote", finishedLobby).Return(nil)
+	s.scheduler.On("Cancel", fixtureLobbyID, models.LobbyTimerRematch).Return(nil)
+
+	resp, err := s.service.RespondRematch(callerContext("player2"), &lobby.RespondRematchRequest{
+		LobbyId: fixtureLobbyID,
+		Accept:  false,
+	})
+
+	s.NoError(err)
+	s.False(resp.Players[0].RematchAccepted)
+	s.Nil(resp.RematchLobbyId)
+	s.lobbyRepo.AssertNotCalled(s.T(), "SetRematchAccepted", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRespondRematchWaitsForTheOtherPlayers() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	player2 := newUser(2, "player2")
+	player3 := newUser(3, "player3")
+	deadline := fixtureNow.Add(time.Second)
+	before := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2), asPlayer(player3)),
+		deadline, player1.ID)
+	after := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2), asPlayer(player3)),
+		deadline, player1.ID, player2.ID)
+	s.userRepo.On("FindByUsername", "player2").Return(player2, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.lobbyRepo.On("SetRematchAccepted", before, player2.ID).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+
+	resp, err := s.service.RespondRematch(callerContext("player2"), &lobby.RespondRematchRequest{
+		LobbyId: fixtureLobbyID,
+		Accept:  true,
+	})
+
+	s.NoError(err)
+	s.True(resp.Players[1].RematchAccepted)
+	s.Nil(resp.RematchLobbyId)
+	s.lobbyRepo.AssertNotCalled(s.T(), "CreateRematch", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRespondRematchCreatesTheRematchWhenEveryoneAccepted() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	player2 := newUser(2, "player2")
+	deadline := fixtureNow.Add(time.Second)
+	before := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)), deadline, player1.ID)
+	after := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)), deadline,
+		player1.ID, player2.ID)
+	s.userRepo.On("FindByUsername", "player2").Return(player2, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.lobbyRepo.On("SetRematchAccepted", before, player2.ID).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+	s.expectNotBlocked()
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("CreateRematch", after, mock.MatchedBy(func(rematch *models.Lobby) bool {
+		return rematch.LobbyID != fixtureLobbyID &&
+			rematch.Name == fixtureLobbyName &&
+			rematch.Status == models.LobbyStatusWaiting &&
+			rematch.Visibility == models.LobbyVisibilityUnlisted &&
+			rematch.GameMode == "DUEL" && rematch.Region == "NA" &&
+			rematch.Settings["map"] == "RUINS" &&
+			len(rematch.Players) == 2 &&
+			rematch.Players[0].UserID == player1.ID && rematch.Players[1].UserID == player2.ID &&
+			rematch.Players[1].Seat == 1
+	})).Return(nil)
+	s.expectScheduled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("StartReadyCheck", mock.AnythingOfType("*models.Lobby"), fixtureNow.Add(fixtureReadyCheckTimeout), false).Return(nil)
+	s.expectCancelled(models.LobbyTimerWaiting)
+	s.scheduler.On("Cancel", fixtureLobbyID, models.LobbyTimerRematch).Return(nil)
+
+	resp, err := s.service.RespondRematch(callerContext("player2"), &lobby.RespondRematchRequest{
+		LobbyId: fixtureLobbyID,
+		Accept:  true,
+	})
+
+	s.NoError(err)
+	s.Require().NotNil(resp.RematchLobbyId)
+	s.NotEqual(fixtureLobbyID, resp.GetRematchLobbyId())
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestRespondRematchWhenAPlayerIsInAnotherLobby() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	player2 := newUser(2, "player2")
+	deadline := fixtureNow.Add(time.Second)
+	before := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)), deadline, player1.ID)
+	after := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)), deadline,
+		player1.ID, player2.ID)
+	s.userRepo.On("FindByUsername", "player2").Return(player2, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.lobbyRepo.On("SetRematchAccepted", before, player2.ID).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+	s.expectNotBlocked()
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("CreateRematch", after, mock.AnythingOfType("*models.Lobby")).Return(lobbyrepo.ErrPlayerInLobby)
+
+	_, err := s.service.RespondRematch(callerContext("player2"), &lobby.RespondRematchRequest{
+		LobbyId: fixtureLobbyID,
+		Accept:  true,
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "another active lobby")
+	s.lobbyRepo.AssertNotCalled(s.T(), "StartReadyCheck", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// everyoneAcceptsTheRematch lets player2 accept last the rematch vote that player1 opened.
+func (s *LobbyServiceTestSuite) everyoneAcceptsTheRematch(player1, player2 *models.User) (*lobby.Lobby, error) {
+	deadline := fixtureNow.Add(time.Second)
+	before := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)), deadline, player1.ID)
+	after := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)), deadline,
+		player1.ID, player2.ID)
+	s.userRepo.On("FindByUsername", "player2").Return(player2, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(before, nil).Once()
+	s.lobbyRepo.On("SetRematchAccepted", before, player2.ID).Return(nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(after, nil).Once()
+
+	return s.service.RespondRematch(callerContext("player2"), &lobby.RespondRematchRequest{
+		LobbyId: fixtureLobbyID,
+		Accept:  true,
+	})
+}
+
+func (s *LobbyServiceTestSuite) TestRespondRematchWhenAPlayerIsOnACooldown() {
+	defer s.stubNow()()
+	player1, player2 := newUser(1, "player1"), newUser(2, "player2")
+	s.expectNotBlocked()
+	s.expectInfractions(player1, infractionsFixture(player1, 0))
+
+	_, err := s.everyoneAcceptsTheRematch(player1, player2)
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "player1 is on a cooldown: the rematch can be created in 1m0s")
+	s.lobbyRepo.AssertNotCalled(s.T(), "CreateRematch", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRespondRematchWhenAPlayerBlockedAnotherOne() {
+	defer s.stubNow()()
+	player1, player2 := newUser(1, "player1"), newUser(2, "player2")
+	s.friendRepo.On("HasBlocked", []uint{player2.ID}, player1.ID).Return(false, nil)
+	s.friendRepo.On("HasBlocked", []uint{player1.ID}, player2.ID).Return(true, nil)
+
+	_, err := s.everyoneAcceptsTheRematch(player1, player2)
+
+	s.assertGrpcError(err, codes.PermissionDenied, "player2 has been blocked by another player")
+	s.lobbyRepo.AssertNotCalled(s.T(), "CreateRematch", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRespondRematchAfterTheVoteExpired() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	player2 := newUser(2, "player2")
+	finishedLobby := inRematchVote(rematchLobbyFixture(asPlayer(player1), asPlayer(player2)), fixtureNow, player1.ID)
+	s.userRepo.On("FindByUsername", "player2").Return(player2, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(finishedLobby, nil)
+
+	_, err := s.service.RespondRematch(callerContext("player2"), &lobby.RespondRematchRequest{
+		LobbyId: fixtureLobbyID,
+		Accept:  true,
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "no rematch vote is running")
+	s.lobbyRepo.AssertNotCalled(s.T(), "SetRematchAccepted", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestRematchTimerEndsTheExpiredVote() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	finishedLobby := inRematchVote(rematchLobbyFixture(asPlayer(player1)), fixtureNow, player1.ID)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(finishedLobby, nil)
+	s.lobbyRepo.On("EndRematchVote", finishedLobby).Return(nil)
+
+	s.scheduler.handlers[models.LobbyTimerRematch](fixtureLobbyID)
+
+	s.lobbyRepo.AssertCalled(s.T(), "EndRematchVote", finishedLobby)
+}
+
+func (s *LobbyServiceTestSuite) TestRematchTimerKeepsANewerVote() {
+	defer s.stubNow()()
+	player1 := newUser(1, "player1")
+	finishedLobby := inRematchVote(rematchLobbyFixture(asPlayer(player1)), fixtureNow.Add(time.Second), player1.ID)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(finishedLobby, nil)
+
+	s.scheduler.handlers[models.LobbyTimerRematch](fixtureLobbyID)
+
+	s.lobbyRepo.AssertNotCalled(s.T(), "EndRematchVote", mock.Anything)
+}
@@ -2,30 +2,116 @@ package lobby
 
 import (
 	"context"
+	"errors"
+	"log"
 	"math/rand"
 	"strings"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gamemode"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/password"
+	friendrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/friend"
+	infractionrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/infraction"
+	inviterepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/invite"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
 	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	partyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/party"
 	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/scheduler"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // LobbyService implements the gRPC lobby service server for managing game lobbies.
 type LobbyService struct {
 	lobby.UnimplementedLobbyServiceServer
-	lobbyRepo lobbyrepo.LobbyRepository
-	userRepo  usrrepo.UserRepository
+	lobbyRepo  lobbyrepo.LobbyRepository
+	userRepo   usrrepo.UserRepository
+	inviteRepo inviterepo.InviteRepository
+	// partyRepo tells which users create and join the lobbies together.
+	partyRepo partyrepo.PartyRepository
+	// friendRepo keeps the users away from the lobbies and the invites of the users who blocked them.
+	friendRepo friendrepo.FriendRepository
+	// infractionRepo holds the games the users left, dodged or declined, which put them on a cooldown.
+	infractionRepo infractionrepo.InfractionRepository
+	// leaderboardRepo is told about every finished game, to keep the standings up to date.
+	leaderboardRepo leaderboardrepo.LeaderboardRepository
+	hasher          password.PasswordHasher
+	scheduler       scheduler.Scheduler
+	// catalog validates the game mode, region and settings of the new lobbies.
+	catalog *gamemode.Catalog
+	cfg     Config
 }
 
-func NewLobbyService(lobbyRepo lobbyrepo.LobbyRepository, userRepo usrrepo.UserRepository) lobby.LobbyServiceServer {
-	return &LobbyService{
-		lobbyRepo: lobbyRepo,
-		userRepo:  userRepo,
+// Config collects the settings of the lobby service.
+type Config struct {
+	Timeouts Timeouts
+	// DisconnectPolicy is applied to the games whose players stay disconnected past the reconnect grace period.
+	DisconnectPolicy DisconnectPolicy
+	Penalties        Penalties
+	// QueueStatsWindow is how far back the queue statistics look at the waits of the matched players.
+	QueueStatsWindow time.Duration
+	// MaxSpectators is how many users can watch a lobby at the same time.
+	MaxSpectators int
+}
+
+// Timeouts collects the durations of the lobby phases that are driven by the server.
+type Timeouts struct {
+	// Waiting is how long a lobby waits for players before being closed.
+	Waiting time.Duration
+	// ReadyCheck is how long the players of a full lobby have to confirm that they are ready.
+	ReadyCheck time.Duration
+	// Game is how long a game lasts.
+	Game time.Duration
+	// ResultReport is how long the result of an ended game can be reported before the server finishes it.
+	ResultReport time.Duration
+	// Rematch is how long the players of a finished game have to accept a rematch.
+	Rematch time.Duration
+	// KickBan is how long a kicked player is kept out of the lobby.
+	KickBan time.Duration
+	// Reconnect is how long a player that disconnected during the game has to come back.
+	Reconnect time.Duration
+	// MatchAccept is how long the players of a pending match have to accept it.
+	MatchAccept time.Duration
+}
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+var errJoinCodesExhausted = errors.New("could not find a free join code")
+
+func NewLobbyService(lobbyRepo lobbyrepo.LobbyRepository, userRepo usrrepo.UserRepository,
+	inviteRepo inviterepo.InviteRepository, partyRepo partyrepo.PartyRepository, friendRepo friendrepo.FriendRepository,
+	infractionRepo infractionrepo.InfractionRepository, leaderboardRepo leaderboardrepo.LeaderboardRepository,
+	hasher password.PasswordHasher, lobbyScheduler scheduler.Scheduler, catalog *gamemode.Catalog,
+	cfg Config) lobby.LobbyServiceServer {
+	s := &LobbyService{
+		lobbyRepo:       lobbyRepo,
+		userRepo:        userRepo,
+		inviteRepo:      inviteRepo,
+		partyRepo:       partyRepo,
+		friendRepo:      friendRepo,
+		infractionRepo:  infractionRepo,
+		leaderboardRepo: leaderboardRepo,
+		hasher:          hasher,
+		scheduler:       lobbyScheduler,
+		catalog:         catalog,
+		cfg:             cfg,
 	}
+
+	lobbyScheduler.Handle(models.LobbyTimerWaiting, s.expireWaitingLobby)
+	lobbyScheduler.Handle(models.LobbyTimerReadyCheck, s.expireReadyCheck)
+	lobbyScheduler.Handle(models.LobbyTimerGameEnd, s.endGame)
+	lobbyScheduler.Handle(models.LobbyTimerResultReport, s.expireResultReport)
+	lobbyScheduler.Handle(models.LobbyTimerRematch, s.expireRematch)
+	lobbyScheduler.Handle(models.LobbyTimerReconnect, s.expireReconnect)
+	lobbyScheduler.Handle(models.LobbyTimerMatchAccept, s.expireMatch)
+	return s
 }
 
 func (s *LobbyService) CreateLobby(ctx context.Context, req *lobby.CreateLobbyRequest) (*lobby.Lobby, error) {
@@ -35,23 +121,110 @@ func (s *LobbyService) CreateLobby(ctx context.Context, req *lobby.CreateLobbyRe
 		return nil, status.Errorf(codes.InvalidArgument, "lobby name cannot be empty")
 	}
 
+	visibility, err := parseVisibility(req.GetVisibility())
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := s.catalog.Mode(req.GetGameMode())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	region, err := s.catalog.Region(req.GetRegion())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	settings, err := mode.Resolve(req.GetSettings())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
 	creator, err := s.userRepo.FindByUsername(req.GetUsername())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Invalid creator: %v", err)
 	}
 
+	group, err := s.groupOf(creator, "create lobbies")
+	if err != nil {
+		return nil, err
+	}
+	if len(group) > mode.Capacity {
+		return nil, status.Errorf(codes.FailedPrecondition, "your party does not fit in a lobby of this game mode")
+	}
+
+	if err := s.checkCooldown(group); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkNotInActiveLobby(creator); err != nil {
+		return nil, err
+	}
+
+	joinCode, err := s.uniqueJoinCode()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate join code: %v", err)
+	}
+
+	var passwordHash string
+	if req.GetPassword() != "" {
+		passwordHash, err = s.hasher.Hash(req.GetPassword())
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+		}
+	}
+
+	// The party of the creator takes the first seats, spread across the teams.
+	var players []models.LobbyPlayer
+	for i, member := range group {
+		membership := models.LobbyPlayer{UserID: member.ID, User: *member, Seat: i}
+		if mode.Teams > 0 {
+			team := i%mode.Teams + 1
+			membership.Team = &team
+		}
+		players = append(players, membership)
+	}
 	newLobby := &models.Lobby{
-		LobbyID: uuid.New().String(),
-		Name:    lobbyName,
-		Players: []models.User{*creator},
-		Status:  models.LobbyStatusWaiting,
+		LobbyID:      uuid.New().String(),
+		Name:         lobbyName,
+		Players:      players,
+		Status:       models.LobbyStatusWaiting,
+		Visibility:   visibility,
+		JoinCode:     &joinCode,
+		PasswordHash: passwordHash,
+		CreatorID:    &creator.ID,
+		HostID:       &creator.ID,
+		GameMode:     mode.Name,
+		Region:       region,
+		Settings:     settings,
+		MaxPlayers:   mode.Capacity,
+		Teams:        mode.Teams,
 	}
 
-	if err := s.lobbyRepo.Create(newLobby); err != nil {
+	// Timers are always scheduled before the change they guard: if the change fails, the timer finds the lobby in
+	// another state and does nothing.
+	waitingDeadline := now().Add(s.cfg.Timeouts.Waiting)
+	if err := s.scheduler.Schedule(newLobby.LobbyID, models.LobbyTimerWaiting, waitingDeadline); err != nil {
+		return nil, status.Errorf(codes.Internal, "Scheduler error: %v", err)
+	}
+
+	err = s.lobbyRepo.Create(newLobby)
+	if errors.Is(err, lobbyrepo.ErrPlayerInLobby) && len(group) > 1 {
+		return nil, status.Errorf(codes.FailedPrecondition, "a member of your party is already in an active lobby")
+	}
+	if errors.Is(err, lobbyrepo.ErrPlayerInLobby) {
+		return nil, status.Errorf(codes.FailedPrecondition, "you are already in an active lobby")
+	}
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
 	}
 
-	return toProtoLobby(newLobby), nil
+	newLobby.Timers = []models.LobbyTimer{{LobbyID: newLobby.LobbyID, Kind: models.LobbyTimerWaiting, FiresAt: waitingDeadline}}
+	if len(newLobby.Players) == newLobby.MaxPlayers {
+		if err := s.startReadyCheck(newLobby, false); err != nil {
+			return nil, err
+		}
+	}
+	return toMemberProtoLobby(newLobby, creator.ID), nil
 }
 
 func (s *LobbyService) JoinLobby(ctx context.Context, req *lobby.JoinLobbyRequest) (*lobby.Lobby, error) {
@@ -65,78 +238,356 @@ func (s *LobbyService) JoinLobby(ctx context.Context, req *lobby.JoinLobbyReques
 		return nil, status.Errorf(codes.Internal, "Lobby not found: %v", err)
 	}
 
-	if len(lobbyToJoin.Players) >= 2 {
+	if lobbyToJoin.Visibility == models.LobbyVisibilityPrivate {
+		return nil, status.Errorf(codes.PermissionDenied, "private lobbies can only be joined with their join code")
+	}
+
+	if err := s.checkPassword(lobbyToJoin, req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	return s.addPlayer(lobbyToJoin, player)
+}
+
+// JoinLobbyByCode lets a player join any lobby, including the private ones, given its join code.
+func (s *LobbyService) JoinLobbyByCode(ctx context.Context, req *lobby.JoinLobbyByCodeRequest) (*lobby.Lobby, error) {
+	joinCode := strings.ToUpper(strings.TrimSpace(req.GetJoinCode()))
+	if joinCode == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "join code cannot be empty")
+	}
+
+	player, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid player: %v", err)
+	}
+
+	lobbyToJoin, err := s.lobbyRepo.FindByJoinCode(joinCode)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "no lobby matches the join code")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if err := s.checkPassword(lobbyToJoin, req.GetPassword()); err != nil {
+		return nil, err
+	}
+
+	return s.addPlayer(lobbyToJoin, player)
+}
+
+func (s *LobbyService) checkPassword(lobbyToJoin *models.Lobby, lobbyPassword string) error {
+	if lobbyToJoin.PasswordHash == "" {
+		return nil
+	}
+	if err := s.hasher.Verify(lobbyToJoin.PasswordHash, lobbyPassword); err != nil {
+		return status.Errorf(codes.PermissionDenied, "invalid lobby password")
+	}
+	return nil
+}
+
+// addPlayer checks the lobby capacity and status, then adds the player to the lobby, together with their party when
+// they lead one: either the whole party joins or nobody does. The player that fills the lobby starts the ready check.
+func (s *LobbyService) addPlayer(lobbyToJoin *models.Lobby, player *models.User) (*lobby.Lobby, error) {
+	if len(lobbyToJoin.Players) >= lobbyToJoin.MaxPlayers {
 		return nil, status.Errorf(codes.FailedPrecondition, "lobby is full")
 	}
 
-	if err := s.lobbyRepo.AddPlayer(lobbyToJoin, player); err != nil {
+	if lobbyToJoin.Status != models.LobbyStatusWaiting {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is not waiting for players")
+	}
+
+	group, err := s.groupOf(player, "join lobbies")
+	if err != nil {
+		return nil, err
+	}
+	if len(group) > 1 && len(lobbyToJoin.Players)+len(group) > lobbyToJoin.MaxPlayers {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby does not have enough free slots for your party")
+	}
+
+	if err := s.checkCooldown(group); err != nil {
+		return nil, err
+	}
+
+	for _, member := range group {
+		if err := s.checkAdmission(lobbyToJoin, member); err != nil {
+			return nil, err
+		}
+	}
+
+	// The checks above only save a write in the common case: the repository checks them again atomically.
+	if len(group) > 1 {
+		err = s.lobbyRepo.AddPlayers(lobbyToJoin, group, lobbyToJoin.MaxPlayers)
+	} else {
+		err = s.lobbyRepo.AddPlayer(lobbyToJoin, player, lobbyToJoin.MaxPlayers)
+	}
+	switch {
+	case errors.Is(err, lobbyrepo.ErrLobbyConflict):
+		return nil, status.Errorf(codes.Aborted, "another player joined the lobby at the same time, please retry")
+	case errors.Is(err, lobbyrepo.ErrLobbyFull) && len(group) > 1:
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby does not have enough free slots for your party")
+	case errors.Is(err, lobbyrepo.ErrLobbyFull):
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is full")
+	case errors.Is(err, lobbyrepo.ErrLobbyNotWaiting):
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is not waiting for players")
+	case errors.Is(err, lobbyrepo.ErrLobbyLocked):
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is locked")
+	case errors.Is(err, lobbyrepo.ErrPlayerInLobby) && len(group) > 1:
+		return nil, status.Errorf(codes.FailedPrecondition, "a member of your party is already in an active lobby")
+	case errors.Is(err, lobbyrepo.ErrPlayerInLobby):
+		return nil, status.Errorf(codes.FailedPrecondition, "you are already in an active lobby")
+	case err != nil:
 		return nil, status.Errorf(codes.Internal, "Can not add the player: %v", err)
 	}
 
-	if err := s.lobbyRepo.UpdateStatus(lobbyToJoin, models.LobbyStatusInProgress); err != nil {
-		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	if len(lobbyToJoin.Players) == lobbyToJoin.MaxPlayers {
+		if err := s.startReadyCheck(lobbyToJoin, true); err != nil {
+			return nil, err
+		}
 	}
 
-	lobbyToJoin.Players = append(lobbyToJoin.Players, *player)
-	lobbyToJoin.Status = models.LobbyStatusInProgress
-	return toProtoLobby(lobbyToJoin), nil
+	return toMemberProtoLobby(lobbyToJoin, player.ID), nil
 }
 
+// FinishGame reports the result of a game. It can be called by the players of the lobby until the result-report
+// window closes, after that the server finishes the game on its own.
 func (s *LobbyService) FinishGame(ctx context.Context, req *lobby.FinishGameRequest) (*lobby.Lobby, error) {
 	gameLobby, err := s.lobbyRepo.FindByID(req.GetLobbyId())
 	if err != nil {
 		return nil, err
 	}
 
-	winnerIndex := rand.Intn(len(gameLobby.Players))
-	winner := gameLobby.Players[winnerIndex]
+	if gameLobby.Status != models.LobbyStatusInProgress {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is not in progress")
+	}
 
-	if err := s.lobbyRepo.UpdateWinner(gameLobby, winner.ID); err != nil {
-		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	if !hasPlayerNamed(gameLobby, req.GetUsername()) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the lobby players can report the result")
 	}
 
-	if err := s.lobbyRepo.UpdateStatus(gameLobby, models.LobbyStatusFinished); err != nil {
-		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	if err := s.finish(gameLobby); err != nil {
+		return nil, err
 	}
 
-	gameLobby.Winner = &winner
-	gameLobby.WinnerID = &winner.ID
-	gameLobby.Status = models.LobbyStatusFinished
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerGameEnd)
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerResultReport)
+	s.cancelTimer(gameLobby.LobbyID, models.LobbyTimerReconnect)
+	gameLobby.Timers = nil
 	return toProtoLobby(gameLobby), nil
 }
 
+// finish picks the winner of the game, or the winning team when the lobby has teams, and moves the lobby to FINISHED.
+// The sides that abandoned a game which went on without them can not win it.
+func (s *LobbyService) finish(gameLobby *models.Lobby) error {
+	players, teams := standingSides(gameLobby)
+	if gameLobby.Teams > 0 {
+		if len(teams) == 0 {
+			return s.finishWith(gameLobby, nil, winningTeam(gameLobby.Players))
+		}
+		return s.finishWith(gameLobby, nil, teams[rand.Intn(len(teams))])
+	}
+	if len(players) == 0 {
+		return s.finishWith(gameLobby, &gameLobby.Players[rand.Intn(len(gameLobby.Players))].User, 0)
+	}
+	return s.finishWith(gameLobby, players[rand.Intn(len(players))], 0)
+}
+
+// finishWith moves the lobby to FINISHED with the given winner, or the given winning team when the lobby has teams.
+// Only the caller that moves the game out of IN_PROGRESS records its result: FinishGame, the result-report timer and
+// a forfeit can race, and the others fail with FailedPrecondition.
+func (s *LobbyService) finishWith(gameLobby *models.Lobby, winner *models.User, team int) error {
+	var err error
+	if gameLobby.Teams > 0 {
+		err = s.lobbyRepo.FinishWithWinningTeam(gameLobby, team)
+	} else {
+		err = s.lobbyRepo.FinishWithWinner(gameLobby, winner.ID)
+	}
+	if errors.Is(err, lobbyrepo.ErrLobbyNotInProgress) {
+		return status.Errorf(codes.FailedPrecondition, "lobby is not in progress")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	var winnerIDs, loserIDs []uint
+	for i, player := range gameLobby.Players {
+		placement := 2
+		if (winner != nil && player.UserID == winner.ID) || (player.Team != nil && *player.Team == team) {
+			placement = 1
+			winnerIDs = append(winnerIDs, player.UserID)
+		} else {
+			loserIDs = append(loserIDs, player.UserID)
+		}
+		gameLobby.Players[i].Placement = &placement
+	}
+
+	// The game is over whatever happens to the standings: a failure only leaves this game out of the leaderboards.
+	if err := s.leaderboardRepo.RecordGame(winnerIDs, loserIDs, now()); err != nil {
+		log.Printf("Failed to record the result of lobby %s in the leaderboards: %v", gameLobby.LobbyID, err)
+	}
+	if winner != nil {
+		gameLobby.Winner = winner
+		gameLobby.WinnerID = &winner.ID
+	} else {
+		gameLobby.WinningTeam = &team
+	}
+	gameLobby.Status = models.LobbyStatusFinished
+	return nil
+}
+
+// GetLobby returns the lobby to anybody, but only its players and its host, authenticated by their token, get to see
+// its join code.
 func (s *LobbyService) GetLobby(ctx context.Context, req *lobby.GetLobbyRequest) (*lobby.Lobby, error) {
 	foundLobby, err := s.lobbyRepo.FindByID(req.GetLobbyId())
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Invalid Lobby ID: %v", err)
 	}
-	return toProtoLobby(foundLobby), nil
+
+	username, ok := caller.FromContext(ctx)
+	if !ok {
+		return toProtoLobby(foundLobby), nil
+	}
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+	return toMemberProtoLobby(foundLobby, user.ID), nil
 }
 
-func (s *LobbyService) ListAvailableLobbies(ctx context.Context, req *lobby.ListAvailableLobbiesRequest) (*lobby.ListAvailableLobbiesResponse, error) {
-	lobbies := s.lobbyRepo.ListAvailable()
-	protoLobbies := make([]*lobby.Lobby, len(lobbies))
-	for i, l := range lobbies {
-		protoLobbies[i] = toProtoLobby(l)
+func (s *LobbyService) GetMyCurrentLobby(ctx context.Context, req *lobby.GetMyCurrentLobbyRequest) (*lobby.Lobby, error) {
+	user, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	currentLobby, err := s.lobbyRepo.FindActiveByPlayer(user.ID)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "you are not in an active lobby")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
 	}
-	return &lobby.ListAvailableLobbiesResponse{Lobbies: protoLobbies}, nil
+	return toMemberProtoLobby(currentLobby, user.ID), nil
 }
 
+// callerPlayer loads the authenticated caller. The action completes the message returned to the anonymous callers.
+func (s *LobbyService) callerPlayer(ctx context.Context, action string) (*models.User, error) {
+	username, ok := caller.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "sign in to %s", action)
+	}
+
+	player, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid player: %v", err)
+	}
+	return player, nil
+}
+
+// checkNotInActiveLobby enforces that a user is in at most one active lobby. The repository enforces the rule
+// atomically as well: this check only gives a more helpful message.
+func (s *LobbyService) checkNotInActiveLobby(user *models.User) error {
+	currentLobby, err := s.lobbyRepo.FindActiveByPlayer(user.ID)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return status.Errorf(codes.FailedPrecondition, "you are already in the active lobby %q", currentLobby.Name)
+}
+
+// uniqueJoinCode generates join codes until it finds one that is not used by another lobby.
+func (s *LobbyService) uniqueJoinCode() (string, error) {
+	for range maxJoinCodeAttempts {
+		code, err := generateJoinCode()
+		if err != nil {
+			return "", err
+		}
+
+		_, err = s.lobbyRepo.FindByJoinCode(code)
+		if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+			return code, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", errJoinCodesExhausted
+}
+
+func parseVisibility(visibility string) (models.LobbyVisibility, error) {
+	switch models.LobbyVisibility(strings.ToUpper(visibility)) {
+	case "", models.LobbyVisibilityPublic:
+		return models.LobbyVisibilityPublic, nil
+	case models.LobbyVisibilityUnlisted:
+		return models.LobbyVisibilityUnlisted, nil
+	case models.LobbyVisibilityPrivate:
+		return models.LobbyVisibilityPrivate, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "invalid lobby visibility: %s", visibility)
+	}
+}
+
+// toProtoLobby converts the lobby for anybody, so the join code is left out: it lets anybody into a private lobby.
 func toProtoLobby(m *models.Lobby) *lobby.Lobby {
 	pLobby := &lobby.Lobby{
-		LobbyId: m.LobbyID,
-		Name:    m.Name,
-		Status:  string(m.Status),
-		Players: make([]*lobby.Player, len(m.Players)),
+		LobbyId:     m.LobbyID,
+		Name:        m.Name,
+		Status:      string(m.Status),
+		Players:     make([]*lobby.Player, len(m.Players)),
+		Visibility:  string(m.Visibility),
+		HasPassword: m.PasswordHash != "",
+		CreatedAt:   timestamppb.New(m.CreatedAt),
+		GameMode:    m.GameMode,
+		Region:      m.Region,
+		Settings:    m.Settings,
+		MaxPlayers:  int32(m.MaxPlayers),
+		Teams:       int32(m.Teams),
+		Locked:      m.Locked,
 	}
 
 	for i, player := range m.Players {
 		pLobby.Players[i] = &lobby.Player{
-			Id:       uint32(player.ID),
-			Username: player.Username,
+			Id:       uint32(player.UserID),
+			Username: player.User.Username,
+			Ready:    player.Ready,
+			Seat:     int32(player.Seat),
+
+			RematchAccepted: player.RematchAccepted,
+			Abandoned:       player.AbandonedAt != nil,
+		}
+		if player.DisconnectedAt != nil {
+			pLobby.Players[i].DisconnectedAt = timestamppb.New(*player.DisconnectedAt)
+		}
+		if m.HostID != nil && player.UserID == *m.HostID {
+			pLobby.HostUsername = &player.User.Username
+		}
+		if player.Team != nil {
+			pLobby.Players[i].Team = int32(*player.Team)
+		}
+		if player.Placement != nil {
+			placement := int32(*player.Placement)
+			pLobby.Players[i].Placement = &placement
+		}
+	}
+
+	if m.ReadyCheckDeadline != nil {
+		pLobby.ReadyCheckDeadline = timestamppb.New(*m.ReadyCheckDeadline)
+	}
+
+	if len(m.Timers) > 0 {
+		pLobby.Deadlines = make(map[string]*timestamppb.Timestamp, len(m.Timers))
+		for _, timer := range m.Timers {
+			pLobby.Deadlines[string(timer.Kind)] = timestamppb.New(timer.FiresAt)
 		}
 	}
 
+	if m.CloseReason != nil {
+		closeReason := string(*m.CloseReason)
+		pLobby.CloseReason = &closeReason
+	}
+
 	if m.WinnerID != nil {
 		winnerID := uint32(*m.WinnerID)
 		pLobby.WinnerId = &winnerID
@@ -144,5 +595,34 @@ func toProtoLobby(m *models.Lobby) *lobby.Lobby {
 			pLobby.WinnerUsername = &m.Winner.Username
 		}
 	}
+
+	pLobby.RematchLobbyId = m.RematchLobbyID
+
+	if m.HostID != nil {
+		hostID := uint32(*m.HostID)
+		pLobby.HostId = &hostID
+	}
+
+	if m.WinningTeam != nil {
+		winningTeam := int32(*m.WinningTeam)
+		pLobby.WinningTeam = &winningTeam
+	}
+
+	for _, spectator := range m.Spectators {
+		pLobby.Spectators = append(pLobby.Spectators, &lobby.Spectator{
+			Id:       uint32(spectator.UserID),
+			Username: spectator.User.Username,
+		})
+	}
+	return pLobby
+}
+
+// toMemberProtoLobby converts the lobby like toProtoLobby, adding the join code when the user is one of its players or
+// its host.
+func toMemberProtoLobby(m *models.Lobby, userID uint) *lobby.Lobby {
+	pLobby := toProtoLobby(m)
+	if m.JoinCode != nil && (hasPlayer(m, userID) || isHost(m, userID)) {
+		pLobby.JoinCode = *m.JoinCode
+	}
 	return pLobby
 }
@@ -3,12 +3,20 @@ package lobby
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gamemode"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/password"
+	friendrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/friend"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
 	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	partyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/party"
 	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/scheduler"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc/codes"
@@ -18,6 +26,22 @@ import (
 const (
 	fixtureLobbyName = "Test Lobby"
 	fixtureLobbyID   = "lobby-123"
+
+	fixtureWaitingTimeout     = 10 * time.Minute
+	fixtureReadyCheckTimeout  = 15 * time.Second
+	fixtureGameDuration       = time.Minute
+	fixtureResultReportWindow = 30 * time.Second
+	fixtureRematchWindow      = 20 * time.Second
+	fixtureKickBan            = 5 * time.Minute
+	fixtureReconnectGrace     = time.Minute
+	fixtureCooldown           = time.Minute
+	fixtureMaxCooldown        = 10 * time.Minute
+	fixtureInfractionDecay    = 24 * time.Hour
+	fixtureDeclineCooldown    = 30 * time.Second
+	fixtureQueueStatsWindow   = time.Hour
+	fixtureMatchAcceptTimeout = 15 * time.Second
+
+	fixtureMaxSpectators = 2
 )
 
 type MockUserRepository struct {
@@ -42,65 +66,641 @@ func (m *MockUserRepository) FindByUsername(username string) (*models.User, erro
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*models.User), args.Error(1)
-}
-
-type MockLobbyRepository struct {
-	mock.Mock
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(user *models.User, hashedPassword string) error {
+	args := m.Called(user, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLastSeen(username string, seenAt time.Time) error {
+	args := m.Called(username, seenAt)
+	return args.Error(0)
+}
+
+type MockLobbyRepository struct {
+	mock.Mock
+}
+
+func (m *MockLobbyRepository) Create(lobby *models.Lobby) error {
+	args := m.Called(lobby)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FindByID(lobbyID string) (*models.Lobby, error) {
+	args := m.Called(lobbyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Lobby), args.Error(1)
+}
+
+func (m *MockLobbyRepository) FindByJoinCode(joinCode string) (*models.Lobby, error) {
+	args := m.Called(joinCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Lobby), args.Error(1)
+}
+
+func (m *MockLobbyRepository) FindActiveByPlayer(userID uint) (*models.Lobby, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Lobby), args.Error(1)
+}
+
+func (m *MockLobbyRepository) ListAvailable(filter lobbyrepo.AvailableFilter) ([]*models.Lobby, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Lobby), args.Error(1)
+}
+
+func (m *MockLobbyRepository) ListFinishedByPlayer(userID uint, offset, limit int) ([]*models.Lobby, error) {
+	args := m.Called(userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Lobby), args.Error(1)
+}
+
+func (m *MockLobbyRepository) ListResultsByPlayer(userID uint) ([]bool, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bool), args.Error(1)
+}
+
+func (m *MockLobbyRepository) AddPlayer(lobby *models.Lobby, player *models.User, capacity int) error {
+	args := m.Called(lobby, player, capacity)
+	if args.Error(0) == nil {
+		membership := models.LobbyPlayer{LobbyID: lobby.LobbyID, UserID: player.ID, User: *player, Seat: len(lobby.Players)}
+		lobby.Players = append(lobby.Players, membership)
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) AddPlayers(lobby *models.Lobby, players []*models.User, capacity int) error {
+	args := m.Called(lobby, players, capacity)
+	if args.Error(0) == nil {
+		for _, player := range players {
+			membership := models.LobbyPlayer{LobbyID: lobby.LobbyID, UserID: player.ID, User: *player, Seat: len(lobby.Players)}
+			lobby.Players = append(lobby.Players, membership)
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) AddSpectator(lobby *models.Lobby, user *models.User, maxSpectators int) error {
+	args := m.Called(lobby, user, maxSpectators)
+	if args.Error(0) == nil {
+		spectator := models.LobbySpectator{LobbyID: lobby.LobbyID, UserID: user.ID, User: *user}
+		lobby.Spectators = append(lobby.Spectators, spectator)
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) SwitchTeam(lobby *models.Lobby, userID uint, team, teamSize int) error {
+	args := m.Called(lobby, userID, team, teamSize)
+	if args.Error(0) == nil {
+		for i := range lobby.Players {
+			if lobby.Players[i].UserID == userID {
+				lobby.Players[i].Team = &team
+			}
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) SwapSeat(lobby *models.Lobby, userID uint, seat int) error {
+	args := m.Called(lobby, userID, seat)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) KickPlayer(lobby *models.Lobby, userID uint, bannedUntil time.Time) error {
+	args := m.Called(lobby, userID, bannedUntil)
+	if args.Error(0) == nil {
+		lobby.Players = slices.DeleteFunc(lobby.Players, func(player models.LobbyPlayer) bool {
+			return player.UserID == userID
+		})
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) LeaveWaiting(lobby *models.Lobby, userID uint, leftAt time.Time) error {
+	args := m.Called(lobby, userID, leftAt)
+	if args.Error(0) == nil {
+		lobby.Players = slices.DeleteFunc(lobby.Players, func(player models.LobbyPlayer) bool {
+			return player.UserID == userID
+		})
+		if len(lobby.Players) == 0 {
+			lobby.Status = models.LobbyStatusCancelled
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) LeaveGame(lobby *models.Lobby, userID uint, leftAt time.Time) error {
+	args := m.Called(lobby, userID, leftAt)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) BannedUntil(lobbyID string, userID uint) (*time.Time, error) {
+	args := m.Called(lobbyID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockLobbyRepository) UpdateHost(lobby *models.Lobby, hostID uint) error {
+	args := m.Called(lobby, hostID)
+	if args.Error(0) == nil {
+		lobby.HostID = &hostID
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) SetLocked(lobby *models.Lobby, locked bool) error {
+	args := m.Called(lobby, locked)
+	if args.Error(0) == nil {
+		lobby.Locked = locked
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) Rename(lobby *models.Lobby, name string) error {
+	args := m.Called(lobby, name)
+	if args.Error(0) == nil {
+		lobby.Name = name
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) AssignTeams(lobby *models.Lobby, teams map[uint]int) error {
+	args := m.Called(lobby, teams)
+	if args.Error(0) == nil {
+		for i := range lobby.Players {
+			if team, ok := teams[lobby.Players[i].UserID]; ok {
+				lobby.Players[i].Team = &team
+			}
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) UpdateStatus(lobby *models.Lobby, status models.LobbyStatus) error {
+	args := m.Called(lobby, status)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FinishWithWinner(lobby *models.Lobby, winnerID uint) error {
+	args := m.Called(lobby, winnerID)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FinishWithWinningTeam(lobby *models.Lobby, team int) error {
+	args := m.Called(lobby, team)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) AbandonGame(lobby *models.Lobby) error {
+	args := m.Called(lobby)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) StartReadyCheck(lobby *models.Lobby, deadline time.Time, matched bool) error {
+	args := m.Called(lobby, deadline, matched)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) SetPlayerReady(lobby *models.Lobby, player *models.User) error {
+	args := m.Called(lobby, player)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) CompleteReadyCheck(lobby *models.Lobby) error {
+	args := m.Called(lobby)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FailReadyCheck(lobby *models.Lobby, removedPlayerIDs []uint) error {
+	args := m.Called(lobby, removedPlayerIDs)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) DeleteUnconfirmed(lobby *models.Lobby) error {
+	args := m.Called(lobby)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) StartRematchVote(lobby *models.Lobby, playerID uint, startedAt, deadline time.Time) error {
+	args := m.Called(lobby, playerID, startedAt, deadline)
+	if args.Error(0) == nil {
+		lobby.RematchDeadline = &deadline
+		for i := range lobby.Players {
+			lobby.Players[i].RematchAccepted = lobby.Players[i].UserID == playerID
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) SetRematchAccepted(lobby *models.Lobby, playerID uint) error {
+	args := m.Called(lobby, playerID)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) EndRematchVote(lobby *models.Lobby) error {
+	args := m.Called(lobby)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) CreateRematch(lobby *models.Lobby, rematch *models.Lobby) error {
+	args := m.Called(lobby, rematch)
+	if args.Error(0) == nil {
+		lobby.RematchLobbyID = &rematch.LobbyID
+		lobby.RematchDeadline = nil
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) CloseWaiting(lobbyID string, reason models.LobbyCloseReason, closedAt time.Time) error {
+	args := m.Called(lobbyID, reason, closedAt)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) Delete(lobbyID string) error {
+	args := m.Called(lobbyID)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) ListStale(createdBefore, creatorSeenBefore, creatorDisconnectedBefore time.Time) ([]*models.Lobby, error) {
+	args := m.Called(createdBefore, creatorSeenBefore, creatorDisconnectedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Lobby), args.Error(1)
+}
+
+func (m *MockLobbyRepository) ListWaiting() ([]lobbyrepo.WaitingLobby, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]lobbyrepo.WaitingLobby), args.Error(1)
+}
+
+func (m *MockLobbyRepository) ListMatchWaits(since time.Time) ([]lobbyrepo.MatchWait, error) {
+	args := m.Called(since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]lobbyrepo.MatchWait), args.Error(1)
+}
+
+func (m *MockLobbyRepository) SyncConnections(at time.Time) ([]string, error) {
+	args := m.Called(at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLobbyRepository) AbandonPlayers(lobby *models.Lobby, playerIDs []uint, abandonedAt time.Time) error {
+	args := m.Called(lobby, playerIDs, abandonedAt)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) Enqueue(ticket *models.MatchTicket) error {
+	args := m.Called(ticket)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FindTicket(userID uint) (*models.MatchTicket, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MatchTicket), args.Error(1)
+}
+
+func (m *MockLobbyRepository) Dequeue(userID uint) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FormMatch(match *models.PendingMatch, size int) error {
+	args := m.Called(match, size)
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FindMatch(matchID string) (*models.PendingMatch, error) {
+	args := m.Called(matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PendingMatch), args.Error(1)
+}
+
+func (m *MockLobbyRepository) AcceptMatch(match *models.PendingMatch, playerID uint, acceptedAt time.Time) error {
+	args := m.Called(match, playerID, acceptedAt)
+	if args.Error(0) == nil {
+		for i := range match.Tickets {
+			if match.Tickets[i].UserID == playerID {
+				match.Tickets[i].AcceptedAt = &acceptedAt
+			}
+		}
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) CreateMatchLobby(match *models.PendingMatch, lobby *models.Lobby) error {
+	args := m.Called(match, lobby)
+	if args.Error(0) == nil {
+		match.Status = models.PendingMatchAccepted
+		match.LobbyID = &lobby.LobbyID
+		match.Tickets = nil
+	}
+	return args.Error(0)
+}
+
+func (m *MockLobbyRepository) FailMatch(match *models.PendingMatch, status models.PendingMatchStatus, droppedPlayerIDs []uint) error {
+	args := m.Called(match, status, droppedPlayerIDs)
+	if args.Error(0) == nil {
+		match.Status = status
+		match.Tickets = nil
+	}
+	return args.Error(0)
+}
+
+type MockInviteRepository struct {
+	mock.Mock
+}
+
+func (m *MockInviteRepository) Create(invite *models.Invite) error {
+	args := m.Called(invite)
+	return args.Error(0)
+}
+
+func (m *MockInviteRepository) FindByID(id uint) (*models.Invite, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Invite), args.Error(1)
+}
+
+func (m *MockInviteRepository) FindPending(lobbyID string, inviteeID uint, now time.Time) (*models.Invite, error) {
+	args := m.Called(lobbyID, inviteeID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Invite), args.Error(1)
+}
+
+func (m *MockInviteRepository) ListPending(inviteeID uint, now time.Time) []*models.Invite {
+	args := m.Called(inviteeID, now)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]*models.Invite)
+}
+
+func (m *MockInviteRepository) UpdateStatus(invite *models.Invite, status models.InviteStatus) error {
+	args := m.Called(invite, status)
+	return args.Error(0)
+}
+
+// MockPartyRepository implements only the methods the lobby service calls: the embedded interface is nil.
+type MockPartyRepository struct {
+	mock.Mock
+	partyrepo.PartyRepository
+}
+
+func (m *MockPartyRepository) FindByMember(userID uint) (*models.Party, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Party), args.Error(1)
+}
+
+// MockFriendRepository implements only the methods the lobby service calls: the embedded interface is nil.
+type MockFriendRepository struct {
+	mock.Mock
+	friendrepo.FriendRepository
+}
+
+func (m *MockFriendRepository) HasBlocked(blockerIDs []uint, blockedID uint) (bool, error) {
+	args := m.Called(blockerIDs, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockInfractionRepository struct {
+	mock.Mock
+}
+
+func (m *MockInfractionRepository) Record(lobbyID string, kind models.InfractionKind, userIDs []uint, at time.Time) error {
+	args := m.Called(lobbyID, kind, userIDs, at)
+	return args.Error(0)
+}
+
+func (m *MockInfractionRepository) ListByUser(userID uint, since time.Time) ([]*models.Infraction, error) {
+	args := m.Called(userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Infraction), args.Error(1)
+}
+
+type MockLeaderboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardRepository) RecordGame(winnerIDs, loserIDs []uint, finishedAt time.Time) error {
+	args := m.Called(winnerIDs, loserIDs, finishedAt)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardRepository) ListEntries(season *models.Season, metric models.RankingMetric, offset, limit int) ([]leaderboardrepo.Entry, error) {
+	args := m.Called(season, metric, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]leaderboardrepo.Entry), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) FindEntry(season *models.Season, metric models.RankingMetric, userID uint) (*leaderboardrepo.Entry, error) {
+	args := m.Called(season, metric, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*leaderboardrepo.Entry), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) CreateSeason(season *models.Season) error {
+	args := m.Called(season)
+	return args.Error(0)
+}
+
+func (m *MockLeaderboardRepository) FindSeason(seasonID uint) (*models.Season, error) {
+	args := m.Called(seasonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) FindSeasonAt(at time.Time) (*models.Season, error) {
+	args := m.Called(at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
 }
 
-func (m *MockLobbyRepository) Create(lobby *models.Lobby) error {
-	args := m.Called(lobby)
-	return args.Error(0)
+func (m *MockLeaderboardRepository) LatestSeason() (*models.Season, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Season), args.Error(1)
 }
 
-func (m *MockLobbyRepository) FindByID(lobbyID string) (*models.Lobby, error) {
-	args := m.Called(lobbyID)
+func (m *MockLeaderboardRepository) ListSeasons() ([]*models.Season, error) {
+	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*models.Lobby), args.Error(1)
+	return args.Get(0).([]*models.Season), args.Error(1)
 }
 
-func (m *MockLobbyRepository) ListAvailable() []*models.Lobby {
-	args := m.Called()
+func (m *MockLeaderboardRepository) ListUnarchivedSeasons(endedBefore time.Time) ([]*models.Season, error) {
+	args := m.Called(endedBefore)
 	if args.Get(0) == nil {
-		return nil
+		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*models.Lobby)
+	return args.Get(0).([]*models.Season), args.Error(1)
 }
 
-func (m *MockLobbyRepository) AddPlayer(lobby *models.Lobby, player *models.User) error {
-	args := m.Called(lobby, player)
+func (m *MockLeaderboardRepository) ArchiveSeason(seasonID uint, archivedAt time.Time) error {
+	args := m.Called(seasonID, archivedAt)
 	return args.Error(0)
 }
 
-func (m *MockLobbyRepository) UpdateStatus(lobby *models.Lobby, status models.LobbyStatus) error {
-	args := m.Called(lobby, status)
+// MockScheduler keeps the registered handlers, so that tests can fire the timers on demand.
+type MockScheduler struct {
+	mock.Mock
+	handlers map[models.LobbyTimerKind]scheduler.Handler
+}
+
+func (m *MockScheduler) Handle(kind models.LobbyTimerKind, handler scheduler.Handler) {
+	m.handlers[kind] = handler
+}
+
+func (m *MockScheduler) Schedule(lobbyID string, kind models.LobbyTimerKind, firesAt time.Time) error {
+	args := m.Called(lobbyID, kind, firesAt)
 	return args.Error(0)
 }
 
-func (m *MockLobbyRepository) UpdateWinner(lobby *models.Lobby, winnerID uint) error {
-	args := m.Called(lobby, winnerID)
+func (m *MockScheduler) Cancel(lobbyID string, kind models.LobbyTimerKind) error {
+	args := m.Called(lobbyID, kind)
 	return args.Error(0)
 }
 
-func (m *MockLobbyRepository) Delete(lobbyID string) error {
-	args := m.Called(lobbyID)
+func (m *MockScheduler) Start(ctx context.Context) error {
+	args := m.Called(ctx)
 	return args.Error(0)
 }
 
 type LobbyServiceTestSuite struct {
 	suite.Suite
-	lobbyRepo *MockLobbyRepository
-	userRepo  *MockUserRepository
-	service   lobby.LobbyServiceServer
+	lobbyRepo       *MockLobbyRepository
+	userRepo        *MockUserRepository
+	inviteRepo      *MockInviteRepository
+	partyRepo       *MockPartyRepository
+	friendRepo      *MockFriendRepository
+	infractionRepo  *MockInfractionRepository
+	leaderboardRepo *MockLeaderboardRepository
+	hasher          password.PasswordHasher
+	scheduler       *MockScheduler
+	service         lobby.LobbyServiceServer
 }
 
 func (s *LobbyServiceTestSuite) SetupTest() {
 	s.lobbyRepo = new(MockLobbyRepository)
 	s.userRepo = new(MockUserRepository)
-	s.service = NewLobbyService(s.lobbyRepo, s.userRepo)
+	s.inviteRepo = new(MockInviteRepository)
+	s.partyRepo = new(MockPartyRepository)
+	s.friendRepo = new(MockFriendRepository)
+	s.infractionRepo = new(MockInfractionRepository)
+	s.expectNoInfractions()
+	s.leaderboardRepo = new(MockLeaderboardRepository)
+	// Cheap argon2id parameters, so that the suite stays fast.
+	s.hasher = password.NewPasswordHasher(password.NewArgon2idHasher(password.Argon2idParams{
+		Memory:      64,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	}))
+	s.useDisconnectPolicy(DisconnectForfeit)
+}
+
+// useDisconnectPolicy builds the service again, with the given disconnect policy.
+func (s *LobbyServiceTestSuite) useDisconnectPolicy(policy DisconnectPolicy) {
+	s.scheduler = &MockScheduler{handlers: make(map[models.LobbyTimerKind]scheduler.Handler)}
+	s.service = NewLobbyService(s.lobbyRepo, s.userRepo, s.inviteRepo, s.partyRepo, s.friendRepo, s.infractionRepo,
+		s.leaderboardRepo, s.hasher, s.scheduler, gamemode.DefaultCatalog(), Config{
+			Timeouts: Timeouts{
+				Waiting:      fixtureWaitingTimeout,
+				ReadyCheck:   fixtureReadyCheckTimeout,
+				Game:         fixtureGameDuration,
+				ResultReport: fixtureResultReportWindow,
+				Rematch:      fixtureRematchWindow,
+				KickBan:      fixtureKickBan,
+				Reconnect:    fixtureReconnectGrace,
+				MatchAccept:  fixtureMatchAcceptTimeout,
+			},
+			DisconnectPolicy: policy,
+			Penalties: Penalties{
+				Cooldown:        fixtureCooldown,
+				MaxCooldown:     fixtureMaxCooldown,
+				Decay:           fixtureInfractionDecay,
+				DeclineCooldown: fixtureDeclineCooldown,
+			},
+			QueueStatsWindow: fixtureQueueStatsWindow,
+			MaxSpectators:    fixtureMaxSpectators,
+		})
+}
+
+func (s *LobbyServiceTestSuite) expectScheduled(kind models.LobbyTimerKind) {
+	s.scheduler.On("Schedule", mock.AnythingOfType("string"), kind, mock.AnythingOfType("time.Time")).Return(nil)
+}
+
+// expectAdmitted lets every user join the lobbies of the test, as none of them has been kicked or blocked.
+func (s *LobbyServiceTestSuite) expectAdmitted() {
+	s.lobbyRepo.On("BannedUntil", mock.AnythingOfType("string"), mock.AnythingOfType("uint")).Return(nil, nil)
+	s.expectNotBlocked()
+}
+
+// expectNotBlocked lets every user of the test invite and join the others, as nobody blocked anybody.
+func (s *LobbyServiceTestSuite) expectNotBlocked() {
+	s.friendRepo.On("HasBlocked", mock.Anything, mock.AnythingOfType("uint")).Return(false, nil)
+}
+
+// expectNoParty lets every user of the test create and join the lobbies on their own, as none of them is in a party.
+func (s *LobbyServiceTestSuite) expectNoParty() {
+	s.partyRepo.On("FindByMember", mock.AnythingOfType("uint")).Return(nil, partyrepo.ErrPartyNotFound)
+}
+
+func (s *LobbyServiceTestSuite) expectCancelled(kind models.LobbyTimerKind) {
+	s.scheduler.On("Cancel", mock.AnythingOfType("string"), kind).Return(nil)
 }
 
 // Helper to assert on gRPC errors cleanly
@@ -115,10 +715,14 @@ func (s *LobbyServiceTestSuite) assertGrpcError(err error, code codes.Code, msgC
 }
 
 func (s *LobbyServiceTestSuite) TestCreateLobbySuccess() {
+	s.expectNoParty()
 	// Arrange
 	mockUser := &models.User{Username: "testuser"}
 	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser"}
 	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
 	s.lobbyRepo.On("Create", mock.AnythingOfType("*models.Lobby")).Return(nil)
 
 	// Act
@@ -129,10 +733,94 @@ func (s *LobbyServiceTestSuite) TestCreateLobbySuccess() {
 	s.Equal(fixtureLobbyName, resp.Name)
 	s.Len(resp.Players, 1)
 	s.Equal("testuser", resp.Players[0].Username)
+	s.Equal("testuser", resp.GetHostUsername())
+	s.Equal(string(models.LobbyVisibilityPublic), resp.Visibility)
+	s.Len(resp.JoinCode, joinCodeLength)
+	s.False(resp.HasPassword)
 	s.lobbyRepo.AssertExpectations(s.T())
 	s.userRepo.AssertExpectations(s.T())
 }
 
+func (s *LobbyServiceTestSuite) TestCreatePrivateLobbyWithPassword() {
+	s.expectNoParty()
+	mockUser := &models.User{Username: "testuser"}
+	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser", Visibility: "private", Password: "secret"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.MatchedBy(func(l *models.Lobby) bool {
+		return l.Visibility == models.LobbyVisibilityPrivate && s.hasher.Verify(l.PasswordHash, "secret") == nil
+	})).Return(nil)
+
+	resp, err := s.service.CreateLobby(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyVisibilityPrivate), resp.Visibility)
+	s.True(resp.HasPassword)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWithInvalidVisibility() {
+	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser", Visibility: "secret"}
+
+	_, err := s.service.CreateLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.InvalidArgument, "invalid lobby visibility")
+	s.lobbyRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyRetriesWhenJoinCodeIsTaken() {
+	s.expectNoParty()
+	originalGenerateJoinCode := generateJoinCode
+	defer func() { generateJoinCode = originalGenerateJoinCode }()
+	joinCodes := []string{"TAKEN2", "FREE23"}
+	generateJoinCode = func() (string, error) {
+		code := joinCodes[0]
+		joinCodes = joinCodes[1:]
+		return code, nil
+	}
+
+	mockUser := &models.User{Username: "testuser"}
+	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", "TAKEN2").Return(&models.Lobby{}, nil)
+	s.lobbyRepo.On("FindByJoinCode", "FREE23").Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.AnythingOfType("*models.Lobby")).Return(nil)
+
+	resp, err := s.service.CreateLobby(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal("FREE23", resp.JoinCode)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestNewJoinCodeUsesOnlyUnambiguousCharacters() {
+	code, err := newJoinCode()
+	s.NoError(err)
+	s.Len(code, joinCodeLength)
+	for _, c := range code {
+		s.Contains(joinCodeAlphabet, string(c))
+	}
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWhenJoinCodesAreExhausted() {
+	s.expectNoParty()
+	mockUser := &models.User{Username: "testuser"}
+	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(&models.Lobby{}, nil)
+
+	_, err := s.service.CreateLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.Internal, "failed to generate join code")
+	s.lobbyRepo.AssertNumberOfCalls(s.T(), "FindByJoinCode", maxJoinCodeAttempts)
+	s.lobbyRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
 func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWithEmptyName() {
 	req := &lobby.CreateLobbyRequest{Name: "   ", Username: "testuser"} // Whitespace name
 
@@ -154,10 +842,14 @@ func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWhenUserNotFound() {
 }
 
 func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWhenRepoCreateFails() {
+	s.expectNoParty()
 	mockUser := &models.User{Username: "testuser"}
 	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser"}
 	dbError := errors.New("database connection failed")
 	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
 	s.lobbyRepo.On("Create", mock.AnythingOfType("*models.Lobby")).Return(dbError)
 
 	_, err := s.service.CreateLobby(context.Background(), req)
@@ -166,20 +858,102 @@ func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWhenRepoCreateFails() {
 	s.lobbyRepo.AssertExpectations(s.T())
 }
 
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWhenTheCreatorIsInAnActiveLobby() {
+	s.expectNoParty()
+	mockUser := &models.User{Username: "testuser"}
+	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Name: "Old Lobby"}, nil)
+
+	_, err := s.service.CreateLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.FailedPrecondition, `already in the active lobby "Old Lobby"`)
+	s.scheduler.AssertNotCalled(s.T(), "Schedule", mock.Anything, mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "Create", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyFailsWhenTheCreatorJoinedALobbyMeanwhile() {
+	s.expectNoParty()
+	mockUser := &models.User{Username: "testuser"}
+	req := &lobby.CreateLobbyRequest{Name: fixtureLobbyName, Username: "testuser"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.AnythingOfType("*models.Lobby")).Return(lobbyrepo.ErrPlayerInLobby)
+
+	_, err := s.service.CreateLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already in an active lobby")
+}
+
+func (s *LobbyServiceTestSuite) TestGetMyCurrentLobbySuccess() {
+	mockUser := newUser(1, "testuser")
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(&models.Lobby{LobbyID: fixtureLobbyID, Name: fixtureLobbyName}, nil)
+
+	resp, err := s.service.GetMyCurrentLobby(context.Background(), &lobby.GetMyCurrentLobbyRequest{Username: "testuser"})
+
+	s.NoError(err)
+	s.Equal(fixtureLobbyID, resp.LobbyId)
+}
+
+func (s *LobbyServiceTestSuite) TestGetMyCurrentLobbyWhenTheUserIsInNoLobby() {
+	mockUser := newUser(1, "testuser")
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+
+	_, err := s.service.GetMyCurrentLobby(context.Background(), &lobby.GetMyCurrentLobbyRequest{Username: "testuser"})
+
+	s.assertGrpcError(err, codes.NotFound, "not in an active lobby")
+}
+
+func (s *LobbyServiceTestSuite) TestGetMyCurrentLobbyFailsOnRepositoryError() {
+	mockUser := newUser(1, "testuser")
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, errors.New("db error"))
+
+	_, err := s.service.GetMyCurrentLobby(context.Background(), &lobby.GetMyCurrentLobbyRequest{Username: "testuser"})
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenThePlayerIsInAnActiveLobby() {
+	s.expectNoParty()
+	mockPlayer := &models.User{Username: "player2"}
+	mockLobby := &models.Lobby{LobbyID: "1234", Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(&models.User{})}
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", "1234").Return(mockLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(lobbyrepo.ErrPlayerInLobby)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: "1234", Username: "player2"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already in an active lobby")
+}
+
 func (s *LobbyServiceTestSuite) TestJoinLobbySuccess() {
+	s.expectNoParty()
 	mockPlayer := &models.User{Username: "player2"}
-	mockLobby := &models.Lobby{LobbyID: "1234", Players: []models.User{{Username: "creator"}}}
+	mockLobby := &models.Lobby{LobbyID: "1234", Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(&models.User{Username: "creator"})}
 	req := &lobby.JoinLobbyRequest{LobbyId: "1234", Username: "player2"}
 
 	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
 	s.lobbyRepo.On("FindByID", "1234").Return(mockLobby, nil)
-	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer).Return(nil)
-	s.lobbyRepo.On("UpdateStatus", mockLobby, models.LobbyStatusInProgress).Return(nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(nil)
+	s.expectScheduled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("StartReadyCheck", mockLobby, mock.AnythingOfType("time.Time"), true).Return(nil)
+	s.expectCancelled(models.LobbyTimerWaiting)
 
 	resp, err := s.service.JoinLobby(context.Background(), req)
 
 	s.NoError(err)
 	s.Len(resp.Players, 2)
+	s.Equal(string(models.LobbyStatusReadyCheck), resp.Status)
+	s.NotNil(resp.ReadyCheckDeadline)
+	s.Contains(resp.Deadlines, string(models.LobbyTimerReadyCheck))
+	s.scheduler.AssertExpectations(s.T())
 	s.lobbyRepo.AssertExpectations(s.T())
 }
 
@@ -204,19 +978,22 @@ func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenLobbyNotFound() {
 	_, err := s.service.JoinLobby(context.Background(), req)
 
 	s.assertGrpcError(err, codes.Internal, "Lobby not found")
-	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func (s *LobbyServiceTestSuite) TestJoinLobbyFailsOnUpdateStatus() {
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsOnStartReadyCheck() {
+	s.expectNoParty()
 	mockPlayer := &models.User{Username: "player2"}
-	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Players: []models.User{{Username: "creator"}}}
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(&models.User{Username: "creator"})}
 	req := &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2"}
 	dbError := errors.New("status update failed")
 
 	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
 	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
-	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer).Return(nil) // This call succeeds
-	s.lobbyRepo.On("UpdateStatus", mockLobby, models.LobbyStatusInProgress).Return(dbError)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(nil) // This call succeeds
+	s.expectScheduled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("StartReadyCheck", mockLobby, mock.AnythingOfType("time.Time"), true).Return(dbError)
 
 	_, err := s.service.JoinLobby(context.Background(), req)
 
@@ -224,9 +1001,33 @@ func (s *LobbyServiceTestSuite) TestJoinLobbyFailsOnUpdateStatus() {
 	s.lobbyRepo.AssertExpectations(s.T()) // Verify all expected calls were made
 }
 
+func (s *LobbyServiceTestSuite) TestJoinLobbyDoesNotStartTheReadyCheckOfALobbyThatChangedMeanwhile() {
+	s.expectNoParty()
+	creator, player := newUser(1, "creator"), newUser(2, "player2")
+	waitingLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(creator)}
+	// The creator left the lobby between the join and the start of the ready check.
+	current := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(player)}
+	s.userRepo.On("FindByUsername", "player2").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil).Once()
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", waitingLobby, player, 2).Return(nil)
+	s.expectScheduled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("StartReadyCheck", waitingLobby, mock.AnythingOfType("time.Time"), true).Return(lobbyrepo.ErrLobbyConflict)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(current, nil).Once()
+
+	resp, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2"})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusWaiting), resp.Status)
+	s.Require().Len(resp.Players, 1)
+	s.Equal("player2", resp.Players[0].Username)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", fixtureLobbyID, models.LobbyTimerWaiting)
+}
+
 func (s *LobbyServiceTestSuite) TestJoinLobbyWhenLobbyIsFull() {
 	mockPlayer := &models.User{Username: "player3"}
-	mockFullLobby := &models.Lobby{Players: []models.User{{}, {}}} // Lobby with 2 players
+	mockFullLobby := &models.Lobby{MaxPlayers: 2, Players: seated(&models.User{}, &models.User{})} // Lobby with 2 players
 	req := &lobby.JoinLobbyRequest{LobbyId: "full-lobby", Username: "player3"}
 
 	s.userRepo.On("FindByUsername", "player3").Return(mockPlayer, nil)
@@ -235,23 +1036,172 @@ func (s *LobbyServiceTestSuite) TestJoinLobbyWhenLobbyIsFull() {
 	_, err := s.service.JoinLobby(context.Background(), req)
 
 	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is full")
-	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *LobbyServiceTestSuite) TestJoinLobbyWhenAddPlayerFails() {
+	s.expectNoParty()
 	mockPlayer := &models.User{Username: "player2"}
-	mockLobby := &models.Lobby{LobbyID: "1234", Players: []models.User{{}}}
+	mockLobby := &models.Lobby{LobbyID: "1234", Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(&models.User{})}
 	req := &lobby.JoinLobbyRequest{LobbyId: "1234", Username: "player2"}
 	dbErr := errors.New("db error")
 
 	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
 	s.lobbyRepo.On("FindByID", "1234").Return(mockLobby, nil)
-	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer).Return(dbErr)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(dbErr)
 
 	_, err := s.service.JoinLobby(context.Background(), req)
 
 	s.assertGrpcError(err, codes.Internal, "db error")
-	s.lobbyRepo.AssertNotCalled(s.T(), "UpdateStatus", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "StartReadyCheck", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyReportsAConflictToTheLoserOfARace() {
+	s.expectNoParty()
+	mockPlayer := &models.User{Username: "player2"}
+	mockLobby := &models.Lobby{LobbyID: "1234", Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(&models.User{})}
+	req := &lobby.JoinLobbyRequest{LobbyId: "1234", Username: "player2"}
+
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", "1234").Return(mockLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(lobbyrepo.ErrLobbyConflict)
+
+	_, err := s.service.JoinLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.Aborted, "please retry")
+	s.lobbyRepo.AssertNotCalled(s.T(), "StartReadyCheck", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenTheLobbyFilledUpMeanwhile() {
+	s.expectNoParty()
+	mockPlayer := &models.User{Username: "player2"}
+	mockLobby := &models.Lobby{LobbyID: "1234", Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(&models.User{})}
+	req := &lobby.JoinLobbyRequest{LobbyId: "1234", Username: "player2"}
+
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", "1234").Return(mockLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(lobbyrepo.ErrLobbyFull)
+
+	_, err := s.service.JoinLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is full")
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenLobbyIsPrivate() {
+	mockPlayer := &models.User{Username: "player2"}
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Visibility: models.LobbyVisibilityPrivate, Players: seated(&models.User{})}
+	req := &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2"}
+
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+
+	_, err := s.service.JoinLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.PermissionDenied, "join code")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWithWrongPassword() {
+	hash, err := s.hasher.Hash("secret")
+	s.Require().NoError(err)
+	mockPlayer := &models.User{Username: "player2"}
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, PasswordHash: hash, Players: seated(&models.User{})}
+	req := &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2", Password: "wrong"}
+
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+
+	_, err = s.service.JoinLobby(context.Background(), req)
+
+	s.assertGrpcError(err, codes.PermissionDenied, "invalid lobby password")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyByCodeSuccess() {
+	s.expectNoParty()
+	hash, err := s.hasher.Hash("secret")
+	s.Require().NoError(err)
+	mockPlayer := &models.User{Username: "player2"}
+	mockLobby := &models.Lobby{
+		LobbyID:      fixtureLobbyID,
+		Status:       models.LobbyStatusWaiting,
+		MaxPlayers:   2,
+		Visibility:   models.LobbyVisibilityPrivate,
+		PasswordHash: hash,
+		Players:      seated(&models.User{Username: "creator"}),
+	}
+	req := &lobby.JoinLobbyByCodeRequest{JoinCode: " abc234 ", Username: "player2", Password: "secret"}
+
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByJoinCode", "ABC234").Return(mockLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddPlayer", mockLobby, mockPlayer, 2).Return(nil)
+	s.expectScheduled(models.LobbyTimerReadyCheck)
+	s.lobbyRepo.On("StartReadyCheck", mockLobby, mock.AnythingOfType("time.Time"), true).Return(nil)
+	s.expectCancelled(models.LobbyTimerWaiting)
+
+	resp, err := s.service.JoinLobbyByCode(context.Background(), req)
+
+	s.NoError(err)
+	s.Equal(fixtureLobbyID, resp.LobbyId)
+	s.Len(resp.Players, 2)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyByCodeFailsWithEmptyCode() {
+	req := &lobby.JoinLobbyByCodeRequest{JoinCode: "  ", Username: "player2"}
+
+	_, err := s.service.JoinLobbyByCode(context.Background(), req)
+
+	s.assertGrpcError(err, codes.InvalidArgument, "join code cannot be empty")
+	s.userRepo.AssertNotCalled(s.T(), "FindByUsername", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyByCodeFailsWhenUserNotFound() {
+	req := &lobby.JoinLobbyByCodeRequest{JoinCode: "ABC234", Username: "unknownUser"}
+	s.userRepo.On("FindByUsername", "unknownUser").Return(nil, usrrepo.ErrUserNotFound)
+
+	_, err := s.service.JoinLobbyByCode(context.Background(), req)
+
+	s.assertGrpcError(err, codes.Internal, "Invalid player")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FindByJoinCode", mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyByCodeFailsWhenCodeIsUnknown() {
+	mockPlayer := &models.User{Username: "player2"}
+	req := &lobby.JoinLobbyByCodeRequest{JoinCode: "ABC234", Username: "player2"}
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByJoinCode", "ABC234").Return(nil, lobbyrepo.ErrLobbyNotFound)
+
+	_, err := s.service.JoinLobbyByCode(context.Background(), req)
+
+	s.assertGrpcError(err, codes.NotFound, "no lobby matches the join code")
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyByCodeFailsOnRepositoryError() {
+	mockPlayer := &models.User{Username: "player2"}
+	req := &lobby.JoinLobbyByCodeRequest{JoinCode: "ABC234", Username: "player2"}
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByJoinCode", "ABC234").Return(nil, errors.New("db error"))
+
+	_, err := s.service.JoinLobbyByCode(context.Background(), req)
+
+	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
+}
+
+func (s *LobbyServiceTestSuite) TestJoinLobbyFailsWhenLobbyIsCancelled() {
+	mockPlayer := &models.User{Username: "player2"}
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusCancelled, MaxPlayers: 2}
+	s.userRepo.On("FindByUsername", "player2").Return(mockPlayer, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+
+	_, err := s.service.JoinLobby(context.Background(), &lobby.JoinLobbyRequest{LobbyId: fixtureLobbyID, Username: "player2"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not waiting for players")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddPlayer", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *LobbyServiceTestSuite) TestFinishGameSuccess() {
@@ -259,13 +1209,17 @@ func (s *LobbyServiceTestSuite) TestFinishGameSuccess() {
 	mockPlayer1.ID = 1
 	mockLobby := &models.Lobby{
 		LobbyID: fixtureLobbyID,
-		Players: []models.User{mockPlayer1}, // Lobby with one player
+		Status:  models.LobbyStatusInProgress,
+		Players: seated(&mockPlayer1), // Lobby with one player
 	}
-	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID}
+	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "player1"}
 
 	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
-	s.lobbyRepo.On("UpdateWinner", mockLobby, mockPlayer1.ID).Return(nil)
-	s.lobbyRepo.On("UpdateStatus", mockLobby, models.LobbyStatusFinished).Return(nil)
+	s.lobbyRepo.On("FinishWithWinner", mockLobby, mockPlayer1.ID).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{mockPlayer1.ID}, []uint(nil), mock.AnythingOfType("time.Time")).Return(nil)
+	s.expectCancelled(models.LobbyTimerGameEnd)
+	s.expectCancelled(models.LobbyTimerResultReport)
+	s.expectCancelled(models.LobbyTimerReconnect)
 
 	resp, err := s.service.FinishGame(context.Background(), req)
 
@@ -273,7 +1227,55 @@ func (s *LobbyServiceTestSuite) TestFinishGameSuccess() {
 	s.Equal(string(models.LobbyStatusFinished), resp.Status)
 	s.NotNil(resp.WinnerId)
 	s.Equal(uint32(mockPlayer1.ID), *resp.WinnerId)
+	s.Empty(resp.Deadlines)
 	s.lobbyRepo.AssertExpectations(s.T())
+	s.leaderboardRepo.AssertExpectations(s.T())
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestFinishGameSucceedsWhenTheLeaderboardsFail() {
+	mockPlayer1 := newUser(1, "player1")
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: seated(mockPlayer1)}
+
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+	s.lobbyRepo.On("FinishWithWinner", mockLobby, mockPlayer1.ID).Return(nil)
+	s.leaderboardRepo.On("RecordGame", []uint{mockPlayer1.ID}, []uint(nil), mock.AnythingOfType("time.Time")).Return(errors.New("db error"))
+	s.expectCancelled(models.LobbyTimerGameEnd)
+	s.expectCancelled(models.LobbyTimerResultReport)
+	s.expectCancelled(models.LobbyTimerReconnect)
+
+	resp, err := s.service.FinishGame(context.Background(), &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "player1"})
+
+	s.NoError(err)
+	s.Equal(string(models.LobbyStatusFinished), resp.Status)
+}
+
+func (s *LobbyServiceTestSuite) TestFinishGameFailsWhenLobbyIsNotInProgress() {
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting}
+	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "player1"}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+
+	_, err := s.service.FinishGame(context.Background(), req)
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is not in progress")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestFinishGameFailsForASpectator() {
+	player, spectator := newUser(1, "player1"), newUser(2, "spectator")
+	mockLobby := &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Status:     models.LobbyStatusInProgress,
+		Players:    seated(player),
+		Spectators: []models.LobbySpectator{{LobbyID: fixtureLobbyID, UserID: spectator.ID, User: *spectator}},
+	}
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
+
+	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "spectator"}
+	_, err := s.service.FinishGame(context.Background(), req)
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the lobby players")
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
 }
 
 func (s *LobbyServiceTestSuite) TestFinishGameFailsWhenLobbyNotFound() {
@@ -283,40 +1285,39 @@ func (s *LobbyServiceTestSuite) TestFinishGameFailsWhenLobbyNotFound() {
 	_, err := s.service.FinishGame(context.Background(), req)
 
 	s.ErrorIs(err, lobbyrepo.ErrLobbyNotFound)
-	s.lobbyRepo.AssertNotCalled(s.T(), "UpdateWinner", mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
 }
 
-func (s *LobbyServiceTestSuite) TestFinishGameFailsOnUpdateWinner() {
+func (s *LobbyServiceTestSuite) TestFinishGameFailsOnFinishWithWinner() {
 	mockPlayer1 := models.User{Username: "player1"}
 	mockPlayer1.ID = 1
-	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Players: []models.User{mockPlayer1}}
-	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID}
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: seated(&mockPlayer1)}
+	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "player1"}
 	dbError := errors.New("db write failed")
 
 	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
-	s.lobbyRepo.On("UpdateWinner", mockLobby, mockPlayer1.ID).Return(dbError)
+	s.lobbyRepo.On("FinishWithWinner", mockLobby, mockPlayer1.ID).Return(dbError)
 
 	_, err := s.service.FinishGame(context.Background(), req)
 
 	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
-	s.lobbyRepo.AssertNotCalled(s.T(), "UpdateStatus", mock.Anything, mock.Anything)
+	s.leaderboardRepo.AssertNotCalled(s.T(), "RecordGame", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func (s *LobbyServiceTestSuite) TestFinishGameFailsOnUpdateStatus() {
+func (s *LobbyServiceTestSuite) TestFinishGameDoesNotRecordAGameFinishedConcurrently() {
 	mockPlayer1 := models.User{Username: "player1"}
 	mockPlayer1.ID = 1
-	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Players: []models.User{mockPlayer1}}
-	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID}
-	dbError := errors.New("db status update failed")
+	mockLobby := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusInProgress, Players: seated(&mockPlayer1)}
+	req := &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "player1"}
 
 	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
-	s.lobbyRepo.On("UpdateWinner", mockLobby, mockPlayer1.ID).Return(nil)
-	s.lobbyRepo.On("UpdateStatus", mockLobby, models.LobbyStatusFinished).Return(dbError)
+	s.lobbyRepo.On("FinishWithWinner", mockLobby, mockPlayer1.ID).Return(lobbyrepo.ErrLobbyNotInProgress)
 
 	_, err := s.service.FinishGame(context.Background(), req)
 
-	s.assertGrpcError(err, codes.Internal, "Lobby DB error")
-	s.lobbyRepo.AssertExpectations(s.T())
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is not in progress")
+	s.leaderboardRepo.AssertNotCalled(s.T(), "RecordGame", mock.Anything, mock.Anything, mock.Anything)
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
 }
 
 func (s *LobbyServiceTestSuite) TestGetLobbySuccess() {
@@ -324,7 +1325,7 @@ func (s *LobbyServiceTestSuite) TestGetLobbySuccess() {
 	mockLobby := &models.Lobby{
 		LobbyID: fixtureLobbyID,
 		Name:    fixtureLobbyName,
-		Players: []models.User{{Username: "player1"}},
+		Players: seated(&models.User{Username: "player1"}),
 	}
 	req := &lobby.GetLobbyRequest{LobbyId: fixtureLobbyID}
 	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(mockLobby, nil)
@@ -338,6 +1339,50 @@ func (s *LobbyServiceTestSuite) TestGetLobbySuccess() {
 	s.lobbyRepo.AssertExpectations(s.T())
 }
 
+// privateLobbyFixture returns a private lobby hosted by its only player.
+func privateLobbyFixture(host *models.User) *models.Lobby {
+	joinCode := "ABC234"
+	return &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Name:       fixtureLobbyName,
+		Visibility: models.LobbyVisibilityPrivate,
+		JoinCode:   &joinCode,
+		HostID:     &host.ID,
+		Players:    seated(host),
+	}
+}
+
+func (s *LobbyServiceTestSuite) TestGetLobbyShowsTheJoinCodeToItsPlayers() {
+	host := newUser(1, "host")
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(privateLobbyFixture(host), nil)
+	s.userRepo.On("FindByUsername", "host").Return(host, nil)
+
+	resp, err := s.service.GetLobby(callerContext("host"), &lobby.GetLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Equal("ABC234", resp.JoinCode)
+}
+
+func (s *LobbyServiceTestSuite) TestGetLobbyHidesTheJoinCodeFromOtherUsers() {
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(privateLobbyFixture(newUser(1, "host")), nil)
+	s.userRepo.On("FindByUsername", "stranger").Return(newUser(2, "stranger"), nil)
+
+	resp, err := s.service.GetLobby(callerContext("stranger"), &lobby.GetLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Empty(resp.JoinCode)
+}
+
+func (s *LobbyServiceTestSuite) TestGetLobbyHidesTheJoinCodeWithoutAnAuthenticatedCaller() {
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(privateLobbyFixture(newUser(1, "host")), nil)
+
+	resp, err := s.service.GetLobby(context.Background(), &lobby.GetLobbyRequest{LobbyId: fixtureLobbyID})
+
+	s.NoError(err)
+	s.Empty(resp.JoinCode)
+	s.userRepo.AssertNotCalled(s.T(), "FindByUsername", mock.Anything)
+}
+
 func (s *LobbyServiceTestSuite) TestGetLobbyFailsWhenNotFound() {
 	req := &lobby.GetLobbyRequest{LobbyId: "non-existent"}
 	s.lobbyRepo.On("FindByID", "non-existent").Return(nil, lobbyrepo.ErrLobbyNotFound)
@@ -352,7 +1397,7 @@ func (s *LobbyServiceTestSuite) TestListAvailableLobbiesSuccess() {
 		{LobbyID: "lobby-1", Name: "First Lobby"},
 		{LobbyID: "lobby-2", Name: "Second Lobby"},
 	}
-	s.lobbyRepo.On("ListAvailable").Return(mockLobbies)
+	s.lobbyRepo.On("ListAvailable", mock.Anything).Return(mockLobbies, nil)
 	resp, err := s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{})
 
 	s.NoError(err)
@@ -363,7 +1408,7 @@ func (s *LobbyServiceTestSuite) TestListAvailableLobbiesSuccess() {
 
 func (s *LobbyServiceTestSuite) TestListAvailableLobbiesSuccessWhenEmpty() {
 	mockLobbies := []*models.Lobby{} // Return an empty slice
-	s.lobbyRepo.On("ListAvailable").Return(mockLobbies)
+	s.lobbyRepo.On("ListAvailable", mock.Anything).Return(mockLobbies, nil)
 
 	resp, err := s.service.ListAvailableLobbies(context.Background(), &lobby.ListAvailableLobbiesRequest{})
 
@@ -0,0 +1,65 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SpectateLobby lets the user watch an active lobby. Spectators see the lobby like its players do, but they do not
+// take a seat and can not report the result of the game. Private lobbies can not be watched, and the password of
+// the lobby is required like for joining it.
+func (s *LobbyService) SpectateLobby(ctx context.Context, req *lobby.SpectateLobbyRequest) (*lobby.Lobby, error) {
+	spectator, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid spectator: %v", err)
+	}
+
+	watchedLobby, err := s.lobbyRepo.FindByID(req.GetLobbyId())
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if watchedLobby.Visibility == models.LobbyVisibilityPrivate {
+		return nil, status.Errorf(codes.PermissionDenied, "private lobbies can not be watched")
+	}
+	if !slices.Contains(models.ActiveLobbyStatuses, watchedLobby.Status) {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby is over")
+	}
+	if err := s.checkPassword(watchedLobby, req.GetPassword()); err != nil {
+		return nil, err
+	}
+	if err := s.checkAdmission(watchedLobby, spectator); err != nil {
+		return nil, err
+	}
+
+	err = s.lobbyRepo.AddSpectator(watchedLobby, spectator, s.cfg.MaxSpectators)
+	switch {
+	case errors.Is(err, lobbyrepo.ErrSpectatorsFull):
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby has no room for more spectators")
+	case errors.Is(err, lobbyrepo.ErrPlayerInLobby):
+		return nil, status.Errorf(codes.FailedPrecondition, "you are already playing in this lobby")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Can not add the spectator: %v", err)
+	}
+	return toProtoLobby(watchedLobby), nil
+}
+
+// hasPlayerNamed reports whether the user is one of the current players of the lobby.
+func hasPlayerNamed(l *models.Lobby, username string) bool {
+	for _, player := range l.Players {
+		if player.User.Username == username {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package lobby
+
+import (
+	"context"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+func (s *LobbyServiceTestSuite) spectatedLobbyFixture(status models.LobbyStatus) *models.Lobby {
+	return &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Name:       fixtureLobbyName,
+		Status:     status,
+		Visibility: models.LobbyVisibilityPublic,
+		MaxPlayers: 2,
+		Players:    seated(newUser(1, "player1"), newUser(2, "player2")),
+	}
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbySuccess() {
+	spectator := newUser(3, "spectator")
+	watchedLobby := s.spectatedLobbyFixture(models.LobbyStatusInProgress)
+	s.userRepo.On("FindByUsername", "spectator").Return(spectator, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(watchedLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddSpectator", watchedLobby, spectator, fixtureMaxSpectators).Return(nil)
+
+	resp, err := s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "spectator",
+	})
+
+	s.NoError(err)
+	s.Len(resp.Players, 2)
+	s.Require().Len(resp.Spectators, 1)
+	s.Equal("spectator", resp.Spectators[0].Username)
+	s.Equal(uint32(spectator.ID), resp.Spectators[0].Id)
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbyFailsWhenThereIsNoRoomLeft() {
+	spectator := newUser(3, "spectator")
+	watchedLobby := s.spectatedLobbyFixture(models.LobbyStatusWaiting)
+	s.userRepo.On("FindByUsername", "spectator").Return(spectator, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(watchedLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddSpectator", watchedLobby, spectator, fixtureMaxSpectators).Return(lobbyrepo.ErrSpectatorsFull)
+
+	_, err := s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "spectator",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "no room for more spectators")
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbyFailsForAPlayerOfTheLobby() {
+	player := newUser(1, "player1")
+	watchedLobby := s.spectatedLobbyFixture(models.LobbyStatusWaiting)
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(watchedLobby, nil)
+	s.expectAdmitted()
+	s.lobbyRepo.On("AddSpectator", watchedLobby, player, fixtureMaxSpectators).Return(lobbyrepo.ErrPlayerInLobby)
+
+	_, err := s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "player1",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already playing")
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbyFailsForAFinishedLobby() {
+	s.userRepo.On("FindByUsername", "spectator").Return(newUser(3, "spectator"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(s.spectatedLobbyFixture(models.LobbyStatusFinished), nil)
+
+	_, err := s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "spectator",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "lobby is over")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddSpectator", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbyFailsForAPrivateLobby() {
+	watchedLobby := s.spectatedLobbyFixture(models.LobbyStatusInProgress)
+	watchedLobby.Visibility = models.LobbyVisibilityPrivate
+	s.userRepo.On("FindByUsername", "spectator").Return(newUser(3, "spectator"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(watchedLobby, nil)
+
+	_, err := s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "spectator",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "private lobbies")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddSpectator", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbyFailsWithAWrongPassword() {
+	watchedLobby := s.spectatedLobbyFixture(models.LobbyStatusInProgress)
+	hash, err := s.hasher.Hash("secret")
+	s.Require().NoError(err)
+	watchedLobby.PasswordHash = hash
+	s.userRepo.On("FindByUsername", "spectator").Return(newUser(3, "spectator"), nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(watchedLobby, nil)
+
+	_, err = s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "spectator",
+		Password: "wrong",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "invalid lobby password")
+	s.lobbyRepo.AssertNotCalled(s.T(), "AddSpectator", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSpectateLobbyFailsWhenTheLobbyDoesNotExist() {
+	s.userRepo.On("FindByUsername", "spectator").Return(newUser(3, "spectator"), nil)
+	s.lobbyRepo.On("FindByID", "missing").Return(nil, lobbyrepo.ErrLobbyNotFound)
+
+	_, err := s.service.SpectateLobby(context.Background(), &lobby.SpectateLobbyRequest{
+		LobbyId:  "missing",
+		Username: "spectator",
+	})
+
+	s.assertGrpcError(err, codes.NotFound, "lobby not found")
+}
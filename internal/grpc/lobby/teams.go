@@ -0,0 +1,169 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"slices"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gamemode"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SwitchTeam moves the caller to another team of their lobby, as long as the team has room for them.
+func (s *LobbyService) SwitchTeam(ctx context.Context, req *lobby.SwitchTeamRequest) (*lobby.Lobby, error) {
+	player, waitingLobby, err := s.waitingLobbyOf(req.GetUsername(), req.GetLobbyId())
+	if err != nil {
+		return nil, err
+	}
+
+	if waitingLobby.Teams == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "lobby has no teams")
+	}
+	team := int(req.GetTeam())
+	if team < 1 || team > waitingLobby.Teams {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid team: it must be between 1 and %d", waitingLobby.Teams)
+	}
+
+	teamSize := waitingLobby.MaxPlayers / waitingLobby.Teams
+	err = s.lobbyRepo.SwitchTeam(waitingLobby, player.ID, team, teamSize)
+	if err := toTeamError(err); err != nil {
+		return nil, err
+	}
+	return toMemberProtoLobby(waitingLobby, player.ID), nil
+}
+
+// SwapSeat moves the caller to another seat of their lobby. The player sitting there, if any, trades places with the
+// caller.
+func (s *LobbyService) SwapSeat(ctx context.Context, req *lobby.SwapSeatRequest) (*lobby.Lobby, error) {
+	player, waitingLobby, err := s.waitingLobbyOf(req.GetUsername(), req.GetLobbyId())
+	if err != nil {
+		return nil, err
+	}
+
+	seat := int(req.GetSeat())
+	if seat < 0 || seat >= waitingLobby.MaxPlayers {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid seat: it must be between 0 and %d", waitingLobby.MaxPlayers-1)
+	}
+
+	err = s.lobbyRepo.SwapSeat(waitingLobby, player.ID, seat)
+	if err := toTeamError(err); err != nil {
+		return nil, err
+	}
+	return toMemberProtoLobby(waitingLobby, player.ID), nil
+}
+
+// waitingLobbyOf loads the user and the lobby, checking that the lobby is waiting and that the user is one of its
+// players.
+func (s *LobbyService) waitingLobbyOf(username, lobbyID string) (*models.User, *models.Lobby, error) {
+	player, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Invalid player: %v", err)
+	}
+
+	waitingLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "lobby not found")
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	if waitingLobby.Status != models.LobbyStatusWaiting {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "lobby is not waiting for players")
+	}
+	if !hasPlayer(waitingLobby, player.ID) {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "only the lobby players can change their place")
+	}
+	return player, waitingLobby, nil
+}
+
+// toTeamError translates the errors of the repository when a player changes their place in the lobby.
+func toTeamError(err error) error {
+	switch {
+	case errors.Is(err, lobbyrepo.ErrLobbyConflict):
+		return status.Errorf(codes.Aborted, "the lobby changed at the same time, please retry")
+	case errors.Is(err, lobbyrepo.ErrTeamFull):
+		return status.Errorf(codes.FailedPrecondition, "team is full")
+	case errors.Is(err, lobbyrepo.ErrLobbyNotWaiting):
+		return status.Errorf(codes.FailedPrecondition, "lobby is not waiting for players")
+	case errors.Is(err, lobbyrepo.ErrPlayerNotInLobby):
+		return status.Errorf(codes.PermissionDenied, "only the lobby players can change their place")
+	case err != nil:
+		return status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+	return nil
+}
+
+// balanceTeams splits the players of the lobby into teams of close total rating, when the lobby asks for it. The
+// strongest players are placed first, each one in the team with room that has the lowest total so far.
+func (s *LobbyService) balanceTeams(l *models.Lobby) {
+	if l.Teams == 0 || l.Settings[gamemode.AutoBalance] != "ON" {
+		return
+	}
+
+	type ratedPlayer struct {
+		userID uint
+		seat   int
+		rating int
+	}
+	players := make([]ratedPlayer, len(l.Players))
+	for i, player := range l.Players {
+		players[i] = ratedPlayer{userID: player.UserID, seat: player.Seat, rating: models.InitialRating}
+		entry, err := s.leaderboardRepo.FindEntry(nil, models.RankingByRating, player.UserID)
+		if err != nil && !errors.Is(err, leaderboardrepo.ErrStandingNotFound) {
+			log.Printf("Failed to read the rating of player %d to balance lobby %s: %v", player.UserID, l.LobbyID, err)
+			return
+		}
+		if err == nil {
+			players[i].rating = entry.Rating
+		}
+	}
+	slices.SortFunc(players, func(a, b ratedPlayer) int {
+		if a.rating != b.rating {
+			return b.rating - a.rating
+		}
+		return a.seat - b.seat
+	})
+
+	teamSize := l.MaxPlayers / l.Teams
+	sizes := make([]int, l.Teams+1)
+	totals := make([]int, l.Teams+1)
+	teams := make(map[uint]int, len(players))
+	for _, player := range players {
+		best := 0
+		for team := 1; team <= l.Teams; team++ {
+			if sizes[team] < teamSize && (best == 0 || totals[team] < totals[best]) {
+				best = team
+			}
+		}
+		sizes[best]++
+		totals[best] += player.rating
+		teams[player.userID] = best
+	}
+
+	// Unbalanced teams do not prevent the game from starting.
+	if err := s.lobbyRepo.AssignTeams(l, teams); err != nil {
+		log.Printf("Failed to balance the teams of lobby %s: %v", l.LobbyID, err)
+	}
+}
+
+// winningTeam picks the winning team among the teams that have players.
+func winningTeam(players []models.LobbyPlayer) int {
+	var teams []int
+	for _, player := range players {
+		if player.Team != nil && !slices.Contains(teams, *player.Team) {
+			teams = append(teams, *player.Team)
+		}
+	}
+	if len(teams) == 0 {
+		return 1
+	}
+	return teams[rand.Intn(len(teams))]
+}
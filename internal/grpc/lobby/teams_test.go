@@ -0,0 +1,269 @@
+package lobby
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gamemode"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+)
+
+// teamLobbyFixture returns a lobby of two teams of two, with the users seated in order and alternating teams.
+func teamLobbyFixture(status models.LobbyStatus, users ...*models.User) *models.Lobby {
+	players := seated(users...)
+	for i := range players {
+		team := i%2 + 1
+		players[i].Team = &team
+	}
+	return &models.Lobby{
+		LobbyID:    fixtureLobbyID,
+		Status:     status,
+		GameMode:   "TEAM_DEATHMATCH",
+		MaxPlayers: 4,
+		Teams:      2,
+		Settings:   map[string]string{gamemode.AutoBalance: "ON"},
+		Players:    players,
+	}
+}
+
+func (s *LobbyServiceTestSuite) TestCreateLobbyWithTeamsPutsTheCreatorInTheFirstTeam() {
+	s.expectNoParty()
+	mockUser := &models.User{Username: "testuser"}
+	s.userRepo.On("FindByUsername", "testuser").Return(mockUser, nil)
+	s.lobbyRepo.On("FindActiveByPlayer", mockUser.ID).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.lobbyRepo.On("FindByJoinCode", mock.AnythingOfType("string")).Return(nil, lobbyrepo.ErrLobbyNotFound)
+	s.expectScheduled(models.LobbyTimerWaiting)
+	s.lobbyRepo.On("Create", mock.MatchedBy(func(l *models.Lobby) bool {
+		return l.Teams == 2 && *l.Players[0].Team == 1
+	})).Return(nil)
+
+	resp, err := s.service.CreateLobby(context.Background(), &lobby.CreateLobbyRequest{
+		Name:     fixtureLobbyName,
+		Username: "testuser",
+		GameMode: "TEAM_DEATHMATCH",
+	})
+
+	s.NoError(err)
+	s.Equal(int32(2), resp.Teams)
+	s.Equal(int32(1), resp.Players[0].Team)
+	s.Equal("ON", resp.Settings[gamemode.AutoBalance])
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestSwitchTeamSuccess() {
+	player := newUser(1, "player1")
+	waitingLobby := teamLobbyFixture(models.LobbyStatusWaiting, player, newUser(2, "player2"))
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.lobbyRepo.On("SwitchTeam", waitingLobby, player.ID, 2, 2).Return(nil)
+
+	resp, err := s.service.SwitchTeam(context.Background(), &lobby.SwitchTeamRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "player1",
+		Team:     2,
+	})
+
+	s.NoError(err)
+	s.Equal(int32(2), resp.Players[0].Team)
+}
+
+func (s *LobbyServiceTestSuite) TestSwitchTeamFailsWhenItCanNotChangeTeam() {
+	player := newUser(1, "player1")
+	withoutTeams := &models.Lobby{LobbyID: fixtureLobbyID, Status: models.LobbyStatusWaiting, MaxPlayers: 2, Players: seated(player)}
+	testCases := []struct {
+		name    string
+		lobby   *models.Lobby
+		team    int32
+		code    codes.Code
+		message string
+	}{
+		{"lobby without teams", withoutTeams, 1, codes.FailedPrecondition, "lobby has no teams"},
+		{"unknown team", teamLobbyFixture(models.LobbyStatusWaiting, player), 3, codes.InvalidArgument, "invalid team: it must be between 1 and 2"},
+		{"game started", teamLobbyFixture(models.LobbyStatusInProgress, player), 2, codes.FailedPrecondition, "lobby is not waiting for players"},
+		{"not a player", teamLobbyFixture(models.LobbyStatusWaiting, newUser(2, "player2")), 2, codes.PermissionDenied, "only the lobby players"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.userRepo.On("FindByUsername", "player1").Return(player, nil).Once()
+			s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(tc.lobby, nil).Once()
+
+			_, err := s.service.SwitchTeam(context.Background(), &lobby.SwitchTeamRequest{
+				LobbyId:  fixtureLobbyID,
+				Username: "player1",
+				Team:     tc.team,
+			})
+
+			s.assertGrpcError(err, tc.code, tc.message)
+		})
+	}
+	s.lobbyRepo.AssertNotCalled(s.T(), "SwitchTeam", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSwitchTeamFailsWhenTheTeamIsFull() {
+	player := newUser(1, "player1")
+	waitingLobby := teamLobbyFixture(models.LobbyStatusWaiting, player, newUser(2, "player2"), newUser(3, "player3"))
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.lobbyRepo.On("SwitchTeam", waitingLobby, player.ID, 2, 2).Return(lobbyrepo.ErrTeamFull)
+
+	_, err := s.service.SwitchTeam(context.Background(), &lobby.SwitchTeamRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "player1",
+		Team:     2,
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "team is full")
+}
+
+func (s *LobbyServiceTestSuite) TestSwapSeatSuccess() {
+	player := newUser(1, "player1")
+	waitingLobby := teamLobbyFixture(models.LobbyStatusWaiting, player, newUser(2, "player2"))
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.lobbyRepo.On("SwapSeat", waitingLobby, player.ID, 3).Return(nil)
+
+	_, err := s.service.SwapSeat(context.Background(), &lobby.SwapSeatRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "player1",
+		Seat:     3,
+	})
+
+	s.NoError(err)
+	s.lobbyRepo.AssertExpectations(s.T())
+}
+
+func (s *LobbyServiceTestSuite) TestSwapSeatFailsWithASeatOutsideOfTheLobby() {
+	player := newUser(1, "player1")
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(teamLobbyFixture(models.LobbyStatusWaiting, player), nil)
+
+	_, err := s.service.SwapSeat(context.Background(), &lobby.SwapSeatRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "player1",
+		Seat:     4,
+	})
+
+	s.assertGrpcError(err, codes.InvalidArgument, "invalid seat: it must be between 0 and 3")
+	s.lobbyRepo.AssertNotCalled(s.T(), "SwapSeat", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestSwapSeatFailsWhenTheLobbyChangedAtTheSameTime() {
+	player := newUser(1, "player1")
+	waitingLobby := teamLobbyFixture(models.LobbyStatusWaiting, player, newUser(2, "player2"))
+	s.userRepo.On("FindByUsername", "player1").Return(player, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(waitingLobby, nil)
+	s.lobbyRepo.On("SwapSeat", waitingLobby, player.ID, 1).Return(lobbyrepo.ErrLobbyConflict)
+
+	_, err := s.service.SwapSeat(context.Background(), &lobby.SwapSeatRequest{
+		LobbyId:  fixtureLobbyID,
+		Username: "player1",
+		Seat:     1,
+	})
+
+	s.assertGrpcError(err, codes.Aborted, "please retry")
+}
+
+// confirmLastPlayer has the last player of the ready check confirm, once everybody else did, which starts the game.
+func (s *LobbyServiceTestSuite) confirmLastPlayer(readyLobby *models.Lobby) error {
+	deadline := fixtureNow.Add(time.Second)
+	readyLobby.ReadyCheckDeadline = &deadline
+	for i := range readyLobby.Players {
+		readyLobby.Players[i].Ready = true
+	}
+	last := &readyLobby.Players[len(readyLobby.Players)-1].User
+	s.userRepo.On("FindByUsername", last.Username).Return(last, nil)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(readyLobby, nil)
+	s.lobbyRepo.On("SetPlayerReady", readyLobby, last).Return(nil)
+	s.expectScheduled(models.LobbyTimerGameEnd)
+	s.lobbyRepo.On("CompleteReadyCheck", readyLobby).Return(nil)
+	s.expectCancelled(models.LobbyTimerReadyCheck)
+
+	_, err := s.service.SetReady(callerContext(last.Username), &lobby.SetReadyRequest{LobbyId: fixtureLobbyID})
+	return err
+}
+
+func (s *LobbyServiceTestSuite) expectRating(userID uint, rating int) {
+	entry := &leaderboardrepo.Entry{UserID: userID, Rating: rating}
+	s.leaderboardRepo.On("FindEntry", (*models.Season)(nil), models.RankingByRating, userID).Return(entry, nil)
+}
+
+func (s *LobbyServiceTestSuite) TestStartGameBalancesTheTeamsByRating() {
+	defer s.stubNow()()
+	users := []*models.User{newUser(1, "player1"), newUser(2, "player2"), newUser(3, "player3"), newUser(4, "player4")}
+	readyLobby := teamLobbyFixture(models.LobbyStatusReadyCheck, users...)
+	s.expectRating(1, 1200)
+	s.expectRating(2, 1100)
+	s.leaderboardRepo.On("FindEntry", (*models.Season)(nil), models.RankingByRating, uint(3)).
+		Return(nil, leaderboardrepo.ErrStandingNotFound)
+	s.expectRating(4, 900)
+	s.lobbyRepo.On("AssignTeams", readyLobby, map[uint]int{1: 1, 2: 2, 3: 2, 4: 1}).Return(nil)
+
+	err := s.confirmLastPlayer(readyLobby)
+
+	s.NoError(err)
+	s.lobbyRepo.AssertExpectations(s.T())
+	s.Equal(1, *readyLobby.Players[3].Team)
+}
+
+func (s *LobbyServiceTestSuite) TestStartGameKeepsTheTeamsWithoutAutoBalance() {
+	defer s.stubNow()()
+	readyLobby := teamLobbyFixture(models.LobbyStatusReadyCheck, newUser(1, "player1"), newUser(2, "player2"))
+	readyLobby.Settings[gamemode.AutoBalance] = "OFF"
+
+	err := s.confirmLastPlayer(readyLobby)
+
+	s.NoError(err)
+	s.leaderboardRepo.AssertNotCalled(s.T(), "FindEntry", mock.Anything, mock.Anything, mock.Anything)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AssignTeams", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestStartGameKeepsTheTeamsWhenTheRatingsCanNotBeRead() {
+	defer s.stubNow()()
+	readyLobby := teamLobbyFixture(models.LobbyStatusReadyCheck, newUser(1, "player1"), newUser(2, "player2"))
+	s.leaderboardRepo.On("FindEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+
+	err := s.confirmLastPlayer(readyLobby)
+
+	s.NoError(err)
+	s.Equal(models.LobbyStatusInProgress, readyLobby.Status)
+	s.lobbyRepo.AssertNotCalled(s.T(), "AssignTeams", mock.Anything, mock.Anything)
+}
+
+func (s *LobbyServiceTestSuite) TestFinishGameWithTeamsReportsTheWinningTeam() {
+	users := []*models.User{newUser(1, "player1"), newUser(2, "player2"), newUser(3, "player3"), newUser(4, "player4")}
+	gameLobby := teamLobbyFixture(models.LobbyStatusInProgress, users...)
+	s.lobbyRepo.On("FindByID", fixtureLobbyID).Return(gameLobby, nil)
+	s.lobbyRepo.On("FinishWithWinningTeam", gameLobby, mock.AnythingOfType("int")).Return(nil)
+	s.leaderboardRepo.On("RecordGame", mock.AnythingOfType("[]uint"), mock.AnythingOfType("[]uint"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	s.expectCancelled(models.LobbyTimerGameEnd)
+	s.expectCancelled(models.LobbyTimerResultReport)
+	s.expectCancelled(models.LobbyTimerReconnect)
+
+	resp, err := s.service.FinishGame(context.Background(), &lobby.FinishGameRequest{LobbyId: fixtureLobbyID, Username: "player1"})
+
+	s.NoError(err)
+	s.Nil(resp.WinnerId)
+	s.Require().NotNil(resp.WinningTeam)
+	winningTeam := resp.GetWinningTeam()
+	var winnerIDs, loserIDs []uint
+	for _, player := range resp.Players {
+		if player.Team == winningTeam {
+			s.Equal(int32(1), player.GetPlacement())
+			winnerIDs = append(winnerIDs, uint(player.Id))
+		} else {
+			s.Equal(int32(2), player.GetPlacement())
+			loserIDs = append(loserIDs, uint(player.Id))
+		}
+	}
+	s.Len(winnerIDs, 2)
+	s.leaderboardRepo.AssertCalled(s.T(), "RecordGame", winnerIDs, loserIDs, mock.AnythingOfType("time.Time"))
+	s.lobbyRepo.AssertNotCalled(s.T(), "FinishWithWinner", mock.Anything, mock.Anything)
+}
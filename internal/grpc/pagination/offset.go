@@ -0,0 +1,51 @@
+package pagination
+
+import (
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OffsetPage is a page of a list whose page token is the number of items already returned: items added in the
+// meantime may make a page repeat the last item of the previous one, but no item is ever skipped.
+type OffsetPage struct {
+	Offset int
+	Size   int
+}
+
+// ParseOffsetPage reads the page of a request. A zero size means defaultSize, and a size over maxSize is capped.
+func ParseOffsetPage(pageSize int32, pageToken string, defaultSize, maxSize int) (OffsetPage, error) {
+	page := OffsetPage{Size: int(pageSize)}
+	switch {
+	case page.Size < 0:
+		return page, status.Errorf(codes.InvalidArgument, "invalid page size: it can not be negative")
+	case page.Size == 0:
+		page.Size = defaultSize
+	case page.Size > maxSize:
+		page.Size = maxSize
+	}
+
+	if pageToken != "" {
+		offset, err := strconv.Atoi(pageToken)
+		if err != nil || offset < 0 {
+			return page, status.Errorf(codes.InvalidArgument, "invalid page token")
+		}
+		page.Offset = offset
+	}
+	return page, nil
+}
+
+// Limit is how many items to read: one more than the page holds tells whether there is a next page.
+func (p OffsetPage) Limit() int {
+	return p.Size + 1
+}
+
+// Cut returns the items of the page, out of the ones read with Limit, and the token of the next page, which is empty
+// on the last page.
+func Cut[T any](p OffsetPage, items []T) ([]T, string) {
+	if len(items) <= p.Size {
+		return items, ""
+	}
+	return items[:p.Size], strconv.Itoa(p.Offset + p.Size)
+}
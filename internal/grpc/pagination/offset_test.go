@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseOffsetPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		pageSize  int32
+		pageToken string
+		want      OffsetPage
+	}{
+		{"first page with the default size", 0, "", OffsetPage{Offset: 0, Size: 10}},
+		{"later page", 5, "15", OffsetPage{Offset: 15, Size: 5}},
+		{"size over the maximum", 100, "", OffsetPage{Offset: 0, Size: 50}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := ParseOffsetPage(tt.pageSize, tt.pageToken, 10, 50)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestParseOffsetPageFailsWithAnInvalidPage(t *testing.T) {
+	for _, tt := range []struct {
+		pageSize  int32
+		pageToken string
+	}{{-1, ""}, {0, "abc"}, {0, "-5"}} {
+		_, err := ParseOffsetPage(tt.pageSize, tt.pageToken, 10, 50)
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestCutKeepsTheItemsOfThePage(t *testing.T) {
+	page := OffsetPage{Offset: 4, Size: 2}
+
+	items, next := Cut(page, []string{"a", "b", "c"})
+	assert.Equal(t, []string{"a", "b"}, items)
+	assert.Equal(t, "6", next)
+
+	items, next = Cut(page, []string{"a", "b"})
+	assert.Equal(t, []string{"a", "b"}, items)
+	assert.Empty(t, next)
+	assert.Equal(t, 3, page.Limit())
+}
@@ -0,0 +1,299 @@
+package party
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	friendrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/friend"
+	partyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/party"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const inviteTTL = 15 * time.Minute
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+// PartyService implements the gRPC party service. Joining lobbies as a party is up to the lobby service, which reads
+// the parties from the same repository.
+type PartyService struct {
+	party.UnimplementedPartyServiceServer
+	partyRepo partyrepo.PartyRepository
+	userRepo  usrrepo.UserRepository
+	// friendRepo keeps the users from inviting the users who blocked them.
+	friendRepo friendrepo.FriendRepository
+	// maxSize is how many members a party can have, its leader included.
+	maxSize int
+}
+
+func NewPartyService(partyRepo partyrepo.PartyRepository, userRepo usrrepo.UserRepository,
+	friendRepo friendrepo.FriendRepository, maxSize int) party.PartyServiceServer {
+	return &PartyService{
+		partyRepo:  partyRepo,
+		userRepo:   userRepo,
+		friendRepo: friendRepo,
+		maxSize:    maxSize,
+	}
+}
+
+func (s *PartyService) CreateParty(ctx context.Context, req *party.CreatePartyRequest) (*party.Party, error) {
+	leader, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid leader: %v", err)
+	}
+
+	newParty := &models.Party{LeaderID: leader.ID, Leader: *leader}
+	err = s.partyRepo.Create(newParty)
+	if errors.Is(err, partyrepo.ErrAlreadyInParty) {
+		return nil, status.Errorf(codes.FailedPrecondition, "you are already in a party")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+	return s.toProtoParty(newParty), nil
+}
+
+func (s *PartyService) GetMyParty(ctx context.Context, req *party.GetMyPartyRequest) (*party.Party, error) {
+	member, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	memberParty, err := s.partyRepo.FindByMember(member.ID)
+	if errors.Is(err, partyrepo.ErrPartyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "you are not in a party")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+	return s.toProtoParty(memberParty), nil
+}
+
+// InviteToParty lets the leader invite another user, as long as the party has room for them.
+func (s *PartyService) InviteToParty(ctx context.Context, req *party.InviteToPartyRequest) (*party.PartyInvite, error) {
+	inviter, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid inviter: %v", err)
+	}
+
+	if req.GetInviteeUsername() == inviter.Username {
+		return nil, status.Errorf(codes.InvalidArgument, "you cannot invite yourself")
+	}
+
+	invitee, err := s.userRepo.FindByUsername(req.GetInviteeUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user to invite not found")
+	}
+
+	invitingParty, err := s.findParty(uint(req.GetPartyId()))
+	if err != nil {
+		return nil, err
+	}
+
+	if invitingParty.LeaderID != inviter.ID {
+		return nil, status.Errorf(codes.PermissionDenied, "only the leader of the party can send invites")
+	}
+	if hasMember(invitingParty, invitee.ID) {
+		return nil, status.Errorf(codes.FailedPrecondition, "user is already in the party")
+	}
+	if len(invitingParty.Members) >= s.maxSize {
+		return nil, status.Errorf(codes.FailedPrecondition, "party is full")
+	}
+
+	blocked, err := s.friendRepo.HasBlocked([]uint{invitee.ID}, inviter.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Friend DB error: %v", err)
+	}
+	if blocked {
+		return nil, status.Errorf(codes.PermissionDenied, "you cannot invite this user")
+	}
+
+	currentTime := now()
+	_, err = s.partyRepo.FindPendingInvite(invitingParty.ID, invitee.ID, currentTime)
+	if err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "user has already been invited")
+	}
+	if !errors.Is(err, partyrepo.ErrInviteNotFound) {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+
+	invite := &models.PartyInvite{
+		PartyID:   invitingParty.ID,
+		InviterID: inviter.ID,
+		Inviter:   *inviter,
+		InviteeID: invitee.ID,
+		Invitee:   *invitee,
+		Status:    models.InviteStatusPending,
+		ExpiresAt: currentTime.Add(inviteTTL),
+	}
+	if err := s.partyRepo.CreateInvite(invite); err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+	return toProtoInvite(invite), nil
+}
+
+func (s *PartyService) ListMyPartyInvites(ctx context.Context, req *party.ListMyPartyInvitesRequest) (*party.ListMyPartyInvitesResponse, error) {
+	invitee, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	invites := s.partyRepo.ListPendingInvites(invitee.ID, now())
+	protoInvites := make([]*party.PartyInvite, 0, len(invites))
+	for _, invite := range invites {
+		// The party may have been disbanded in the meantime: such invites can not be accepted anymore.
+		if _, err := s.partyRepo.FindByID(invite.PartyID); err != nil {
+			continue
+		}
+		protoInvites = append(protoInvites, toProtoInvite(invite))
+	}
+	return &party.ListMyPartyInvitesResponse{Invites: protoInvites}, nil
+}
+
+// AcceptPartyInvite adds the caller to the party, as long as they are not in another party.
+func (s *PartyService) AcceptPartyInvite(ctx context.Context, req *party.RespondPartyInviteRequest) (*party.Party, error) {
+	invite, member, err := s.pendingInvite(req)
+	if err != nil {
+		return nil, err
+	}
+
+	invitingParty, err := s.findParty(invite.PartyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.partyRepo.AddMember(invitingParty, member, s.maxSize)
+	switch {
+	case errors.Is(err, partyrepo.ErrPartyNotFound):
+		return nil, status.Errorf(codes.NotFound, "party not found")
+	case errors.Is(err, partyrepo.ErrAlreadyInParty):
+		return nil, status.Errorf(codes.FailedPrecondition, "you are already in a party")
+	case errors.Is(err, partyrepo.ErrPartyFull):
+		return nil, status.Errorf(codes.FailedPrecondition, "party is full")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+
+	if err := s.partyRepo.UpdateInviteStatus(invite, models.InviteStatusAccepted); err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+	return s.toProtoParty(invitingParty), nil
+}
+
+func (s *PartyService) DeclinePartyInvite(ctx context.Context, req *party.RespondPartyInviteRequest) (*party.PartyInvite, error) {
+	invite, _, err := s.pendingInvite(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.partyRepo.UpdateInviteStatus(invite, models.InviteStatusDeclined); err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+	invite.Status = models.InviteStatusDeclined
+	return toProtoInvite(invite), nil
+}
+
+func (s *PartyService) LeaveParty(ctx context.Context, req *party.LeavePartyRequest) (*party.LeavePartyResponse, error) {
+	member, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	memberParty, err := s.findParty(uint(req.GetPartyId()))
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.partyRepo.RemoveMember(memberParty, member.ID)
+	if errors.Is(err, partyrepo.ErrNotInParty) {
+		return nil, status.Errorf(codes.FailedPrecondition, "you are not in the party")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+	return &party.LeavePartyResponse{}, nil
+}
+
+func (s *PartyService) findParty(partyID uint) (*models.Party, error) {
+	foundParty, err := s.partyRepo.FindByID(partyID)
+	if errors.Is(err, partyrepo.ErrPartyNotFound) {
+		return nil, status.Errorf(codes.NotFound, "party not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+	return foundParty, nil
+}
+
+// pendingInvite retrieves the invite, checking that it is addressed to the caller and that it can still be
+// answered. Invites found expired are marked as such.
+func (s *PartyService) pendingInvite(req *party.RespondPartyInviteRequest) (*models.PartyInvite, *models.User, error) {
+	invitee, err := s.userRepo.FindByUsername(req.GetUsername())
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	invite, err := s.partyRepo.FindInvite(uint(req.GetInviteId()))
+	if errors.Is(err, partyrepo.ErrInviteNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "invite not found")
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+	}
+
+	if invite.InviteeID != invitee.ID {
+		return nil, nil, status.Errorf(codes.PermissionDenied, "the invite is not addressed to you")
+	}
+
+	if invite.Status != models.InviteStatusPending {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "invite is no longer pending")
+	}
+
+	if !now().Before(invite.ExpiresAt) {
+		if err := s.partyRepo.UpdateInviteStatus(invite, models.InviteStatusExpired); err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "Party DB error: %v", err)
+		}
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "invite has expired")
+	}
+
+	return invite, invitee, nil
+}
+
+func hasMember(p *models.Party, userID uint) bool {
+	for _, member := range p.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *PartyService) toProtoParty(p *models.Party) *party.Party {
+	pParty := &party.Party{
+		PartyId:        uint32(p.ID),
+		LeaderUsername: p.Leader.Username,
+		Members:        make([]*party.PartyMember, len(p.Members)),
+		MaxSize:        int32(s.maxSize),
+	}
+	for i, member := range p.Members {
+		pParty.Members[i] = &party.PartyMember{Id: uint32(member.UserID), Username: member.User.Username}
+	}
+	return pParty
+}
+
+func toProtoInvite(invite *models.PartyInvite) *party.PartyInvite {
+	return &party.PartyInvite{
+		InviteId:        uint32(invite.ID),
+		PartyId:         uint32(invite.PartyID),
+		InviterUsername: invite.Inviter.Username,
+		InviteeUsername: invite.Invitee.Username,
+		Status:          string(invite.Status),
+		ExpiresAt:       timestamppb.New(invite.ExpiresAt),
+	}
+}
@@ -0,0 +1,446 @@
+package party
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	friendrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/friend"
+	partyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/party"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	fixturePartyID = 7
+	fixtureMaxSize = 3
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// MockUserRepository implements only the methods the party service calls: the embedded interface is nil.
+type MockUserRepository struct {
+	mock.Mock
+	usrrepo.UserRepository
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+// MockFriendRepository implements only the methods the party service calls: the embedded interface is nil.
+type MockFriendRepository struct {
+	mock.Mock
+	friendrepo.FriendRepository
+}
+
+func (m *MockFriendRepository) HasBlocked(blockerIDs []uint, blockedID uint) (bool, error) {
+	args := m.Called(blockerIDs, blockedID)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockPartyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPartyRepository) Create(p *models.Party) error {
+	args := m.Called(p)
+	if args.Error(0) == nil {
+		p.ID = fixturePartyID
+		p.Members = []models.PartyMember{{PartyID: p.ID, UserID: p.LeaderID, User: p.Leader}}
+	}
+	return args.Error(0)
+}
+
+func (m *MockPartyRepository) FindByID(partyID uint) (*models.Party, error) {
+	args := m.Called(partyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Party), args.Error(1)
+}
+
+func (m *MockPartyRepository) FindByMember(userID uint) (*models.Party, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Party), args.Error(1)
+}
+
+func (m *MockPartyRepository) AddMember(p *models.Party, user *models.User, maxSize int) error {
+	args := m.Called(p, user, maxSize)
+	if args.Error(0) == nil {
+		p.Members = append(p.Members, models.PartyMember{PartyID: p.ID, UserID: user.ID, User: *user})
+	}
+	return args.Error(0)
+}
+
+func (m *MockPartyRepository) RemoveMember(p *models.Party, userID uint) error {
+	args := m.Called(p, userID)
+	return args.Error(0)
+}
+
+func (m *MockPartyRepository) CreateInvite(invite *models.PartyInvite) error {
+	args := m.Called(invite)
+	return args.Error(0)
+}
+
+func (m *MockPartyRepository) FindInvite(inviteID uint) (*models.PartyInvite, error) {
+	args := m.Called(inviteID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PartyInvite), args.Error(1)
+}
+
+func (m *MockPartyRepository) FindPendingInvite(partyID, inviteeID uint, now time.Time) (*models.PartyInvite, error) {
+	args := m.Called(partyID, inviteeID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PartyInvite), args.Error(1)
+}
+
+func (m *MockPartyRepository) ListPendingInvites(inviteeID uint, now time.Time) []*models.PartyInvite {
+	args := m.Called(inviteeID, now)
+	return args.Get(0).([]*models.PartyInvite)
+}
+
+func (m *MockPartyRepository) UpdateInviteStatus(invite *models.PartyInvite, status models.InviteStatus) error {
+	args := m.Called(invite, status)
+	return args.Error(0)
+}
+
+type PartyServiceTestSuite struct {
+	suite.Suite
+	partyRepo  *MockPartyRepository
+	userRepo   *MockUserRepository
+	friendRepo *MockFriendRepository
+	service    party.PartyServiceServer
+	leader     *models.User
+	friend     *models.User
+}
+
+func (s *PartyServiceTestSuite) SetupTest() {
+	s.partyRepo = new(MockPartyRepository)
+	s.userRepo = new(MockUserRepository)
+	s.friendRepo = new(MockFriendRepository)
+	s.service = NewPartyService(s.partyRepo, s.userRepo, s.friendRepo, fixtureMaxSize)
+
+	s.leader = &models.User{Username: "leader"}
+	s.leader.ID = 1
+	s.friend = &models.User{Username: "friend"}
+	s.friend.ID = 2
+	for _, user := range []*models.User{s.leader, s.friend} {
+		s.userRepo.On("FindByUsername", user.Username).Return(user, nil)
+	}
+}
+
+func (s *PartyServiceTestSuite) stubNow() func() {
+	original := now
+	now = func() time.Time { return fixtureNow }
+	return func() { now = original }
+}
+
+func (s *PartyServiceTestSuite) assertGrpcError(err error, code codes.Code, msgContains string) {
+	s.Require().Error(err)
+	st, ok := status.FromError(err)
+	s.Require().True(ok)
+	s.Equal(code, st.Code())
+	s.Contains(st.Message(), msgContains)
+}
+
+// partyFixture returns the party of the leader, with the other users as members.
+func (s *PartyServiceTestSuite) partyFixture(members ...*models.User) *models.Party {
+	p := &models.Party{ID: fixturePartyID, LeaderID: s.leader.ID, Leader: *s.leader}
+	for _, member := range append([]*models.User{s.leader}, members...) {
+		p.Members = append(p.Members, models.PartyMember{PartyID: p.ID, UserID: member.ID, User: *member})
+	}
+	return p
+}
+
+func (s *PartyServiceTestSuite) inviteFixture(status models.InviteStatus, expiresAt time.Time) *models.PartyInvite {
+	invite := &models.PartyInvite{
+		PartyID:   fixturePartyID,
+		InviterID: s.leader.ID,
+		Inviter:   *s.leader,
+		InviteeID: s.friend.ID,
+		Invitee:   *s.friend,
+		Status:    status,
+		ExpiresAt: expiresAt,
+	}
+	invite.ID = 5
+	return invite
+}
+
+func (s *PartyServiceTestSuite) TestCreatePartySuccess() {
+	s.partyRepo.On("Create", mock.MatchedBy(func(p *models.Party) bool {
+		return p.LeaderID == s.leader.ID
+	})).Return(nil)
+
+	resp, err := s.service.CreateParty(context.Background(), &party.CreatePartyRequest{Username: "leader"})
+
+	s.NoError(err)
+	s.Equal(uint32(fixturePartyID), resp.PartyId)
+	s.Equal("leader", resp.LeaderUsername)
+	s.Require().Len(resp.Members, 1)
+	s.Equal("leader", resp.Members[0].Username)
+	s.Equal(int32(fixtureMaxSize), resp.MaxSize)
+}
+
+func (s *PartyServiceTestSuite) TestCreatePartyFailsWhenTheUserIsInAParty() {
+	s.partyRepo.On("Create", mock.AnythingOfType("*models.Party")).Return(partyrepo.ErrAlreadyInParty)
+
+	_, err := s.service.CreateParty(context.Background(), &party.CreatePartyRequest{Username: "leader"})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already in a party")
+}
+
+func (s *PartyServiceTestSuite) TestGetMyPartyWhenTheUserIsNotInAParty() {
+	s.partyRepo.On("FindByMember", s.friend.ID).Return(nil, partyrepo.ErrPartyNotFound)
+
+	_, err := s.service.GetMyParty(context.Background(), &party.GetMyPartyRequest{Username: "friend"})
+
+	s.assertGrpcError(err, codes.NotFound, "not in a party")
+}
+
+func (s *PartyServiceTestSuite) TestGetMyPartySuccess() {
+	s.partyRepo.On("FindByMember", s.friend.ID).Return(s.partyFixture(s.friend), nil)
+
+	resp, err := s.service.GetMyParty(context.Background(), &party.GetMyPartyRequest{Username: "friend"})
+
+	s.NoError(err)
+	s.Equal("leader", resp.LeaderUsername)
+	s.Len(resp.Members, 2)
+}
+
+func (s *PartyServiceTestSuite) TestInviteToPartySuccess() {
+	defer s.stubNow()()
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(s.partyFixture(), nil)
+	s.friendRepo.On("HasBlocked", []uint{s.friend.ID}, s.leader.ID).Return(false, nil)
+	s.partyRepo.On("FindPendingInvite", uint(fixturePartyID), s.friend.ID, fixtureNow).Return(nil, partyrepo.ErrInviteNotFound)
+	s.partyRepo.On("CreateInvite", mock.MatchedBy(func(invite *models.PartyInvite) bool {
+		return invite.InviteeID == s.friend.ID && invite.ExpiresAt.Equal(fixtureNow.Add(inviteTTL))
+	})).Return(nil)
+
+	resp, err := s.service.InviteToParty(context.Background(), &party.InviteToPartyRequest{
+		PartyId:         fixturePartyID,
+		Username:        "leader",
+		InviteeUsername: "friend",
+	})
+
+	s.NoError(err)
+	s.Equal("friend", resp.InviteeUsername)
+	s.Equal(string(models.InviteStatusPending), resp.Status)
+	s.partyRepo.AssertExpectations(s.T())
+}
+
+func (s *PartyServiceTestSuite) TestInviteToPartyFailsWhenTheCallerIsNotTheLeader() {
+	other := &models.User{Username: "other"}
+	other.ID = 3
+	s.userRepo.On("FindByUsername", "other").Return(other, nil)
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(s.partyFixture(s.friend), nil)
+
+	_, err := s.service.InviteToParty(context.Background(), &party.InviteToPartyRequest{
+		PartyId:         fixturePartyID,
+		Username:        "friend",
+		InviteeUsername: "other",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "only the leader")
+	s.partyRepo.AssertNotCalled(s.T(), "CreateInvite", mock.Anything)
+}
+
+func (s *PartyServiceTestSuite) TestInviteToPartyFailsWhenThePartyIsFull() {
+	other := &models.User{Username: "other"}
+	other.ID = 3
+	outsider := &models.User{Username: "outsider"}
+	outsider.ID = 4
+	s.userRepo.On("FindByUsername", "outsider").Return(outsider, nil)
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(s.partyFixture(s.friend, other), nil)
+
+	_, err := s.service.InviteToParty(context.Background(), &party.InviteToPartyRequest{
+		PartyId:         fixturePartyID,
+		Username:        "leader",
+		InviteeUsername: "outsider",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "party is full")
+}
+
+func (s *PartyServiceTestSuite) TestInviteToPartyFailsWhenTheInviteeBlockedTheLeader() {
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(s.partyFixture(), nil)
+	s.friendRepo.On("HasBlocked", []uint{s.friend.ID}, s.leader.ID).Return(true, nil)
+
+	_, err := s.service.InviteToParty(context.Background(), &party.InviteToPartyRequest{
+		PartyId:         fixturePartyID,
+		Username:        "leader",
+		InviteeUsername: "friend",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "cannot invite this user")
+	s.partyRepo.AssertNotCalled(s.T(), "CreateInvite", mock.Anything)
+}
+
+func (s *PartyServiceTestSuite) TestInviteToPartyFailsWhenTheUserIsAlreadyInvited() {
+	defer s.stubNow()()
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(s.partyFixture(), nil)
+	s.friendRepo.On("HasBlocked", []uint{s.friend.ID}, s.leader.ID).Return(false, nil)
+	s.partyRepo.On("FindPendingInvite", uint(fixturePartyID), s.friend.ID, fixtureNow).
+		Return(s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(time.Minute)), nil)
+
+	_, err := s.service.InviteToParty(context.Background(), &party.InviteToPartyRequest{
+		PartyId:         fixturePartyID,
+		Username:        "leader",
+		InviteeUsername: "friend",
+	})
+
+	s.assertGrpcError(err, codes.AlreadyExists, "already been invited")
+}
+
+func (s *PartyServiceTestSuite) TestListMyPartyInvitesSkipsTheDisbandedParties() {
+	defer s.stubNow()()
+	invite := s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(time.Minute))
+	disbanded := s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(time.Minute))
+	disbanded.PartyID = 9
+	s.partyRepo.On("ListPendingInvites", s.friend.ID, fixtureNow).Return([]*models.PartyInvite{invite, disbanded})
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(s.partyFixture(), nil)
+	s.partyRepo.On("FindByID", uint(9)).Return(nil, partyrepo.ErrPartyNotFound)
+
+	resp, err := s.service.ListMyPartyInvites(context.Background(), &party.ListMyPartyInvitesRequest{Username: "friend"})
+
+	s.NoError(err)
+	s.Require().Len(resp.Invites, 1)
+	s.Equal("leader", resp.Invites[0].InviterUsername)
+}
+
+func (s *PartyServiceTestSuite) TestAcceptPartyInviteSuccess() {
+	defer s.stubNow()()
+	invite := s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(time.Minute))
+	invitingParty := s.partyFixture()
+	s.partyRepo.On("FindInvite", invite.ID).Return(invite, nil)
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(invitingParty, nil)
+	s.partyRepo.On("AddMember", invitingParty, s.friend, fixtureMaxSize).Return(nil)
+	s.partyRepo.On("UpdateInviteStatus", invite, models.InviteStatusAccepted).Return(nil)
+
+	resp, err := s.service.AcceptPartyInvite(context.Background(), &party.RespondPartyInviteRequest{
+		InviteId: uint32(invite.ID),
+		Username: "friend",
+	})
+
+	s.NoError(err)
+	s.Require().Len(resp.Members, 2)
+	s.Equal("friend", resp.Members[1].Username)
+	s.partyRepo.AssertExpectations(s.T())
+}
+
+func (s *PartyServiceTestSuite) TestAcceptPartyInviteFailsWhenTheUserIsInAnotherParty() {
+	defer s.stubNow()()
+	invite := s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(time.Minute))
+	invitingParty := s.partyFixture()
+	s.partyRepo.On("FindInvite", invite.ID).Return(invite, nil)
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(invitingParty, nil)
+	s.partyRepo.On("AddMember", invitingParty, s.friend, fixtureMaxSize).Return(partyrepo.ErrAlreadyInParty)
+
+	_, err := s.service.AcceptPartyInvite(context.Background(), &party.RespondPartyInviteRequest{
+		InviteId: uint32(invite.ID),
+		Username: "friend",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "already in a party")
+	s.partyRepo.AssertNotCalled(s.T(), "UpdateInviteStatus", mock.Anything, mock.Anything)
+}
+
+func (s *PartyServiceTestSuite) TestAcceptPartyInviteFailsWhenTheInviteExpired() {
+	defer s.stubNow()()
+	invite := s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(-time.Minute))
+	s.partyRepo.On("FindInvite", invite.ID).Return(invite, nil)
+	s.partyRepo.On("UpdateInviteStatus", invite, models.InviteStatusExpired).Return(nil)
+
+	_, err := s.service.AcceptPartyInvite(context.Background(), &party.RespondPartyInviteRequest{
+		InviteId: uint32(invite.ID),
+		Username: "friend",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "invite has expired")
+	s.partyRepo.AssertExpectations(s.T())
+}
+
+func (s *PartyServiceTestSuite) TestAcceptPartyInviteFailsWhenTheInviteIsForSomebodyElse() {
+	invite := s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(time.Minute))
+	s.partyRepo.On("FindInvite", invite.ID).Return(invite, nil)
+
+	_, err := s.service.AcceptPartyInvite(context.Background(), &party.RespondPartyInviteRequest{
+		InviteId: uint32(invite.ID),
+		Username: "leader",
+	})
+
+	s.assertGrpcError(err, codes.PermissionDenied, "not addressed to you")
+}
+
+func (s *PartyServiceTestSuite) TestDeclinePartyInviteSuccess() {
+	defer s.stubNow()()
+	invite := s.inviteFixture(models.InviteStatusPending, fixtureNow.Add(time.Minute))
+	s.partyRepo.On("FindInvite", invite.ID).Return(invite, nil)
+	s.partyRepo.On("UpdateInviteStatus", invite, models.InviteStatusDeclined).Return(nil)
+
+	resp, err := s.service.DeclinePartyInvite(context.Background(), &party.RespondPartyInviteRequest{
+		InviteId: uint32(invite.ID),
+		Username: "friend",
+	})
+
+	s.NoError(err)
+	s.Equal(string(models.InviteStatusDeclined), resp.Status)
+}
+
+func (s *PartyServiceTestSuite) TestLeavePartySuccess() {
+	memberParty := s.partyFixture(s.friend)
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(memberParty, nil)
+	s.partyRepo.On("RemoveMember", memberParty, s.friend.ID).Return(nil)
+
+	_, err := s.service.LeaveParty(context.Background(), &party.LeavePartyRequest{
+		PartyId:  fixturePartyID,
+		Username: "friend",
+	})
+
+	s.NoError(err)
+	s.partyRepo.AssertExpectations(s.T())
+}
+
+func (s *PartyServiceTestSuite) TestLeavePartyFailsWhenTheUserIsNotInTheParty() {
+	memberParty := s.partyFixture()
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(memberParty, nil)
+	s.partyRepo.On("RemoveMember", memberParty, s.friend.ID).Return(partyrepo.ErrNotInParty)
+
+	_, err := s.service.LeaveParty(context.Background(), &party.LeavePartyRequest{
+		PartyId:  fixturePartyID,
+		Username: "friend",
+	})
+
+	s.assertGrpcError(err, codes.FailedPrecondition, "not in the party")
+}
+
+func (s *PartyServiceTestSuite) TestLeavePartyFailsWhenThePartyDoesNotExist() {
+	s.partyRepo.On("FindByID", uint(fixturePartyID)).Return(nil, partyrepo.ErrPartyNotFound)
+
+	_, err := s.service.LeaveParty(context.Background(), &party.LeavePartyRequest{
+		PartyId:  fixturePartyID,
+		Username: "friend",
+	})
+
+	s.assertGrpcError(err, codes.NotFound, "party not found")
+}
+
+func TestPartyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PartyServiceTestSuite))
+}
@@ -0,0 +1,175 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	presencerepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/presence"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxRequestedUsers is how many users a single request can ask the presence of.
+const maxRequestedUsers = 100
+
+// Config tells how long the presence of a user lasts and how often it is checked for changes.
+type Config struct {
+	// Timeout is how long a heartbeat keeps the user online. The clients are asked to send a heartbeat every third
+	// of it, so that a late heartbeat does not disconnect the user.
+	Timeout time.Duration
+	// WatchInterval is how often the streams of WatchPresence look for changes. The presences are read from the
+	// database, so that the heartbeats received by the other replicas are seen as well.
+	WatchInterval time.Duration
+}
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+// PresenceService implements the gRPC presence service.
+type PresenceService struct {
+	presence.UnimplementedPresenceServiceServer
+	presenceRepo presencerepo.PresenceRepository
+	userRepo     usrrepo.UserRepository
+	cfg          Config
+}
+
+func NewPresenceService(presenceRepo presencerepo.PresenceRepository, userRepo usrrepo.UserRepository,
+	cfg Config) presence.PresenceServiceServer {
+	return &PresenceService{
+		presenceRepo: presenceRepo,
+		userRepo:     userRepo,
+		cfg:          cfg,
+	}
+}
+
+// Heartbeat keeps the authenticated caller online.
+func (s *PresenceService) Heartbeat(ctx context.Context, req *presence.HeartbeatRequest) (*presence.HeartbeatResponse, error) {
+	username, ok := caller.FromContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "sign in to be seen online")
+	}
+
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid user: %v", err)
+	}
+
+	heartbeatAt := now()
+	expiresAt := heartbeatAt.Add(s.cfg.Timeout)
+	if err := s.presenceRepo.Heartbeat(user.ID, heartbeatAt, expiresAt); err != nil {
+		return nil, status.Errorf(codes.Internal, "Presence DB error: %v", err)
+	}
+
+	return &presence.HeartbeatResponse{
+		ExpiresAt:       timestamppb.New(expiresAt),
+		IntervalSeconds: uint32(max(s.cfg.Timeout/3, time.Second) / time.Second),
+	}, nil
+}
+
+func (s *PresenceService) GetPresence(ctx context.Context, req *presence.GetPresenceRequest) (*presence.GetPresenceResponse, error) {
+	users, err := s.findUsers(req.GetUsernames())
+	if err != nil {
+		return nil, err
+	}
+
+	at := now()
+	presences, err := s.presencesOf(users, at)
+	if err != nil {
+		return nil, err
+	}
+	onlineCount, err := s.presenceRepo.CountOnline(at)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Presence DB error: %v", err)
+	}
+
+	return &presence.GetPresenceResponse{Presences: presences, OnlineCount: uint32(onlineCount)}, nil
+}
+
+// WatchPresence sends the presence of every user first, then only the users that went online or offline since the
+// previous check.
+func (s *PresenceService) WatchPresence(req *presence.WatchPresenceRequest, stream presence.PresenceService_WatchPresenceServer) error {
+	if len(req.GetUsernames()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "at least one username is required")
+	}
+	users, err := s.findUsers(req.GetUsernames())
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(s.cfg.WatchInterval)
+	defer ticker.Stop()
+
+	sent := make(map[string]bool, len(users))
+	for {
+		presences, err := s.presencesOf(users, now())
+		if err != nil {
+			return err
+		}
+		for _, userPresence := range presences {
+			if online, ok := sent[userPresence.Username]; ok && online == userPresence.Online {
+				continue
+			}
+			if err := stream.Send(userPresence); err != nil {
+				return err
+			}
+			sent[userPresence.Username] = userPresence.Online
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PresenceService) findUsers(usernames []string) ([]*models.User, error) {
+	if len(usernames) > maxRequestedUsers {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d usernames can be requested", maxRequestedUsers)
+	}
+
+	users := make([]*models.User, 0, len(usernames))
+	for _, username := range usernames {
+		user, err := s.userRepo.FindByUsername(username)
+		if errors.Is(err, usrrepo.ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found: %s", username)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "User DB error: %v", err)
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// presencesOf returns the presence of the users at the given time, in the same order.
+func (s *PresenceService) presencesOf(users []*models.User, at time.Time) ([]*presence.UserPresence, error) {
+	userIDs := make([]uint, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	stored, err := s.presenceRepo.FindByUsers(userIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Presence DB error: %v", err)
+	}
+	byUser := make(map[uint]*models.Presence, len(stored))
+	for _, userPresence := range stored {
+		byUser[userPresence.UserID] = userPresence
+	}
+
+	presences := make([]*presence.UserPresence, len(users))
+	for i, user := range users {
+		presences[i] = &presence.UserPresence{Username: user.Username}
+		if userPresence, ok := byUser[user.ID]; ok {
+			presences[i].Online = userPresence.Online(at)
+			presences[i].LastHeartbeatAt = timestamppb.New(userPresence.LastHeartbeatAt)
+		}
+	}
+	return presences, nil
+}
@@ -0,0 +1,231 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/caller"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var fixtureConfig = Config{Timeout: 90 * time.Second, WatchInterval: time.Millisecond}
+
+// MockUserRepository implements only the methods the presence service calls: the embedded interface is nil.
+type MockUserRepository struct {
+	mock.Mock
+	usrrepo.UserRepository
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+type MockPresenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPresenceRepository) Heartbeat(userID uint, heartbeatAt, expiresAt time.Time) error {
+	args := m.Called(userID, heartbeatAt, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockPresenceRepository) FindByUsers(userIDs []uint) ([]*models.Presence, error) {
+	args := m.Called(userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Presence), args.Error(1)
+}
+
+func (m *MockPresenceRepository) CountOnline(at time.Time) (int64, error) {
+	args := m.Called(at)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// fakeStream collects the presences sent to the client until its context is cancelled.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *presence.UserPresence
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStream) Send(userPresence *presence.UserPresence) error {
+	f.sent <- userPresence
+	return nil
+}
+
+type PresenceServiceTestSuite struct {
+	suite.Suite
+	presenceRepo *MockPresenceRepository
+	userRepo     *MockUserRepository
+	service      presence.PresenceServiceServer
+	alice        *models.User
+	bob          *models.User
+}
+
+func (s *PresenceServiceTestSuite) SetupTest() {
+	s.presenceRepo = new(MockPresenceRepository)
+	s.userRepo = new(MockUserRepository)
+	s.service = NewPresenceService(s.presenceRepo, s.userRepo, fixtureConfig)
+
+	s.alice = &models.User{Username: "alice"}
+	s.alice.ID = 1
+	s.bob = &models.User{Username: "bob"}
+	s.bob.ID = 2
+	for _, user := range []*models.User{s.alice, s.bob} {
+		s.userRepo.On("FindByUsername", user.Username).Return(user, nil)
+	}
+	s.userRepo.On("FindByUsername", "ghost").Return(nil, usrrepo.ErrUserNotFound)
+}
+
+func (s *PresenceServiceTestSuite) stubNow() func() {
+	original := now
+	now = func() time.Time { return fixtureNow }
+	return func() { now = original }
+}
+
+func (s *PresenceServiceTestSuite) assertGrpcError(err error, code codes.Code) {
+	s.Require().Error(err)
+	st, ok := status.FromError(err)
+	s.Require().True(ok)
+	s.Equal(code, st.Code())
+}
+
+func (s *PresenceServiceTestSuite) TestHeartbeatKeepsTheUserOnlineUntilTheTimeout() {
+	defer s.stubNow()()
+	expiresAt := fixtureNow.Add(fixtureConfig.Timeout)
+	s.presenceRepo.On("Heartbeat", s.alice.ID, fixtureNow, expiresAt).Return(nil)
+
+	resp, err := s.service.Heartbeat(caller.NewContext(context.Background(), "alice"), &presence.HeartbeatRequest{})
+
+	s.NoError(err)
+	s.True(resp.ExpiresAt.AsTime().Equal(expiresAt))
+	s.Equal(uint32(30), resp.IntervalSeconds)
+	s.presenceRepo.AssertExpectations(s.T())
+}
+
+func (s *PresenceServiceTestSuite) TestHeartbeatFailsWhenUnauthenticated() {
+	_, err := s.service.Heartbeat(context.Background(), &presence.HeartbeatRequest{})
+
+	s.assertGrpcError(err, codes.Unauthenticated)
+	s.presenceRepo.AssertNotCalled(s.T(), "Heartbeat", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *PresenceServiceTestSuite) TestHeartbeatWhenTheRepositoryFails() {
+	s.presenceRepo.On("Heartbeat", s.alice.ID, mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	_, err := s.service.Heartbeat(caller.NewContext(context.Background(), "alice"), &presence.HeartbeatRequest{})
+
+	s.assertGrpcError(err, codes.Internal)
+}
+
+func (s *PresenceServiceTestSuite) TestGetPresenceTellsOnlineExpiredAndUntrackedUsersApart() {
+	defer s.stubNow()()
+	carol := &models.User{Username: "carol"}
+	carol.ID = 3
+	s.userRepo.On("FindByUsername", "carol").Return(carol, nil)
+	s.presenceRepo.On("FindByUsers", []uint{s.alice.ID, s.bob.ID, carol.ID}).Return([]*models.Presence{
+		{UserID: s.bob.ID, LastHeartbeatAt: fixtureNow.Add(-2 * time.Minute), ExpiresAt: fixtureNow.Add(-time.Minute)},
+		{UserID: s.alice.ID, LastHeartbeatAt: fixtureNow.Add(-time.Second), ExpiresAt: fixtureNow.Add(time.Minute)},
+	}, nil)
+	s.presenceRepo.On("CountOnline", fixtureNow).Return(int64(7), nil)
+
+	resp, err := s.service.GetPresence(context.Background(), &presence.GetPresenceRequest{
+		Usernames: []string{"alice", "bob", "carol"},
+	})
+
+	s.NoError(err)
+	s.Equal(uint32(7), resp.OnlineCount)
+	s.Require().Len(resp.Presences, 3)
+	s.Equal("alice", resp.Presences[0].Username)
+	s.True(resp.Presences[0].Online)
+	s.Equal("bob", resp.Presences[1].Username)
+	s.False(resp.Presences[1].Online)
+	s.NotNil(resp.Presences[1].LastHeartbeatAt)
+	s.Equal("carol", resp.Presences[2].Username)
+	s.False(resp.Presences[2].Online)
+	s.Nil(resp.Presences[2].LastHeartbeatAt)
+}
+
+func (s *PresenceServiceTestSuite) TestGetPresenceOfNobodyCountsTheOnlineUsers() {
+	defer s.stubNow()()
+	s.presenceRepo.On("FindByUsers", []uint{}).Return([]*models.Presence{}, nil)
+	s.presenceRepo.On("CountOnline", fixtureNow).Return(int64(3), nil)
+
+	resp, err := s.service.GetPresence(context.Background(), &presence.GetPresenceRequest{})
+
+	s.NoError(err)
+	s.Empty(resp.Presences)
+	s.Equal(uint32(3), resp.OnlineCount)
+}
+
+func (s *PresenceServiceTestSuite) TestGetPresenceOfAnUnknownUser() {
+	_, err := s.service.GetPresence(context.Background(), &presence.GetPresenceRequest{Usernames: []string{"ghost"}})
+
+	s.assertGrpcError(err, codes.NotFound)
+	s.presenceRepo.AssertNotCalled(s.T(), "FindByUsers", mock.Anything)
+}
+
+func (s *PresenceServiceTestSuite) TestGetPresenceOfTooManyUsers() {
+	_, err := s.service.GetPresence(context.Background(), &presence.GetPresenceRequest{
+		Usernames: make([]string, maxRequestedUsers+1),
+	})
+
+	s.assertGrpcError(err, codes.InvalidArgument)
+	s.userRepo.AssertNotCalled(s.T(), "FindByUsername", mock.Anything)
+}
+
+func (s *PresenceServiceTestSuite) TestWatchPresenceSendsOnlyTheChanges() {
+	defer s.stubNow()()
+	online := &models.Presence{UserID: s.alice.ID, LastHeartbeatAt: fixtureNow, ExpiresAt: fixtureNow.Add(time.Minute)}
+	expired := &models.Presence{UserID: s.alice.ID, LastHeartbeatAt: fixtureNow, ExpiresAt: fixtureNow}
+	// The user is online for the first two checks, then the heartbeat expires.
+	s.presenceRepo.On("FindByUsers", []uint{s.alice.ID}).Return([]*models.Presence{online}, nil).Twice()
+	s.presenceRepo.On("FindByUsers", []uint{s.alice.ID}).Return([]*models.Presence{expired}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeStream{ctx: ctx, sent: make(chan *presence.UserPresence, 2)}
+	done := make(chan error)
+
+	go func() {
+		done <- s.service.WatchPresence(&presence.WatchPresenceRequest{Usernames: []string{"alice"}}, stream)
+	}()
+	first := <-stream.sent
+	second := <-stream.sent
+	cancel()
+
+	s.NoError(<-done)
+	s.True(first.Online)
+	s.False(second.Online)
+	s.Equal("alice", second.Username)
+}
+
+func (s *PresenceServiceTestSuite) TestWatchPresenceRequiresAUsername() {
+	stream := &fakeStream{ctx: context.Background(), sent: make(chan *presence.UserPresence, 1)}
+
+	err := s.service.WatchPresence(&presence.WatchPresenceRequest{}, stream)
+
+	s.assertGrpcError(err, codes.InvalidArgument)
+}
+
+func TestPresenceService(t *testing.T) {
+	suite.Run(t, new(PresenceServiceTestSuite))
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"context"
+	"errors"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/stats"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/grpc/pagination"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultGamesPageSize = 10
+	maxGamesPageSize     = 50
+)
+
+// StatsService implements the gRPC stats service server, computing the statistics of the players from their
+// finished games. A game counts for a player only if they played it until the end.
+type StatsService struct {
+	stats.UnimplementedStatsServiceServer
+	lobbyRepo lobbyrepo.LobbyRepository
+	userRepo  usrrepo.UserRepository
+}
+
+func NewStatsService(lobbyRepo lobbyrepo.LobbyRepository, userRepo usrrepo.UserRepository) stats.StatsServiceServer {
+	return &StatsService{lobbyRepo: lobbyRepo, userRepo: userRepo}
+}
+
+func (s *StatsService) GetPlayerStats(ctx context.Context, req *stats.GetPlayerStatsRequest) (*stats.PlayerStats, error) {
+	player, err := s.findPlayer(req.GetPlayer())
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.lobbyRepo.ListResultsByPlayer(player.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	playerStats := &stats.PlayerStats{Username: player.Username, GamesPlayed: int32(len(results))}
+	for _, won := range results {
+		if won {
+			playerStats.Wins++
+		}
+	}
+	playerStats.Losses = playerStats.GamesPlayed - playerStats.Wins
+	if playerStats.GamesPlayed > 0 {
+		playerStats.WinRate = float64(playerStats.Wins) / float64(playerStats.GamesPlayed)
+	}
+	playerStats.CurrentStreak = currentStreak(results)
+	return playerStats, nil
+}
+
+// currentStreak counts the results equal to the most recent one, which comes first: wins count as positive and
+// losses as negative.
+func currentStreak(results []bool) int32 {
+	var streak int32
+	for _, won := range results {
+		if won != results[0] {
+			break
+		}
+		streak++
+	}
+	if len(results) > 0 && !results[0] {
+		return -streak
+	}
+	return streak
+}
+
+// ListRecentGames pages through the finished games of the player. As for the matches of the lobby service, the page
+// token is the number of games already returned.
+func (s *StatsService) ListRecentGames(ctx context.Context, req *stats.ListRecentGamesRequest) (*stats.ListRecentGamesResponse, error) {
+	page, err := pagination.ParseOffsetPage(req.GetPageSize(), req.GetPageToken(), defaultGamesPageSize,
+		maxGamesPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := s.findPlayer(req.GetPlayer())
+	if err != nil {
+		return nil, err
+	}
+
+	games, err := s.lobbyRepo.ListFinishedByPlayer(player.ID, page.Offset, page.Limit())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Lobby DB error: %v", err)
+	}
+
+	resp := &stats.ListRecentGamesResponse{}
+	games, resp.NextPageToken = pagination.Cut(page, games)
+	resp.Games = make([]*stats.Game, len(games))
+	for i, game := range games {
+		resp.Games[i] = toProtoGame(game, player.ID)
+	}
+	return resp, nil
+}
+
+func (s *StatsService) findPlayer(username string) (*models.User, error) {
+	player, err := s.userRepo.FindByUsername(username)
+	if errors.Is(err, usrrepo.ErrUserNotFound) {
+		return nil, status.Errorf(codes.NotFound, "player %q not found", username)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "User DB error: %v", err)
+	}
+	return player, nil
+}
+
+// toProtoGame describes the finished game from the point of view of the given player.
+func toProtoGame(game *models.Lobby, userID uint) *stats.Game {
+	pGame := &stats.Game{
+		LobbyId:    game.LobbyID,
+		LobbyName:  game.Name,
+		FinishedAt: timestamppb.New(game.UpdatedAt),
+	}
+	if game.Winner != nil {
+		pGame.WinnerUsername = game.Winner.Username
+	}
+	if game.WinningTeam != nil {
+		winningTeam := int32(*game.WinningTeam)
+		pGame.WinningTeam = &winningTeam
+	}
+	for _, player := range game.Players {
+		if player.UserID != userID {
+			pGame.Opponents = append(pGame.Opponents, player.User.Username)
+		} else {
+			pGame.Won = player.Placement != nil && *player.Placement == 1
+		}
+	}
+	return pGame
+}
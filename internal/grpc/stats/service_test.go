@@ -0,0 +1,252 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/stats"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	usrrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/user"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindByID(id uint) (*models.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByUsername(username string) (*models.User, error) {
+	args := m.Called(username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePassword(user *models.User, hashedPassword string) error {
+	args := m.Called(user, hashedPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateLastSeen(username string, seenAt time.Time) error {
+	args := m.Called(username, seenAt)
+	return args.Error(0)
+}
+
+type MockLobbyRepository struct {
+	mock.Mock
+	lobbyrepo.LobbyRepository
+}
+
+func (m *MockLobbyRepository) ListResultsByPlayer(userID uint) ([]bool, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bool), args.Error(1)
+}
+
+func (m *MockLobbyRepository) ListFinishedByPlayer(userID uint, offset, limit int) ([]*models.Lobby, error) {
+	args := m.Called(userID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Lobby), args.Error(1)
+}
+
+type StatsServiceTestSuite struct {
+	suite.Suite
+	lobbyRepo *MockLobbyRepository
+	userRepo  *MockUserRepository
+	service   stats.StatsServiceServer
+	player    *models.User
+	opponent  *models.User
+}
+
+func (s *StatsServiceTestSuite) SetupTest() {
+	s.lobbyRepo = new(MockLobbyRepository)
+	s.userRepo = new(MockUserRepository)
+	s.service = NewStatsService(s.lobbyRepo, s.userRepo)
+
+	s.player = &models.User{Username: "player"}
+	s.player.ID = 1
+	s.opponent = &models.User{Username: "opponent"}
+	s.opponent.ID = 2
+}
+
+func (s *StatsServiceTestSuite) assertGrpcError(err error, code codes.Code) {
+	s.Require().Error(err)
+	st, ok := status.FromError(err)
+	s.Require().True(ok)
+	s.Equal(code, st.Code())
+}
+
+func (s *StatsServiceTestSuite) game(lobbyID string, winner *models.User) *models.Lobby {
+	game := &models.Lobby{
+		LobbyID:  lobbyID,
+		Name:     lobbyID,
+		Status:   models.LobbyStatusFinished,
+		WinnerID: &winner.ID,
+		Winner:   winner,
+		Players: []models.LobbyPlayer{
+			{UserID: s.player.ID, User: *s.player},
+			{UserID: s.opponent.ID, User: *s.opponent, Seat: 1},
+		},
+		UpdatedAt: time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC),
+	}
+	for i := range game.Players {
+		placement := 2
+		if game.Players[i].UserID == winner.ID {
+			placement = 1
+		}
+		game.Players[i].Placement = &placement
+	}
+	return game
+}
+
+func (s *StatsServiceTestSuite) TestGetPlayerStatsSuccess() {
+	s.userRepo.On("FindByUsername", "player").Return(s.player, nil)
+	s.lobbyRepo.On("ListResultsByPlayer", s.player.ID).Return([]bool{true, true, false, true}, nil)
+
+	resp, err := s.service.GetPlayerStats(context.Background(), &stats.GetPlayerStatsRequest{Player: "player"})
+
+	s.NoError(err)
+	s.Equal("player", resp.Username)
+	s.Equal(int32(4), resp.GamesPlayed)
+	s.Equal(int32(3), resp.Wins)
+	s.Equal(int32(1), resp.Losses)
+	s.InDelta(0.75, resp.WinRate, 1e-9)
+	s.Equal(int32(2), resp.CurrentStreak)
+}
+
+func (s *StatsServiceTestSuite) TestGetPlayerStatsCountsALosingStreakAsNegative() {
+	s.userRepo.On("FindByUsername", "player").Return(s.player, nil)
+	s.lobbyRepo.On("ListResultsByPlayer", s.player.ID).Return([]bool{false, false, false, true}, nil)
+
+	resp, err := s.service.GetPlayerStats(context.Background(), &stats.GetPlayerStatsRequest{Player: "player"})
+
+	s.NoError(err)
+	s.Equal(int32(-3), resp.CurrentStreak)
+}
+
+func (s *StatsServiceTestSuite) TestGetPlayerStatsWithoutGames() {
+	s.userRepo.On("FindByUsername", "player").Return(s.player, nil)
+	s.lobbyRepo.On("ListResultsByPlayer", s.player.ID).Return([]bool{}, nil)
+
+	resp, err := s.service.GetPlayerStats(context.Background(), &stats.GetPlayerStatsRequest{Player: "player"})
+
+	s.NoError(err)
+	s.Zero(resp.GamesPlayed)
+	s.Zero(resp.WinRate)
+	s.Zero(resp.CurrentStreak)
+}
+
+func (s *StatsServiceTestSuite) TestGetPlayerStatsFailsForAnUnknownPlayer() {
+	s.userRepo.On("FindByUsername", "ghost").Return(nil, usrrepo.ErrUserNotFound)
+
+	_, err := s.service.GetPlayerStats(context.Background(), &stats.GetPlayerStatsRequest{Player: "ghost"})
+
+	s.assertGrpcError(err, codes.NotFound)
+}
+
+func (s *StatsServiceTestSuite) TestGetPlayerStatsFailsOnRepositoryError() {
+	s.userRepo.On("FindByUsername", "player").Return(s.player, nil)
+	s.lobbyRepo.On("ListResultsByPlayer", s.player.ID).Return(nil, errors.New("db error"))
+
+	_, err := s.service.GetPlayerStats(context.Background(), &stats.GetPlayerStatsRequest{Player: "player"})
+
+	s.assertGrpcError(err, codes.Internal)
+}
+
+func (s *StatsServiceTestSuite) TestListRecentGamesDescribesTheGamesFromThePlayerSide() {
+	s.userRepo.On("FindByUsername", "player").Return(s.player, nil)
+	s.lobbyRepo.On("ListFinishedByPlayer", s.player.ID, 0, defaultGamesPageSize+1).Return([]*models.Lobby{
+		s.game("won", s.player),
+		s.game("lost", s.opponent),
+	}, nil)
+
+	resp, err := s.service.ListRecentGames(context.Background(), &stats.ListRecentGamesRequest{Player: "player"})
+
+	s.NoError(err)
+	s.Require().Len(resp.Games, 2)
+	s.True(resp.Games[0].Won)
+	s.Equal([]string{"opponent"}, resp.Games[0].Opponents)
+	s.Equal("player", resp.Games[0].WinnerUsername)
+	s.False(resp.Games[1].Won)
+	s.Equal("opponent", resp.Games[1].WinnerUsername)
+	s.Empty(resp.NextPageToken)
+}
+
+func (s *StatsServiceTestSuite) TestListRecentGamesReportsTheWinningTeam() {
+	game := s.game("teams", s.player)
+	game.WinnerID, game.Winner = nil, nil
+	winningTeam := 2
+	game.WinningTeam = &winningTeam
+	s.userRepo.On("FindByUsername", "player").Return(s.player, nil)
+	s.lobbyRepo.On("ListFinishedByPlayer", s.player.ID, 0, defaultGamesPageSize+1).Return([]*models.Lobby{game}, nil)
+
+	resp, err := s.service.ListRecentGames(context.Background(), &stats.ListRecentGamesRequest{Player: "player"})
+
+	s.NoError(err)
+	s.Require().Len(resp.Games, 1)
+	s.True(resp.Games[0].Won)
+	s.Empty(resp.Games[0].WinnerUsername)
+	s.Equal(int32(2), resp.Games[0].GetWinningTeam())
+}
+
+func (s *StatsServiceTestSuite) TestListRecentGamesPagesThroughTheGames() {
+	s.userRepo.On("FindByUsername", "player").Return(s.player, nil)
+	s.lobbyRepo.On("ListFinishedByPlayer", s.player.ID, 2, 2).Return([]*models.Lobby{
+		s.game("first", s.player),
+		s.game("second", s.player),
+	}, nil)
+
+	req := &stats.ListRecentGamesRequest{Player: "player", PageSize: 1, PageToken: "2"}
+	resp, err := s.service.ListRecentGames(context.Background(), req)
+
+	s.NoError(err)
+	s.Len(resp.Games, 1)
+	s.Equal("3", resp.NextPageToken)
+}
+
+func (s *StatsServiceTestSuite) TestListRecentGamesFailsWithAnInvalidPage() {
+	for _, req := range []*stats.ListRecentGamesRequest{
+		{Player: "player", PageSize: -1},
+		{Player: "player", PageToken: "not-a-number"},
+	} {
+		_, err := s.service.ListRecentGames(context.Background(), req)
+
+		s.assertGrpcError(err, codes.InvalidArgument)
+	}
+	s.lobbyRepo.AssertNotCalled(s.T(), "ListFinishedByPlayer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *StatsServiceTestSuite) TestListRecentGamesFailsForAnUnknownPlayer() {
+	s.userRepo.On("FindByUsername", "ghost").Return(nil, usrrepo.ErrUserNotFound)
+
+	_, err := s.service.ListRecentGames(context.Background(), &stats.ListRecentGamesRequest{Player: "ghost"})
+
+	s.assertGrpcError(err, codes.NotFound)
+}
+
+func TestStatsService(t *testing.T) {
+	suite.Run(t, new(StatsServiceTestSuite))
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/gin-gonic/gin"
+)
+
+type ChatHandler struct {
+	chatClient *gateway.ChatGatewayClient
+}
+
+func NewChatHandler(client *gateway.ChatGatewayClient) *ChatHandler {
+	return &ChatHandler{chatClient: client}
+}
+
+// SendMessage posts the text of the form to the chat of the lobby. It answers with JSON, for the chat panel of the
+// lobby page.
+func (h *ChatHandler) SendMessage(c *gin.Context) {
+	message, err := h.chatClient.SendMessage(c.Request.Context(), &chat.SendMessageRequest{
+		LobbyId: c.Param("lobby_id"),
+		Text:    c.PostForm("text"),
+	})
+	if err != nil {
+		statusCode, errorMessage := chatFailure(err)
+		c.JSON(statusCode, gin.H{"error": errorMessage})
+		return
+	}
+
+	c.JSON(http.StatusOK, message)
+}
+
+// StreamMessages relays the messages of the lobby chat as server-sent events, until the browser goes away.
+func (h *ChatHandler) StreamMessages(c *gin.Context) {
+	err := h.chatClient.StreamMessages(c.Request.Context(), c.Param("lobby_id"),
+		func(message *chat.ChatMessage) error {
+			if !c.Writer.Written() {
+				c.Header("Cache-Control", "no-cache")
+			}
+			c.SSEvent("message", message)
+			c.Writer.Flush()
+			return nil
+		})
+
+	// Once the first event is written the status can not change anymore: the browser reconnects on its own.
+	if err != nil && !c.Writer.Written() {
+		statusCode, errorMessage := chatFailure(err)
+		c.JSON(statusCode, gin.H{"error": errorMessage})
+	}
+}
+
+func chatFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The message is empty, too long, or was rejected by the content filter."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "Only the players and the spectators of the lobby can chat."
+		case http.StatusTooManyRequests:
+			return http.StatusTooManyRequests, "You are sending messages too fast, please wait a moment."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred in the chat."
+}
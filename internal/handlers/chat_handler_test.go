@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/chat"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type ChatHandlerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockGateway *httptest.Server
+	handler     *ChatHandler
+}
+
+func (s *ChatHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+}
+
+func (s *ChatHandlerTestSuite) AfterTest() {
+	if s.mockGateway != nil {
+		s.mockGateway.Close()
+	}
+}
+
+func (s *ChatHandlerTestSuite) setup(mockHandler http.HandlerFunc) {
+	s.mockGateway = httptest.NewServer(mockHandler)
+	s.handler = NewChatHandler(gateway.NewChatGatewayClient(s.mockGateway.URL))
+
+	s.router.Use(func(c *gin.Context) {
+		middleware.SetUserInContext(c, &middleware.User{Username: "testuser"})
+		c.Next()
+	})
+	s.router.POST("/lobbies/:lobby_id/messages", s.handler.SendMessage)
+	s.router.GET("/lobbies/:lobby_id/messages", s.handler.StreamMessages)
+}
+
+func (s *ChatHandlerTestSuite) TestSendMessageSuccess() {
+	var sendReq chat.SendMessageRequest
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &sendReq))
+		resp, _ := protojson.Marshal(&chat.ChatMessage{Id: 1, Username: "testuser", Text: sendReq.Text})
+		_, _ = w.Write(resp)
+	})
+
+	formData := url.Values{"text": {"good luck"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/messages", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("lobby-123", sendReq.LobbyId)
+	s.Contains(w.Body.String(), `"text":"good luck"`)
+}
+
+func (s *ChatHandlerTestSuite) TestSendMessageWhenRateLimited() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	formData := url.Values{"text": {"spam"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/messages", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.JSONEq(`{"error": "You are sending messages too fast, please wait a moment."}`, w.Body.String())
+}
+
+func (s *ChatHandlerTestSuite) TestStreamMessagesRelaysTheMessagesAsEvents() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/lobbies/lobby-123/messages", r.URL.Path)
+		_, _ = w.Write([]byte(`{"result":{"id":1,"username":"player1","text":"first"}}` + "\n"))
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-123/messages", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Header().Get("Content-Type"), "text/event-stream")
+	s.Contains(w.Body.String(), "event:message")
+	s.Contains(w.Body.String(), `"text":"first"`)
+}
+
+func (s *ChatHandlerTestSuite) TestStreamMessagesForAUserOutsideTheLobby() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-123/messages", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), "Only the players and the spectators")
+}
+
+func TestChatHandler(t *testing.T) {
+	suite.Run(t, new(ChatHandlerTestSuite))
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// FriendHandler handles the forms of the index page that manage the friends of the user. Every action goes back to
+// the index page, which shows the friends list and the pending friend requests.
+type FriendHandler struct {
+	friendClient *gateway.FriendGatewayClient
+}
+
+func NewFriendHandler(client *gateway.FriendGatewayClient) *FriendHandler {
+	return &FriendHandler{friendClient: client}
+}
+
+func (h *FriendHandler) SendFriendRequest(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	friendUsername, ok := usernameForm(c, "friend_username", user.Username, "Friend Request Failed")
+	if !ok {
+		return
+	}
+
+	_, err := h.friendClient.SendFriendRequest(c.Request.Context(), &friend.SendFriendRequestRequest{
+		Username:       user.Username,
+		FriendUsername: friendUsername,
+	})
+	if err != nil {
+		renderFriendFailure(c, user.Username, "Friend Request Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (h *FriendHandler) AcceptFriendRequest(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	requestID, ok := uintParam(c, "request_id", user.Username, "Invalid Friend Request",
+		"The friend request identifier is not valid.")
+	if !ok {
+		return
+	}
+
+	_, err := h.friendClient.AcceptFriendRequest(c.Request.Context(), &friend.AcceptFriendRequestRequest{
+		RequestId: requestID,
+		Username:  user.Username,
+	})
+	if err != nil {
+		renderFriendFailure(c, user.Username, "Accept Friend Request Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// RemoveFriend ends a friendship. The same form declines a pending request sent by the user in the path, or
+// withdraws one sent to them.
+func (h *FriendHandler) RemoveFriend(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	err := h.friendClient.RemoveFriend(c.Request.Context(), &friend.RemoveFriendRequest{
+		FriendUsername: c.Param("username"),
+		Username:       user.Username,
+	})
+	if err != nil {
+		renderFriendFailure(c, user.Username, "Remove Friend Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (h *FriendHandler) BlockUser(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	blockedUsername, ok := usernameForm(c, "blocked_username", user.Username, "Block User Failed")
+	if !ok {
+		return
+	}
+
+	err := h.friendClient.BlockUser(c.Request.Context(), &friend.BlockUserRequest{
+		Username:        user.Username,
+		BlockedUsername: blockedUsername,
+	})
+	if err != nil {
+		renderFriendFailure(c, user.Username, "Block User Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// usernameForm reads a username from the form, rendering the error page when it is empty.
+func usernameForm(c *gin.Context, field, username, errorTitle string) (string, bool) {
+	value := strings.TrimSpace(c.PostForm(field))
+	if value == "" {
+		c.HTML(http.StatusBadRequest, indexPageFilename, gin.H{
+			"ErrorTitle":   errorTitle,
+			"ErrorMessage": "The username cannot be empty.",
+			"is_logged_in": true,
+			"username":     username,
+		})
+		return "", false
+	}
+	return value, true
+}
+
+func renderFriendFailure(c *gin.Context, username, title string, err error) {
+	statusCode, message := friendFailure(err)
+	c.HTML(statusCode, indexPageFilename, gin.H{
+		"ErrorTitle":   title,
+		"ErrorMessage": message,
+		"is_logged_in": true,
+		"username":     username,
+	})
+}
+
+func friendFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "You cannot befriend or block yourself, a user you have blocked, or a request that was already accepted."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The user or the friend request does not exist, or you are not friends with that user."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "That user is not accepting friend requests from you, or the request is not addressed to you."
+		case http.StatusConflict:
+			return http.StatusConflict, "You are already friends with that user, or a friend request is pending between you."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while managing your friends."
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type FriendHandlerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockGateway *httptest.Server
+	handler     *FriendHandler
+}
+
+func (s *FriendHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.router.LoadHTMLGlob("../../web/templates/*")
+}
+
+func (s *FriendHandlerTestSuite) AfterTest() {
+	if s.mockGateway != nil {
+		s.mockGateway.Close()
+	}
+}
+
+func (s *FriendHandlerTestSuite) setup(mockHandler http.HandlerFunc) {
+	s.mockGateway = httptest.NewServer(mockHandler)
+	s.handler = NewFriendHandler(gateway.NewFriendGatewayClient(s.mockGateway.URL))
+
+	s.router.Use(func(c *gin.Context) {
+		middleware.SetUserInContext(c, &middleware.User{Username: "testuser"})
+		c.Next()
+	})
+	s.router.POST("/friends/request", s.handler.SendFriendRequest)
+	s.router.POST("/friends/block", s.handler.BlockUser)
+	s.router.POST("/friends/:username/remove", s.handler.RemoveFriend)
+	s.router.POST("/friend-requests/:request_id/accept", s.handler.AcceptFriendRequest)
+}
+
+func (s *FriendHandlerTestSuite) post(path string, form url.Values) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *FriendHandlerTestSuite) TestSendFriendRequestSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var sendReq friend.SendFriendRequestRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &sendReq))
+		s.Equal("testuser", sendReq.Username)
+		s.Equal("bob", sendReq.FriendUsername)
+
+		respBody, _ := protojson.Marshal(&friend.FriendRequest{RequestId: 5})
+		_, _ = w.Write(respBody)
+	})
+
+	w := s.post("/friends/request", url.Values{"friend_username": {" bob "}})
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *FriendHandlerTestSuite) TestSendFriendRequestFailsWithEmptyUsername() {
+	s.setup(nil)
+
+	w := s.post("/friends/request", url.Values{"friend_username": {" "}})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The username cannot be empty.")
+}
+
+func (s *FriendHandlerTestSuite) TestSendFriendRequestWhenAlreadyFriends() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+
+	w := s.post("/friends/request", url.Values{"friend_username": {"bob"}})
+
+	s.Equal(http.StatusConflict, w.Code)
+	s.Contains(w.Body.String(), "You are already friends with that user")
+}
+
+func (s *FriendHandlerTestSuite) TestSendFriendRequestWhenBlocked() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	w := s.post("/friends/request", url.Values{"friend_username": {"bob"}})
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), "That user is not accepting friend requests from you")
+}
+
+func (s *FriendHandlerTestSuite) TestAcceptFriendRequestSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/friends/requests/5/accept", r.URL.Path)
+		respBody, _ := protojson.Marshal(&friend.Friend{Username: "bob"})
+		_, _ = w.Write(respBody)
+	})
+
+	w := s.post("/friend-requests/5/accept", nil)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *FriendHandlerTestSuite) TestAcceptFriendRequestWithAnInvalidRequest() {
+	s.setup(nil)
+
+	w := s.post("/friend-requests/abc/accept", nil)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The friend request identifier is not valid.")
+}
+
+func (s *FriendHandlerTestSuite) TestRemoveFriendSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/friends/bob/remove", r.URL.Path)
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	w := s.post("/friends/bob/remove", nil)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *FriendHandlerTestSuite) TestRemoveFriendWhenNotFriends() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	w := s.post("/friends/bob/remove", nil)
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Contains(w.Body.String(), "you are not friends with that user")
+}
+
+func (s *FriendHandlerTestSuite) TestBlockUserSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var blockReq friend.BlockUserRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &blockReq))
+		s.Equal("testuser", blockReq.Username)
+		s.Equal("bob", blockReq.BlockedUsername)
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	w := s.post("/friends/block", url.Values{"blocked_username": {"bob"}})
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *FriendHandlerTestSuite) TestBlockUserWhenBlockingYourself() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	w := s.post("/friends/block", url.Values{"blocked_username": {"testuser"}})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "You cannot befriend or block yourself")
+}
+
+func TestFriendHandler(t *testing.T) {
+	suite.Run(t, new(FriendHandlerTestSuite))
+}
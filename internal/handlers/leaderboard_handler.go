@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+const leaderboardPageFilename = "leaderboard.html"
+
+type LeaderboardHandler struct {
+	leaderboardClient *gateway.LeaderboardGatewayClient
+}
+
+func NewLeaderboardHandler(client *gateway.LeaderboardGatewayClient) *LeaderboardHandler {
+	return &LeaderboardHandler{leaderboardClient: client}
+}
+
+// ShowLeaderboardPage shows a page of the ranking selected by the metric and season query parameters, all-time wins
+// by default. The page is public; logged users also see their own rank.
+func (h *LeaderboardHandler) ShowLeaderboardPage(c *gin.Context) {
+	metric := c.DefaultQuery("metric", "WINS")
+	data := gin.H{
+		"metric":       metric,
+		"seasons":      []*leaderboard.Season{},
+		"is_logged_in": false,
+	}
+	user, loggedIn := middleware.UserFromContext(c)
+	if loggedIn {
+		data["is_logged_in"] = true
+		data["username"] = user.Username
+	}
+
+	// The season selector is an addition to the page: the leaderboard is still shown without it.
+	if seasons, err := h.leaderboardClient.ListSeasons(c.Request.Context()); err == nil {
+		data["seasons"] = seasons
+	}
+
+	seasonID, err := strconv.ParseUint(c.DefaultQuery("season", "0"), 10, 32)
+	if err != nil {
+		data["ErrorTitle"] = "Error Fetching Leaderboard"
+		data["ErrorMessage"] = "The requested leaderboard does not exist."
+		c.HTML(http.StatusBadRequest, leaderboardPageFilename, data)
+		return
+	}
+	data["season_id"] = uint32(seasonID)
+
+	resp, err := h.leaderboardClient.GetLeaderboard(c.Request.Context(), metric, uint32(seasonID), c.Query("page_token"))
+	if err != nil {
+		statusCode, message := http.StatusInternalServerError, "The server is currently unavailable."
+		var apiErr *gateway.APIError
+		if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound) {
+			statusCode, message = apiErr.StatusCode, "The requested leaderboard does not exist."
+		}
+		data["ErrorTitle"] = "Error Fetching Leaderboard"
+		data["ErrorMessage"] = message
+		c.HTML(statusCode, leaderboardPageFilename, data)
+		return
+	}
+	data["season"] = resp.Season
+	data["entries"] = resp.Entries
+	data["next_page_token"] = resp.NextPageToken
+
+	// Users that did not finish any game are not ranked, the page just says so.
+	if loggedIn {
+		if myRank, err := h.leaderboardClient.GetMyRank(c.Request.Context(), user.Username, metric, uint32(seasonID)); err == nil {
+			data["myRank"] = myRank
+		}
+	}
+
+	c.HTML(http.StatusOK, leaderboardPageFilename, data)
+}
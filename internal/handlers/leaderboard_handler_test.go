@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/leaderboard"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type LeaderboardHandlerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockGateway *httptest.Server
+	handler     *LeaderboardHandler
+}
+
+func (s *LeaderboardHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.router.LoadHTMLGlob("../../web/templates/*")
+}
+
+func (s *LeaderboardHandlerTestSuite) AfterTest() {
+	if s.mockGateway != nil {
+		s.mockGateway.Close()
+	}
+}
+
+func (s *LeaderboardHandlerTestSuite) setup(mockHandler http.HandlerFunc) {
+	s.mockGateway = httptest.NewServer(mockHandler)
+	s.handler = NewLeaderboardHandler(gateway.NewLeaderboardGatewayClient(s.mockGateway.URL))
+	s.router.GET("/leaderboard", s.handler.ShowLeaderboardPage)
+}
+
+func (s *LeaderboardHandlerTestSuite) logIn() {
+	s.router.Use(func(c *gin.Context) {
+		middleware.SetUserInContext(c, &middleware.User{Username: "testuser"})
+		c.Next()
+	})
+}
+
+func (s *LeaderboardHandlerTestSuite) writeProto(w http.ResponseWriter, m proto.Message) {
+	w.WriteHeader(http.StatusOK)
+	body, _ := protojson.Marshal(m)
+	_, err := w.Write(body)
+	if err != nil {
+		s.T().Fatalf("Failed to write response: %v", err)
+	}
+}
+
+// gatewayFixture answers with a running season, a page of its leaderboard and the rank of the user.
+func (s *LeaderboardHandlerTestSuite) gatewayFixture(w http.ResponseWriter, r *http.Request) {
+	season := &leaderboard.Season{
+		Id:       2,
+		Number:   2,
+		StartsAt: timestamppb.New(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		EndsAt:   timestamppb.New(time.Date(2030, time.January, 31, 0, 0, 0, 0, time.UTC)),
+	}
+	switch r.URL.Path {
+	case "/api/v1/seasons":
+		s.writeProto(w, &leaderboard.ListSeasonsResponse{Seasons: []*leaderboard.Season{season, {Id: 1, Number: 1, Archived: true}}})
+	case "/api/v1/leaderboard":
+		s.Equal("RATING", r.URL.Query().Get("metric"))
+		s.Equal("2", r.URL.Query().Get("season_id"))
+		s.writeProto(w, &leaderboard.GetLeaderboardResponse{
+			Season:        season,
+			Entries:       []*leaderboard.LeaderboardEntry{{Rank: 1, Username: "champion", Wins: 9, Losses: 1, Rating: 1234}},
+			NextPageToken: "10",
+		})
+	case "/api/v1/leaderboard/me":
+		s.writeProto(w, &leaderboard.LeaderboardEntry{Rank: 42, Username: "testuser"})
+	}
+}
+
+func (s *LeaderboardHandlerTestSuite) TestShowLeaderboardPageSuccess() {
+	s.logIn()
+	s.setup(s.gatewayFixture)
+
+	req, _ := http.NewRequest(http.MethodGet, "/leaderboard?metric=RATING&season=2", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	body := w.Body.String()
+	s.Contains(body, `href="/users/champion"`)
+	s.Contains(body, "1234")
+	s.Contains(body, "Your rank: <strong>#42</strong>")
+	s.Contains(body, "Season 1 (ended)")
+	s.Contains(body, `<option value="2" selected>Season 2</option>`)
+	s.Contains(body, "From 2030-01-01 to 2030-01-31.")
+	s.Contains(body, `href="/leaderboard?season=2&metric=RATING&page_token=10"`)
+}
+
+func (s *LeaderboardHandlerTestSuite) TestShowLeaderboardPageForAGuest() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.NotEqual("/api/v1/leaderboard/me", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("{}"))
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/leaderboard", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "Nobody is ranked yet.")
+	s.NotContains(w.Body.String(), "Your rank")
+	s.NotContains(w.Body.String(), "Next page")
+}
+
+func (s *LeaderboardHandlerTestSuite) TestShowLeaderboardPageForAnUnrankedUser() {
+	s.logIn()
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/leaderboard/me" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("{}"))
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/leaderboard", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "You are not ranked yet")
+}
+
+func (s *LeaderboardHandlerTestSuite) TestShowLeaderboardPageForAnUnknownSeason() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/leaderboard" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("{}"))
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/leaderboard?season=9", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Contains(w.Body.String(), "The requested leaderboard does not exist.")
+}
+
+func (s *LeaderboardHandlerTestSuite) TestShowLeaderboardPageWithAnInvalidSeason() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.NotEqual("/api/v1/leaderboard", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("{}"))
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/leaderboard?season=current", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The requested leaderboard does not exist.")
+}
+
+func (s *LeaderboardHandlerTestSuite) TestShowLeaderboardPageWhenTheGatewayFails() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/leaderboard", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Contains(w.Body.String(), "The server is currently unavailable.")
+}
+
+func TestLeaderboardHandler(t *testing.T) {
+	suite.Run(t, new(LeaderboardHandlerTestSuite))
+}
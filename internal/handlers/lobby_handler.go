@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
@@ -12,8 +15,9 @@ import (
 )
 
 const (
-	indexPageFilename = "index.html"
-	lobbyPageFilename = "lobby.html"
+	indexPageFilename   = "index.html"
+	lobbyPageFilename   = "lobby.html"
+	matchesPageFilename = "matches.html"
 )
 
 type LobbyHandler struct {
@@ -39,16 +43,27 @@ func (h *LobbyHandler) CreateLobby(c *gin.Context) {
 		return
 	}
 
+	gameMode := c.PostForm("game_mode")
 	createReq := &lobby.CreateLobbyRequest{
-		Name:     lobbyName,
-		Username: user.Username,
+		Name:       lobbyName,
+		Username:   user.Username,
+		Visibility: c.PostForm("visibility"),
+		Password:   c.PostForm("password"),
+		GameMode:   gameMode,
+		Region:     c.PostForm("region"),
+		Settings:   modeSettings(c, gameMode),
 	}
 
 	newLobby, err := h.lobbyClient.CreateLobby(c.Request.Context(), createReq)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, indexPageFilename, gin.H{
+		statusCode, message := http.StatusInternalServerError, fmt.Sprintf("An unexpected error occurred while creating the lobby: %v.", err)
+		var apiErr *gateway.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			statusCode, message = http.StatusBadRequest, "You are already in an active lobby or on a cooldown, or the lobby settings are not valid."
+		}
+		c.HTML(statusCode, indexPageFilename, gin.H{
 			"ErrorTitle":   "Lobby Creation Failed",
-			"ErrorMessage": fmt.Sprintf("An unexpected error occurred while creating the lobby: %v.", err),
+			"ErrorMessage": message,
 			"is_logged_in": true,
 			"username":     user.Username,
 		})
@@ -58,6 +73,20 @@ func (h *LobbyHandler) CreateLobby(c *gin.Context) {
 	c.Redirect(http.StatusSeeOther, "/lobbies/"+newLobby.LobbyId)
 }
 
+// modeSettings collects the settings of the game mode from the create form. The form has the settings of every game
+// mode, named settings.<MODE>.<setting>: only the ones of the chosen mode that are not left to their default are sent.
+func modeSettings(c *gin.Context, gameMode string) map[string]string {
+	prefix := "settings." + gameMode + "."
+	settings := make(map[string]string)
+	for field, values := range c.Request.PostForm {
+		name, ok := strings.CutPrefix(field, prefix)
+		if ok && len(values) > 0 && values[0] != "" {
+			settings[name] = values[0]
+		}
+	}
+	return settings
+}
+
 func (h *LobbyHandler) JoinLobby(c *gin.Context) {
 	user, _ := middleware.UserFromContext(c)
 	lobbyID := c.Param("lobby_id")
@@ -65,13 +94,15 @@ func (h *LobbyHandler) JoinLobby(c *gin.Context) {
 	joinReq := &lobby.JoinLobbyRequest{
 		LobbyId:  lobbyID,
 		Username: user.Username,
+		Password: c.PostForm("password"),
 	}
 
 	err := h.lobbyClient.JoinLobby(c.Request.Context(), joinReq)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, indexPageFilename, gin.H{
+		statusCode, message := joinFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
 			"ErrorTitle":   "Join Lobby Failed",
-			"ErrorMessage": "An unexpected error occurred while joining the lobby.",
+			"ErrorMessage": message,
 			"is_logged_in": true,
 			"username":     user.Username,
 		})
@@ -81,6 +112,360 @@ func (h *LobbyHandler) JoinLobby(c *gin.Context) {
 	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
 }
 
+// SpectateLobby lets the user watch the lobby, with the password posted in the form when the lobby has one.
+func (h *LobbyHandler) SpectateLobby(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	spectateReq := &lobby.SpectateLobbyRequest{
+		LobbyId:  lobbyID,
+		Username: user.Username,
+		Password: c.PostForm("password"),
+	}
+
+	_, err := h.lobbyClient.SpectateLobby(c.Request.Context(), spectateReq)
+	if err != nil {
+		statusCode, message := spectateFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Spectate Lobby Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+func (h *LobbyHandler) JoinLobbyByCode(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	joinCode := strings.TrimSpace(c.PostForm("join_code"))
+	if joinCode == "" {
+		c.HTML(http.StatusBadRequest, indexPageFilename, gin.H{
+			"ErrorTitle":   "Join Lobby Failed",
+			"ErrorMessage": "Join code cannot be empty.",
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	joinReq := &lobby.JoinLobbyByCodeRequest{
+		JoinCode: joinCode,
+		Username: user.Username,
+		Password: c.PostForm("password"),
+	}
+
+	joinedLobby, err := h.lobbyClient.JoinLobbyByCode(c.Request.Context(), joinReq)
+	if err != nil {
+		statusCode, message := joinFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Join Lobby Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+joinedLobby.LobbyId)
+}
+
+func (h *LobbyHandler) SetReady(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	readyReq := &lobby.SetReadyRequest{LobbyId: lobbyID}
+	_, err := h.lobbyClient.SetReady(c.Request.Context(), readyReq)
+	if err != nil {
+		statusCode, message := readyFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Ready Check Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+// DeclineReadyCheck takes the player out of the lobby during its ready check, and brings them back to the home page.
+func (h *LobbyHandler) DeclineReadyCheck(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	declineReq := &lobby.DeclineReadyCheckRequest{LobbyId: lobbyID}
+	_, err := h.lobbyClient.DeclineReadyCheck(c.Request.Context(), declineReq)
+	if err != nil {
+		statusCode, message := declineFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Decline Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// LeaveLobby takes the user out of the lobby and sends them back to the lobby list.
+func (h *LobbyHandler) LeaveLobby(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	leaveReq := &lobby.LeaveLobbyRequest{LobbyId: lobbyID}
+	if _, err := h.lobbyClient.LeaveLobby(c.Request.Context(), leaveReq); err != nil {
+		statusCode, message := leaveFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Leave Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// SearchMatch puts the user in the matchmaking queue of the game mode and region posted in the form. The home page
+// shows the search, and the match once it is found.
+func (h *LobbyHandler) SearchMatch(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	searchReq := &lobby.SearchMatchRequest{GameMode: c.PostForm("game_mode"), Region: c.PostForm("region")}
+	if _, err := h.lobbyClient.SearchMatch(c.Request.Context(), searchReq); err != nil {
+		h.renderMatchmakingFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// CancelSearch takes the user out of the matchmaking queue.
+func (h *LobbyHandler) CancelSearch(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	if err := h.lobbyClient.CancelSearch(c.Request.Context()); err != nil {
+		h.renderMatchmakingFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// AcceptMatch accepts the match found for the user, and takes them to its lobby once every player accepted it.
+func (h *LobbyHandler) AcceptMatch(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	acceptReq := &lobby.RespondMatchRequest{MatchId: c.Param("match_id")}
+	search, err := h.lobbyClient.AcceptMatch(c.Request.Context(), acceptReq)
+	if err != nil {
+		h.renderMatchmakingFailure(c, user.Username, err)
+		return
+	}
+
+	if search.GetLobbyId() != "" {
+		c.Redirect(http.StatusSeeOther, "/lobbies/"+search.GetLobbyId())
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// DeclineMatch declines the match found for the user, which also ends their search.
+func (h *LobbyHandler) DeclineMatch(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	declineReq := &lobby.RespondMatchRequest{MatchId: c.Param("match_id")}
+	if err := h.lobbyClient.DeclineMatch(c.Request.Context(), declineReq); err != nil {
+		h.renderMatchmakingFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (h *LobbyHandler) renderMatchmakingFailure(c *gin.Context, username string, err error) {
+	statusCode, message := matchmakingFailure(err)
+	c.HTML(statusCode, indexPageFilename, gin.H{
+		"ErrorTitle":   "Matchmaking Failed",
+		"ErrorMessage": message,
+		"is_logged_in": true,
+		"username":     username,
+	})
+}
+
+// RequestRematch opens the rematch vote of the finished game.
+func (h *LobbyHandler) RequestRematch(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	rematchReq := &lobby.RequestRematchRequest{LobbyId: lobbyID}
+	finishedLobby, err := h.lobbyClient.RequestRematch(c.Request.Context(), rematchReq)
+	if err != nil {
+		h.renderRematchFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+rematchTarget(finishedLobby))
+}
+
+// RespondRematch accepts or declines the rematch vote, as posted in the form. The player whose acceptance creates
+// the rematch is taken straight to its lobby.
+func (h *LobbyHandler) RespondRematch(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	respondReq := &lobby.RespondRematchRequest{LobbyId: lobbyID, Accept: c.PostForm("accept") == "true"}
+	finishedLobby, err := h.lobbyClient.RespondRematch(c.Request.Context(), respondReq)
+	if err != nil {
+		h.renderRematchFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+rematchTarget(finishedLobby))
+}
+
+func (h *LobbyHandler) renderRematchFailure(c *gin.Context, username string, err error) {
+	statusCode, message := rematchFailure(err)
+	c.HTML(statusCode, indexPageFilename, gin.H{
+		"ErrorTitle":   "Rematch Failed",
+		"ErrorMessage": message,
+		"is_logged_in": true,
+		"username":     username,
+	})
+}
+
+// rematchTarget is the lobby of the rematch once it exists, the finished lobby otherwise.
+func rematchTarget(finishedLobby *lobby.Lobby) string {
+	if finishedLobby.RematchLobbyId != nil {
+		return finishedLobby.GetRematchLobbyId()
+	}
+	return finishedLobby.GetLobbyId()
+}
+
+// SwitchTeam moves the user to the team posted in the form.
+func (h *LobbyHandler) SwitchTeam(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	team, err := strconv.Atoi(c.PostForm("team"))
+	if err != nil {
+		h.renderPlaceFailure(c, user.Username, http.StatusBadRequest, "The team is not valid.")
+		return
+	}
+
+	switchReq := &lobby.SwitchTeamRequest{LobbyId: lobbyID, Username: user.Username, Team: int32(team)}
+	if _, err := h.lobbyClient.SwitchTeam(c.Request.Context(), switchReq); err != nil {
+		statusCode, message := placeFailure(err)
+		h.renderPlaceFailure(c, user.Username, statusCode, message)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+// SwapSeat moves the user to the seat posted in the form, trading places with the player sitting there.
+func (h *LobbyHandler) SwapSeat(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	seat, err := strconv.Atoi(c.PostForm("seat"))
+	if err != nil {
+		h.renderPlaceFailure(c, user.Username, http.StatusBadRequest, "The seat is not valid.")
+		return
+	}
+
+	swapReq := &lobby.SwapSeatRequest{LobbyId: lobbyID, Username: user.Username, Seat: int32(seat)}
+	if _, err := h.lobbyClient.SwapSeat(c.Request.Context(), swapReq); err != nil {
+		statusCode, message := placeFailure(err)
+		h.renderPlaceFailure(c, user.Username, statusCode, message)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+func (h *LobbyHandler) renderPlaceFailure(c *gin.Context, username string, statusCode int, message string) {
+	c.HTML(statusCode, indexPageFilename, gin.H{
+		"ErrorTitle":   "Change Of Place Failed",
+		"ErrorMessage": message,
+		"is_logged_in": true,
+		"username":     username,
+	})
+}
+
+// KickPlayer removes the player posted in the form from the lobby of the host.
+func (h *LobbyHandler) KickPlayer(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	kickReq := &lobby.KickPlayerRequest{LobbyId: lobbyID, PlayerUsername: c.PostForm("player")}
+	if _, err := h.lobbyClient.KickPlayer(c.Request.Context(), kickReq); err != nil {
+		h.renderHostFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+// TransferHost hands over the host role to the player posted in the form.
+func (h *LobbyHandler) TransferHost(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	transferReq := &lobby.TransferHostRequest{LobbyId: lobbyID, NewHostUsername: c.PostForm("player")}
+	if _, err := h.lobbyClient.TransferHost(c.Request.Context(), transferReq); err != nil {
+		h.renderHostFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+// SetLobbyLocked locks the lobby when the form posts locked=true, and unlocks it otherwise.
+func (h *LobbyHandler) SetLobbyLocked(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	lockReq := &lobby.SetLobbyLockedRequest{LobbyId: lobbyID, Locked: c.PostForm("locked") == "true"}
+	if _, err := h.lobbyClient.SetLobbyLocked(c.Request.Context(), lockReq); err != nil {
+		h.renderHostFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+func (h *LobbyHandler) RenameLobby(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	renameReq := &lobby.RenameLobbyRequest{LobbyId: lobbyID, Name: c.PostForm("name")}
+	if _, err := h.lobbyClient.RenameLobby(c.Request.Context(), renameReq); err != nil {
+		h.renderHostFailure(c, user.Username, err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+func (h *LobbyHandler) renderHostFailure(c *gin.Context, username string, err error) {
+	statusCode, message := hostFailure(err)
+	c.HTML(statusCode, indexPageFilename, gin.H{
+		"ErrorTitle":   "Lobby Change Failed",
+		"ErrorMessage": message,
+		"is_logged_in": true,
+		"username":     username,
+	})
+}
+
 func (h *LobbyHandler) GetLobbyPage(c *gin.Context) {
 	user, _ := middleware.UserFromContext(c)
 	lobbyID := c.Param("lobby_id")
@@ -103,17 +488,342 @@ func (h *LobbyHandler) GetLobbyPage(c *gin.Context) {
 		return
 	}
 
+	playing := slices.ContainsFunc(lobbyData.Players, func(player *lobby.Player) bool {
+		return player.Username == user.Username
+	})
+	spectating := slices.ContainsFunc(lobbyData.Spectators, func(spectator *lobby.Spectator) bool {
+		return spectator.Username == user.Username
+	})
 	c.HTML(http.StatusOK, lobbyPageFilename, gin.H{
 		"lobby":        lobbyData,
+		"hosting":      playing && lobbyData.GetHostUsername() == user.Username,
+		"teams":        sequence(1, int(lobbyData.Teams)),
+		"seats":        sequence(0, int(lobbyData.MaxPlayers)),
+		"playing":      playing,
+		"spectating":   spectating,
 		"is_logged_in": c.GetBool("is_logged_in"),
 		"username":     user.Username,
 	})
 }
 
+// sequence returns count consecutive numbers starting from first, for the templates to range over.
+func sequence(first, count int) []int {
+	numbers := make([]int, count)
+	for i := range numbers {
+		numbers[i] = first + i
+	}
+	return numbers
+}
+
+// GetMatchesPage shows the finished games of the user, one page at a time.
+func (h *LobbyHandler) GetMatchesPage(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	matches, err := h.lobbyClient.ListMyMatches(c.Request.Context(), user.Username, c.Query("page_token"))
+	if err != nil {
+		statusCode, message := http.StatusInternalServerError, "The server is currently unavailable."
+		var apiErr *gateway.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			statusCode, message = http.StatusBadRequest, "The requested page of matches does not exist."
+		}
+		c.HTML(statusCode, matchesPageFilename, gin.H{
+			"ErrorTitle":   "Error Fetching Matches",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.HTML(http.StatusOK, matchesPageFilename, gin.H{
+		"matches":         matches.Matches,
+		"next_page_token": matches.NextPageToken,
+		"is_logged_in":    true,
+		"username":        user.Username,
+	})
+}
+
+// joinFailure translates the gateway error into the status code and the message shown to the user.
+func joinFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusNotFound:
+			return http.StatusNotFound, "No lobby matches the given join code."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "Wrong lobby password, the lobby can only be joined with its join code, you were kicked from it, or a player of the lobby has blocked you."
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The lobby is full, locked or not waiting for players anymore, it has no room for your party, or you are already in an active lobby or on a cooldown."
+		case http.StatusConflict:
+			return http.StatusConflict, "Another player joined the lobby at the same time, please try again."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while joining the lobby."
+}
+
+func spectateFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "Wrong lobby password, the lobby is private, you were kicked from it, or a player of the lobby has blocked you."
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The lobby is over or locked, has no room for more spectators, or you are playing in it."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while joining the spectators."
+}
+
+func (h *LobbyHandler) InviteToLobby(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+	lobbyID := c.Param("lobby_id")
+
+	inviteeUsername := strings.TrimSpace(c.PostForm("invitee_username"))
+	if inviteeUsername == "" {
+		c.HTML(http.StatusBadRequest, indexPageFilename, gin.H{
+			"ErrorTitle":   "Invite Failed",
+			"ErrorMessage": "The username to invite cannot be empty.",
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	inviteReq := &lobby.InviteToLobbyRequest{
+		LobbyId:         lobbyID,
+		InviteeUsername: inviteeUsername,
+	}
+
+	_, err := h.lobbyClient.InviteToLobby(c.Request.Context(), inviteReq)
+	if err != nil {
+		statusCode, message := inviteFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Invite Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+lobbyID)
+}
+
+func (h *LobbyHandler) AcceptInvite(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	respondReq, ok := respondInviteRequest(c, user.Username)
+	if !ok {
+		return
+	}
+
+	joinedLobby, err := h.lobbyClient.AcceptInvite(c.Request.Context(), respondReq)
+	if err != nil {
+		statusCode, message := respondInviteFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Accept Invite Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/lobbies/"+joinedLobby.LobbyId)
+}
+
+func (h *LobbyHandler) DeclineInvite(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	respondReq, ok := respondInviteRequest(c, user.Username)
+	if !ok {
+		return
+	}
+
+	err := h.lobbyClient.DeclineInvite(c.Request.Context(), respondReq)
+	if err != nil {
+		statusCode, message := respondInviteFailure(err)
+		c.HTML(statusCode, indexPageFilename, gin.H{
+			"ErrorTitle":   "Decline Invite Failed",
+			"ErrorMessage": message,
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// respondInviteRequest builds the request from the invite_id path parameter, rendering the error page when it is
+// not a valid identifier.
+func respondInviteRequest(c *gin.Context, username string) (*lobby.RespondInviteRequest, bool) {
+	inviteID, err := strconv.ParseUint(c.Param("invite_id"), 10, 32)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, indexPageFilename, gin.H{
+			"ErrorTitle":   "Invalid Invite",
+			"ErrorMessage": "The invite identifier is not valid.",
+			"is_logged_in": true,
+			"username":     username,
+		})
+		return nil, false
+	}
+	return &lobby.RespondInviteRequest{InviteId: uint32(inviteID)}, true
+}
+
+func readyFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The ready check is over."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby you are looking for does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "You are not a player of this lobby."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while confirming the ready check."
+}
+
+func declineFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The ready check is over."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby you are looking for does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "You are not a player of this lobby."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while declining the ready check."
+}
+
+func leaveFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The lobby is over."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby you are looking for does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "You are not a player of this lobby."
+		case http.StatusConflict:
+			return http.StatusConflict, "The lobby changed at the same time, please try again."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while leaving the lobby."
+}
+
+func rematchFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The rematch vote is over, or a player is already in another lobby."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby you are looking for does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "You are not a player of this lobby."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while voting for the rematch."
+}
+
+func hostFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The change is not valid, or the lobby does not allow it anymore."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby or the player you are looking for does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "Only the host of the lobby can do that."
+		case http.StatusConflict:
+			return http.StatusConflict, "The lobby changed at the same time, please try again."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while changing the lobby."
+}
+
+func placeFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The team is full, the place does not exist, or the lobby is not waiting for players anymore."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The lobby you are looking for does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "You are not a player of this lobby."
+		case http.StatusConflict:
+			return http.StatusConflict, "The lobby changed at the same time, please try again."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while changing your place in the lobby."
+}
+
+func inviteFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The user cannot be invited: the lobby is not waiting for players or they are already in it."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The user or the lobby does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "Only the players of the lobby can send invites, and only to users who have not blocked them."
+		case http.StatusConflict:
+			return http.StatusConflict, "That user has already been invited to the lobby."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while sending the invite."
+}
+
+func matchmakingFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The match is over, or you are already searching, in a lobby, in a party or on a cooldown."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "You are not searching for a match, or the match no longer exists."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "You are not a player of this match."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while searching for a match."
+}
+
+func respondInviteFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The invite has expired, was already answered, or the lobby is full."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The invite or its lobby no longer exists."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "This invite is not addressed to you."
+		case http.StatusConflict:
+			return http.StatusConflict, "Another player joined the lobby at the same time, please try again."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while answering the invite."
+}
+
+// FinishLobby reports the result of the game on behalf of the user, who must be one of its players.
 func (h *LobbyHandler) FinishLobby(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
 	lobbyID := c.Param("lobby_id")
 
-	finishedLobby, err := h.lobbyClient.FinishLobby(c.Request.Context(), lobbyID)
+	finishedLobby, err := h.lobbyClient.FinishLobby(c.Request.Context(), lobbyID, user.Username)
 	var apiErr *gateway.APIError
 	if errors.As(err, &apiErr) {
 		c.JSON(apiErr.StatusCode, gin.H{"error": apiErr.Message})
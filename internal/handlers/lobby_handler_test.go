@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
@@ -13,6 +15,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type LobbyHandlerTestSuite struct {
@@ -70,74 +73,1105 @@ func (s *LobbyHandlerTestSuite) TestCreateLobbySuccess() {
 	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
 }
 
+func (s *LobbyHandlerTestSuite) TestCreateLobbySendsTheSettingsOfTheChosenGameMode() {
+	var createReq lobby.CreateLobbyRequest
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &createReq))
+		resp, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123"})
+		_, _ = w.Write(resp)
+	})
+	s.router.POST("/lobbies/create", s.handler.CreateLobby)
+
+	formData := url.Values{
+		"name":                             {"My New Lobby"},
+		"game_mode":                        {"FREE_FOR_ALL"},
+		"region":                           {"NA"},
+		"settings.FREE_FOR_ALL.map":        {"RUINS"},
+		"settings.FREE_FOR_ALL.time_limit": {""},
+		"settings.DUEL.map":                {"CANYON"},
+	}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/create", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("FREE_FOR_ALL", createReq.GameMode)
+	s.Equal("NA", createReq.Region)
+	s.Equal(map[string]string{"map": "RUINS"}, createReq.Settings)
+}
+
 func (s *LobbyHandlerTestSuite) TestCreateLobbyFailsWithEmptyName() {
 	s.setup(nil)
 	s.router.POST("/lobbies/create", s.handler.CreateLobby)
 
-	formData := url.Values{"name": {""}}
-	req, _ := http.NewRequest(http.MethodPost, "/lobbies/create", strings.NewReader(formData.Encode()))
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	formData := url.Values{"name": {""}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/create", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "Lobby Creation Failed")
+	s.Contains(w.Body.String(), "Lobby name cannot be empty.")
+}
+
+func (s *LobbyHandlerTestSuite) TestCreateLobbyFailsWhenTheGatewayFails() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	s.router.POST("/lobbies/create", s.handler.CreateLobby)
+
+	formData := url.Values{"name": {"A Lobby"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/create", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Contains(w.Body.String(), "An unexpected error occurred")
+}
+
+func (s *LobbyHandlerTestSuite) TestCreateLobbyFailsWhenTheUserIsInAnActiveLobby() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/create", s.handler.CreateLobby)
+
+	formData := url.Values{"name": {"A Lobby"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/create", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "You are already in an active lobby")
+}
+
+func (s *LobbyHandlerTestSuite) TestJoinLobbySuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp := &lobby.Lobby{LobbyId: "lobby-456"}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.POST("/lobbies/:lobby_id/join", s.handler.JoinLobby)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-456/join", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-456", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestJoinLobbyGatewayFailure() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	s.router.POST("/lobbies/:lobby_id/join", s.handler.JoinLobby)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/any-id/join", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Contains(w.Body.String(), "Join Lobby Failed")
+	s.Contains(w.Body.String(), "An unexpected error occurred while joining the lobby.")
+}
+
+func (s *LobbyHandlerTestSuite) TestJoinLobbyWithWrongPassword() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	s.router.POST("/lobbies/:lobby_id/join", s.handler.JoinLobby)
+
+	formData := url.Values{"password": {"wrong"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/any-id/join", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), "Wrong lobby password")
+}
+
+func (s *LobbyHandlerTestSuite) TestJoinLobbyLosingARaceForTheLastSeat() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	s.router.POST("/lobbies/:lobby_id/join", s.handler.JoinLobby)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/any-id/join", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusConflict, w.Code)
+	s.Contains(w.Body.String(), "Another player joined the lobby at the same time")
+}
+
+func (s *LobbyHandlerTestSuite) TestSpectateLobbySuccess() {
+	var spectateReq lobby.SpectateLobbyRequest
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/lobbies/lobby-456/spectate", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &spectateReq))
+		resp, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-456"})
+		_, _ = w.Write(resp)
+	})
+	s.router.POST("/lobbies/:lobby_id/spectate", s.handler.SpectateLobby)
+
+	formData := url.Values{"password": {"secret"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-456/spectate", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-456", w.Header().Get("Location"))
+	s.Equal("testuser", spectateReq.Username)
+	s.Equal("secret", spectateReq.Password)
+}
+
+func (s *LobbyHandlerTestSuite) TestSpectateLobbyWhenThereIsNoRoomLeft() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/:lobby_id/spectate", s.handler.SpectateLobby)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-456/spectate", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "Spectate Lobby Failed")
+	s.Contains(w.Body.String(), "no room for more spectators")
+}
+
+func (s *LobbyHandlerTestSuite) TestJoinLobbyByCodeSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var joinReq lobby.JoinLobbyByCodeRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &joinReq))
+		s.Equal("ABC234", joinReq.JoinCode)
+		s.Equal("testuser", joinReq.Username)
+		s.Equal("secret", joinReq.Password)
+
+		w.WriteHeader(http.StatusOK)
+		resp := &lobby.Lobby{LobbyId: "lobby-456"}
+		respBody, _ := protojson.Marshal(resp)
+		_, err := w.Write(respBody)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.POST("/lobbies/join-by-code", s.handler.JoinLobbyByCode)
+
+	formData := url.Values{"join_code": {" ABC234 "}, "password": {"secret"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/join-by-code", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-456", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestJoinLobbyByCodeFailsWithEmptyCode() {
+	s.setup(nil)
+	s.router.POST("/lobbies/join-by-code", s.handler.JoinLobbyByCode)
+
+	formData := url.Values{"join_code": {"  "}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/join-by-code", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "Join code cannot be empty.")
+}
+
+func (s *LobbyHandlerTestSuite) TestJoinLobbyByCodeWithUnknownCode() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	s.router.POST("/lobbies/join-by-code", s.handler.JoinLobbyByCode)
+
+	formData := url.Values{"join_code": {"ZZZZZZ"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/join-by-code", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Contains(w.Body.String(), "No lobby matches the given join code.")
+}
+
+func (s *LobbyHandlerTestSuite) TestSetReadySuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var readyReq lobby.SetReadyRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &readyReq))
+		s.Equal("lobby-123", readyReq.LobbyId)
+
+		w.WriteHeader(http.StatusOK)
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123"})
+		_, err := w.Write(respBody)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.POST("/lobbies/:lobby_id/ready", s.handler.SetReady)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/ready", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestSetReadyWhenTheReadyCheckIsOver() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/:lobby_id/ready", s.handler.SetReady)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/ready", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The ready check is over.")
+}
+
+func (s *LobbyHandlerTestSuite) TestDeclineReadyCheckSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/lobbies/lobby-123/decline", r.URL.Path)
+		var declineReq lobby.DeclineReadyCheckRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &declineReq))
+		s.Equal("lobby-123", declineReq.LobbyId)
+
+		w.WriteHeader(http.StatusOK)
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123", Status: "WAITING"})
+		_, err := w.Write(respBody)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.POST("/lobbies/:lobby_id/decline", s.handler.DeclineReadyCheck)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/decline", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestDeclineReadyCheckWhenTheReadyCheckIsOver() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/:lobby_id/decline", s.handler.DeclineReadyCheck)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/decline", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The ready check is over.")
+}
+
+func (s *LobbyHandlerTestSuite) TestSearchMatchSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("/api/v1/matchmaking", r.URL.Path)
+		var searchReq lobby.SearchMatchRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &searchReq))
+		s.Equal("TEAM_DEATHMATCH", searchReq.GameMode)
+		s.Equal("NA", searchReq.Region)
+
+		respBody, _ := protojson.Marshal(&lobby.MatchSearch{Status: "SEARCHING"})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/matchmaking/search", s.handler.SearchMatch)
+
+	formData := url.Values{"game_mode": {"TEAM_DEATHMATCH"}, "region": {"NA"}}
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/search", strings.NewReader(formData.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestSearchMatchWhenOnACooldown() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/matchmaking/search", s.handler.SearchMatch)
+
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/search", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "Matchmaking Failed")
+}
+
+func (s *LobbyHandlerTestSuite) TestCancelSearchSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPut, r.Method)
+		s.Equal("/api/v1/matchmaking/cancel", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+	s.router.POST("/matchmaking/cancel", s.handler.CancelSearch)
+
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/cancel", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestAcceptMatchTakesThePlayerToTheLobbyOfTheMatch() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPut, r.Method)
+		s.Equal("/api/v1/matchmaking/match-1/accept", r.URL.Path)
+
+		respBody, _ := protojson.Marshal(&lobby.MatchSearch{Status: "MATCHED", MatchId: "match-1", LobbyId: "lobby-123"})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/matchmaking/:match_id/accept", s.handler.AcceptMatch)
+
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/match-1/accept", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestAcceptMatchWaitsForTheOtherPlayers() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		respBody, _ := protojson.Marshal(&lobby.MatchSearch{Status: "MATCH_FOUND", MatchId: "match-1", Accepted: true})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/matchmaking/:match_id/accept", s.handler.AcceptMatch)
+
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/match-1/accept", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestAcceptMatchWhenTheMatchIsOver() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/matchmaking/:match_id/accept", s.handler.AcceptMatch)
+
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/match-1/accept", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The match is over")
+}
+
+func (s *LobbyHandlerTestSuite) TestDeclineMatchSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPut, r.Method)
+		s.Equal("/api/v1/matchmaking/match-1/decline", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+	s.router.POST("/matchmaking/:match_id/decline", s.handler.DeclineMatch)
+
+	req, _ := http.NewRequest(http.MethodPost, "/matchmaking/match-1/decline", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestLeaveLobbySuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPut, r.Method)
+		s.Equal("/api/v1/lobbies/lobby-123/leave", r.URL.Path)
+
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123", Status: "WAITING"})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/lobbies/:lobby_id/leave", s.handler.LeaveLobby)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/leave", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestLeaveLobbyWhenTheLobbyIsOver() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/:lobby_id/leave", s.handler.LeaveLobby)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/leave", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The lobby is over.")
+}
+
+func (s *LobbyHandlerTestSuite) TestRequestRematchSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var rematchReq lobby.RequestRematchRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &rematchReq))
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("/api/v1/lobbies/lobby-123/rematch", r.URL.Path)
+
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123"})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/lobbies/:lobby_id/rematch", s.handler.RequestRematch)
+
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/rematch", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestRespondRematchTakesThePlayerToTheRematch() {
+	rematchLobbyID := "lobby-456"
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var respondReq lobby.RespondRematchRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &respondReq))
+		s.Equal(http.MethodPut, r.Method)
+		s.True(respondReq.Accept)
+
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123", RematchLobbyId: &rematchLobbyID})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/lobbies/:lobby_id/rematch/respond", s.handler.RespondRematch)
+
+	formData := url.Values{"accept": {"true"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/rematch/respond", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-456", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestRespondRematchWhenTheVoteIsOver() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/:lobby_id/rematch/respond", s.handler.RespondRematch)
+
+	formData := url.Values{"accept": {"false"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/rematch/respond", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The rematch vote is over")
+}
+
+func (s *LobbyHandlerTestSuite) TestSwitchTeamSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var switchReq lobby.SwitchTeamRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &switchReq))
+		s.Equal("/api/v1/lobbies/lobby-123/team", r.URL.Path)
+		s.Equal("testuser", switchReq.Username)
+		s.Equal(int32(2), switchReq.Team)
+
+		w.WriteHeader(http.StatusOK)
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123"})
+		_, err := w.Write(respBody)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.POST("/lobbies/:lobby_id/team", s.handler.SwitchTeam)
+
+	form := url.Values{"team": {"2"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/team", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestSwitchTeamWhenTheTeamIsFull() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/:lobby_id/team", s.handler.SwitchTeam)
+
+	form := url.Values{"team": {"2"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/team", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The team is full")
+}
+
+func (s *LobbyHandlerTestSuite) TestSwapSeatWithAnInvalidSeat() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("the gateway should not be called")
+	})
+	s.router.POST("/lobbies/:lobby_id/seat", s.handler.SwapSeat)
+
+	form := url.Values{"seat": {"first"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/seat", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The seat is not valid.")
+}
+
+func (s *LobbyHandlerTestSuite) TestKickPlayerSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var kickReq lobby.KickPlayerRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &kickReq))
+		s.Equal(http.MethodPut, r.Method)
+		s.Equal("/api/v1/lobbies/lobby-123/kick", r.URL.Path)
+		s.Equal("troll", kickReq.PlayerUsername)
+
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123"})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/lobbies/:lobby_id/kick", s.handler.KickPlayer)
+
+	form := url.Values{"player": {"troll"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/kick", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestKickPlayerWhenTheUserIsNotTheHost() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	s.router.POST("/lobbies/:lobby_id/kick", s.handler.KickPlayer)
+
+	form := url.Values{"player": {"other"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/kick", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), "Only the host of the lobby can do that.")
+}
+
+func (s *LobbyHandlerTestSuite) TestTransferHostSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var transferReq lobby.TransferHostRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &transferReq))
+		s.Equal("/api/v1/lobbies/lobby-123/host", r.URL.Path)
+		s.Equal("other", transferReq.NewHostUsername)
+
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123"})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/lobbies/:lobby_id/host", s.handler.TransferHost)
+
+	form := url.Values{"player": {"other"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/host", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestSetLobbyLockedSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var lockReq lobby.SetLobbyLockedRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &lockReq))
+		s.Equal("/api/v1/lobbies/lobby-123/lock", r.URL.Path)
+		s.True(lockReq.Locked)
+
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123", Locked: true})
+		_, _ = w.Write(respBody)
+	})
+	s.router.POST("/lobbies/:lobby_id/lock", s.handler.SetLobbyLocked)
+
+	form := url.Values{"locked": {"true"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/lock", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+}
+
+func (s *LobbyHandlerTestSuite) TestRenameLobbyWithAnEmptyName() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/lobbies/:lobby_id/name", s.handler.RenameLobby)
+
+	form := url.Values{"name": {""}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/name", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The change is not valid")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsTheHostControlsToTheHost() {
+	hostUsername := "testuser"
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId:      "lobby-789",
+			Status:       "WAITING",
+			MaxPlayers:   2,
+			HostUsername: &hostUsername,
+			Players:      []*lobby.Player{{Username: "testuser"}, {Username: "other", Seat: 1}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "testuser</a> (host)")
+	s.Contains(w.Body.String(), `id="host-container"`)
+	s.Contains(w.Body.String(), `action="/lobbies/lobby-789/kick"`)
+	s.Contains(w.Body.String(), `<input type="hidden" name="locked" value="true">`)
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageHidesTheHostControlsFromTheOtherPlayers() {
+	hostUsername := "other"
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId:      "lobby-789",
+			Status:       "WAITING",
+			MaxPlayers:   2,
+			HostUsername: &hostUsername,
+			Locked:       true,
+			Players:      []*lobby.Player{{Username: "testuser"}, {Username: "other", Seat: 1}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "other</a> (host)")
+	s.Contains(w.Body.String(), "(locked)")
+	s.NotContains(w.Body.String(), `id="host-container"`)
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageLetsThePlayerChangeTeamAndSeat() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp := &lobby.Lobby{
+			LobbyId:    "lobby-789",
+			Status:     "WAITING",
+			MaxPlayers: 4,
+			Teams:      2,
+			Players:    []*lobby.Player{{Username: "testuser", Team: 1}, {Username: "other", Seat: 1, Team: 2}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "Seat 1, Team 2")
+	s.Contains(w.Body.String(), "Switch to team 2")
+	s.NotContains(w.Body.String(), "Switch to team 1")
+	s.Contains(w.Body.String(), `<option value="3">Seat 3</option>`)
+	s.NotContains(w.Body.String(), `<option value="0">`)
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsTheWinningTeam() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		winningTeam := int32(2)
+		resp := &lobby.Lobby{
+			LobbyId:     "lobby-789",
+			Status:      "FINISHED",
+			Teams:       2,
+			WinningTeam: &winningTeam,
+			Players:     []*lobby.Player{{Username: "testuser", Team: 1}, {Username: "other", Seat: 1, Team: 2}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "Winning team: <span id=\"winner\">Team 2</span>")
+	s.NotContains(w.Body.String(), "Switch to team")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageLetsThePlayersAskForARematch() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId: "lobby-789",
+			Status:  "FINISHED",
+			Players: []*lobby.Player{{Username: "testuser"}, {Username: "opponent", Seat: 1}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `action="/lobbies/lobby-789/rematch"`)
+	s.NotContains(w.Body.String(), "/lobbies/lobby-789/rematch/respond")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageDuringTheRematchVote() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId: "lobby-789",
+			Status:  "FINISHED",
+			Players: []*lobby.Player{
+				{Username: "opponent", RematchAccepted: true},
+				{Username: "testuser", Seat: 1},
+			},
+			Deadlines: map[string]*timestamppb.Timestamp{"REMATCH": timestamppb.Now()},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "Rematch vote:")
+	s.Contains(w.Body.String(), "<li>opponent: accepted</li>")
+	s.Contains(w.Body.String(), "<li>testuser: waiting</li>")
+	s.Contains(w.Body.String(), "/lobbies/lobby-789/rematch/respond")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageLinksTheRematch() {
+	rematchLobbyID := "lobby-790"
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId:        "lobby-789",
+			Status:         "FINISHED",
+			Players:        []*lobby.Player{{Username: "testuser"}},
+			RematchLobbyId: &rematchLobbyID,
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `<a id="rematch-link" href="/lobbies/lobby-790">`)
+	s.NotContains(w.Body.String(), `action="/lobbies/lobby-789/rematch"`)
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageDuringReadyCheck() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp := &lobby.Lobby{
+			LobbyId:            "lobby-789",
+			Status:             "READY_CHECK",
+			Players:            []*lobby.Player{{Username: "testuser"}, {Username: "other", Ready: true}},
+			ReadyCheckDeadline: timestamppb.New(time.Date(2030, time.January, 1, 12, 0, 0, 0, time.UTC)),
+			Deadlines: map[string]*timestamppb.Timestamp{
+				"READY_CHECK": timestamppb.New(time.Date(2030, time.January, 1, 12, 0, 0, 0, time.UTC)),
+			},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "/lobbies/lobby-789/ready")
+	s.Contains(w.Body.String(), "/lobbies/lobby-789/decline")
+	s.Contains(w.Body.String(), "2030-01-01T12:00:00Z")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsTheEndOfTheGame() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp := &lobby.Lobby{
+			LobbyId: "lobby-789",
+			Status:  "IN_PROGRESS",
+			Players: []*lobby.Player{{Username: "testuser"}, {Username: "other"}},
+			Deadlines: map[string]*timestamppb.Timestamp{
+				"GAME_END": timestamppb.New(time.Date(2030, time.January, 1, 12, 5, 0, 0, time.UTC)),
+			},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
 
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
 	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "Game ends in")
+	s.Contains(w.Body.String(), "2030-01-01T12:05:00Z")
+	s.NotContains(w.Body.String(), "/finish")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsTheDisconnectedPlayers() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		resp := &lobby.Lobby{
+			LobbyId: "lobby-789",
+			Status:  "IN_PROGRESS",
+			Players: []*lobby.Player{
+				{Username: "testuser"},
+				{Username: "other", DisconnectedAt: timestamppb.New(time.Date(2030, time.January, 1, 12, 0, 0, 0, time.UTC))},
+				{Username: "gone", Abandoned: true},
+			},
+			Deadlines: map[string]*timestamppb.Timestamp{
+				"RECONNECT": timestamppb.New(time.Date(2030, time.January, 1, 12, 1, 0, 0, time.UTC)),
+			},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
 
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusBadRequest, w.Code)
-	s.Contains(w.Body.String(), "Lobby Creation Failed")
-	s.Contains(w.Body.String(), "Lobby name cannot be empty.")
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "other</a> (disconnected)")
+	s.Contains(w.Body.String(), "gone</a> (abandoned)")
+	s.Contains(w.Body.String(), "reconnect-container")
+	s.Contains(w.Body.String(), "2030-01-01T12:01:00Z")
 }
 
-func (s *LobbyHandlerTestSuite) TestCreateLobbyFailsWhenTheGatewayFails() {
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsThatTheGameWasAbandoned() {
 	s.setup(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusOK)
+		resp := &lobby.Lobby{LobbyId: "lobby-789", Status: "ABANDONED", Players: []*lobby.Player{{Username: "testuser"}}}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
 	})
-	s.router.POST("/lobbies/create", s.handler.CreateLobby)
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
 
-	formData := url.Values{"name": {"A Lobby"}}
-	req, _ := http.NewRequest(http.MethodPost, "/lobbies/create", strings.NewReader(formData.Encode()))
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
 
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "stopped without a result")
+	s.NotContains(w.Body.String(), "reconnect-container")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsWhyTheLobbyWasClosed() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		closeReason := "CREATOR_INACTIVE"
+		resp := &lobby.Lobby{LobbyId: "lobby-789", Status: "CANCELLED", CloseReason: &closeReason}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusInternalServerError, w.Code)
-	s.Contains(w.Body.String(), "An unexpected error occurred")
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "its creator went inactive")
 }
 
-func (s *LobbyHandlerTestSuite) TestJoinLobbySuccess() {
+func (s *LobbyHandlerTestSuite) TestGetMatchesPageSuccess() {
 	s.setup(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		resp := &lobby.Lobby{LobbyId: "lobby-456"}
+		winner := "testuser"
+		resp := &lobby.ListMyMatchesResponse{
+			Matches: []*lobby.Match{{
+				Lobby:      &lobby.Lobby{LobbyId: "lobby-789", Name: "The Best Lobby", WinnerUsername: &winner},
+				Placement:  1,
+				FinishedAt: timestamppb.New(time.Date(2030, time.January, 1, 12, 5, 0, 0, time.UTC)),
+			}},
+			NextPageToken: "10",
+		}
 		body, _ := protojson.Marshal(resp)
 		_, err := w.Write(body)
 		if err != nil {
 			s.T().Fatalf("Failed to write response: %v", err)
 		}
 	})
-	s.router.POST("/lobbies/:lobby_id/join", s.handler.JoinLobby)
+	s.router.GET("/matches", s.handler.GetMatchesPage)
 
-	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-456/join", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/matches", nil)
 	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusSeeOther, w.Code)
-	s.Equal("/lobbies/lobby-456", w.Header().Get("Location"))
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "The Best Lobby")
+	s.Contains(w.Body.String(), "2030-01-01 12:05")
+	s.Contains(w.Body.String(), `href="/matches?page_token=10"`)
 }
 
-func (s *LobbyHandlerTestSuite) TestJoinLobbyGatewayFailure() {
+func (s *LobbyHandlerTestSuite) TestGetMatchesPageWithoutMatches() {
 	s.setup(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte("{}"))
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
 	})
-	s.router.POST("/lobbies/:lobby_id/join", s.handler.JoinLobby)
+	s.router.GET("/matches", s.handler.GetMatchesPage)
 
-	req, _ := http.NewRequest(http.MethodPost, "/lobbies/any-id/join", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/matches", nil)
 	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "You have not finished any game yet.")
+	s.NotContains(w.Body.String(), "Older matches")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetMatchesPageWithAnInvalidPageToken() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.GET("/matches", s.handler.GetMatchesPage)
 
+	req, _ := http.NewRequest(http.MethodGet, "/matches?page_token=oops", nil)
+	w := httptest.NewRecorder()
 	s.router.ServeHTTP(w, req)
 
-	s.Equal(http.StatusInternalServerError, w.Code)
-	s.Contains(w.Body.String(), "Join Lobby Failed")
-	s.Contains(w.Body.String(), "An unexpected error occurred while joining the lobby.")
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The requested page of matches does not exist.")
 }
 
 func (s *LobbyHandlerTestSuite) TestGetLobbyPageSuccess() {
@@ -160,6 +1194,110 @@ func (s *LobbyHandlerTestSuite) TestGetLobbyPageSuccess() {
 	s.Contains(w.Body.String(), "The Best Lobby")
 }
 
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsTheJoinCodeOnlyWhenTheGatewayReturnsIt() {
+	joinCode := "ABC234"
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-789", Name: "The Best Lobby", JoinCode: joinCode})
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "Join Code:")
+	s.Contains(w.Body.String(), "ABC234")
+
+	joinCode = ""
+	w = httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.NotContains(w.Body.String(), "Join Code:")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageShowsTheGameModeAndSettings() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId:    "lobby-789",
+			Name:       "The Best Lobby",
+			Status:     "WAITING",
+			GameMode:   "FREE_FOR_ALL",
+			Region:     "NA",
+			Settings:   map[string]string{"map": "RUINS", "time_limit": "15"},
+			MaxPlayers: 4,
+			Players:    []*lobby.Player{{Username: "creator"}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "FREE_FOR_ALL (1/4 players)")
+	s.Contains(w.Body.String(), "<strong>Region:</strong> NA")
+	s.Contains(w.Body.String(), "<li>map: RUINS</li>")
+	s.Contains(w.Body.String(), "<li>time_limit: 15</li>")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageIsReadOnlyForSpectators() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId:    "lobby-789",
+			Name:       "The Best Lobby",
+			Status:     "WAITING",
+			MaxPlayers: 2,
+			Players:    []*lobby.Player{{Username: "creator"}},
+			Spectators: []*lobby.Spectator{{Username: "testuser"}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `<ul id="spectators">`)
+	s.Contains(w.Body.String(), `id="spectator-notice"`)
+	s.Contains(w.Body.String(), `id="chat-panel"`)
+	s.NotContains(w.Body.String(), "/lobbies/lobby-789/invite")
+	s.NotContains(w.Body.String(), "/lobbies/lobby-789/spectate")
+}
+
+func (s *LobbyHandlerTestSuite) TestGetLobbyPageLetsOtherUsersWatch() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		resp := &lobby.Lobby{
+			LobbyId:    "lobby-789",
+			Name:       "The Best Lobby",
+			Status:     "IN_PROGRESS",
+			Visibility: "PUBLIC",
+			MaxPlayers: 2,
+			Players:    []*lobby.Player{{Username: "creator"}, {Username: "opponent", Seat: 1}},
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/lobbies/:lobby_id", s.handler.GetLobbyPage)
+
+	req, _ := http.NewRequest(http.MethodGet, "/lobbies/lobby-789", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "/lobbies/lobby-789/spectate")
+	s.NotContains(w.Body.String(), `id="spectator-notice"`)
+	s.NotContains(w.Body.String(), `id="chat-panel"`)
+}
+
 func (s *LobbyHandlerTestSuite) TestGetLobbyPageGatewayFailure() {
 	s.setup(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -191,9 +1329,13 @@ func (s *LobbyHandlerTestSuite) TestGetLobbyPageNotFound() {
 
 func (s *LobbyHandlerTestSuite) TestFinishLobbySuccess() {
 	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var finishReq lobby.FinishGameRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &finishReq))
+		s.Equal("testuser", finishReq.Username)
 		w.WriteHeader(http.StatusOK)
 		resp := &lobby.Lobby{LobbyId: "lobby-abc", Status: "Finished"}
-		body, _ := protojson.Marshal(resp)
+		body, _ = protojson.Marshal(resp)
 		_, err := w.Write(body)
 		if err != nil {
 			s.T().Fatalf("Failed to write response: %v", err)
@@ -228,6 +1370,146 @@ func (s *LobbyHandlerTestSuite) TestFinishLobbyGatewayFailure() {
 	s.JSONEq(`{"error": "An unexpected error occurred"}`, w.Body.String())
 }
 
+func (s *LobbyHandlerTestSuite) TestInviteToLobbySuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var inviteReq lobby.InviteToLobbyRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &inviteReq))
+		s.Equal("lobby-123", inviteReq.LobbyId)
+		s.Equal("friend", inviteReq.InviteeUsername)
+
+		w.WriteHeader(http.StatusOK)
+		respBody, _ := protojson.Marshal(&lobby.Invite{InviteId: 7})
+		_, err := w.Write(respBody)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.POST("/lobbies/:lobby_id/invite", s.handler.InviteToLobby)
+
+	formData := url.Values{"invitee_username": {"friend"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/invite", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestInviteToLobbyFailsWithEmptyUsername() {
+	s.setup(nil)
+	s.router.POST("/lobbies/:lobby_id/invite", s.handler.InviteToLobby)
+
+	formData := url.Values{"invitee_username": {" "}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/invite", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The username to invite cannot be empty.")
+}
+
+func (s *LobbyHandlerTestSuite) TestInviteToLobbyWhenAlreadyInvited() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	})
+	s.router.POST("/lobbies/:lobby_id/invite", s.handler.InviteToLobby)
+
+	formData := url.Values{"invitee_username": {"friend"}}
+	req, _ := http.NewRequest(http.MethodPost, "/lobbies/lobby-123/invite", strings.NewReader(formData.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusConflict, w.Code)
+	s.Contains(w.Body.String(), "already been invited")
+}
+
+func (s *LobbyHandlerTestSuite) TestAcceptInviteSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/invites/7/accept", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		respBody, _ := protojson.Marshal(&lobby.Lobby{LobbyId: "lobby-123"})
+		_, err := w.Write(respBody)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.POST("/invites/:invite_id/accept", s.handler.AcceptInvite)
+
+	req, _ := http.NewRequest(http.MethodPost, "/invites/7/accept", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestAcceptInviteWhenExpired() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.POST("/invites/:invite_id/accept", s.handler.AcceptInvite)
+
+	req, _ := http.NewRequest(http.MethodPost, "/invites/7/accept", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The invite has expired")
+}
+
+func (s *LobbyHandlerTestSuite) TestAcceptInviteWithInvalidID() {
+	s.setup(nil)
+	s.router.POST("/invites/:invite_id/accept", s.handler.AcceptInvite)
+
+	req, _ := http.NewRequest(http.MethodPost, "/invites/not-a-number/accept", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The invite identifier is not valid.")
+}
+
+func (s *LobbyHandlerTestSuite) TestDeclineInviteSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/invites/7/decline", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	})
+	s.router.POST("/invites/:invite_id/decline", s.handler.DeclineInvite)
+
+	req, _ := http.NewRequest(http.MethodPost, "/invites/7/decline", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *LobbyHandlerTestSuite) TestDeclineInviteNotAddressedToUser() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	s.router.POST("/invites/:invite_id/decline", s.handler.DeclineInvite)
+
+	req, _ := http.NewRequest(http.MethodPost, "/invites/7/decline", nil)
+	w := httptest.NewRecorder()
+
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), "This invite is not addressed to you.")
+}
+
 func TestLobbyHandler(t *testing.T) {
 	suite.Run(t, new(LobbyHandlerTestSuite))
 }
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// PartyHandler handles the forms of the index page that manage the party of the user. Every action goes back to the
+// index page, which shows the party and the pending party invites.
+type PartyHandler struct {
+	partyClient *gateway.PartyGatewayClient
+}
+
+func NewPartyHandler(client *gateway.PartyGatewayClient) *PartyHandler {
+	return &PartyHandler{partyClient: client}
+}
+
+func (h *PartyHandler) CreateParty(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	_, err := h.partyClient.CreateParty(c.Request.Context(), &party.CreatePartyRequest{Username: user.Username})
+	if err != nil {
+		renderPartyFailure(c, user.Username, "Create Party Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (h *PartyHandler) InviteToParty(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	partyID, ok := uintParam(c, "party_id", user.Username, "Invalid Party", "The party identifier is not valid.")
+	if !ok {
+		return
+	}
+
+	inviteeUsername := strings.TrimSpace(c.PostForm("invitee_username"))
+	if inviteeUsername == "" {
+		c.HTML(http.StatusBadRequest, indexPageFilename, gin.H{
+			"ErrorTitle":   "Party Invite Failed",
+			"ErrorMessage": "The username to invite cannot be empty.",
+			"is_logged_in": true,
+			"username":     user.Username,
+		})
+		return
+	}
+
+	_, err := h.partyClient.InviteToParty(c.Request.Context(), &party.InviteToPartyRequest{
+		PartyId:         partyID,
+		Username:        user.Username,
+		InviteeUsername: inviteeUsername,
+	})
+	if err != nil {
+		renderPartyFailure(c, user.Username, "Party Invite Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (h *PartyHandler) AcceptPartyInvite(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	inviteID, ok := uintParam(c, "invite_id", user.Username, "Invalid Invite", "The invite identifier is not valid.")
+	if !ok {
+		return
+	}
+
+	_, err := h.partyClient.AcceptPartyInvite(c.Request.Context(), &party.RespondPartyInviteRequest{
+		InviteId: inviteID,
+		Username: user.Username,
+	})
+	if err != nil {
+		renderPartyFailure(c, user.Username, "Accept Party Invite Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (h *PartyHandler) DeclinePartyInvite(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	inviteID, ok := uintParam(c, "invite_id", user.Username, "Invalid Invite", "The invite identifier is not valid.")
+	if !ok {
+		return
+	}
+
+	err := h.partyClient.DeclinePartyInvite(c.Request.Context(), &party.RespondPartyInviteRequest{
+		InviteId: inviteID,
+		Username: user.Username,
+	})
+	if err != nil {
+		renderPartyFailure(c, user.Username, "Decline Party Invite Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+func (h *PartyHandler) LeaveParty(c *gin.Context) {
+	user, _ := middleware.UserFromContext(c)
+
+	partyID, ok := uintParam(c, "party_id", user.Username, "Invalid Party", "The party identifier is not valid.")
+	if !ok {
+		return
+	}
+
+	err := h.partyClient.LeaveParty(c.Request.Context(), &party.LeavePartyRequest{
+		PartyId:  partyID,
+		Username: user.Username,
+	})
+	if err != nil {
+		renderPartyFailure(c, user.Username, "Leave Party Failed", err)
+		return
+	}
+
+	c.Redirect(http.StatusSeeOther, "/")
+}
+
+// uintParam parses an identifier from the path, rendering the error page when it is not valid.
+func uintParam(c *gin.Context, name, username, errorTitle, errorMessage string) (uint32, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 32)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, indexPageFilename, gin.H{
+			"ErrorTitle":   errorTitle,
+			"ErrorMessage": errorMessage,
+			"is_logged_in": true,
+			"username":     username,
+		})
+		return 0, false
+	}
+	return uint32(id), true
+}
+
+func renderPartyFailure(c *gin.Context, username, title string, err error) {
+	statusCode, message := partyFailure(err)
+	c.HTML(statusCode, indexPageFilename, gin.H{
+		"ErrorTitle":   title,
+		"ErrorMessage": message,
+		"is_logged_in": true,
+		"username":     username,
+	})
+}
+
+func partyFailure(err error) (int, string) {
+	var apiErr *gateway.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusBadRequest:
+			return http.StatusBadRequest, "The party is full, the invite is no longer valid, or you are already in a party."
+		case http.StatusNotFound:
+			return http.StatusNotFound, "The user, the party or the invite does not exist."
+		case http.StatusForbidden:
+			return http.StatusForbidden, "Only the leader can invite users to the party, only users who have not blocked them, and only the invited user can answer an invite."
+		case http.StatusConflict:
+			return http.StatusConflict, "That user has already been invited to the party."
+		}
+	}
+	return http.StatusInternalServerError, "An unexpected error occurred while managing the party."
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type PartyHandlerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockGateway *httptest.Server
+	handler     *PartyHandler
+}
+
+func (s *PartyHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.router.LoadHTMLGlob("../../web/templates/*")
+}
+
+func (s *PartyHandlerTestSuite) AfterTest() {
+	if s.mockGateway != nil {
+		s.mockGateway.Close()
+	}
+}
+
+func (s *PartyHandlerTestSuite) setup(mockHandler http.HandlerFunc) {
+	s.mockGateway = httptest.NewServer(mockHandler)
+	s.handler = NewPartyHandler(gateway.NewPartyGatewayClient(s.mockGateway.URL))
+
+	s.router.Use(func(c *gin.Context) {
+		middleware.SetUserInContext(c, &middleware.User{Username: "testuser"})
+		c.Next()
+	})
+	s.router.POST("/parties/create", s.handler.CreateParty)
+	s.router.POST("/parties/:party_id/invite", s.handler.InviteToParty)
+	s.router.POST("/parties/:party_id/leave", s.handler.LeaveParty)
+	s.router.POST("/party-invites/:invite_id/accept", s.handler.AcceptPartyInvite)
+	s.router.POST("/party-invites/:invite_id/decline", s.handler.DeclinePartyInvite)
+}
+
+func (s *PartyHandlerTestSuite) post(path string, form url.Values) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *PartyHandlerTestSuite) TestCreatePartySuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var createReq party.CreatePartyRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &createReq))
+		s.Equal("testuser", createReq.Username)
+
+		respBody, _ := protojson.Marshal(&party.Party{PartyId: 3})
+		_, _ = w.Write(respBody)
+	})
+
+	w := s.post("/parties/create", nil)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *PartyHandlerTestSuite) TestCreatePartyWhenAlreadyInAParty() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	w := s.post("/parties/create", nil)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "you are already in a party")
+}
+
+func (s *PartyHandlerTestSuite) TestInviteToPartySuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		var inviteReq party.InviteToPartyRequest
+		body, _ := io.ReadAll(r.Body)
+		s.Require().NoError(protojson.Unmarshal(body, &inviteReq))
+		s.Equal(uint32(3), inviteReq.PartyId)
+		s.Equal("testuser", inviteReq.Username)
+		s.Equal("friend", inviteReq.InviteeUsername)
+
+		respBody, _ := protojson.Marshal(&party.PartyInvite{InviteId: 7})
+		_, _ = w.Write(respBody)
+	})
+
+	w := s.post("/parties/3/invite", url.Values{"invitee_username": {"friend"}})
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *PartyHandlerTestSuite) TestInviteToPartyFailsWithEmptyUsername() {
+	s.setup(nil)
+
+	w := s.post("/parties/3/invite", url.Values{"invitee_username": {" "}})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The username to invite cannot be empty.")
+}
+
+func (s *PartyHandlerTestSuite) TestInviteToPartyWhenNotTheLeader() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	w := s.post("/parties/3/invite", url.Values{"invitee_username": {"friend"}})
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Contains(w.Body.String(), "Only the leader can invite users to the party")
+}
+
+func (s *PartyHandlerTestSuite) TestInviteToPartyWithAnInvalidParty() {
+	s.setup(nil)
+
+	w := s.post("/parties/abc/invite", url.Values{"invitee_username": {"friend"}})
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The party identifier is not valid.")
+}
+
+func (s *PartyHandlerTestSuite) TestAcceptPartyInviteSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/party-invites/7/accept", r.URL.Path)
+		respBody, _ := protojson.Marshal(&party.Party{PartyId: 3})
+		_, _ = w.Write(respBody)
+	})
+
+	w := s.post("/party-invites/7/accept", nil)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *PartyHandlerTestSuite) TestAcceptPartyInviteWhenThePartyIsFull() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	w := s.post("/party-invites/7/accept", nil)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The party is full")
+}
+
+func (s *PartyHandlerTestSuite) TestDeclinePartyInviteSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/party-invites/7/decline", r.URL.Path)
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	w := s.post("/party-invites/7/decline", nil)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *PartyHandlerTestSuite) TestDeclinePartyInviteWithAnInvalidInvite() {
+	s.setup(nil)
+
+	w := s.post("/party-invites/abc/decline", nil)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The invite identifier is not valid.")
+}
+
+func (s *PartyHandlerTestSuite) TestLeavePartySuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/parties/3/leave", r.URL.Path)
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	w := s.post("/parties/3/leave", nil)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/", w.Header().Get("Location"))
+}
+
+func (s *PartyHandlerTestSuite) TestLeavePartyWhenThePartyDoesNotExist() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	w := s.post("/parties/3/leave", nil)
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Contains(w.Body.String(), "does not exist")
+}
+
+func TestPartyHandler(t *testing.T) {
+	suite.Run(t, new(PartyHandlerTestSuite))
+}
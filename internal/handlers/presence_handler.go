@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/gin-gonic/gin"
+)
+
+type PresenceHandler struct {
+	presenceClient *gateway.PresenceGatewayClient
+}
+
+func NewPresenceHandler(client *gateway.PresenceGatewayClient) *PresenceHandler {
+	return &PresenceHandler{presenceClient: client}
+}
+
+// Heartbeat keeps the user online. It answers with JSON, for the script of the pages that sends the heartbeats.
+func (h *PresenceHandler) Heartbeat(c *gin.Context) {
+	heartbeat, err := h.presenceClient.Heartbeat(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not record the heartbeat."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"interval_seconds": heartbeat.IntervalSeconds})
+}
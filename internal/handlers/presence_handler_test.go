@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type PresenceHandlerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockGateway *httptest.Server
+	handler     *PresenceHandler
+}
+
+func (s *PresenceHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+}
+
+func (s *PresenceHandlerTestSuite) AfterTest() {
+	if s.mockGateway != nil {
+		s.mockGateway.Close()
+	}
+}
+
+func (s *PresenceHandlerTestSuite) setup(mockHandler http.HandlerFunc) {
+	s.mockGateway = httptest.NewServer(mockHandler)
+	s.handler = NewPresenceHandler(gateway.NewPresenceGatewayClient(s.mockGateway.URL))
+
+	s.router.Use(func(c *gin.Context) {
+		middleware.SetUserInContext(c, &middleware.User{Username: "testuser"})
+		c.Next()
+	})
+	s.router.POST("/presence/heartbeat", s.handler.Heartbeat)
+}
+
+func (s *PresenceHandlerTestSuite) heartbeat() *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/presence/heartbeat", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *PresenceHandlerTestSuite) TestHeartbeatSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/presence/heartbeat", r.URL.Path)
+
+		respBody, _ := protojson.Marshal(&presence.HeartbeatResponse{IntervalSeconds: 30})
+		_, _ = w.Write(respBody)
+	})
+
+	w := s.heartbeat()
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"interval_seconds": 30}`, w.Body.String())
+}
+
+func (s *PresenceHandlerTestSuite) TestHeartbeatWhenTheGatewayFails() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	w := s.heartbeat()
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Contains(w.Body.String(), "Could not record the heartbeat.")
+}
+
+func TestPresenceHandler(t *testing.T) {
+	suite.Run(t, new(PresenceHandlerTestSuite))
+}
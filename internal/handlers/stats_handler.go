@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+const profilePageFilename = "profile.html"
+
+type StatsHandler struct {
+	statsClient *gateway.StatsGatewayClient
+}
+
+func NewStatsHandler(client *gateway.StatsGatewayClient) *StatsHandler {
+	return &StatsHandler{statsClient: client}
+}
+
+// ShowProfilePage shows the statistics of a player and their recent games, one page at a time. The page is public.
+func (h *StatsHandler) ShowProfilePage(c *gin.Context) {
+	player := c.Param("username")
+	data := gin.H{
+		"player":       player,
+		"is_logged_in": false,
+	}
+	if user, ok := middleware.UserFromContext(c); ok {
+		data["is_logged_in"] = true
+		data["username"] = user.Username
+	}
+
+	playerStats, err := h.statsClient.GetPlayerStats(c.Request.Context(), player)
+	if err != nil {
+		statusCode, title, message := http.StatusInternalServerError, "Error Fetching Profile", "The server is currently unavailable."
+		var apiErr *gateway.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			statusCode, title, message = http.StatusNotFound, "Player Not Found", "No player has this username."
+		}
+		data["ErrorTitle"] = title
+		data["ErrorMessage"] = message
+		c.HTML(statusCode, profilePageFilename, data)
+		return
+	}
+	data["stats"] = playerStats
+	data["win_rate"] = fmt.Sprintf("%.1f%%", playerStats.WinRate*100)
+	data["streak"] = describeStreak(playerStats.CurrentStreak)
+
+	games, err := h.statsClient.ListRecentGames(c.Request.Context(), player, c.Query("page_token"))
+	if err != nil {
+		statusCode, message := http.StatusInternalServerError, "The server is currently unavailable."
+		var apiErr *gateway.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+			statusCode, message = http.StatusBadRequest, "The requested page of games does not exist."
+		}
+		data["ErrorTitle"] = "Error Fetching Games"
+		data["ErrorMessage"] = message
+		c.HTML(statusCode, profilePageFilename, data)
+		return
+	}
+	data["games"] = games.Games
+	data["next_page_token"] = games.NextPageToken
+
+	c.HTML(http.StatusOK, profilePageFilename, data)
+}
+
+// describeStreak turns the signed streak of the player into the text shown on the profile.
+func describeStreak(streak int32) string {
+	switch {
+	case streak > 0:
+		return fmt.Sprintf("%d won in a row", streak)
+	case streak < 0:
+		return fmt.Sprintf("%d lost in a row", -streak)
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/gen/stats"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type StatsHandlerTestSuite struct {
+	suite.Suite
+	router      *gin.Engine
+	mockGateway *httptest.Server
+	handler     *StatsHandler
+}
+
+func (s *StatsHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.router = gin.Default()
+	s.router.LoadHTMLGlob("../../web/templates/*")
+}
+
+func (s *StatsHandlerTestSuite) AfterTest() {
+	if s.mockGateway != nil {
+		s.mockGateway.Close()
+	}
+}
+
+func (s *StatsHandlerTestSuite) setup(mockHandler http.HandlerFunc) {
+	s.mockGateway = httptest.NewServer(mockHandler)
+	s.handler = NewStatsHandler(gateway.NewStatsGatewayClient(s.mockGateway.URL))
+	s.router.GET("/users/:username", s.handler.ShowProfilePage)
+}
+
+func (s *StatsHandlerTestSuite) writeProto(w http.ResponseWriter, m proto.Message) {
+	w.WriteHeader(http.StatusOK)
+	body, _ := protojson.Marshal(m)
+	_, err := w.Write(body)
+	if err != nil {
+		s.T().Fatalf("Failed to write response: %v", err)
+	}
+}
+
+func (s *StatsHandlerTestSuite) TestShowProfilePageSuccess() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			s.writeProto(w, &stats.PlayerStats{Username: "player1", GamesPlayed: 4, Wins: 3, Losses: 1, WinRate: 0.75, CurrentStreak: 2})
+			return
+		}
+		s.writeProto(w, &stats.ListRecentGamesResponse{
+			Games: []*stats.Game{{
+				LobbyId:        "lobby-789",
+				LobbyName:      "The Best Lobby",
+				Opponents:      []string{"player2"},
+				WinnerUsername: "player1",
+				Won:            true,
+				FinishedAt:     timestamppb.New(time.Date(2030, time.January, 1, 12, 5, 0, 0, time.UTC)),
+			}},
+			NextPageToken: "10",
+		})
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/player1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "75.0%")
+	s.Contains(w.Body.String(), "2 won in a row")
+	s.Contains(w.Body.String(), "The Best Lobby")
+	s.Contains(w.Body.String(), `href="/users/player2"`)
+	s.Contains(w.Body.String(), "2030-01-01 12:05")
+	s.Contains(w.Body.String(), `href="/users/player1?page_token=10"`)
+	s.Contains(w.Body.String(), "Login")
+}
+
+func (s *StatsHandlerTestSuite) TestShowProfilePageShowsTheWinningTeam() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			s.writeProto(w, &stats.PlayerStats{Username: "player1", GamesPlayed: 1, Wins: 1, WinRate: 1})
+			return
+		}
+		winningTeam := int32(2)
+		s.writeProto(w, &stats.ListRecentGamesResponse{
+			Games: []*stats.Game{{
+				LobbyId:     "lobby-789",
+				LobbyName:   "Team Lobby",
+				Opponents:   []string{"player2", "player3", "player4"},
+				Won:         true,
+				WinningTeam: &winningTeam,
+				FinishedAt:  timestamppb.New(time.Date(2030, time.January, 1, 12, 5, 0, 0, time.UTC)),
+			}},
+		})
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/player1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "<td>Team 2</td>")
+}
+
+func (s *StatsHandlerTestSuite) TestShowProfilePageShowsTheMenuOfALoggedInUser() {
+	s.router.Use(func(c *gin.Context) {
+		middleware.SetUserInContext(c, &middleware.User{Username: "testuser"})
+		c.Next()
+	})
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			s.writeProto(w, &stats.PlayerStats{Username: "player1", CurrentStreak: -3})
+			return
+		}
+		s.writeProto(w, &stats.ListRecentGamesResponse{})
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/player1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "3 lost in a row")
+	s.Contains(w.Body.String(), "This player has not finished any game yet.")
+	s.Contains(w.Body.String(), "Logout")
+	s.NotContains(w.Body.String(), "Older games")
+}
+
+func (s *StatsHandlerTestSuite) TestShowProfilePageForAnUnknownPlayer() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/ghost", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Contains(w.Body.String(), "Player Not Found")
+}
+
+func (s *StatsHandlerTestSuite) TestShowProfilePageWithAnInvalidPageToken() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/stats") {
+			s.writeProto(w, &stats.PlayerStats{Username: "player1"})
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/player1?page_token=oops", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The requested page of games does not exist.")
+}
+
+func (s *StatsHandlerTestSuite) TestShowProfilePageWhenTheGatewayFails() {
+	s.setup(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/users/player1", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Contains(w.Body.String(), "Error Fetching Profile")
+}
+
+func TestStatsHandler(t *testing.T) {
+	suite.Run(t, new(StatsHandlerTestSuite))
+}
@@ -3,12 +3,17 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/auth"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -21,37 +26,158 @@ const (
 // UserHandler is responsible of handling user HTML pages and cookies.
 // It delegates the login and register business logic to the gateway clients.
 type UserHandler struct {
-	lobbyClient *gateway.LobbyGatewayClient
-	authClient  *gateway.AuthGatewayClient
+	lobbyClient    *gateway.LobbyGatewayClient
+	authClient     *gateway.AuthGatewayClient
+	partyClient    *gateway.PartyGatewayClient
+	friendClient   *gateway.FriendGatewayClient
+	presenceClient *gateway.PresenceGatewayClient
 }
 
-func NewUserHandler(authClient *gateway.AuthGatewayClient, lobbyClient *gateway.LobbyGatewayClient) *UserHandler {
+func NewUserHandler(authClient *gateway.AuthGatewayClient, lobbyClient *gateway.LobbyGatewayClient,
+	partyClient *gateway.PartyGatewayClient, friendClient *gateway.FriendGatewayClient,
+	presenceClient *gateway.PresenceGatewayClient) *UserHandler {
 	return &UserHandler{
-		authClient:  authClient,
-		lobbyClient: lobbyClient,
+		authClient:     authClient,
+		lobbyClient:    lobbyClient,
+		partyClient:    partyClient,
+		friendClient:   friendClient,
+		presenceClient: presenceClient,
 	}
 }
 
+// ShowIndexPage shows the available lobbies to the logged users, filtered and sorted by the q, game_mode, region,
+// min_free_slots, created_within and sort query parameters.
 func (h *UserHandler) ShowIndexPage(c *gin.Context) {
+	statusCode := http.StatusOK
 	data := gin.H{
-		"lobbies":      []*lobby.Lobby{},
-		"is_logged_in": false,
+		"lobbies":        []*lobby.Lobby{},
+		"invites":        []*lobby.Invite{},
+		"partyInvites":   []*party.PartyInvite{},
+		"friends":        []*friend.Friend{},
+		"friendRequests": []*friend.FriendRequest{},
+		"is_logged_in":   false,
+		"q":              c.Query("q"),
+		"game_mode":      c.Query("game_mode"),
+		"region":         c.Query("region"),
+		"min_free_slots": c.Query("min_free_slots"),
+		"created_within": c.Query("created_within"),
+		"sort":           c.Query("sort"),
 	}
 
 	if user, ok := middleware.UserFromContext(c); ok {
 		data["is_logged_in"] = true
 		data["username"] = user.Username
 
-		lobbies, err := h.lobbyClient.ListAvailableLobbies(c.Request.Context())
-		if err != nil {
-			data["ErrorTitle"] = "Lobby Service Error"
-			data["ErrorMessage"] = "Could not retrieve the list of available lobbies."
+		req, problem := availableLobbiesRequest(c)
+		if problem != "" {
+			statusCode = http.StatusBadRequest
+			data["ErrorTitle"] = "Invalid Search"
+			data["ErrorMessage"] = problem
+		} else if resp, err := h.lobbyClient.ListAvailableLobbies(c.Request.Context(), req); err != nil {
+			var apiErr *gateway.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+				statusCode = http.StatusBadRequest
+				data["ErrorTitle"] = "Invalid Search"
+				data["ErrorMessage"] = "The requested page of lobbies does not exist."
+			} else {
+				data["ErrorTitle"] = "Lobby Service Error"
+				data["ErrorMessage"] = "Could not retrieve the list of available lobbies."
+			}
 		} else {
-			data["lobbies"] = lobbies
+			data["lobbies"] = resp.Lobbies
+			if resp.NextPageToken != "" {
+				next := c.Request.URL.Query()
+				next.Set("page_token", resp.NextPageToken)
+				data["more_lobbies_url"] = "/?" + next.Encode()
+			}
 		}
+
+		// Without the game modes the lobbies can still be created with the default one.
+		if gameModes, err := h.lobbyClient.ListGameModes(c.Request.Context()); err == nil {
+			data["gameModes"] = gameModes.Modes
+			data["regions"] = gameModes.Regions
+		}
+
+		// The queue follows the game mode and region of the search, so that the players can compare them.
+		if problem == "" {
+			queueReq := &lobby.GetQueueStatsRequest{GameMode: c.Query("game_mode"), Region: c.Query("region")}
+			if queueStats, err := h.lobbyClient.GetQueueStats(c.Request.Context(), queueReq); err == nil {
+				data["queueStats"] = queueStats
+			}
+		}
+
+		// The pending invites are an addition to the page: if they can not be retrieved the lobbies are still shown.
+		if invites, err := h.lobbyClient.ListMyInvites(c.Request.Context()); err == nil {
+			data["invites"] = invites
+		}
+
+		// Like the invites, the party of the user is left out of the page when it can not be retrieved.
+		if myParty, err := h.partyClient.GetMyParty(c.Request.Context(), user.Username); err == nil {
+			data["party"] = myParty
+			data["leadsParty"] = myParty.LeaderUsername == user.Username
+		}
+		if partyInvites, err := h.partyClient.ListMyPartyInvites(c.Request.Context(), user.Username); err == nil {
+			data["partyInvites"] = partyInvites
+		}
+		if friends, err := h.friendClient.ListFriends(c.Request.Context(), user.Username); err == nil {
+			data["friends"] = friends.Friends
+			data["friendRequests"] = friends.IncomingRequests
+		}
+		if presence, err := h.presenceClient.GetPresence(c.Request.Context()); err == nil {
+			data["onlineCount"] = presence.OnlineCount
+		}
+		// Players that come back while in an active lobby are offered the way back to it.
+		if currentLobby, err := h.lobbyClient.GetMyCurrentLobby(c.Request.Context(), user.Username); err == nil {
+			data["currentLobby"] = currentLobby
+		}
+		// The search of the player, with the match found for it, is polled by the page until the match is complete.
+		if search, err := h.lobbyClient.GetMySearch(c.Request.Context()); err == nil {
+			data["search"] = search
+		} else if c.Query("searching") != "" && data["currentLobby"] != nil {
+			// The search ended while the page was polling it: every player accepted the match, whose lobby is ready.
+			c.Redirect(http.StatusSeeOther, "/lobbies/"+data["currentLobby"].(*lobby.Lobby).LobbyId)
+			return
+		}
+		// Players on a cooldown are told how long they have to wait before they can create or join a lobby.
+		if cooldown, err := h.lobbyClient.GetMyCooldown(c.Request.Context(), user.Username); err == nil &&
+			cooldown.CooldownUntil != nil {
+			if remaining := time.Until(cooldown.CooldownUntil.AsTime()); remaining > 0 {
+				data["cooldown"] = remaining.Round(time.Second).String()
+			}
+		}
+	}
+
+	c.HTML(statusCode, "index.html", data)
+}
+
+// availableLobbiesRequest builds the request for the available lobbies from the query parameters of the index page.
+// When a parameter is not valid, it returns the problem to show to the user instead.
+func availableLobbiesRequest(c *gin.Context) (*lobby.ListAvailableLobbiesRequest, string) {
+	req := &lobby.ListAvailableLobbiesRequest{
+		NameQuery: c.Query("q"),
+		GameMode:  c.Query("game_mode"),
+		Region:    c.Query("region"),
+		Sort:      c.Query("sort"),
+		PageToken: c.Query("page_token"),
+	}
+
+	if minFreeSlots := c.Query("min_free_slots"); minFreeSlots != "" {
+		slots, err := strconv.ParseInt(minFreeSlots, 10, 32)
+		if err != nil || slots < 0 {
+			return nil, "The number of free slots must be a positive number."
+		}
+		req.MinFreeSlots = int32(slots)
+	}
+
+	if createdWithin := c.Query("created_within"); createdWithin != "" {
+		within, err := time.ParseDuration(createdWithin)
+		if err != nil || within <= 0 {
+			return nil, "The creation time must be a duration such as 10m or 1h."
+		}
+		req.CreatedAfter = timestamppb.New(time.Now().Add(-within))
 	}
 
-	c.HTML(http.StatusOK, "index.html", data)
+	return req, ""
 }
 
 func (h *UserHandler) ShowLoginPage(c *gin.Context) {
@@ -6,15 +6,21 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/gen/auth"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/friend"
 	"github.com/NicoPolazzi/multiplayer-queue/gen/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/party"
+	"github.com/NicoPolazzi/multiplayer-queue/gen/presence"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/gateway"
 	"github.com/NicoPolazzi/multiplayer-queue/internal/middleware"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type MockTokenManager struct {
@@ -36,10 +42,19 @@ type UserHandlerTestSuite struct {
 	router           *gin.Engine
 	mockAuthGateway  *httptest.Server
 	mockLobbyGateway *httptest.Server
+	mockPartyGateway *httptest.Server
+	// partyGateway answers the party requests of the index page. By default the user is in no party.
+	partyGateway     http.HandlerFunc
 	handler          *UserHandler
 	authClient       *gateway.AuthGatewayClient
 	lobbyClient      *gateway.LobbyGatewayClient
 	mockTokenManager *MockTokenManager
+	// friendGateway answers the friends requests of the index page. By default the user has no friends.
+	friendGateway     http.HandlerFunc
+	mockFriendGateway *httptest.Server
+	// presenceGateway answers the online count of the index page. By default nobody is online.
+	presenceGateway     http.HandlerFunc
+	mockPresenceGateway *httptest.Server
 }
 
 func (s *UserHandlerTestSuite) SetupTest() {
@@ -48,6 +63,22 @@ func (s *UserHandlerTestSuite) SetupTest() {
 	s.router.LoadHTMLGlob("../../web/templates/*")
 
 	s.mockTokenManager = new(MockTokenManager)
+	s.partyGateway = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/party-invites" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("{}"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+	s.friendGateway = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}
+	s.presenceGateway = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("{}"))
+	}
 
 	authMiddleware := middleware.NewAuthMiddleware(s.mockTokenManager)
 	s.router.Use(authMiddleware.CheckUser())
@@ -60,6 +91,15 @@ func (s *UserHandlerTestSuite) TearDownTest() {
 	if s.mockLobbyGateway != nil {
 		s.mockLobbyGateway.Close()
 	}
+	if s.mockPartyGateway != nil {
+		s.mockPartyGateway.Close()
+	}
+	if s.mockFriendGateway != nil {
+		s.mockFriendGateway.Close()
+	}
+	if s.mockPresenceGateway != nil {
+		s.mockPresenceGateway.Close()
+	}
 }
 
 func (s *UserHandlerTestSuite) setup(authHandler, lobbyHandler http.HandlerFunc) {
@@ -71,13 +111,21 @@ func (s *UserHandlerTestSuite) setup(authHandler, lobbyHandler http.HandlerFunc)
 		s.mockLobbyGateway = httptest.NewServer(lobbyHandler)
 		s.lobbyClient = gateway.NewLobbyGatewayClient(s.mockLobbyGateway.URL)
 	}
-	s.handler = NewUserHandler(s.authClient, s.lobbyClient)
+	s.mockPartyGateway = httptest.NewServer(s.partyGateway)
+	s.mockFriendGateway = httptest.NewServer(s.friendGateway)
+	s.mockPresenceGateway = httptest.NewServer(s.presenceGateway)
+	s.handler = NewUserHandler(s.authClient, s.lobbyClient, gateway.NewPartyGatewayClient(s.mockPartyGateway.URL),
+		gateway.NewFriendGatewayClient(s.mockFriendGateway.URL),
+		gateway.NewPresenceGatewayClient(s.mockPresenceGateway.URL))
 }
 
 func (s *UserHandlerTestSuite) TestShowIndexPageAsLoggedInUser() {
 	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		resp := &lobby.ListAvailableLobbiesResponse{Lobbies: []*lobby.Lobby{{Name: "Fun Lobby"}}}
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{Lobbies: []*lobby.Lobby{{Name: "Fun Lobby"}}}
+		if r.URL.Path == "/api/v1/invites" {
+			resp = &lobby.ListMyInvitesResponse{Invites: []*lobby.Invite{{InviteId: 7, LobbyName: "Invited Lobby", InviterUsername: "friend"}}}
+		}
 		body, _ := protojson.Marshal(resp)
 		_, err := w.Write(body)
 		if err != nil {
@@ -96,9 +144,564 @@ func (s *UserHandlerTestSuite) TestShowIndexPageAsLoggedInUser() {
 	s.Equal(http.StatusOK, w.Code)
 	s.Contains(w.Body.String(), "Welcome back, testuser!")
 	s.Contains(w.Body.String(), "Fun Lobby")
+	s.Contains(w.Body.String(), "Invited Lobby")
+	s.Contains(w.Body.String(), "/invites/7/accept")
 	s.mockTokenManager.AssertExpectations(s.T())
 }
 
+func (s *UserHandlerTestSuite) TestShowIndexPageLeadsBackToTheCurrentLobby() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/lobbies/current":
+			resp = &lobby.Lobby{LobbyId: "lobby-123", Name: "My Lobby", Status: "WAITING"}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "My Lobby")
+	s.Contains(w.Body.String(), `href="/lobbies/lobby-123"`)
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageShowsTheCooldown() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/cooldown":
+			resp = &lobby.GetMyCooldownResponse{CooldownUntil: timestamppb.New(time.Now().Add(90 * time.Minute)), Infractions: 3}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `id="cooldown"`)
+	s.Contains(w.Body.String(), "you can create or join lobbies again in 1h")
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageOffersToAcceptTheMatchFound() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/matchmaking":
+			resp = &lobby.MatchSearch{
+				Status:          "MATCH_FOUND",
+				MatchId:         "match-1",
+				AcceptDeadline:  timestamppb.New(time.Now().Add(10 * time.Second)),
+				AcceptedPlayers: 1,
+				Players:         2,
+			}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `id="match-found"`)
+	s.Contains(w.Body.String(), "1 of 2 players accepted")
+	s.Contains(w.Body.String(), "/matchmaking/match-1/accept")
+	s.Contains(w.Body.String(), "/matchmaking/match-1/decline")
+	s.NotContains(w.Body.String(), "Find a Match")
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageOffersTheMatchmakingWhenNotSearching() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/matchmaking", "/api/v1/lobbies/current":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/?searching=1", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `action="/matchmaking/search"`)
+	s.NotContains(w.Body.String(), `id="search"`)
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageFollowsThePlayerToTheLobbyOfTheMatch() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/lobbies/current":
+			resp = &lobby.Lobby{LobbyId: "lobby-123", Status: "IN_PROGRESS"}
+		case "/api/v1/matchmaking":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/?searching=1", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusSeeOther, w.Code)
+	s.Equal("/lobbies/lobby-123", w.Header().Get("Location"))
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageWithoutACooldown() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/cooldown":
+			resp = &lobby.GetMyCooldownResponse{Infractions: 1}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.NotContains(w.Body.String(), `id="cooldown"`)
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageShowsTheQueueOfTheSearchedGameMode() {
+	median, p90, estimate := uint32(25), uint32(70), uint32(0)
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/queue-stats":
+			s.Equal("TEAM_DEATHMATCH", r.URL.Query().Get("game_mode"))
+			s.Equal("NA", r.URL.Query().Get("region"))
+			resp = &lobby.GetQueueStatsResponse{
+				SearchingPlayers:     3,
+				OpenLobbies:          []*lobby.OpenLobbies{{GameMode: "TEAM_DEATHMATCH", Region: "NA", Lobbies: 2, Players: 3}},
+				MatchedPlayers:       8,
+				MedianWaitSeconds:    &median,
+				P90WaitSeconds:       &p90,
+				EstimatedWaitSeconds: &estimate,
+			}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/?game_mode=TEAM_DEATHMATCH&region=NA", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "Queue of TEAM_DEATHMATCH in NA")
+	s.Contains(w.Body.String(), "3 players are waiting in open lobbies.")
+	s.Contains(w.Body.String(), "8 players were matched recently: half of them waited 25s or less, and 90% waited 70s or less.")
+	s.Contains(w.Body.String(), "none, a lobby needs a single player to fill up")
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageShowsTheEstimatedWait() {
+	estimate := uint32(40)
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/invites":
+			resp = &lobby.ListMyInvitesResponse{}
+		case "/api/v1/queue-stats":
+			resp = &lobby.GetQueueStatsResponse{MedianWaitSeconds: &estimate, EstimatedWaitSeconds: &estimate}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `id="queue-stats"`)
+	s.Contains(w.Body.String(), "<strong>Estimated wait:</strong> 40s.")
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageShowsTheParty() {
+	s.partyGateway = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &party.Party{
+			PartyId:        3,
+			LeaderUsername: "testuser",
+			Members:        []*party.PartyMember{{Id: 1, Username: "testuser"}, {Id: 2, Username: "friend"}},
+			MaxSize:        4,
+		}
+		if r.URL.Path == "/api/v1/party-invites" {
+			resp = &party.ListMyPartyInvitesResponse{Invites: []*party.PartyInvite{{InviteId: 9, InviterUsername: "other"}}}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	}
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		if r.URL.Path == "/api/v1/invites" {
+			resp = &lobby.ListMyInvitesResponse{}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "testuser (leader), friend")
+	s.Contains(w.Body.String(), `action="/parties/3/invite"`)
+	s.Contains(w.Body.String(), `action="/parties/3/leave"`)
+	s.Contains(w.Body.String(), "/party-invites/9/accept")
+	s.NotContains(w.Body.String(), `action="/parties/create"`)
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageShowsTheFriends() {
+	s.friendGateway = func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/friends", r.URL.Path)
+		s.Equal("testuser", r.URL.Query().Get("username"))
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(&friend.ListFriendsResponse{
+			Friends: []*friend.Friend{
+				{Username: "bob", Presence: "IN_GAME"},
+				{Username: "carol", Presence: "OFFLINE"},
+			},
+			IncomingRequests: []*friend.FriendRequest{{RequestId: 5, FromUsername: "dave"}},
+		})
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	}
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		if r.URL.Path == "/api/v1/invites" {
+			resp = &lobby.ListMyInvitesResponse{}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "bob")
+	s.Contains(w.Body.String(), "In game")
+	s.Contains(w.Body.String(), "Offline")
+	s.Contains(w.Body.String(), `action="/friends/bob/remove"`)
+	s.Contains(w.Body.String(), `action="/friend-requests/5/accept"`)
+	s.Contains(w.Body.String(), `action="/friends/dave/remove"`)
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageShowsHowManyPlayersAreOnline() {
+	s.presenceGateway = func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("/api/v1/presence", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		body, _ := protojson.Marshal(&presence.GetPresenceResponse{OnlineCount: 12})
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	}
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		if r.URL.Path == "/api/v1/invites" {
+			resp = &lobby.ListMyInvitesResponse{}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), "12 players online right now.")
+	s.Contains(w.Body.String(), "/presence/heartbeat")
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageOffersToCreateAParty() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		var resp proto.Message = &lobby.ListAvailableLobbiesResponse{}
+		if r.URL.Path == "/api/v1/invites" {
+			resp = &lobby.ListMyInvitesResponse{}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Contains(w.Body.String(), `action="/parties/create"`)
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageSearchesTheLobbies() {
+	var received url.Values
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		var resp proto.Message = &lobby.ListMyInvitesResponse{}
+		if r.URL.Path == "/api/v1/lobbies/available" {
+			received = r.URL.Query()
+			resp = &lobby.ListAvailableLobbiesResponse{
+				Lobbies:       []*lobby.Lobby{{LobbyId: "lobby-1", Name: "Friday night"}},
+				NextPageToken: "next-token",
+			}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, err := w.Write(body)
+		if err != nil {
+			s.T().Fatalf("Failed to write response: %v", err)
+		}
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/?q=friday&min_free_slots=1&created_within=1h&sort=NAME", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("friday", received.Get("name_query"))
+	s.Equal("1", received.Get("min_free_slots"))
+	s.Equal("NAME", received.Get("sort"))
+	s.NotEmpty(received.Get("created_after"))
+	s.Contains(w.Body.String(), "Friday night")
+	s.Contains(w.Body.String(), `value="friday"`)
+	s.Contains(w.Body.String(), `<option value="NAME" selected>`)
+	s.Contains(w.Body.String(), "/?created_within=1h&amp;min_free_slots=1&amp;page_token=next-token&amp;q=friday&amp;sort=NAME")
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageOffersTheGameModes() {
+	var received url.Values
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		var resp proto.Message = &lobby.ListMyInvitesResponse{}
+		switch r.URL.Path {
+		case "/api/v1/lobbies/available":
+			received = r.URL.Query()
+			resp = &lobby.ListAvailableLobbiesResponse{Lobbies: []*lobby.Lobby{
+				{LobbyId: "lobby-1", Name: "Brawl", GameMode: "FREE_FOR_ALL", Region: "NA", MaxPlayers: 4, Players: []*lobby.Player{{Username: "creator"}}},
+			}}
+		case "/api/v1/game-modes":
+			resp = &lobby.ListGameModesResponse{
+				Modes: []*lobby.GameMode{
+					{Name: "DUEL", DisplayName: "Duel", Capacity: 2},
+					{Name: "FREE_FOR_ALL", DisplayName: "Free for all", Capacity: 4, Settings: []*lobby.GameSetting{
+						{Name: "map", Values: []string{"ARENA", "RUINS"}, DefaultValue: "ARENA"},
+					}},
+				},
+				Regions: []string{"EU", "NA"},
+			}
+		}
+		body, _ := protojson.Marshal(resp)
+		_, _ = w.Write(body)
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/?game_mode=FREE_FOR_ALL&region=NA", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("FREE_FOR_ALL", received.Get("game_mode"))
+	s.Equal("NA", received.Get("region"))
+	s.Contains(w.Body.String(), "<td>1/4</td>")
+	s.Contains(w.Body.String(), `<option value="FREE_FOR_ALL" selected>Free for all</option>`)
+	s.Contains(w.Body.String(), `<option value="FREE_FOR_ALL">Free for all (4 players)</option>`)
+	s.Contains(w.Body.String(), `name="settings.FREE_FOR_ALL.map"`)
+	s.Contains(w.Body.String(), "Default (ARENA)")
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageRejectsInvalidFilters() {
+	testCases := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{"negative free slots", "min_free_slots=-1", "The number of free slots must be a positive number."},
+		{"unknown creation time", "created_within=yesterday", "The creation time must be a duration such as 10m or 1h."},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v1/lobbies/available" {
+					s.T().Errorf("The lobbies should not be listed with invalid filters")
+				}
+				body, _ := protojson.Marshal(&lobby.ListMyInvitesResponse{})
+				_, _ = w.Write(body)
+			})
+			s.router.GET("/", s.handler.ShowIndexPage)
+			s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+			req, _ := http.NewRequest(http.MethodGet, "/?"+tc.query, nil)
+			req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+			w := httptest.NewRecorder()
+			s.router.ServeHTTP(w, req)
+
+			s.Equal(http.StatusBadRequest, w.Code)
+			s.Contains(w.Body.String(), "Invalid Search")
+			s.Contains(w.Body.String(), tc.message)
+		})
+	}
+}
+
+func (s *UserHandlerTestSuite) TestShowIndexPageWithAnInvalidPageToken() {
+	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	s.router.GET("/", s.handler.ShowIndexPage)
+	s.mockTokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/?page_token=stale", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.Contains(w.Body.String(), "The requested page of lobbies does not exist.")
+}
+
 func (s *UserHandlerTestSuite) TestShowIndexPageLobbyServiceFails() {
 	s.setup(nil, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
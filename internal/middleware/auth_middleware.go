@@ -30,6 +30,8 @@ func (m *AuthMiddleware) CheckUser() gin.HandlerFunc {
 		}
 
 		SetUserInContext(ctx, &User{Username: username})
+		// The gateway clients forward the token, so that the services know who is calling.
+		ctx.Request = ctx.Request.WithContext(token.NewContext(ctx.Request.Context(), tokenString))
 		ctx.Next()
 	}
 }
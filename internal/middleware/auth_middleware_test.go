@@ -63,6 +63,19 @@ func (s *AuthMiddlewareTestSuite) TestCheckUserWhenTokenIsValid() {
 	s.tokenManager.AssertExpectations(s.T())
 }
 
+func (s *AuthMiddlewareTestSuite) TestCheckUserForwardsTheTokenToTheGatewayRequests() {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "valid-token"})
+	_, ctx := s.createTestContext(req)
+	s.tokenManager.On("Validate", "valid-token").Return("testuser", nil)
+
+	s.authMiddleware.CheckUser()(ctx)
+
+	tokenString, ok := token.FromContext(ctx.Request.Context())
+	s.True(ok)
+	s.Equal("valid-token", tokenString)
+}
+
 func (s *AuthMiddlewareTestSuite) TestCheckUserWhenCookieIsNotSet() {
 	req, _ := http.NewRequest(http.MethodGet, "/", nil)
 	_, ctx := s.createTestContext(req)
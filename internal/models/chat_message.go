@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// ChatMessage is a message sent to the chat of a lobby. Only the most recent messages of each lobby are kept.
+type ChatMessage struct {
+	ID      uint   `gorm:"primaryKey"`
+	LobbyID string `gorm:"not null;index"`
+	UserID  uint   `gorm:"not null"`
+	User    User
+	// Text is stored as it was shown, after the content filter.
+	Text   string    `gorm:"not null"`
+	SentAt time.Time `gorm:"not null"`
+}
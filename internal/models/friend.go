@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type FriendshipStatus string
+
+const (
+	FriendshipStatusPending  FriendshipStatus = "PENDING"
+	FriendshipStatusAccepted FriendshipStatus = "ACCEPTED"
+)
+
+// Friendship links two users. It starts as a PENDING request from the requester, and becomes ACCEPTED when the
+// addressee accepts it. There is at most one friendship between two users, whoever asked first.
+type Friendship struct {
+	ID          uint             `gorm:"primaryKey"`
+	RequesterID uint             `gorm:"not null;uniqueIndex:idx_friendship_pair"`
+	Requester   User             `gorm:"foreignKey:RequesterID"`
+	AddresseeID uint             `gorm:"not null;uniqueIndex:idx_friendship_pair;index"`
+	Addressee   User             `gorm:"foreignKey:AddresseeID"`
+	Status      FriendshipStatus `gorm:"type:string;not null;default:'PENDING'"`
+	CreatedAt   time.Time
+	AcceptedAt  *time.Time
+}
+
+// Other returns the user of the friendship that is not the given one.
+func (f *Friendship) Other(userID uint) User {
+	if f.RequesterID == userID {
+		return f.Addressee
+	}
+	return f.Requester
+}
+
+// Block keeps the blocked user away from the blocker: they can not send them friend requests or invites, nor join
+// the lobbies they play in.
+type Block struct {
+	ID        uint `gorm:"primaryKey"`
+	BlockerID uint `gorm:"not null;uniqueIndex:idx_block_pair"`
+	BlockedID uint `gorm:"not null;uniqueIndex:idx_block_pair"`
+	CreatedAt time.Time
+}
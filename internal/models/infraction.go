@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type InfractionKind string
+
+const (
+	InfractionDodge   InfractionKind = "DODGE"   // Did not confirm the ready check of a full lobby, or accept a match in time
+	InfractionAbandon InfractionKind = "ABANDON" // Stayed disconnected from a game past the reconnect grace period
+	InfractionDecline InfractionKind = "DECLINE" // Declined the ready check of a full lobby, or a match
+	InfractionLeave   InfractionKind = "LEAVE"   // Left the lobby during its ready check or its game
+)
+
+// Infraction is a lobby the user left while the other players were counting on them, or a pending match they let
+// down, in which case LobbyID holds the identifier of the match. The recent infractions of a user put them on a
+// cooldown before they can create or join lobbies again.
+type Infraction struct {
+	ID        uint           `gorm:"primaryKey"`
+	UserID    uint           `gorm:"not null;index"`
+	User      User           `gorm:"foreignKey:UserID"`
+	LobbyID   string         `gorm:"not null"`
+	Kind      InfractionKind `gorm:"type:string;not null"`
+	CreatedAt time.Time      `gorm:"not null;index"`
+}
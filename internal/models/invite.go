@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type InviteStatus string
+
+const (
+	InviteStatusPending  InviteStatus = "PENDING"  // Waiting for the invitee answer
+	InviteStatusAccepted InviteStatus = "ACCEPTED" // The invitee joined the lobby
+	InviteStatusDeclined InviteStatus = "DECLINED" // The invitee refused the invite
+	InviteStatusExpired  InviteStatus = "EXPIRED"  // The invitee answered too late
+)
+
+type Invite struct {
+	gorm.Model
+	LobbyID   string       `gorm:"index;not null"`
+	InviterID uint         `gorm:"not null"`
+	Inviter   User         `gorm:"foreignKey:InviterID"`
+	InviteeID uint         `gorm:"index;not null"`
+	Invitee   User         `gorm:"foreignKey:InviteeID"`
+	Status    InviteStatus `gorm:"type:string;not null;default:'PENDING'"`
+	ExpiresAt time.Time    `gorm:"not null"`
+}
@@ -10,17 +10,85 @@ type LobbyStatus string
 
 const (
 	LobbyStatusWaiting    LobbyStatus = "WAITING"     // Waiting for opponent
+	LobbyStatusReadyCheck LobbyStatus = "READY_CHECK" // Lobby is full, waiting for every player to confirm
 	LobbyStatusInProgress LobbyStatus = "IN_PROGRESS" // Game is in progress
 	LobbyStatusFinished   LobbyStatus = "FINISHED"    // Game has finished
+	LobbyStatusCancelled  LobbyStatus = "CANCELLED"   // Closed before the game could start
+	LobbyStatusAbandoned  LobbyStatus = "ABANDONED"   // Stopped without a result after a player disconnected
+)
+
+// ActiveLobbyStatuses are the statuses of a lobby that still holds its players: a user can be in only one such
+// lobby at a time.
+var ActiveLobbyStatuses = []LobbyStatus{LobbyStatusWaiting, LobbyStatusReadyCheck, LobbyStatusInProgress}
+
+type LobbyCloseReason string
+
+const (
+	LobbyCloseWaitingTimeout  LobbyCloseReason = "WAITING_TIMEOUT"  // Nobody filled the lobby before its waiting timeout
+	LobbyCloseExpired         LobbyCloseReason = "EXPIRED"          // The lobby outlived the configured TTL
+	LobbyCloseCreatorInactive LobbyCloseReason = "CREATOR_INACTIVE" // The creator of the lobby went inactive
+	LobbyCloseEmpty           LobbyCloseReason = "EMPTY"            // The last player left the lobby
+)
+
+type LobbyVisibility string
+
+const (
+	LobbyVisibilityPublic   LobbyVisibility = "PUBLIC"   // Listed and joinable by everyone
+	LobbyVisibilityUnlisted LobbyVisibility = "UNLISTED" // Not listed, joinable by link or join code
+	LobbyVisibilityPrivate  LobbyVisibility = "PRIVATE"  // Not listed, joinable only by join code
 )
 
 type Lobby struct {
-	LobbyID   string `gorm:"primaryKey"`
-	Name      string `gorm:"not null"`
-	Players   []User `gorm:"foreignKey:LobbyID"`
-	WinnerID  *uint
-	Winner    *User       `gorm:"foreignKey:WinnerID"`
-	Status    LobbyStatus `gorm:"type:string;not null;default:'WAITING'"`
+	LobbyID string `gorm:"primaryKey"`
+	Name    string `gorm:"not null"`
+	// Players are the current members of the lobby, ordered by seat. The repository loads only the memberships that
+	// did not end.
+	Players []LobbyPlayer `gorm:"foreignKey:LobbyID"`
+	// Spectators are the users watching the lobby, ordered by arrival. Like the players, only the current ones are
+	// loaded.
+	Spectators []LobbySpectator `gorm:"foreignKey:LobbyID"`
+	// WinnerID is set once a game without teams is finished, WinningTeam once a game with teams is.
+	WinnerID     *uint
+	Winner       *User `gorm:"foreignKey:WinnerID"`
+	WinningTeam  *int
+	Status       LobbyStatus     `gorm:"type:string;not null;default:'WAITING'"`
+	Visibility   LobbyVisibility `gorm:"type:string;not null;default:'PUBLIC'"`
+	JoinCode     *string         `gorm:"uniqueIndex"`
+	PasswordHash string
+	CreatorID    *uint `gorm:"index"`
+	// HostID is the player that controls the lobby: it can kick the other players, lock the lobby and rename it, and
+	// hand the role over to another player. It starts as the creator of the lobby.
+	HostID *uint `gorm:"index"`
+	// Locked lobbies can not be joined by anyone, not even with an invite.
+	Locked bool `gorm:"not null;default:false"`
+	// GameMode, Region and Settings are validated against the catalog of the game modes when the lobby is created.
+	// MaxPlayers is the capacity of the game mode at that time. The defaults are those of the lobbies created before
+	// the game modes.
+	GameMode   string            `gorm:"not null;default:'DUEL'"`
+	Region     string            `gorm:"not null;default:'EU'"`
+	Settings   map[string]string `gorm:"serializer:json"`
+	MaxPlayers int               `gorm:"not null;default:2"`
+	// Teams is the number of teams of the game mode, 0 when the players do not play in teams. The teams are
+	// numbered from 1.
+	Teams int `gorm:"not null;default:0"`
+	// CloseReason and ClosedAt are set only once the lobby is CANCELLED.
+	CloseReason *LobbyCloseReason `gorm:"type:string"`
+	ClosedAt    *time.Time
+	// Version is bumped by every change to the players of the lobby, to detect concurrent joins.
+	Version uint `gorm:"not null;default:0"`
+	// ReadyCheckDeadline is set only while the lobby is in the READY_CHECK status.
+	ReadyCheckDeadline *time.Time
+	// MatchedAt is the last time the players joining the lobby filled it up and started its ready check, or the time the
+	// matchmaker created it for the players it paired. Rematches, and the lobbies a party fills when it creates them,
+	// start full, so they are never matched.
+	MatchedAt *time.Time
+	// RematchDeadline is set only while the players of the FINISHED lobby vote for a rematch. RematchLobbyID is the
+	// lobby created once they all accepted it.
+	RematchDeadline *time.Time
+	RematchLobbyID  *string
+	// Timers are the deadlines the scheduler holds for the lobby. They are deleted by the scheduler itself, hence
+	// no constraint ties them to the lobby.
+	Timers    []LobbyTimer `gorm:"foreignKey:LobbyID;constraint:-"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// LobbyPlayer is the membership of a user in a lobby. The row is kept once the membership ends, so that the lobbies
+// a user played in make up their match history.
+type LobbyPlayer struct {
+	ID      uint   `gorm:"primaryKey"`
+	LobbyID string `gorm:"not null;index"`
+	UserID  uint   `gorm:"not null;index"`
+	User    User
+	// Seat is the position of the player in the lobby, starting from 0.
+	Seat int `gorm:"not null"`
+	// Team is nil as long as the lobby does not split its players into teams, otherwise it starts from 1.
+	Team *int
+	// Ready reports whether the player confirmed the ready check of the lobby.
+	Ready bool `gorm:"not null;default:false"`
+	// RematchAccepted reports whether the player accepted the rematch vote of the finished game.
+	RematchAccepted bool `gorm:"not null;default:false"`
+	// Placement is the final standing of the player, 1 being the winner. It is set once the game is finished.
+	Placement *int
+	JoinedAt  time.Time `gorm:"not null;autoCreateTime"`
+	// LeftAt is set when the player leaves the lobby before the end of the game, or is removed from it.
+	LeftAt *time.Time
+	// BannedUntil is set when the host kicks the player out of the lobby: the player can not join it again before then.
+	BannedUntil *time.Time
+	// DisconnectedAt is set while the heartbeats of the player are missing during the game: it is the time the last
+	// heartbeat expired. It is cleared when the player reconnects.
+	DisconnectedAt *time.Time
+	// AbandonedAt is set when the player stayed disconnected past the reconnect grace period. It is kept even if the
+	// player reconnects later, to penalize the abandonment.
+	AbandonedAt *time.Time
+}
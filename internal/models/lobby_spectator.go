@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// LobbySpectator is a user watching a lobby without playing in it. Spectators do not take a seat, so they do not count
+// toward the capacity of the lobby.
+type LobbySpectator struct {
+	ID      uint   `gorm:"primaryKey"`
+	LobbyID string `gorm:"not null;index"`
+	UserID  uint   `gorm:"not null;index"`
+	User    User
+	// JoinedAt orders the spectators of a lobby.
+	JoinedAt time.Time `gorm:"not null;autoCreateTime"`
+	// LeftAt is set when the spectator joins the lobby as a player, or when the lobby is deleted.
+	LeftAt *time.Time
+}
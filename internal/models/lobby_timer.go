@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type LobbyTimerKind string
+
+const (
+	LobbyTimerWaiting      LobbyTimerKind = "WAITING_TIMEOUT" // Closes a lobby that nobody joined in time
+	LobbyTimerReadyCheck   LobbyTimerKind = "READY_CHECK"     // Removes the players that did not confirm in time
+	LobbyTimerGameEnd      LobbyTimerKind = "GAME_END"        // Ends the game and opens the result-report window
+	LobbyTimerResultReport LobbyTimerKind = "RESULT_REPORT"   // Finishes a game whose result was not reported in time
+	LobbyTimerRematch      LobbyTimerKind = "REMATCH"         // Ends a rematch vote that not every player accepted in time
+	LobbyTimerReconnect    LobbyTimerKind = "RECONNECT"       // Ends the grace period of the players that disconnected
+	LobbyTimerMatchAccept  LobbyTimerKind = "MATCH_ACCEPT"    // Ends a pending match that not every player accepted in time
+)
+
+// LobbyTimer is a deadline owned by the scheduler. A lobby has at most one timer of each kind. The timers of the
+// pending matches hold the identifier of the match in place of the one of a lobby.
+type LobbyTimer struct {
+	ID        uint           `gorm:"primaryKey"`
+	LobbyID   string         `gorm:"not null;uniqueIndex:idx_lobby_timer"`
+	Kind      LobbyTimerKind `gorm:"type:string;not null;uniqueIndex:idx_lobby_timer"`
+	FiresAt   time.Time      `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
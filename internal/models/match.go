@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+type PendingMatchStatus string
+
+const (
+	PendingMatchPending   PendingMatchStatus = "PENDING"   // Waiting for every player to accept
+	PendingMatchAccepted  PendingMatchStatus = "ACCEPTED"  // Every player accepted, and the lobby of the game was created
+	PendingMatchDeclined  PendingMatchStatus = "DECLINED"  // A player declined, the others went back to searching
+	PendingMatchExpired   PendingMatchStatus = "EXPIRED"   // Not every player accepted in time, the others went back to searching
+	PendingMatchCancelled PendingMatchStatus = "CANCELLED" // A player entered a lobby meanwhile, the others went back to searching
+)
+
+// MatchTicket is a user searching for a game of a mode and region. A user searches for one game at most.
+type MatchTicket struct {
+	ID       uint   `gorm:"primaryKey"`
+	UserID   uint   `gorm:"not null;uniqueIndex"`
+	User     User   `gorm:"foreignKey:UserID"`
+	GameMode string `gorm:"not null;index:idx_match_ticket_queue"`
+	Region   string `gorm:"not null;index:idx_match_ticket_queue"`
+	// QueuedAt is when the user started searching. The matchmaker pairs the tickets that waited the longest first, and
+	// the time is kept when a match falls through, so that the users that did not decline it keep their place.
+	QueuedAt time.Time `gorm:"not null"`
+	// PendingMatchID is set while the ticket is part of a match waiting for the answers of its players.
+	PendingMatchID *string `gorm:"index"`
+	// AcceptedAt is set once the user accepted the pending match.
+	AcceptedAt *time.Time
+}
+
+// PendingMatch is a group of tickets the matchmaker paired for a game. Its lobby is only created once every player
+// accepted it before the deadline, otherwise the tickets of the players that did not decline go back to the queue.
+type PendingMatch struct {
+	MatchID  string             `gorm:"primaryKey"`
+	GameMode string             `gorm:"not null"`
+	Region   string             `gorm:"not null"`
+	Status   PendingMatchStatus `gorm:"type:string;not null;default:'PENDING'"`
+	Deadline time.Time          `gorm:"not null"`
+	// Tickets are those of the players of the match, in queue order. They are only loaded while the match is PENDING:
+	// afterwards they are either deleted or back in the queue.
+	Tickets []MatchTicket `gorm:"foreignKey:PendingMatchID"`
+	// LobbyID is set once the match is ACCEPTED.
+	LobbyID   *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Party is a group of users that create and join the lobbies together. It outlives the games of its members, until
+// the last of them leaves it.
+type Party struct {
+	ID uint `gorm:"primaryKey"`
+	// Only the leader can invite users, and create or join lobbies for the whole party.
+	LeaderID  uint `gorm:"not null"`
+	Leader    User `gorm:"foreignKey:LeaderID"`
+	Members   []PartyMember
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PartyMember is the membership of a user to a party. A user belongs to one party at most.
+type PartyMember struct {
+	ID       uint      `gorm:"primaryKey"`
+	PartyID  uint      `gorm:"not null;index"`
+	UserID   uint      `gorm:"not null;uniqueIndex"`
+	User     User      `gorm:"foreignKey:UserID"`
+	JoinedAt time.Time `gorm:"not null"`
+}
+
+// PartyInvite asks a user to join a party. It goes through the same statuses as the invites to a lobby.
+type PartyInvite struct {
+	gorm.Model
+	PartyID   uint         `gorm:"index;not null"`
+	InviterID uint         `gorm:"not null"`
+	Inviter   User         `gorm:"foreignKey:InviterID"`
+	InviteeID uint         `gorm:"index;not null"`
+	Invitee   User         `gorm:"foreignKey:InviteeID"`
+	Status    InviteStatus `gorm:"type:string;not null;default:'PENDING'"`
+	ExpiresAt time.Time    `gorm:"not null"`
+}
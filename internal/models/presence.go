@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Presence is the connection of a user, kept alive by the heartbeats of their clients. The user is online until
+// ExpiresAt. A user without a presence never sent a heartbeat: their connection is not tracked, so they are neither
+// online nor known to be disconnected.
+type Presence struct {
+	UserID          uint      `gorm:"primaryKey;autoIncrement:false"`
+	User            User      `gorm:"foreignKey:UserID"`
+	LastHeartbeatAt time.Time `gorm:"not null"`
+	ExpiresAt       time.Time `gorm:"not null;index"`
+}
+
+// Online tells whether the user was still connected at the given time.
+func (p *Presence) Online(at time.Time) bool {
+	return at.Before(p.ExpiresAt)
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// AllTime is the SeasonID of the standings that span every game ever played.
+const AllTime uint = 0
+
+// InitialRating is the rating of a player that has not finished any game yet.
+const InitialRating = 1000
+
+type RankingMetric string
+
+const (
+	RankingByWins   RankingMetric = "WINS"   // Most games won first
+	RankingByRating RankingMetric = "RATING" // Highest Elo rating first
+)
+
+// Season is a time-boxed period over which the players are ranked from scratch.
+type Season struct {
+	ID       uint      `gorm:"primaryKey"`
+	Number   int       `gorm:"uniqueIndex;not null"`
+	StartsAt time.Time `gorm:"not null"`
+	EndsAt   time.Time `gorm:"index;not null"`
+	// ArchivedAt is set once the standings of the ended season have been moved to archived_standings.
+	ArchivedAt *time.Time
+}
+
+// Standing is the live position of a player in the all-time ranking, when SeasonID is AllTime, or in the ranking of
+// a season that is not archived yet. The indexes on the scores keep the rank of a player a cheap count.
+type Standing struct {
+	ID        uint `gorm:"primaryKey"`
+	SeasonID  uint `gorm:"not null;uniqueIndex:idx_standings_season_user;index:idx_standings_season_wins;index:idx_standings_season_rating"`
+	UserID    uint `gorm:"not null;uniqueIndex:idx_standings_season_user"`
+	User      User
+	Wins      int `gorm:"not null;default:0;index:idx_standings_season_wins"`
+	Losses    int `gorm:"not null;default:0"`
+	Rating    int `gorm:"not null;index:idx_standings_season_rating"`
+	UpdatedAt time.Time
+}
+
+// ArchivedStanding is the final position of a player in an ended season, ranked both by wins and by rating.
+type ArchivedStanding struct {
+	ID         uint `gorm:"primaryKey"`
+	SeasonID   uint `gorm:"not null;uniqueIndex:idx_archived_standings_season_user;index:idx_archived_standings_season_wins_rank;index:idx_archived_standings_season_rating_rank"`
+	UserID     uint `gorm:"not null;uniqueIndex:idx_archived_standings_season_user"`
+	User       User
+	Wins       int `gorm:"not null"`
+	Losses     int `gorm:"not null"`
+	Rating     int `gorm:"not null"`
+	WinsRank   int `gorm:"not null;index:idx_archived_standings_season_wins_rank"`
+	RatingRank int `gorm:"not null;index:idx_archived_standings_season_rating_rank"`
+}
@@ -1,12 +1,15 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type User struct {
 	gorm.Model
-	Username string  `gorm:"uniqueIndex;not null"`
-	Password string  `gorm:"not null"`
-	LobbyID  *string `gorm:"index"`
+	Username string `gorm:"uniqueIndex;not null"`
+	Password string `gorm:"not null"`
+	// LastSeenAt is the last time the user called the API.
+	LastSeenAt *time.Time
 }
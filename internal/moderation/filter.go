@@ -0,0 +1,61 @@
+package moderation
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrMessageRejected = errors.New("message rejected by the content filter")
+
+// ContentFilter moderates the text of the chat messages before they are stored and delivered. A filter can rewrite
+// the text, or reject the whole message with ErrMessageRejected.
+type ContentFilter interface {
+	Filter(text string) (string, error)
+}
+
+// DefaultWordList is the list of profanities masked by the chat when no other list is configured.
+var DefaultWordList = []string{"arse", "asshole", "bastard", "bitch", "bollocks", "crap", "damn", "dick", "fuck", "shit"}
+
+type wordListFilter struct {
+	words map[string]struct{}
+}
+
+// NewWordListFilter returns a filter that masks the words of the list with asterisks, ignoring the case. Only whole
+// words are masked, so that the innocent words containing a profanity are left alone.
+func NewWordListFilter(words []string) ContentFilter {
+	f := &wordListFilter{words: make(map[string]struct{}, len(words))}
+	for _, word := range words {
+		f.words[strings.ToLower(strings.TrimSpace(word))] = struct{}{}
+	}
+	return f
+}
+
+func (f *wordListFilter) Filter(text string) (string, error) {
+	var filtered strings.Builder
+	runes := []rune(text)
+	for start := 0; start < len(runes); {
+		end := start
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		if end == start {
+			filtered.WriteRune(runes[start])
+			start++
+			continue
+		}
+
+		word := string(runes[start:end])
+		if _, found := f.words[strings.ToLower(word)]; found {
+			filtered.WriteString(strings.Repeat("*", end-start))
+		} else {
+			filtered.WriteString(word)
+		}
+		start = end
+	}
+	return filtered.String(), nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,35 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordListFilterMasksTheListedWords(t *testing.T) {
+	filter := NewWordListFilter([]string{"darn", "Heck"})
+
+	filtered, err := filter.Filter("Darn it, what the heck!")
+
+	require.NoError(t, err)
+	assert.Equal(t, "**** it, what the ****!", filtered)
+}
+
+func TestWordListFilterKeepsTheWordsContainingAListedWord(t *testing.T) {
+	filter := NewWordListFilter([]string{"ass"})
+
+	filtered, err := filter.Filter("a classic pass")
+
+	require.NoError(t, err)
+	assert.Equal(t, "a classic pass", filtered)
+}
+
+func TestWordListFilterKeepsTheTextWithoutListedWords(t *testing.T) {
+	filter := NewWordListFilter(DefaultWordList)
+
+	filtered, err := filter.Filter("gg wp, señor 👍")
+
+	require.NoError(t, err)
+	assert.Equal(t, "gg wp, señor 👍", filtered)
+}
@@ -0,0 +1,152 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// The parameters read from a stored hash must be within these bounds, so that a corrupted or forged hash can neither
+// make argon2 panic nor make Verify use an unbounded amount of memory and time. argon2 needs at least one iteration,
+// one thread and 8 KiB of memory per thread.
+const (
+	maxArgon2idMemory     = 1024 * 1024 // 1 GiB, in KiB
+	maxArgon2idIterations = 64
+	maxArgon2idThreads    = 64
+)
+
+// package-level variable used for test purpose only.
+var randRead = rand.Read
+
+// Argon2idParams are the tunable parameters of argon2id. Memory is expressed in KiB.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// DefaultArgon2idParams follows the OWASP minimum recommendation (19 MiB of memory, 2 iterations, 1 thread).
+var DefaultArgon2idParams = Argon2idParams{
+	Memory:      19 * 1024,
+	Iterations:  2,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// Validate checks the memory, the iterations and the parallelism against the bounds of the hashes that can be
+// verified.
+func (p Argon2idParams) Validate() error {
+	if p.Iterations < 1 || p.Iterations > maxArgon2idIterations {
+		return fmt.Errorf("argon2id iterations must be between 1 and %d, got %d", maxArgon2idIterations, p.Iterations)
+	}
+	if p.Parallelism < 1 || p.Parallelism > maxArgon2idThreads {
+		return fmt.Errorf("argon2id parallelism must be between 1 and %d, got %d", maxArgon2idThreads, p.Parallelism)
+	}
+	if p.Memory < 8*uint32(p.Parallelism) || p.Memory > maxArgon2idMemory {
+		return fmt.Errorf("argon2id memory must be between %d and %d KiB, got %d",
+			8*uint32(p.Parallelism), maxArgon2idMemory, p.Memory)
+	}
+	return nil
+}
+
+// argon2idHasher stores hashes in the PHC string format: $argon2id$v=19$m=<memory>,t=<iterations>,p=<threads>$<salt>$<key>
+type argon2idHasher struct {
+	params Argon2idParams
+}
+
+func NewArgon2idHasher(params Argon2idParams) Algorithm {
+	return &argon2idHasher{params: params}
+}
+
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := randRead(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.Memory,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idHasher) Verify(hash, password string) error {
+	params, salt, key, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return err
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, computed) != 1 {
+		return ErrMismatchedPassword
+	}
+	return nil
+}
+
+func (h *argon2idHasher) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeArgon2idHash(hash)
+	if err != nil {
+		return true
+	}
+	return params.Memory < h.params.Memory ||
+		params.Iterations < h.params.Iterations ||
+		params.Parallelism < h.params.Parallelism ||
+		params.SaltLength < h.params.SaltLength ||
+		params.KeyLength < h.params.KeyLength
+}
+
+func (h *argon2idHasher) Identifies(hash string) bool {
+	return strings.HasPrefix(hash, argon2idPrefix)
+}
+
+func decodeArgon2idHash(hash string) (Argon2idParams, []byte, []byte, error) {
+	var params Argon2idParams
+
+	// The leading "$" produces an empty first part.
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrMalformedHash
+	}
+	if params.Validate() != nil {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
@@ -0,0 +1,74 @@
+package password
+
+import (
+	"errors"
+)
+
+var (
+	ErrMismatchedPassword = errors.New("password does not match the hash")
+	ErrUnknownAlgorithm   = errors.New("hash was produced by an unknown algorithm")
+	ErrMalformedHash      = errors.New("malformed password hash")
+)
+
+// PasswordHasher hashes and verifies passwords. Every hash carries the identifier of the algorithm that produced
+// it, together with the parameters used, so that it can be verified even after the configuration changes.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+
+	// Verify returns nil if the password matches the hash, ErrMismatchedPassword otherwise.
+	Verify(hash, password string) error
+
+	// NeedsRehash reports whether the hash was produced by an older algorithm or with weaker parameters than
+	// the ones currently configured.
+	NeedsRehash(hash string) bool
+}
+
+// Algorithm is a PasswordHasher that is able to recognize its own hashes.
+type Algorithm interface {
+	PasswordHasher
+	Identifies(hash string) bool
+}
+
+// upgradingHasher produces new hashes with the current algorithm, but it is still able to verify the hashes
+// produced by the legacy ones.
+type upgradingHasher struct {
+	current Algorithm
+	legacy  []Algorithm
+}
+
+// NewPasswordHasher returns a PasswordHasher that hashes with current and verifies both current and legacy hashes.
+// Hashes produced by a legacy algorithm always need a rehash.
+func NewPasswordHasher(current Algorithm, legacy ...Algorithm) PasswordHasher {
+	return &upgradingHasher{current: current, legacy: legacy}
+}
+
+func (h *upgradingHasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+func (h *upgradingHasher) Verify(hash, password string) error {
+	algorithm, err := h.algorithmFor(hash)
+	if err != nil {
+		return err
+	}
+	return algorithm.Verify(hash, password)
+}
+
+func (h *upgradingHasher) NeedsRehash(hash string) bool {
+	if !h.current.Identifies(hash) {
+		return true
+	}
+	return h.current.NeedsRehash(hash)
+}
+
+func (h *upgradingHasher) algorithmFor(hash string) (Algorithm, error) {
+	if h.current.Identifies(hash) {
+		return h.current, nil
+	}
+	for _, algorithm := range h.legacy {
+		if algorithm.Identifies(hash) {
+			return algorithm, nil
+		}
+	}
+	return nil, ErrUnknownAlgorithm
+}
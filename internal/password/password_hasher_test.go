@@ -0,0 +1,160 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const fixturePassword string = "password123"
+
+// Cheap parameters, so that the suite stays fast.
+var fixtureParams = Argon2idParams{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+type PasswordHasherTestSuite struct {
+	suite.Suite
+	argon2id Algorithm
+	bcrypt   Algorithm
+	hasher   PasswordHasher
+}
+
+func (s *PasswordHasherTestSuite) SetupTest() {
+	s.argon2id = NewArgon2idHasher(fixtureParams)
+	s.bcrypt = NewBcryptHasher(bcrypt.MinCost)
+	s.hasher = NewPasswordHasher(s.argon2id, s.bcrypt)
+}
+
+func (s *PasswordHasherTestSuite) TestArgon2idHashAndVerify() {
+	hash, err := s.argon2id.Hash(fixturePassword)
+	s.NoError(err)
+	s.True(strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+	s.True(s.argon2id.Identifies(hash))
+	s.NoError(s.argon2id.Verify(hash, fixturePassword))
+	s.ErrorIs(s.argon2id.Verify(hash, "wrong"), ErrMismatchedPassword)
+}
+
+func (s *PasswordHasherTestSuite) TestArgon2idHashWhenSaltGenerationFails() {
+	originalRandRead := randRead
+	defer func() { randRead = originalRandRead }()
+	randRead = func(b []byte) (int, error) {
+		return 0, errors.New("mock entropy error")
+	}
+
+	hash, err := s.argon2id.Hash(fixturePassword)
+	s.Error(err)
+	s.Empty(hash)
+}
+
+func (s *PasswordHasherTestSuite) TestArgon2idVerifyWithMalformedHash() {
+	malformed := []string{
+		"$argon2id$",
+		"$argon2id$v=18$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$not base64!$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$",
+	}
+	for _, hash := range malformed {
+		s.ErrorIs(s.argon2id.Verify(hash, fixturePassword), ErrMalformedHash, hash)
+		s.True(s.argon2id.NeedsRehash(hash), hash)
+	}
+}
+
+func (s *PasswordHasherTestSuite) TestArgon2idVerifyWithParamsOutOfRange() {
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{"no iterations", "$argon2id$v=19$m=64,t=0,p=1$c2FsdA$a2V5"},
+		{"too many iterations", "$argon2id$v=19$m=64,t=65,p=1$c2FsdA$a2V5"},
+		{"no memory", "$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5"},
+		{"less memory than the threads need", "$argon2id$v=19$m=15,t=1,p=2$c2FsdA$a2V5"},
+		{"too much memory", "$argon2id$v=19$m=1048577,t=1,p=1$c2FsdA$a2V5"},
+		{"no threads", "$argon2id$v=19$m=64,t=1,p=0$c2FsdA$a2V5"},
+		{"too many threads", "$argon2id$v=19$m=1024,t=1,p=65$c2FsdA$a2V5"},
+		{"threads overflowing", "$argon2id$v=19$m=64,t=1,p=256$c2FsdA$a2V5"},
+		{"negative memory", "$argon2id$v=19$m=-1,t=1,p=1$c2FsdA$a2V5"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.ErrorIs(s.argon2id.Verify(tt.hash, fixturePassword), ErrMalformedHash)
+			s.True(s.argon2id.NeedsRehash(tt.hash))
+		})
+	}
+}
+
+func (s *PasswordHasherTestSuite) TestArgon2idParamsValidate() {
+	s.NoError(DefaultArgon2idParams.Validate())
+	s.NoError(fixtureParams.Validate())
+
+	noIterations := fixtureParams
+	noIterations.Iterations = 0
+	s.ErrorContains(noIterations.Validate(), "iterations")
+
+	tooLittleMemory := fixtureParams
+	tooLittleMemory.Parallelism = 16
+	s.ErrorContains(tooLittleMemory.Validate(), "memory")
+}
+
+func (s *PasswordHasherTestSuite) TestArgon2idNeedsRehashWhenParamsAreWeaker() {
+	hash, err := s.argon2id.Hash(fixturePassword)
+	s.Require().NoError(err)
+	s.False(s.argon2id.NeedsRehash(hash))
+
+	stronger := fixtureParams
+	stronger.Iterations = 2
+	s.True(NewArgon2idHasher(stronger).NeedsRehash(hash))
+
+	// A hash made with the old parameters must still be verifiable.
+	s.NoError(NewArgon2idHasher(stronger).Verify(hash, fixturePassword))
+}
+
+func (s *PasswordHasherTestSuite) TestBcryptHashAndVerify() {
+	hash, err := s.bcrypt.Hash(fixturePassword)
+	s.NoError(err)
+	s.True(s.bcrypt.Identifies(hash))
+	s.False(s.bcrypt.NeedsRehash(hash))
+	s.NoError(s.bcrypt.Verify(hash, fixturePassword))
+	s.ErrorIs(s.bcrypt.Verify(hash, "wrong"), ErrMismatchedPassword)
+	s.ErrorIs(s.bcrypt.Verify("$2a$invalid", fixturePassword), ErrMalformedHash)
+}
+
+func (s *PasswordHasherTestSuite) TestBcryptNeedsRehashWhenCostIsLower() {
+	hash, err := s.bcrypt.Hash(fixturePassword)
+	s.Require().NoError(err)
+	s.True(NewBcryptHasher(bcrypt.MinCost + 1).NeedsRehash(hash))
+}
+
+func (s *PasswordHasherTestSuite) TestHasherUsesTheCurrentAlgorithm() {
+	hash, err := s.hasher.Hash(fixturePassword)
+	s.NoError(err)
+	s.True(s.argon2id.Identifies(hash))
+	s.False(s.hasher.NeedsRehash(hash))
+	s.NoError(s.hasher.Verify(hash, fixturePassword))
+}
+
+func (s *PasswordHasherTestSuite) TestHasherVerifiesLegacyHashesAndAsksForRehash() {
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte(fixturePassword), bcrypt.MinCost)
+	s.Require().NoError(err)
+
+	s.NoError(s.hasher.Verify(string(legacyHash), fixturePassword))
+	s.ErrorIs(s.hasher.Verify(string(legacyHash), "wrong"), ErrMismatchedPassword)
+	s.True(s.hasher.NeedsRehash(string(legacyHash)))
+}
+
+func (s *PasswordHasherTestSuite) TestHasherWithUnknownAlgorithm() {
+	s.ErrorIs(s.hasher.Verify("plaintext", fixturePassword), ErrUnknownAlgorithm)
+	s.True(s.hasher.NeedsRehash("plaintext"))
+}
+
+func TestPasswordHasher(t *testing.T) {
+	suite.Run(t, new(PasswordHasherTestSuite))
+}
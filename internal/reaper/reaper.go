@@ -0,0 +1,111 @@
+package reaper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/scheduler"
+)
+
+// Config tells the reaper which WAITING lobbies are stale and how often to look for them.
+type Config struct {
+	// TTL is the maximum age of a WAITING lobby.
+	TTL time.Duration
+	// CreatorIdle is how long the creator of a WAITING lobby can stay away from the API.
+	CreatorIdle time.Duration
+	// CreatorDisconnected is how long the creator of a WAITING lobby can stay disconnected once the heartbeats of
+	// their clients stop. It is usually much shorter than CreatorIdle, which applies to clients that do not send
+	// heartbeats.
+	CreatorDisconnected time.Duration
+	Interval            time.Duration
+}
+
+// Reaper periodically cancels the WAITING lobbies that nobody is going to fill.
+//
+// Several replicas can run a reaper on the same database: a lobby is closed only by the replica whose conditional
+// update wins, the others skip it.
+type Reaper interface {
+	// Start runs a first pass immediately, then one every interval until the context is done.
+	Start(ctx context.Context)
+}
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+type lobbyReaper struct {
+	lobbyRepo lobbyrepo.LobbyRepository
+	scheduler scheduler.Scheduler
+	cfg       Config
+}
+
+func NewReaper(lobbyRepo lobbyrepo.LobbyRepository, lobbyScheduler scheduler.Scheduler, cfg Config) Reaper {
+	return &lobbyReaper{
+		lobbyRepo: lobbyRepo,
+		scheduler: lobbyScheduler,
+		cfg:       cfg,
+	}
+}
+
+func (r *lobbyReaper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			if closed := r.reap(); closed > 0 {
+				log.Printf("Reaper closed %d stale lobbies", closed)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// reap closes the stale lobbies and returns how many of them this replica closed.
+func (r *lobbyReaper) reap() int {
+	reapedAt := now()
+	expiredBefore := reapedAt.Add(-r.cfg.TTL)
+	stale, err := r.lobbyRepo.ListStale(expiredBefore, reapedAt.Add(-r.cfg.CreatorIdle),
+		reapedAt.Add(-r.cfg.CreatorDisconnected))
+	if err != nil {
+		log.Printf("Failed to list the stale lobbies: %v", err)
+		return 0
+	}
+
+	closed := 0
+	for _, staleLobby := range stale {
+		reason := models.LobbyCloseCreatorInactive
+		if staleLobby.CreatedAt.Before(expiredBefore) {
+			reason = models.LobbyCloseExpired
+		}
+		if r.close(staleLobby.LobbyID, reason, reapedAt) {
+			closed++
+		}
+	}
+	return closed
+}
+
+func (r *lobbyReaper) close(lobbyID string, reason models.LobbyCloseReason, closedAt time.Time) bool {
+	err := r.lobbyRepo.CloseWaiting(lobbyID, reason, closedAt)
+	if errors.Is(err, lobbyrepo.ErrLobbyNotWaiting) {
+		// Another replica closed the lobby, or a player filled it, since it was listed.
+		return false
+	}
+	if err != nil {
+		log.Printf("Failed to close the stale lobby %s: %v", lobbyID, err)
+		return false
+	}
+
+	if err := r.scheduler.Cancel(lobbyID, models.LobbyTimerWaiting); err != nil {
+		log.Printf("Failed to cancel the waiting timeout of lobby %s: %v", lobbyID, err)
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package reaper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/scheduler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var fixtureConfig = Config{
+	TTL:                 time.Hour,
+	CreatorIdle:         15 * time.Minute,
+	CreatorDisconnected: 2 * time.Minute,
+	Interval:            time.Minute,
+}
+
+type MockScheduler struct {
+	mock.Mock
+}
+
+func (m *MockScheduler) Handle(kind models.LobbyTimerKind, handler scheduler.Handler) {
+	m.Called(kind, handler)
+}
+
+func (m *MockScheduler) Schedule(lobbyID string, kind models.LobbyTimerKind, firesAt time.Time) error {
+	args := m.Called(lobbyID, kind, firesAt)
+	return args.Error(0)
+}
+
+func (m *MockScheduler) Cancel(lobbyID string, kind models.LobbyTimerKind) error {
+	args := m.Called(lobbyID, kind)
+	return args.Error(0)
+}
+
+func (m *MockScheduler) Start(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type ReaperTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	lobbyRepo   lobbyrepo.LobbyRepository
+	scheduler   *MockScheduler
+	reaper      *lobbyReaper
+	originalNow func() time.Time
+}
+
+func (s *ReaperTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *ReaperTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *ReaperTestSuite) SetupTest() {
+	tables := []any{&models.User{}, &models.Lobby{}, &models.LobbyPlayer{}, &models.LobbySpectator{}, &models.LobbyTimer{},
+		&models.Presence{}}
+	err := s.db.Migrator().DropTable(tables...)
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(tables...)
+	s.Require().NoError(err)
+
+	s.lobbyRepo = lobbyrepo.NewSQLLobbyRepository(s.db)
+	s.scheduler = new(MockScheduler)
+	s.reaper = NewReaper(s.lobbyRepo, s.scheduler, fixtureConfig).(*lobbyReaper)
+
+	s.originalNow = now
+	now = func() time.Time { return fixtureNow }
+}
+
+func (s *ReaperTestSuite) TearDownTest() {
+	now = s.originalNow
+}
+
+// createLobby stores a lobby with its creator, who was last seen at lastSeenAt.
+func (s *ReaperTestSuite) createLobby(lobbyID string, status models.LobbyStatus, createdAt, lastSeenAt time.Time) *models.User {
+	creator := models.User{Username: "creator-" + lobbyID, Password: "password", LastSeenAt: &lastSeenAt}
+	s.Require().NoError(s.db.Create(&creator).Error)
+	lobby := models.Lobby{
+		LobbyID:   lobbyID,
+		Name:      lobbyID,
+		Status:    status,
+		CreatorID: &creator.ID,
+		Players:   []models.LobbyPlayer{{UserID: creator.ID}},
+		CreatedAt: createdAt,
+	}
+	s.Require().NoError(s.db.Create(&lobby).Error)
+	return &creator
+}
+
+func (s *ReaperTestSuite) closeReason(lobbyID string) *models.LobbyCloseReason {
+	foundLobby, err := s.lobbyRepo.FindByID(lobbyID)
+	s.Require().NoError(err)
+	return foundLobby.CloseReason
+}
+
+func (s *ReaperTestSuite) TestReapClosesTheExpiredLobbies() {
+	creator := s.createLobby("expired", models.LobbyStatusWaiting, fixtureNow.Add(-2*time.Hour), fixtureNow)
+	s.scheduler.On("Cancel", "expired", models.LobbyTimerWaiting).Return(nil)
+
+	closed := s.reaper.reap()
+
+	s.Equal(1, closed)
+	s.Equal(models.LobbyCloseExpired, *s.closeReason("expired"))
+	var membership models.LobbyPlayer
+	s.Require().NoError(s.db.First(&membership, "lobby_id = ? AND user_id = ?", "expired", creator.ID).Error)
+	s.NotNil(membership.LeftAt)
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *ReaperTestSuite) TestReapClosesTheLobbiesOfInactiveCreators() {
+	s.createLobby("idle", models.LobbyStatusWaiting, fixtureNow.Add(-30*time.Minute), fixtureNow.Add(-20*time.Minute))
+	s.scheduler.On("Cancel", "idle", models.LobbyTimerWaiting).Return(nil)
+
+	closed := s.reaper.reap()
+
+	s.Equal(1, closed)
+	s.Equal(models.LobbyCloseCreatorInactive, *s.closeReason("idle"))
+}
+
+func (s *ReaperTestSuite) TestReapClosesTheLobbiesOfDisconnectedCreators() {
+	creator := s.createLobby("gone", models.LobbyStatusWaiting, fixtureNow.Add(-30*time.Minute), fixtureNow.Add(-5*time.Minute))
+	presence := models.Presence{UserID: creator.ID, LastHeartbeatAt: fixtureNow.Add(-5 * time.Minute),
+		ExpiresAt: fixtureNow.Add(-3 * time.Minute)}
+	s.Require().NoError(s.db.Create(&presence).Error)
+	s.scheduler.On("Cancel", "gone", models.LobbyTimerWaiting).Return(nil)
+
+	closed := s.reaper.reap()
+
+	s.Equal(1, closed)
+	s.Equal(models.LobbyCloseCreatorInactive, *s.closeReason("gone"))
+}
+
+func (s *ReaperTestSuite) TestReapKeepsTheLobbiesOfCreatorsThatJustDisconnected() {
+	creator := s.createLobby("blip", models.LobbyStatusWaiting, fixtureNow.Add(-30*time.Minute), fixtureNow.Add(-time.Minute))
+	presence := models.Presence{UserID: creator.ID, LastHeartbeatAt: fixtureNow.Add(-time.Minute),
+		ExpiresAt: fixtureNow.Add(-30 * time.Second)}
+	s.Require().NoError(s.db.Create(&presence).Error)
+
+	closed := s.reaper.reap()
+
+	s.Zero(closed)
+	s.Nil(s.closeReason("blip"))
+}
+
+func (s *ReaperTestSuite) TestReapKeepsTheActiveLobbies() {
+	s.createLobby("fresh", models.LobbyStatusWaiting, fixtureNow.Add(-30*time.Minute), fixtureNow.Add(-time.Minute))
+	s.createLobby("started", models.LobbyStatusInProgress, fixtureNow.Add(-2*time.Hour), fixtureNow.Add(-time.Hour))
+
+	closed := s.reaper.reap()
+
+	s.Zero(closed)
+	s.Nil(s.closeReason("fresh"))
+	s.Nil(s.closeReason("started"))
+	s.scheduler.AssertNotCalled(s.T(), "Cancel", mock.Anything, mock.Anything)
+}
+
+func (s *ReaperTestSuite) TestReapIsSafeWithSeveralReplicas() {
+	s.createLobby("expired", models.LobbyStatusWaiting, fixtureNow.Add(-2*time.Hour), fixtureNow)
+	s.scheduler.On("Cancel", "expired", models.LobbyTimerWaiting).Return(nil).Once()
+	otherReplica := NewReaper(lobbyrepo.NewSQLLobbyRepository(s.db), s.scheduler, fixtureConfig).(*lobbyReaper)
+	// Both replicas list the lobby before any of them closes it.
+	stale, err := s.lobbyRepo.ListStale(fixtureNow.Add(-fixtureConfig.TTL), fixtureNow.Add(-fixtureConfig.CreatorIdle),
+		fixtureNow.Add(-fixtureConfig.CreatorDisconnected))
+	s.Require().NoError(err)
+	s.Require().Len(stale, 1)
+
+	first := s.reaper.close("expired", models.LobbyCloseExpired, fixtureNow)
+	second := otherReplica.close("expired", models.LobbyCloseCreatorInactive, fixtureNow)
+
+	s.True(first)
+	s.False(second)
+	s.Equal(models.LobbyCloseExpired, *s.closeReason("expired"))
+	s.scheduler.AssertNumberOfCalls(s.T(), "Cancel", 1)
+}
+
+func (s *ReaperTestSuite) TestReapClosesTheLobbyEvenIfTheTimerCannotBeCancelled() {
+	s.createLobby("expired", models.LobbyStatusWaiting, fixtureNow.Add(-2*time.Hour), fixtureNow)
+	s.scheduler.On("Cancel", "expired", models.LobbyTimerWaiting).Return(errors.New("db error"))
+
+	closed := s.reaper.reap()
+
+	s.Equal(1, closed)
+	s.Equal(models.LobbyCloseExpired, *s.closeReason("expired"))
+}
+
+func TestReaper(t *testing.T) {
+	suite.Run(t, new(ReaperTestSuite))
+}
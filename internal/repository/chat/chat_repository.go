@@ -0,0 +1,11 @@
+package chat
+
+import "github.com/NicoPolazzi/multiplayer-queue/internal/models"
+
+type ChatRepository interface {
+	// Create stores the message, then deletes the oldest messages of its lobby so that no more than backlog are
+	// kept.
+	Create(message *models.ChatMessage, backlog int) error
+	// ListRecent returns the last limit messages of the lobby, oldest first.
+	ListRecent(lobbyID string, limit int) ([]*models.ChatMessage, error)
+}
@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"slices"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlChatRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLChatRepository(db *gorm.DB) ChatRepository {
+	return &sqlChatRepository{db: db}
+}
+
+func (r *sqlChatRepository) Create(message *models.ChatMessage, backlog int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User").Create(message).Error; err != nil {
+			return err
+		}
+
+		kept := tx.Session(&gorm.Session{NewDB: true}).Model(&models.ChatMessage{}).
+			Select("id").
+			Where("lobby_id = ?", message.LobbyID).
+			Order("id DESC").
+			Limit(backlog)
+		return tx.Where("lobby_id = ? AND id NOT IN (?)", message.LobbyID, kept).Delete(&models.ChatMessage{}).Error
+	})
+}
+
+func (r *sqlChatRepository) ListRecent(lobbyID string, limit int) ([]*models.ChatMessage, error) {
+	var messages []*models.ChatMessage
+	err := r.db.Preload("User").
+		Where("lobby_id = ?", lobbyID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&messages).Error
+	slices.Reverse(messages)
+	return messages, err
+}
@@ -0,0 +1,87 @@
+package chat
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const fixtureLobbyID = "lobby-123"
+
+type ChatSQLRepositoryTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	chatRepo ChatRepository
+	author   models.User
+}
+
+func (s *ChatSQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *ChatSQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *ChatSQLRepositoryTestSuite) SetupTest() {
+	err := s.db.Migrator().DropTable(&models.User{}, &models.ChatMessage{})
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(&models.User{}, &models.ChatMessage{})
+	s.Require().NoError(err)
+
+	s.author = models.User{Username: "author", Password: "password"}
+	s.Require().NoError(s.db.Create(&s.author).Error)
+	s.chatRepo = NewSQLChatRepository(s.db)
+}
+
+func (s *ChatSQLRepositoryTestSuite) send(lobbyID, text string, backlog int) {
+	message := &models.ChatMessage{LobbyID: lobbyID, UserID: s.author.ID, Text: text, SentAt: time.Now().UTC()}
+	s.Require().NoError(s.chatRepo.Create(message, backlog))
+	s.NotZero(message.ID)
+}
+
+func (s *ChatSQLRepositoryTestSuite) TestListRecentReturnsTheLastMessagesOldestFirst() {
+	for i := 1; i <= 3; i++ {
+		s.send(fixtureLobbyID, fmt.Sprintf("message %d", i), 10)
+	}
+	s.send("other-lobby", "elsewhere", 10)
+
+	messages, err := s.chatRepo.ListRecent(fixtureLobbyID, 2)
+
+	s.NoError(err)
+	s.Require().Len(messages, 2)
+	s.Equal("message 2", messages[0].Text)
+	s.Equal("message 3", messages[1].Text)
+	s.Equal("author", messages[1].User.Username)
+}
+
+func (s *ChatSQLRepositoryTestSuite) TestCreateKeepsOnlyTheBacklogOfTheLobby() {
+	s.send("other-lobby", "elsewhere", 2)
+	for i := 1; i <= 5; i++ {
+		s.send(fixtureLobbyID, fmt.Sprintf("message %d", i), 2)
+	}
+
+	var stored int64
+	s.db.Model(&models.ChatMessage{}).Where("lobby_id = ?", fixtureLobbyID).Count(&stored)
+	s.Equal(int64(2), stored)
+	messages, err := s.chatRepo.ListRecent(fixtureLobbyID, 10)
+	s.NoError(err)
+	s.Require().Len(messages, 2)
+	s.Equal("message 4", messages[0].Text)
+	other, err := s.chatRepo.ListRecent("other-lobby", 10)
+	s.NoError(err)
+	s.Len(other, 1)
+}
+
+func TestChatSQLRepository(t *testing.T) {
+	suite.Run(t, new(ChatSQLRepositoryTestSuite))
+}
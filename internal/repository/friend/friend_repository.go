@@ -0,0 +1,33 @@
+package friend
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+)
+
+var (
+	ErrFriendshipNotFound = errors.New("friendship not found in the database")
+	ErrFriendshipExists   = errors.New("users are already friends or one of them asked the other")
+)
+
+type FriendRepository interface {
+	// CreateRequest stores a pending friendship. It fails with ErrFriendshipExists when the two users already have a
+	// friendship, pending or accepted, in either direction.
+	CreateRequest(friendship *models.Friendship) error
+	FindByID(friendshipID uint) (*models.Friendship, error)
+	// FindBetween returns the friendship of the two users, in either direction, or ErrFriendshipNotFound.
+	FindBetween(userID, otherID uint) (*models.Friendship, error)
+	Accept(friendship *models.Friendship, acceptedAt time.Time) error
+	Delete(friendship *models.Friendship) error
+	// ListFriends returns the accepted friendships of the user, with both users loaded.
+	ListFriends(userID uint) ([]*models.Friendship, error)
+	// ListIncomingRequests returns the pending friendships addressed to the user, oldest first.
+	ListIncomingRequests(userID uint) ([]*models.Friendship, error)
+	// Block stores that the blocker blocked the other user, and ends their friendship if any. Blocking a user twice
+	// is not an error.
+	Block(blockerID, blockedID uint) error
+	// HasBlocked reports whether any of the blockers blocked the user.
+	HasBlocked(blockerIDs []uint, blockedID uint) (bool, error)
+}
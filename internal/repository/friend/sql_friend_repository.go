@@ -0,0 +1,132 @@
+package friend
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlFriendRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLFriendRepository(db *gorm.DB) FriendRepository {
+	return &sqlFriendRepository{db: db}
+}
+
+// CreateRequest looks for a friendship in the opposite direction inside the transaction: the unique index only
+// covers the direction of the new request.
+func (r *sqlFriendRepository) CreateRequest(friendship *models.Friendship) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		_, err := findBetween(tx, friendship.RequesterID, friendship.AddresseeID)
+		if err == nil {
+			return ErrFriendshipExists
+		}
+		if !errors.Is(err, ErrFriendshipNotFound) {
+			return err
+		}
+		return tx.Omit("Requester", "Addressee").Create(friendship).Error
+	})
+}
+
+// withUsers preloads both users of the friendships.
+func withUsers(db *gorm.DB) *gorm.DB {
+	return db.Preload("Requester").Preload("Addressee")
+}
+
+func findBetween(db *gorm.DB, userID, otherID uint) (*models.Friendship, error) {
+	var friendship models.Friendship
+	result := withUsers(db).
+		Where("(requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)",
+			userID, otherID, otherID, userID).
+		First(&friendship)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrFriendshipNotFound
+	}
+	return &friendship, result.Error
+}
+
+func (r *sqlFriendRepository) FindByID(friendshipID uint) (*models.Friendship, error) {
+	var friendship models.Friendship
+	result := withUsers(r.db).First(&friendship, friendshipID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrFriendshipNotFound
+	}
+	return &friendship, result.Error
+}
+
+func (r *sqlFriendRepository) FindBetween(userID, otherID uint) (*models.Friendship, error) {
+	return findBetween(r.db, userID, otherID)
+}
+
+func (r *sqlFriendRepository) Accept(friendship *models.Friendship, acceptedAt time.Time) error {
+	result := r.db.Model(&models.Friendship{}).
+		Where("id = ? AND status = ?", friendship.ID, models.FriendshipStatusPending).
+		Updates(map[string]any{"status": models.FriendshipStatusAccepted, "accepted_at": acceptedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFriendshipNotFound
+	}
+	friendship.Status = models.FriendshipStatusAccepted
+	friendship.AcceptedAt = &acceptedAt
+	return nil
+}
+
+func (r *sqlFriendRepository) Delete(friendship *models.Friendship) error {
+	result := r.db.Delete(&models.Friendship{}, friendship.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFriendshipNotFound
+	}
+	return nil
+}
+
+func (r *sqlFriendRepository) ListFriends(userID uint) ([]*models.Friendship, error) {
+	var friendships []*models.Friendship
+	err := withUsers(r.db).
+		Where("status = ?", models.FriendshipStatusAccepted).
+		Where("requester_id = ? OR addressee_id = ?", userID, userID).
+		Order("accepted_at").Order("id").
+		Find(&friendships).Error
+	return friendships, err
+}
+
+func (r *sqlFriendRepository) ListIncomingRequests(userID uint) ([]*models.Friendship, error) {
+	var friendships []*models.Friendship
+	err := withUsers(r.db).
+		Where("status = ? AND addressee_id = ?", models.FriendshipStatusPending, userID).
+		Order("created_at").Order("id").
+		Find(&friendships).Error
+	return friendships, err
+}
+
+func (r *sqlFriendRepository) Block(blockerID, blockedID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		block := models.Block{BlockerID: blockerID, BlockedID: blockedID}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&block).Error; err != nil {
+			return err
+		}
+		return tx.
+			Where("(requester_id = ? AND addressee_id = ?) OR (requester_id = ? AND addressee_id = ?)",
+				blockerID, blockedID, blockedID, blockerID).
+			Delete(&models.Friendship{}).Error
+	})
+}
+
+func (r *sqlFriendRepository) HasBlocked(blockerIDs []uint, blockedID uint) (bool, error) {
+	if len(blockerIDs) == 0 {
+		return false, nil
+	}
+	var blocks int64
+	err := r.db.Model(&models.Block{}).
+		Where("blocker_id IN ? AND blocked_id = ?", blockerIDs, blockedID).
+		Count(&blocks).Error
+	return blocks > 0, err
+}
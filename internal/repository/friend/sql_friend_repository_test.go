@@ -0,0 +1,181 @@
+package friend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type FriendSQLRepositoryTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	friendRepo FriendRepository
+	alice      models.User
+	bob        models.User
+	carol      models.User
+	now        time.Time
+}
+
+func (s *FriendSQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *FriendSQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *FriendSQLRepositoryTestSuite) SetupTest() {
+	err := s.db.Migrator().DropTable(&models.User{}, &models.Friendship{}, &models.Block{})
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(&models.User{}, &models.Friendship{}, &models.Block{})
+	s.Require().NoError(err)
+
+	s.alice = s.createUserInDB("alice")
+	s.bob = s.createUserInDB("bob")
+	s.carol = s.createUserInDB("carol")
+	s.now = time.Now().UTC()
+
+	s.friendRepo = NewSQLFriendRepository(s.db)
+}
+
+func (s *FriendSQLRepositoryTestSuite) createUserInDB(username string) models.User {
+	user := models.User{Username: username, Password: "password"}
+	s.Require().NoError(s.db.Create(&user).Error)
+	return user
+}
+
+func (s *FriendSQLRepositoryTestSuite) createRequestInDB(requester, addressee models.User) *models.Friendship {
+	friendship := &models.Friendship{RequesterID: requester.ID, AddresseeID: addressee.ID, Status: models.FriendshipStatusPending}
+	s.Require().NoError(s.friendRepo.CreateRequest(friendship))
+	return friendship
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestCreateRequestAndFindBetween() {
+	request := s.createRequestInDB(s.alice, s.bob)
+
+	found, err := s.friendRepo.FindBetween(s.bob.ID, s.alice.ID)
+
+	s.NoError(err)
+	s.Equal(request.ID, found.ID)
+	s.Equal("alice", found.Requester.Username)
+	s.Equal("bob", found.Addressee.Username)
+	s.Equal(models.FriendshipStatusPending, found.Status)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestCreateRequestFailsInEitherDirection() {
+	s.createRequestInDB(s.alice, s.bob)
+
+	err := s.friendRepo.CreateRequest(&models.Friendship{RequesterID: s.alice.ID, AddresseeID: s.bob.ID})
+	s.ErrorIs(err, ErrFriendshipExists)
+
+	err = s.friendRepo.CreateRequest(&models.Friendship{RequesterID: s.bob.ID, AddresseeID: s.alice.ID})
+	s.ErrorIs(err, ErrFriendshipExists)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestFindBetweenWithoutFriendship() {
+	_, err := s.friendRepo.FindBetween(s.alice.ID, s.bob.ID)
+
+	s.ErrorIs(err, ErrFriendshipNotFound)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestAcceptMakesThemFriends() {
+	request := s.createRequestInDB(s.alice, s.bob)
+
+	s.Require().NoError(s.friendRepo.Accept(request, s.now))
+
+	friends, err := s.friendRepo.ListFriends(s.bob.ID)
+	s.NoError(err)
+	s.Require().Len(friends, 1)
+	s.Equal("alice", friends[0].Other(s.bob.ID).Username)
+	s.Equal(models.FriendshipStatusAccepted, request.Status)
+	s.NotNil(request.AcceptedAt)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestAcceptFailsWhenTheRequestIsNotPending() {
+	request := s.createRequestInDB(s.alice, s.bob)
+	s.Require().NoError(s.friendRepo.Accept(request, s.now))
+
+	err := s.friendRepo.Accept(request, s.now)
+
+	s.ErrorIs(err, ErrFriendshipNotFound)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestListFriendsSkipsThePendingRequests() {
+	accepted := s.createRequestInDB(s.alice, s.bob)
+	s.Require().NoError(s.friendRepo.Accept(accepted, s.now))
+	s.createRequestInDB(s.carol, s.alice)
+
+	friends, err := s.friendRepo.ListFriends(s.alice.ID)
+
+	s.NoError(err)
+	s.Require().Len(friends, 1)
+	s.Equal("bob", friends[0].Other(s.alice.ID).Username)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestListIncomingRequests() {
+	s.createRequestInDB(s.bob, s.alice)
+	s.createRequestInDB(s.alice, s.carol)
+
+	requests, err := s.friendRepo.ListIncomingRequests(s.alice.ID)
+
+	s.NoError(err)
+	s.Require().Len(requests, 1)
+	s.Equal("bob", requests[0].Requester.Username)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestDelete() {
+	request := s.createRequestInDB(s.alice, s.bob)
+
+	s.Require().NoError(s.friendRepo.Delete(request))
+
+	_, err := s.friendRepo.FindBetween(s.alice.ID, s.bob.ID)
+	s.ErrorIs(err, ErrFriendshipNotFound)
+	s.ErrorIs(s.friendRepo.Delete(request), ErrFriendshipNotFound)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestBlockEndsTheFriendship() {
+	friendship := s.createRequestInDB(s.bob, s.alice)
+	s.Require().NoError(s.friendRepo.Accept(friendship, s.now))
+
+	s.Require().NoError(s.friendRepo.Block(s.alice.ID, s.bob.ID))
+
+	_, err := s.friendRepo.FindBetween(s.alice.ID, s.bob.ID)
+	s.ErrorIs(err, ErrFriendshipNotFound)
+	blocked, err := s.friendRepo.HasBlocked([]uint{s.alice.ID}, s.bob.ID)
+	s.NoError(err)
+	s.True(blocked)
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestBlockTwiceIsNotAnError() {
+	s.Require().NoError(s.friendRepo.Block(s.alice.ID, s.bob.ID))
+
+	s.NoError(s.friendRepo.Block(s.alice.ID, s.bob.ID))
+}
+
+func (s *FriendSQLRepositoryTestSuite) TestHasBlockedOnlyInTheBlockingDirection() {
+	s.Require().NoError(s.friendRepo.Block(s.alice.ID, s.bob.ID))
+
+	blocked, err := s.friendRepo.HasBlocked([]uint{s.bob.ID, s.carol.ID}, s.alice.ID)
+	s.NoError(err)
+	s.False(blocked)
+
+	blocked, err = s.friendRepo.HasBlocked([]uint{s.carol.ID, s.alice.ID}, s.bob.ID)
+	s.NoError(err)
+	s.True(blocked)
+
+	blocked, err = s.friendRepo.HasBlocked(nil, s.bob.ID)
+	s.NoError(err)
+	s.False(blocked)
+}
+
+func TestFriendRepository(t *testing.T) {
+	suite.Run(t, new(FriendSQLRepositoryTestSuite))
+}
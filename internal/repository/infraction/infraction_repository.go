@@ -0,0 +1,14 @@
+package infraction
+
+import (
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+)
+
+type InfractionRepository interface {
+	// Record stores an infraction of the given kind, committed in the lobby at the given time, for every user.
+	Record(lobbyID string, kind models.InfractionKind, userIDs []uint, at time.Time) error
+	// ListByUser returns the infractions the user committed since the given time, the most recent first.
+	ListByUser(userID uint, since time.Time) ([]*models.Infraction, error)
+}
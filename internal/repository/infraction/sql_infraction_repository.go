@@ -0,0 +1,36 @@
+package infraction
+
+import (
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlInfractionRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLInfractionRepository(db *gorm.DB) InfractionRepository {
+	return &sqlInfractionRepository{db: db}
+}
+
+func (r *sqlInfractionRepository) Record(lobbyID string, kind models.InfractionKind, userIDs []uint, at time.Time) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	infractions := make([]*models.Infraction, len(userIDs))
+	for i, userID := range userIDs {
+		infractions[i] = &models.Infraction{UserID: userID, LobbyID: lobbyID, Kind: kind, CreatedAt: at}
+	}
+	return r.db.Omit("User").Create(&infractions).Error
+}
+
+func (r *sqlInfractionRepository) ListByUser(userID uint, since time.Time) ([]*models.Infraction, error) {
+	var infractions []*models.Infraction
+	err := r.db.Where("user_id = ? AND created_at >= ?", userID, since).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&infractions).Error
+	return infractions, err
+}
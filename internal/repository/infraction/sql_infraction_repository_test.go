@@ -0,0 +1,92 @@
+package infraction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+type InfractionSQLRepositoryTestSuite struct {
+	suite.Suite
+	db             *gorm.DB
+	infractionRepo InfractionRepository
+	alice          models.User
+	bob            models.User
+}
+
+func (s *InfractionSQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *InfractionSQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *InfractionSQLRepositoryTestSuite) SetupTest() {
+	err := s.db.Migrator().DropTable(&models.User{}, &models.Infraction{})
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(&models.User{}, &models.Infraction{})
+	s.Require().NoError(err)
+
+	s.alice = s.createUserInDB("alice")
+	s.bob = s.createUserInDB("bob")
+
+	s.infractionRepo = NewSQLInfractionRepository(s.db)
+}
+
+func (s *InfractionSQLRepositoryTestSuite) createUserInDB(username string) models.User {
+	user := models.User{Username: username, Password: "password"}
+	s.Require().NoError(s.db.Create(&user).Error)
+	return user
+}
+
+func (s *InfractionSQLRepositoryTestSuite) TestRecordStoresAnInfractionForEveryUser() {
+	err := s.infractionRepo.Record("lobby-1", models.InfractionDodge, []uint{s.alice.ID, s.bob.ID}, fixtureNow)
+
+	s.NoError(err)
+	for _, user := range []models.User{s.alice, s.bob} {
+		infractions, err := s.infractionRepo.ListByUser(user.ID, fixtureNow)
+		s.Require().NoError(err)
+		s.Require().Len(infractions, 1)
+		s.Equal("lobby-1", infractions[0].LobbyID)
+		s.Equal(models.InfractionDodge, infractions[0].Kind)
+		s.True(infractions[0].CreatedAt.Equal(fixtureNow))
+	}
+}
+
+func (s *InfractionSQLRepositoryTestSuite) TestRecordWithoutUsers() {
+	err := s.infractionRepo.Record("lobby-1", models.InfractionDodge, nil, fixtureNow)
+
+	s.NoError(err)
+	var count int64
+	s.db.Model(&models.Infraction{}).Count(&count)
+	s.Zero(count)
+}
+
+func (s *InfractionSQLRepositoryTestSuite) TestListByUserReturnsTheRecentInfractionsFirst() {
+	s.Require().NoError(s.infractionRepo.Record("old", models.InfractionDodge, []uint{s.alice.ID}, fixtureNow.Add(-48*time.Hour)))
+	s.Require().NoError(s.infractionRepo.Record("first", models.InfractionDodge, []uint{s.alice.ID}, fixtureNow.Add(-time.Hour)))
+	s.Require().NoError(s.infractionRepo.Record("second", models.InfractionAbandon, []uint{s.alice.ID}, fixtureNow))
+	s.Require().NoError(s.infractionRepo.Record("other", models.InfractionDodge, []uint{s.bob.ID}, fixtureNow))
+
+	infractions, err := s.infractionRepo.ListByUser(s.alice.ID, fixtureNow.Add(-24*time.Hour))
+
+	s.NoError(err)
+	s.Require().Len(infractions, 2)
+	s.Equal("second", infractions[0].LobbyID)
+	s.Equal("first", infractions[1].LobbyID)
+}
+
+func TestInfractionSQLRepository(t *testing.T) {
+	suite.Run(t, new(InfractionSQLRepositoryTestSuite))
+}
@@ -0,0 +1,22 @@
+package invite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+)
+
+var (
+	ErrInviteNotFound = errors.New("invite not found in the database")
+)
+
+type InviteRepository interface {
+	Create(invite *models.Invite) error
+	FindByID(inviteID uint) (*models.Invite, error)
+	// FindPending returns the invite for the user to the lobby that is still pending at the given time.
+	FindPending(lobbyID string, inviteeID uint, now time.Time) (*models.Invite, error)
+	// ListPending returns the invites addressed to the user that are still pending at the given time.
+	ListPending(inviteeID uint, now time.Time) []*models.Invite
+	UpdateStatus(invite *models.Invite, status models.InviteStatus) error
+}
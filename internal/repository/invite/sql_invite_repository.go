@@ -0,0 +1,55 @@
+package invite
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlInviteRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLInviteRepository(db *gorm.DB) InviteRepository {
+	return &sqlInviteRepository{db: db}
+}
+
+func (r *sqlInviteRepository) Create(invite *models.Invite) error {
+	return r.db.Create(invite).Error
+}
+
+func (r *sqlInviteRepository) FindByID(inviteID uint) (*models.Invite, error) {
+	var invite models.Invite
+	result := r.db.Preload("Inviter").Preload("Invitee").First(&invite, inviteID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrInviteNotFound
+	}
+	return &invite, result.Error
+}
+
+func (r *sqlInviteRepository) FindPending(lobbyID string, inviteeID uint, now time.Time) (*models.Invite, error) {
+	var invite models.Invite
+	result := r.db.
+		Where("lobby_id = ? AND invitee_id = ? AND status = ? AND expires_at > ?",
+			lobbyID, inviteeID, models.InviteStatusPending, now).
+		First(&invite)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrInviteNotFound
+	}
+	return &invite, result.Error
+}
+
+func (r *sqlInviteRepository) ListPending(inviteeID uint, now time.Time) []*models.Invite {
+	var invites []*models.Invite
+	r.db.Preload("Inviter").Preload("Invitee").
+		Where("invitee_id = ? AND status = ? AND expires_at > ?", inviteeID, models.InviteStatusPending, now).
+		Order("created_at").
+		Find(&invites)
+	return invites
+}
+
+func (r *sqlInviteRepository) UpdateStatus(invite *models.Invite, status models.InviteStatus) error {
+	return r.db.Model(invite).Update("status", status).Error
+}
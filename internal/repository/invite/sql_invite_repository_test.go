@@ -0,0 +1,144 @@
+package invite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type InviteSQLRepositoryTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	inviteRepo InviteRepository
+	lobby      models.Lobby
+	inviter    models.User
+	invitee    models.User
+	now        time.Time
+}
+
+func (s *InviteSQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *InviteSQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *InviteSQLRepositoryTestSuite) SetupTest() {
+	err := s.db.Migrator().DropTable(&models.User{}, &models.Lobby{}, &models.Invite{})
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(&models.User{}, &models.Lobby{}, &models.Invite{})
+	s.Require().NoError(err)
+
+	s.inviter = models.User{Username: "inviter", Password: "password"}
+	s.Require().NoError(s.db.Create(&s.inviter).Error)
+	s.invitee = models.User{Username: "invitee", Password: "password"}
+	s.Require().NoError(s.db.Create(&s.invitee).Error)
+	s.lobby = models.Lobby{LobbyID: uuid.New().String(), Name: "Test Lobby"}
+	s.Require().NoError(s.db.Create(&s.lobby).Error)
+	s.now = time.Now().UTC()
+
+	s.inviteRepo = NewSQLInviteRepository(s.db)
+}
+
+func (s *InviteSQLRepositoryTestSuite) createInviteInDB(status models.InviteStatus, expiresAt time.Time) models.Invite {
+	invite := models.Invite{
+		LobbyID:   s.lobby.LobbyID,
+		InviterID: s.inviter.ID,
+		InviteeID: s.invitee.ID,
+		Status:    status,
+		ExpiresAt: expiresAt,
+	}
+	err := s.db.Create(&invite).Error
+	s.Require().NoError(err)
+	return invite
+}
+
+func (s *InviteSQLRepositoryTestSuite) TestCreateSuccess() {
+	invite := &models.Invite{
+		LobbyID:   s.lobby.LobbyID,
+		InviterID: s.inviter.ID,
+		InviteeID: s.invitee.ID,
+		ExpiresAt: s.now.Add(time.Minute),
+	}
+
+	err := s.inviteRepo.Create(invite)
+
+	s.NoError(err)
+	var addedInvite models.Invite
+	s.db.First(&addedInvite, invite.ID)
+	s.Equal(models.InviteStatusPending, addedInvite.Status)
+	s.Equal(s.lobby.LobbyID, addedInvite.LobbyID)
+}
+
+func (s *InviteSQLRepositoryTestSuite) TestFindByIDSuccess() {
+	invite := s.createInviteInDB(models.InviteStatusPending, s.now.Add(time.Minute))
+
+	foundInvite, err := s.inviteRepo.FindByID(invite.ID)
+
+	s.NoError(err)
+	s.Equal("inviter", foundInvite.Inviter.Username)
+	s.Equal("invitee", foundInvite.Invitee.Username)
+}
+
+func (s *InviteSQLRepositoryTestSuite) TestFindByIDNotFound() {
+	invite, err := s.inviteRepo.FindByID(42)
+	s.ErrorIs(err, ErrInviteNotFound)
+	s.Empty(invite)
+}
+
+func (s *InviteSQLRepositoryTestSuite) TestFindPendingSuccess() {
+	invite := s.createInviteInDB(models.InviteStatusPending, s.now.Add(time.Minute))
+
+	foundInvite, err := s.inviteRepo.FindPending(s.lobby.LobbyID, s.invitee.ID, s.now)
+
+	s.NoError(err)
+	s.Equal(invite.ID, foundInvite.ID)
+}
+
+func (s *InviteSQLRepositoryTestSuite) TestFindPendingIgnoresExpiredAndAnsweredInvites() {
+	s.createInviteInDB(models.InviteStatusPending, s.now.Add(-time.Minute))
+	s.createInviteInDB(models.InviteStatusDeclined, s.now.Add(time.Minute))
+
+	invite, err := s.inviteRepo.FindPending(s.lobby.LobbyID, s.invitee.ID, s.now)
+
+	s.ErrorIs(err, ErrInviteNotFound)
+	s.Empty(invite)
+}
+
+func (s *InviteSQLRepositoryTestSuite) TestListPending() {
+	pending := s.createInviteInDB(models.InviteStatusPending, s.now.Add(time.Minute))
+	s.createInviteInDB(models.InviteStatusPending, s.now.Add(-time.Minute))
+	s.createInviteInDB(models.InviteStatusAccepted, s.now.Add(time.Minute))
+
+	invites := s.inviteRepo.ListPending(s.invitee.ID, s.now)
+
+	s.Len(invites, 1)
+	s.Equal(pending.ID, invites[0].ID)
+	s.Equal("inviter", invites[0].Inviter.Username)
+	s.Empty(s.inviteRepo.ListPending(s.inviter.ID, s.now))
+}
+
+func (s *InviteSQLRepositoryTestSuite) TestUpdateStatusSuccess() {
+	invite := s.createInviteInDB(models.InviteStatusPending, s.now.Add(time.Minute))
+
+	err := s.inviteRepo.UpdateStatus(&invite, models.InviteStatusDeclined)
+
+	s.NoError(err)
+	var updatedInvite models.Invite
+	s.db.First(&updatedInvite, invite.ID)
+	s.Equal(models.InviteStatusDeclined, updatedInvite.Status)
+}
+
+func TestInviteRepository(t *testing.T) {
+	suite.Run(t, new(InviteSQLRepositoryTestSuite))
+}
@@ -0,0 +1,55 @@
+package leaderboard
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+)
+
+var (
+	ErrSeasonExists     = errors.New("season already exists in the database")
+	ErrSeasonNotFound   = errors.New("season not found in the database")
+	ErrSeasonArchived   = errors.New("season is already archived")
+	ErrStandingNotFound = errors.New("standing not found in the database")
+)
+
+// Entry is the position of a player in a ranking. Players with the same score share the same rank.
+type Entry struct {
+	Rank     int
+	UserID   uint
+	Username string
+	Wins     int
+	Losses   int
+	Rating   int
+}
+
+// LeaderboardRepository keeps the standings of the players, all time and per season. The standings of a running
+// season are live; once the season ends they are archived together with the final ranks.
+type LeaderboardRepository interface {
+	// RecordGame credits the wins and the losses of a finished game, and moves the ratings of its players, in the
+	// all-time standings and in the standings of the season running at finishedAt, if any. A game without teams has
+	// a single winner.
+	RecordGame(winnerIDs, loserIDs []uint, finishedAt time.Time) error
+	// ListEntries ranks the players of the season, or of all time when season is nil. Players with the same score
+	// are ordered by id.
+	ListEntries(season *models.Season, metric models.RankingMetric, offset, limit int) ([]Entry, error)
+	// FindEntry returns the position of the user in the season, or of all time when season is nil. It fails with
+	// ErrStandingNotFound if the user did not finish any game in the season.
+	FindEntry(season *models.Season, metric models.RankingMetric, userID uint) (*Entry, error)
+
+	// CreateSeason fails with ErrSeasonExists if a season with the same number exists.
+	CreateSeason(season *models.Season) error
+	FindSeason(seasonID uint) (*models.Season, error)
+	// FindSeasonAt returns the season running at the given time, or ErrSeasonNotFound.
+	FindSeasonAt(at time.Time) (*models.Season, error)
+	// LatestSeason returns the season with the highest number, or ErrSeasonNotFound if there is none.
+	LatestSeason() (*models.Season, error)
+	// ListSeasons returns every season, the most recent first.
+	ListSeasons() ([]*models.Season, error)
+	// ListUnarchivedSeasons returns the seasons ended before the given time whose standings are still live.
+	ListUnarchivedSeasons(endedBefore time.Time) ([]*models.Season, error)
+	// ArchiveSeason snapshots the standings of the season, with their final ranks, into archived_standings and
+	// removes the live ones. It fails with ErrSeasonArchived if the season was archived in the meantime.
+	ArchiveSeason(seasonID uint, archivedAt time.Time) error
+}
@@ -0,0 +1,44 @@
+package leaderboard
+
+import (
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+)
+
+// BackfillStandings builds the all-time standings from the games finished before the leaderboards existed, replaying
+// them in the order they finished. It does nothing once there is any standing, and it expects the standings and
+// lobby_players tables to exist.
+func BackfillStandings(db *gorm.DB) error {
+	var standings int64
+	if err := db.Model(&models.Standing{}).Count(&standings).Error; err != nil {
+		return err
+	}
+	if standings > 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var games []*models.Lobby
+		err := tx.Preload("Players", "left_at IS NULL").
+			Where("status = ? AND winner_id IS NOT NULL", models.LobbyStatusFinished).
+			Order("updated_at").
+			Order("lobby_id").
+			Find(&games).Error
+		if err != nil {
+			return err
+		}
+
+		for _, game := range games {
+			var loserIDs []uint
+			for _, player := range game.Players {
+				if player.UserID != *game.WinnerID {
+					loserIDs = append(loserIDs, player.UserID)
+				}
+			}
+			if err := recordGame(tx, models.AllTime, []uint{*game.WinnerID}, loserIDs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
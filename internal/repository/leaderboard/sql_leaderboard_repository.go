@@ -0,0 +1,364 @@
+package leaderboard
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// eloK is the largest number of rating points a player can win or lose against a single opponent.
+const eloK = 32
+
+type sqlLeaderboardRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLLeaderboardRepository(db *gorm.DB) LeaderboardRepository {
+	return &sqlLeaderboardRepository{db: db}
+}
+
+func (r *sqlLeaderboardRepository) RecordGame(winnerIDs, loserIDs []uint, finishedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := recordGame(tx, models.AllTime, winnerIDs, loserIDs); err != nil {
+			return err
+		}
+
+		season, err := findSeasonAt(tx, finishedAt)
+		if errors.Is(err, ErrSeasonNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return recordGame(tx, season.ID, winnerIDs, loserIDs)
+	})
+}
+
+// recordGame updates the standings of the players in a single ranking. Every winner plays an Elo match against each
+// loser, all of them with the ratings they had before the game. The points of those matches are shared among the
+// winners, so that a team is worth as much as a single player.
+func recordGame(tx *gorm.DB, seasonID uint, winnerIDs, loserIDs []uint) error {
+	winners := make([]*models.Standing, len(winnerIDs))
+	for i, winnerID := range winnerIDs {
+		winner, err := findOrCreateStanding(tx, seasonID, winnerID)
+		if err != nil {
+			return err
+		}
+		winners[i] = winner
+	}
+	losers := make([]*models.Standing, len(loserIDs))
+	for i, loserID := range loserIDs {
+		loser, err := findOrCreateStanding(tx, seasonID, loserID)
+		if err != nil {
+			return err
+		}
+		losers[i] = loser
+	}
+
+	gains := make([]int, len(winners))
+	for _, loser := range losers {
+		loss := 0
+		for i, winner := range winners {
+			delta := eloDelta(winner.Rating, loser.Rating)
+			gains[i] += delta
+			loss += delta
+		}
+		err := tx.Model(loser).Updates(map[string]any{
+			"losses": gorm.Expr("losses + 1"),
+			"rating": gorm.Expr("rating - ?", shared(loss, len(winners))),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, winner := range winners {
+		err := tx.Model(winner).Updates(map[string]any{
+			"wins":   gorm.Expr("wins + 1"),
+			"rating": gorm.Expr("rating + ?", shared(gains[i], len(winners))),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shared is the share of the points that goes to each of the winners.
+func shared(points, winners int) int {
+	return int(math.Round(float64(points) / float64(winners)))
+}
+
+// eloDelta is how many rating points the winner takes from the loser.
+func eloDelta(winnerRating, loserRating int) int {
+	expected := 1 / (1 + math.Pow(10, float64(loserRating-winnerRating)/400))
+	return int(math.Round(eloK * (1 - expected)))
+}
+
+func findOrCreateStanding(tx *gorm.DB, seasonID, userID uint) (*models.Standing, error) {
+	standing := &models.Standing{SeasonID: seasonID, UserID: userID, Rating: models.InitialRating}
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(standing).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// The standing may already exist, in which case the insert above did nothing.
+	standing = &models.Standing{}
+	err = tx.Where("season_id = ? AND user_id = ?", seasonID, userID).First(standing).Error
+	return standing, err
+}
+
+func (r *sqlLeaderboardRepository) ListEntries(season *models.Season, metric models.RankingMetric, offset, limit int) ([]Entry, error) {
+	if season != nil && season.ArchivedAt != nil {
+		return r.listArchivedEntries(season.ID, metric, offset, limit)
+	}
+
+	seasonID := models.AllTime
+	if season != nil {
+		seasonID = season.ID
+	}
+	score := scoreColumn(metric)
+
+	var standings []*models.Standing
+	err := r.db.Preload("User").
+		Where("season_id = ?", seasonID).
+		Order(score + " DESC").
+		Order("user_id").
+		Offset(offset).
+		Limit(limit).
+		Find(&standings).Error
+	if err != nil || len(standings) == 0 {
+		return nil, err
+	}
+
+	// Only the rank of the first entry needs a query: the following ones either share it, or are ranked by their
+	// position since every player before them has a higher score.
+	firstRank, err := r.liveRank(seasonID, score, scoreOf(standings[0], metric))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, len(standings))
+	for i, standing := range standings {
+		rank := offset + i + 1
+		if i == 0 {
+			rank = firstRank
+		} else if scoreOf(standing, metric) == scoreOf(standings[i-1], metric) {
+			rank = entries[i-1].Rank
+		}
+		entries[i] = Entry{
+			Rank:     rank,
+			UserID:   standing.UserID,
+			Username: standing.User.Username,
+			Wins:     standing.Wins,
+			Losses:   standing.Losses,
+			Rating:   standing.Rating,
+		}
+	}
+	return entries, nil
+}
+
+func (r *sqlLeaderboardRepository) listArchivedEntries(seasonID uint, metric models.RankingMetric, offset, limit int) ([]Entry, error) {
+	rank := rankColumn(metric)
+
+	var standings []*models.ArchivedStanding
+	err := r.db.Preload("User").
+		Where("season_id = ?", seasonID).
+		Order(rank).
+		Order("user_id").
+		Offset(offset).
+		Limit(limit).
+		Find(&standings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, len(standings))
+	for i, standing := range standings {
+		entries[i] = archivedEntry(standing, metric)
+	}
+	return entries, nil
+}
+
+func (r *sqlLeaderboardRepository) FindEntry(season *models.Season, metric models.RankingMetric, userID uint) (*Entry, error) {
+	if season != nil && season.ArchivedAt != nil {
+		var standing models.ArchivedStanding
+		err := r.db.Preload("User").Where("season_id = ? AND user_id = ?", season.ID, userID).First(&standing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStandingNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		entry := archivedEntry(&standing, metric)
+		return &entry, nil
+	}
+
+	seasonID := models.AllTime
+	if season != nil {
+		seasonID = season.ID
+	}
+
+	var standing models.Standing
+	err := r.db.Preload("User").Where("season_id = ? AND user_id = ?", seasonID, userID).First(&standing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrStandingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rank, err := r.liveRank(seasonID, scoreColumn(metric), scoreOf(&standing, metric))
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{
+		Rank:     rank,
+		UserID:   standing.UserID,
+		Username: standing.User.Username,
+		Wins:     standing.Wins,
+		Losses:   standing.Losses,
+		Rating:   standing.Rating,
+	}, nil
+}
+
+// liveRank counts the players with a higher score, which the index on the season and the score keeps cheap.
+func (r *sqlLeaderboardRepository) liveRank(seasonID uint, score string, value int) (int, error) {
+	var ahead int64
+	err := r.db.Model(&models.Standing{}).
+		Where("season_id = ? AND "+score+" > ?", seasonID, value).
+		Count(&ahead).Error
+	return int(ahead) + 1, err
+}
+
+func scoreColumn(metric models.RankingMetric) string {
+	if metric == models.RankingByRating {
+		return "rating"
+	}
+	return "wins"
+}
+
+func scoreOf(standing *models.Standing, metric models.RankingMetric) int {
+	if metric == models.RankingByRating {
+		return standing.Rating
+	}
+	return standing.Wins
+}
+
+func rankColumn(metric models.RankingMetric) string {
+	if metric == models.RankingByRating {
+		return "rating_rank"
+	}
+	return "wins_rank"
+}
+
+func archivedEntry(standing *models.ArchivedStanding, metric models.RankingMetric) Entry {
+	rank := standing.WinsRank
+	if metric == models.RankingByRating {
+		rank = standing.RatingRank
+	}
+	return Entry{
+		Rank:     rank,
+		UserID:   standing.UserID,
+		Username: standing.User.Username,
+		Wins:     standing.Wins,
+		Losses:   standing.Losses,
+		Rating:   standing.Rating,
+	}
+}
+
+func (r *sqlLeaderboardRepository) CreateSeason(season *models.Season) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(season)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSeasonExists
+	}
+	return nil
+}
+
+func (r *sqlLeaderboardRepository) FindSeason(seasonID uint) (*models.Season, error) {
+	var season models.Season
+	err := r.db.First(&season, seasonID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (r *sqlLeaderboardRepository) FindSeasonAt(at time.Time) (*models.Season, error) {
+	return findSeasonAt(r.db, at)
+}
+
+// findSeasonAt ignores the archived seasons: their standings can not change anymore.
+func findSeasonAt(tx *gorm.DB, at time.Time) (*models.Season, error) {
+	var season models.Season
+	err := tx.Where("starts_at <= ? AND ends_at > ? AND archived_at IS NULL", at, at).First(&season).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (r *sqlLeaderboardRepository) LatestSeason() (*models.Season, error) {
+	var season models.Season
+	err := r.db.Order("number DESC").First(&season).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (r *sqlLeaderboardRepository) ListSeasons() ([]*models.Season, error) {
+	var seasons []*models.Season
+	err := r.db.Order("number DESC").Find(&seasons).Error
+	return seasons, err
+}
+
+func (r *sqlLeaderboardRepository) ListUnarchivedSeasons(endedBefore time.Time) ([]*models.Season, error) {
+	var seasons []*models.Season
+	err := r.db.Where("ends_at <= ? AND archived_at IS NULL", endedBefore).Order("number").Find(&seasons).Error
+	return seasons, err
+}
+
+func (r *sqlLeaderboardRepository) ArchiveSeason(seasonID uint, archivedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Season{}).
+			Where("id = ? AND archived_at IS NULL", seasonID).
+			Update("archived_at", archivedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSeasonArchived
+		}
+
+		// Players with the same score share the rank, as in the live standings.
+		err := tx.Exec(`INSERT INTO archived_standings
+				(season_id, user_id, wins, losses, rating, wins_rank, rating_rank)
+			SELECT season_id, user_id, wins, losses, rating,
+				RANK() OVER (ORDER BY wins DESC),
+				RANK() OVER (ORDER BY rating DESC)
+			FROM standings
+			WHERE season_id = ?`, seasonID).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("season_id = ?", seasonID).Delete(&models.Standing{}).Error
+	})
+}
@@ -0,0 +1,253 @@
+package leaderboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type LeaderboardSQLRepositoryTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	leaderboardRepo LeaderboardRepository
+	users           []models.User
+	season          *models.Season
+	now             time.Time
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) SetupTest() {
+	tables := []any{
+		&models.User{}, &models.Lobby{}, &models.LobbyPlayer{},
+		&models.Season{}, &models.Standing{}, &models.ArchivedStanding{},
+	}
+	s.Require().NoError(s.db.Migrator().DropTable(tables...))
+	s.Require().NoError(s.db.AutoMigrate(tables...))
+
+	s.users = nil
+	for _, username := range []string{"first", "second", "third"} {
+		user := models.User{Username: username, Password: "password"}
+		s.Require().NoError(s.db.Create(&user).Error)
+		s.users = append(s.users, user)
+	}
+
+	s.now = time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)
+	s.leaderboardRepo = NewSQLLeaderboardRepository(s.db)
+	s.season = &models.Season{
+		Number:   1,
+		StartsAt: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC),
+	}
+	s.Require().NoError(s.leaderboardRepo.CreateSeason(s.season))
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestRecordGameUpdatesTheAllTimeAndTheSeasonStandings() {
+	err := s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[1].ID}, s.now)
+	s.Require().NoError(err)
+
+	for _, seasonID := range []uint{models.AllTime, s.season.ID} {
+		var standings []models.Standing
+		s.Require().NoError(s.db.Where("season_id = ?", seasonID).Order("user_id").Find(&standings).Error)
+		s.Require().Len(standings, 2)
+		s.Equal(1, standings[0].Wins)
+		s.Equal(models.InitialRating+16, standings[0].Rating)
+		s.Equal(1, standings[1].Losses)
+		s.Equal(models.InitialRating-16, standings[1].Rating)
+	}
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestRecordGameOutsideOfASeasonOnlyUpdatesTheAllTimeStandings() {
+	err := s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[1].ID}, s.season.EndsAt)
+	s.Require().NoError(err)
+
+	var seasonStandings int64
+	s.Require().NoError(s.db.Model(&models.Standing{}).Where("season_id = ?", s.season.ID).Count(&seasonStandings).Error)
+	s.Zero(seasonStandings)
+	entry, err := s.leaderboardRepo.FindEntry(nil, models.RankingByWins, s.users[0].ID)
+	s.NoError(err)
+	s.Equal(1, entry.Wins)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestRecordGameMovesFewerPointsWhenTheFavouriteWins() {
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[1].ID}, s.now))
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[1].ID}, s.now))
+
+	entry, err := s.leaderboardRepo.FindEntry(nil, models.RankingByRating, s.users[0].ID)
+
+	s.NoError(err)
+	s.Equal(2, entry.Wins)
+	s.Equal(models.InitialRating+16+15, entry.Rating)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestRecordGameSharesThePointsAmongTheWinningTeam() {
+	err := s.leaderboardRepo.RecordGame([]uint{s.users[0].ID, s.users[1].ID}, []uint{s.users[2].ID}, s.now)
+	s.Require().NoError(err)
+
+	var standings []models.Standing
+	s.Require().NoError(s.db.Where("season_id = ?", models.AllTime).Order("user_id").Find(&standings).Error)
+	s.Require().Len(standings, 3)
+	s.Equal(1, standings[0].Wins)
+	s.Equal(models.InitialRating+8, standings[0].Rating)
+	s.Equal(1, standings[1].Wins)
+	s.Equal(models.InitialRating+8, standings[1].Rating)
+	s.Equal(1, standings[2].Losses)
+	s.Equal(models.InitialRating-16, standings[2].Rating)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestListEntriesRanksThePlayersSharingTheRankOnTies() {
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[1].ID}, []uint{s.users[2].ID}, s.now))
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[2].ID}, s.now))
+
+	entries, err := s.leaderboardRepo.ListEntries(nil, models.RankingByWins, 0, 10)
+
+	s.NoError(err)
+	s.Require().Len(entries, 3)
+	s.Equal(Entry{Rank: 1, UserID: s.users[0].ID, Username: "first", Wins: 1, Rating: entries[0].Rating}, entries[0])
+	s.Equal(1, entries[1].Rank)
+	s.Equal("second", entries[1].Username)
+	s.Equal(3, entries[2].Rank)
+	s.Equal("third", entries[2].Username)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestListEntriesRanksTheFirstEntryOfAPage() {
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[2].ID}, s.now))
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[1].ID}, s.now))
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[1].ID}, []uint{s.users[2].ID}, s.now))
+
+	entries, err := s.leaderboardRepo.ListEntries(s.season, models.RankingByRating, 1, 10)
+
+	s.NoError(err)
+	s.Require().Len(entries, 2)
+	s.Equal("second", entries[0].Username)
+	s.Equal(2, entries[0].Rank)
+	s.Equal("third", entries[1].Username)
+	s.Equal(3, entries[1].Rank)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestFindEntryFailsForAPlayerWithoutGames() {
+	_, err := s.leaderboardRepo.FindEntry(s.season, models.RankingByWins, s.users[0].ID)
+
+	s.ErrorIs(err, ErrStandingNotFound)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestArchiveSeasonSnapshotsTheFinalRanks() {
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[0].ID}, []uint{s.users[1].ID}, s.now))
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{s.users[2].ID}, []uint{s.users[1].ID}, s.now))
+
+	err := s.leaderboardRepo.ArchiveSeason(s.season.ID, s.season.EndsAt)
+	s.Require().NoError(err)
+
+	season, err := s.leaderboardRepo.FindSeason(s.season.ID)
+	s.Require().NoError(err)
+	s.NotNil(season.ArchivedAt)
+	var liveStandings int64
+	s.Require().NoError(s.db.Model(&models.Standing{}).Where("season_id = ?", s.season.ID).Count(&liveStandings).Error)
+	s.Zero(liveStandings)
+
+	entries, err := s.leaderboardRepo.ListEntries(season, models.RankingByWins, 0, 10)
+	s.NoError(err)
+	s.Require().Len(entries, 3)
+	s.Equal("first", entries[0].Username)
+	s.Equal(1, entries[0].Rank)
+	s.Equal("third", entries[1].Username)
+	s.Equal(1, entries[1].Rank)
+	s.Equal("second", entries[2].Username)
+	s.Equal(3, entries[2].Rank)
+
+	entry, err := s.leaderboardRepo.FindEntry(season, models.RankingByRating, s.users[1].ID)
+	s.NoError(err)
+	s.Equal(3, entry.Rank)
+	s.Equal(2, entry.Losses)
+
+	// The all-time standings are not archived.
+	entry, err = s.leaderboardRepo.FindEntry(nil, models.RankingByWins, s.users[1].ID)
+	s.NoError(err)
+	s.Equal(3, entry.Rank)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestArchiveSeasonHappensOnlyOnce() {
+	s.Require().NoError(s.leaderboardRepo.ArchiveSeason(s.season.ID, s.season.EndsAt))
+
+	err := s.leaderboardRepo.ArchiveSeason(s.season.ID, s.season.EndsAt)
+
+	s.ErrorIs(err, ErrSeasonArchived)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestCreateSeasonFailsWhenTheNumberIsTaken() {
+	err := s.leaderboardRepo.CreateSeason(&models.Season{Number: 1, StartsAt: s.now, EndsAt: s.now})
+
+	s.ErrorIs(err, ErrSeasonExists)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestFindSeasonAt() {
+	season, err := s.leaderboardRepo.FindSeasonAt(s.now)
+	s.NoError(err)
+	s.Equal(s.season.ID, season.ID)
+
+	_, err = s.leaderboardRepo.FindSeasonAt(s.season.EndsAt)
+	s.ErrorIs(err, ErrSeasonNotFound)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestListSeasons() {
+	next := &models.Season{Number: 2, StartsAt: s.season.EndsAt, EndsAt: s.season.EndsAt.AddDate(0, 1, 0)}
+	s.Require().NoError(s.leaderboardRepo.CreateSeason(next))
+
+	seasons, err := s.leaderboardRepo.ListSeasons()
+	s.NoError(err)
+	s.Require().Len(seasons, 2)
+	s.Equal(2, seasons[0].Number)
+
+	latest, err := s.leaderboardRepo.LatestSeason()
+	s.NoError(err)
+	s.Equal(next.ID, latest.ID)
+
+	ended, err := s.leaderboardRepo.ListUnarchivedSeasons(next.StartsAt)
+	s.NoError(err)
+	s.Require().Len(ended, 1)
+	s.Equal(s.season.ID, ended[0].ID)
+}
+
+func (s *LeaderboardSQLRepositoryTestSuite) TestBackfillStandingsReplaysTheFinishedGames() {
+	for i, winner := range []models.User{s.users[0], s.users[0], s.users[1]} {
+		game := models.Lobby{
+			LobbyID:   string(rune('a' + i)),
+			Name:      "game",
+			Status:    models.LobbyStatusFinished,
+			WinnerID:  &winner.ID,
+			UpdatedAt: s.now.Add(time.Duration(i) * time.Minute),
+		}
+		s.Require().NoError(s.db.Create(&game).Error)
+		for seat, user := range s.users[:2] {
+			s.Require().NoError(s.db.Create(&models.LobbyPlayer{LobbyID: game.LobbyID, UserID: user.ID, Seat: seat}).Error)
+		}
+	}
+
+	s.Require().NoError(BackfillStandings(s.db))
+	s.Require().NoError(BackfillStandings(s.db))
+
+	entry, err := s.leaderboardRepo.FindEntry(nil, models.RankingByWins, s.users[0].ID)
+	s.NoError(err)
+	s.Equal(2, entry.Wins)
+	s.Equal(1, entry.Losses)
+	_, err = s.leaderboardRepo.FindEntry(nil, models.RankingByWins, s.users[2].ID)
+	s.ErrorIs(err, ErrStandingNotFound)
+}
+
+func TestLeaderboardRepository(t *testing.T) {
+	suite.Run(t, new(LeaderboardSQLRepositoryTestSuite))
+}
@@ -2,6 +2,7 @@ package lobby
 
 import (
 	"errors"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
 )
@@ -10,14 +11,208 @@ var (
 	ErrLobbyExists        = errors.New("lobby already exists in the database")
 	ErrLobbyNotFound      = errors.New("lobby not found in the database")
 	ErrLobbyCleanupFailed = errors.New("failed to clean up lobby associations")
+	ErrLobbyNotWaiting    = errors.New("lobby is not waiting for players")
+	ErrLobbyFull          = errors.New("lobby is full")
+	ErrLobbyConflict      = errors.New("lobby was changed concurrently")
+	ErrPlayerInLobby      = errors.New("player is already in an active lobby")
+	ErrTeamFull           = errors.New("team is full")
+	ErrPlayerNotInLobby   = errors.New("player is not in the lobby")
+	ErrSpectatorsFull     = errors.New("lobby has no room for more spectators")
+	ErrRematchVoteRunning = errors.New("a rematch vote is already running")
+	ErrRematchCreated     = errors.New("the rematch has already been created")
+	ErrLobbyLocked        = errors.New("lobby is locked")
+	ErrLobbyNotInProgress = errors.New("lobby is not in progress")
+	ErrReadyCheckOver     = errors.New("ready check is over")
+	ErrTicketNotFound     = errors.New("match ticket not found in the database")
+	ErrAlreadySearching   = errors.New("user is already searching for a match")
+	ErrTicketMatched      = errors.New("match ticket is part of a pending match")
+	ErrNotEnoughTickets   = errors.New("not enough tickets to form a match")
+	ErrMatchNotFound      = errors.New("pending match not found in the database")
+	ErrMatchOver          = errors.New("pending match is over")
 )
 
+// AvailableSort orders the lobbies listed by ListAvailable. Lobbies with the same sort key are ordered by id.
+type AvailableSort string
+
+const (
+	SortNewest AvailableSort = "NEWEST" // Most recently created first
+	SortOldest AvailableSort = "OLDEST" // Least recently created first
+	SortByName AvailableSort = "NAME"   // Alphabetical order
+)
+
+// AvailableCursor is the position of a lobby in the sort order: only the fields of the sort key are used.
+type AvailableCursor struct {
+	CreatedAt time.Time
+	Name      string
+	LobbyID   string
+}
+
+// AvailableFilter selects a page of the available lobbies. The zero value of each field disables the filter.
+type AvailableFilter struct {
+	// NameContains matches the lobby names containing it, ignoring the case.
+	NameContains string
+	// MinFreeSlots excludes the lobbies that can take fewer players than that.
+	MinFreeSlots  int
+	GameMode      string
+	Region        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// OnlineAt excludes the lobbies whose host was known to be disconnected at that time: their last heartbeat
+	// expired. The lobbies of hosts whose connection is not tracked are kept.
+	OnlineAt time.Time
+	Sort     AvailableSort
+	// After lists only the lobbies that come after the cursor in the sort order.
+	After *AvailableCursor
+	Limit int
+}
+
+// WaitingLobby is how many players a lobby waiting for players has, out of its capacity.
+type WaitingLobby struct {
+	GameMode   string
+	Region     string
+	Players    int
+	MaxPlayers int
+}
+
+// MatchWait is how long a player waited in a lobby before it filled up.
+type MatchWait struct {
+	GameMode string
+	Region   string
+	Wait     time.Duration
+}
+
 type LobbyRepository interface {
+	// Create fails with ErrPlayerInLobby if one of the players is already in an active lobby.
 	Create(lobby *models.Lobby) error
 	FindByID(lobbyID string) (*models.Lobby, error)
-	AddPlayer(lobby *models.Lobby, player *models.User) error
+	FindByJoinCode(joinCode string) (*models.Lobby, error)
+	// FindActiveByPlayer returns the active lobby the user is in, or ErrLobbyNotFound.
+	FindActiveByPlayer(userID uint) (*models.Lobby, error)
+	// AddPlayer seats the player in the lobby atomically, and adds the membership to lobby.Players. In a lobby with
+	// teams the player joins the team with the fewest players. It fails with ErrLobbyConflict if the lobby changed
+	// since it was read, with ErrLobbyFull if the lobby already holds capacity players, with ErrLobbyNotWaiting if
+	// the lobby is not waiting for players, with ErrLobbyLocked if the host locked it, and with ErrPlayerInLobby if
+	// the player is already in an active lobby.
+	AddPlayer(lobby *models.Lobby, player *models.User, capacity int) error
+	// AddPlayers seats every player in the lobby, or none of them, like AddPlayer does for one player. It fails with
+	// ErrLobbyFull if the lobby does not have room for all of them.
+	AddPlayers(lobby *models.Lobby, players []*models.User, capacity int) error
+	// AddSpectator lets the user watch the lobby, and adds them to lobby.Spectators. Watching a lobby twice is not an
+	// error. It fails with ErrSpectatorsFull if the lobby already holds maxSpectators spectators, and with
+	// ErrPlayerInLobby if the user is a player of the lobby. A spectator who joins the lobby as a player stops
+	// watching it.
+	AddSpectator(lobby *models.Lobby, user *models.User, maxSpectators int) error
+	// SwitchTeam moves the player of the waiting lobby to the team, and updates lobby.Players. It fails like
+	// AddPlayer when the lobby changed or is not waiting, with ErrTeamFull if the team already holds teamSize players
+	// and with ErrPlayerNotInLobby if the user is not a player of the lobby.
+	SwitchTeam(lobby *models.Lobby, userID uint, team, teamSize int) error
+	// SwapSeat moves the player of the waiting lobby to the seat, and updates lobby.Players. The player sitting there
+	// takes the seat and the team left by the player. It fails like SwitchTeam.
+	SwapSeat(lobby *models.Lobby, userID uint, seat int) error
+	// KickPlayer ends the membership of the player in the waiting lobby, bans them from it until bannedUntil, and
+	// removes them from lobby.Players. It fails like SwitchTeam.
+	KickPlayer(lobby *models.Lobby, userID uint, bannedUntil time.Time) error
+	// LeaveWaiting ends the membership of the player in the waiting lobby, and removes them from lobby.Players. When
+	// the host leaves, the remaining player with the lowest seat becomes the host, and a lobby nobody is left in is
+	// cancelled with LobbyCloseEmpty. It fails like SwitchTeam.
+	LeaveWaiting(lobby *models.Lobby, userID uint, leftAt time.Time) error
+	// LeaveGame ends the membership of the player in the game in progress, which the player abandoned at leftAt. The
+	// host role passes like in LeaveWaiting. It fails with ErrLobbyNotInProgress if the game is not in progress
+	// anymore, and with ErrPlayerNotInLobby if the user is not a player of the game.
+	LeaveGame(lobby *models.Lobby, userID uint, leftAt time.Time) error
+	// BannedUntil returns the end of the latest ban of the user from the lobby, or nil if the user was never kicked
+	// out of it.
+	BannedUntil(lobbyID string, userID uint) (*time.Time, error)
+	// UpdateHost makes the player the host of the lobby, and updates lobby.HostID. It fails with ErrLobbyConflict if
+	// the lobby changed since it was read, for example because the player left it or its status moved on.
+	UpdateHost(lobby *models.Lobby, hostID uint) error
+	// SetLocked locks or unlocks the lobby, and updates lobby.Locked. It fails like UpdateHost.
+	SetLocked(lobby *models.Lobby, locked bool) error
+	// Rename renames the lobby, and updates lobby.Name. It fails like UpdateHost.
+	Rename(lobby *models.Lobby, name string) error
+	// AssignTeams puts every player of the lobby in the team the map holds for them.
+	AssignTeams(lobby *models.Lobby, teams map[uint]int) error
 	UpdateStatus(lobby *models.Lobby, status models.LobbyStatus) error
-	UpdateWinner(lobby *models.Lobby, winnerID uint) error
+	// FinishWithWinner moves the game from IN_PROGRESS to FINISHED with the winner, who is placed first and
+	// everybody else second. It fails with ErrLobbyNotInProgress if the game is not in progress anymore.
+	FinishWithWinner(lobby *models.Lobby, winnerID uint) error
+	// FinishWithWinningTeam finishes the game like FinishWithWinner, with the players of the team first and
+	// everybody else second.
+	FinishWithWinningTeam(lobby *models.Lobby, team int) error
+	// AbandonGame moves the game from IN_PROGRESS to ABANDONED, without a result. Like the finishing of a game, it
+	// fails with ErrLobbyNotInProgress if the game is not in progress anymore.
+	AbandonGame(lobby *models.Lobby) error
+	// StartReadyCheck moves the full waiting lobby to READY_CHECK until the deadline, and bumps its version. The lobby
+	// is stamped as matched only when the players joining it filled it. It fails with ErrLobbyConflict if the lobby
+	// changed since it was filled: it is not waiting anymore, or is not full.
+	StartReadyCheck(lobby *models.Lobby, deadline time.Time, matched bool) error
+	SetPlayerReady(lobby *models.Lobby, player *models.User) error
+	// CompleteReadyCheck moves the lobby from READY_CHECK to IN_PROGRESS. It fails with ErrReadyCheckOver if the
+	// lobby is not in the ready check anymore.
+	CompleteReadyCheck(lobby *models.Lobby) error
+	// FailReadyCheck removes the players from the lobby and reopens it. When the host is removed, the remaining
+	// player with the lowest seat becomes the host. It fails like CompleteReadyCheck.
+	FailReadyCheck(lobby *models.Lobby, removedPlayerIDs []uint) error
+	// DeleteUnconfirmed deletes the lobby whose ready check nobody confirmed. It fails with ErrReadyCheckOver if the
+	// lobby changed since it was read, is not in the ready check anymore, or has a player that confirmed meanwhile.
+	DeleteUnconfirmed(lobby *models.Lobby) error
+	// StartRematchVote opens the rematch vote of the finished lobby until the deadline, accepted by the player. It
+	// fails with ErrRematchVoteRunning if a vote that did not expire at startedAt is running, and with
+	// ErrRematchCreated if the rematch already exists.
+	StartRematchVote(lobby *models.Lobby, playerID uint, startedAt, deadline time.Time) error
+	SetRematchAccepted(lobby *models.Lobby, playerID uint) error
+	// EndRematchVote closes the rematch vote and clears the acceptances of the players.
+	EndRematchVote(lobby *models.Lobby) error
+	// CreateRematch stores the rematch of the finished lobby and links the two lobbies. It fails with
+	// ErrRematchCreated if another rematch was created first, and like Create if a player is already in an active
+	// lobby.
+	CreateRematch(lobby *models.Lobby, rematch *models.Lobby) error
+	CloseWaiting(lobbyID string, reason models.LobbyCloseReason, closedAt time.Time) error
 	Delete(lobbyID string) error
-	ListAvailable() []*models.Lobby
+	// ListAvailable returns the public lobbies waiting for players that match the filter. Locked lobbies are left
+	// out.
+	ListAvailable(filter AvailableFilter) ([]*models.Lobby, error)
+	ListStale(createdBefore, creatorSeenBefore, creatorDisconnectedBefore time.Time) ([]*models.Lobby, error)
+	// ListWaiting returns the occupancy of the public lobbies waiting for players. Locked lobbies are left out, as are
+	// the unlisted and private ones, which only the users they are shared with can join.
+	ListWaiting() ([]WaitingLobby, error)
+	// ListMatchWaits returns how long the players of the lobbies matched since the given time waited in them, from
+	// when they joined. The lobbies that went back to waiting for players, and the players that left, are left out.
+	ListMatchWaits(since time.Time) ([]MatchWait, error)
+	// SyncConnections marks the players of the games in progress whose heartbeats expired at the given time as
+	// disconnected, and the ones whose heartbeats came back as connected again. It returns the ids of the lobbies
+	// whose players changed, in order.
+	SyncConnections(at time.Time) ([]string, error)
+	// AbandonPlayers records that the players abandoned the game. The players that already abandoned it keep their
+	// first abandonment.
+	AbandonPlayers(lobby *models.Lobby, playerIDs []uint, abandonedAt time.Time) error
+	// ListFinishedByPlayer returns the finished games the user played until the end with their players and winner,
+	// the most recent first.
+	ListFinishedByPlayer(userID uint, offset, limit int) ([]*models.Lobby, error)
+	// ListResultsByPlayer returns whether the user won each of the finished games they played until the end, the most
+	// recent first.
+	ListResultsByPlayer(userID uint) ([]bool, error)
+	// Enqueue stores the ticket of a user that starts searching for a match. It fails with ErrAlreadySearching when
+	// the user already has a ticket.
+	Enqueue(ticket *models.MatchTicket) error
+	// FindTicket returns the ticket of the user, or ErrTicketNotFound.
+	FindTicket(userID uint) (*models.MatchTicket, error)
+	// Dequeue deletes the ticket of the user. It fails with ErrTicketMatched when the ticket is part of a pending
+	// match, and with ErrTicketNotFound when the user has no ticket.
+	Dequeue(userID uint) error
+	// FormMatch stores the match with the size tickets of its game mode and region that have been searching the
+	// longest, out of the ones that are not part of another match. It fails with ErrNotEnoughTickets when there are
+	// not enough of them.
+	FormMatch(match *models.PendingMatch, size int) error
+	// FindMatch returns the match with its tickets and their users, or ErrMatchNotFound.
+	FindMatch(matchID string) (*models.PendingMatch, error)
+	// AcceptMatch records that the player accepted the match. It fails with ErrMatchOver when the match is not
+	// PENDING anymore.
+	AcceptMatch(match *models.PendingMatch, playerID uint, acceptedAt time.Time) error
+	// CreateMatchLobby moves the match to ACCEPTED, deletes its tickets and stores the lobby of its game. It fails
+	// like AcceptMatch, and like Create if a player is already in an active lobby.
+	CreateMatchLobby(match *models.PendingMatch, lobby *models.Lobby) error
+	// FailMatch ends the match with the given status, deletes the tickets of the dropped players and puts the other
+	// tickets back in the queue, where they keep the time they started searching. It fails like AcceptMatch.
+	FailMatch(match *models.PendingMatch, status models.PendingMatchStatus, droppedPlayerIDs []uint) error
 }
@@ -0,0 +1,99 @@
+package lobby
+
+import (
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+)
+
+// legacyMembership is a membership as stored before the lobby_players table, in the users.lobby_id and users.ready
+// columns.
+type legacyMembership struct {
+	UserID    uint
+	LobbyID   string
+	Ready     bool
+	WinnerID  *uint
+	CreatedAt time.Time
+}
+
+// MigrateMemberships moves the memberships stored in the users table into the lobby_players table, then drops the
+// legacy columns. The players of a lobby are seated in the order they were registered, and the players of the
+// finished games get their placement. It does nothing once the legacy columns are gone, and it expects the
+// lobby_players table to exist.
+func MigrateMemberships(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.User{}, "lobby_id") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var legacy []legacyMembership
+		err := tx.Table("users").
+			Select("users.id AS user_id, users.lobby_id, users.ready, lobbies.winner_id, lobbies.created_at").
+			Joins("JOIN lobbies ON lobbies.lobby_id = users.lobby_id").
+			Where("users.deleted_at IS NULL").
+			Order("users.lobby_id").
+			Order("users.id").
+			Scan(&legacy).Error
+		if err != nil {
+			return err
+		}
+
+		seats := make(map[string]int)
+		for _, membership := range legacy {
+			player := models.LobbyPlayer{
+				LobbyID:  membership.LobbyID,
+				UserID:   membership.UserID,
+				Seat:     seats[membership.LobbyID],
+				Ready:    membership.Ready,
+				JoinedAt: membership.CreatedAt,
+			}
+			if membership.WinnerID != nil {
+				placement := 2
+				if *membership.WinnerID == membership.UserID {
+					placement = 1
+				}
+				player.Placement = &placement
+			}
+			seats[membership.LobbyID]++
+			if err := tx.Omit("User").Create(&player).Error; err != nil {
+				return err
+			}
+		}
+
+		if tx.Migrator().HasIndex(&models.User{}, "idx_users_lobby_id") {
+			if err := tx.Migrator().DropIndex(&models.User{}, "idx_users_lobby_id"); err != nil {
+				return err
+			}
+		}
+		for _, column := range []string{"lobby_id", "ready"} {
+			if err := tx.Migrator().DropColumn(&models.User{}, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MigrateHosts makes the creators the hosts of the lobbies created before the hosts. The lobbies without a creator
+// get the player in the lowest seat, as when the host leaves, and keep no host if nobody is left in them. It does
+// nothing for the lobbies that already have a host, and it expects the lobby_players table to exist.
+func MigrateHosts(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Lobby{}).
+			Where("host_id IS NULL AND creator_id IS NOT NULL").
+			Update("host_id", gorm.Expr("creator_id")).Error
+		if err != nil {
+			return err
+		}
+
+		lowestSeat := currentMembers(tx).
+			Select("user_id").
+			Where("lobby_players.lobby_id = lobbies.lobby_id").
+			Order("seat").
+			Limit(1)
+		return tx.Model(&models.Lobby{}).
+			Where("host_id IS NULL AND creator_id IS NULL").
+			Update("host_id", lowestSeat).Error
+	})
+}
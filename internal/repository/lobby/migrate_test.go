@@ -0,0 +1,121 @@
+package lobby
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// legacyUser is the users table as it was before the lobby_players table.
+type legacyUser struct {
+	gorm.Model
+	Username string  `gorm:"uniqueIndex;not null"`
+	Password string  `gorm:"not null"`
+	LobbyID  *string `gorm:"index"`
+	Ready    bool    `gorm:"not null;default:false"`
+}
+
+func (legacyUser) TableName() string {
+	return "users"
+}
+
+func TestMigrateMembershipsMovesThePlayersToTheLobbyPlayersTable(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+filepath.Join(t.TempDir(), "legacy.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&legacyUser{}, &models.Lobby{}, &models.LobbyTimer{}))
+	winnerID := uint(2)
+	finished := models.Lobby{LobbyID: "finished", Name: "finished", Status: models.LobbyStatusFinished, WinnerID: &winnerID}
+	waiting := models.Lobby{LobbyID: "waiting", Name: "waiting", Status: models.LobbyStatusReadyCheck}
+	require.NoError(t, db.Omit("Players").Create(&finished).Error)
+	require.NoError(t, db.Omit("Players").Create(&waiting).Error)
+	for _, user := range []legacyUser{
+		{Username: "loser", LobbyID: &finished.LobbyID},
+		{Username: "winner", LobbyID: &finished.LobbyID},
+		{Username: "ready", LobbyID: &waiting.LobbyID, Ready: true},
+		{Username: "idle"},
+	} {
+		require.NoError(t, db.Create(&user).Error)
+	}
+	require.NoError(t, db.AutoMigrate(&models.LobbyPlayer{}, &models.LobbySpectator{}))
+
+	require.NoError(t, MigrateMemberships(db))
+
+	assert.False(t, db.Migrator().HasColumn(&models.User{}, "lobby_id"))
+	assert.False(t, db.Migrator().HasColumn(&models.User{}, "ready"))
+	var users int64
+	require.NoError(t, db.Model(&models.User{}).Count(&users).Error)
+	assert.Equal(t, int64(4), users)
+	repo := NewSQLLobbyRepository(db)
+	finishedLobby, err := repo.FindByID("finished")
+	require.NoError(t, err)
+	require.Len(t, finishedLobby.Players, 2)
+	assert.Equal(t, "loser", finishedLobby.Players[0].User.Username)
+	assert.Equal(t, 2, *finishedLobby.Players[0].Placement)
+	assert.Equal(t, "winner", finishedLobby.Players[1].User.Username)
+	assert.Equal(t, 1, finishedLobby.Players[1].Seat)
+	assert.Equal(t, 1, *finishedLobby.Players[1].Placement)
+	waitingLobby, err := repo.FindByID("waiting")
+	require.NoError(t, err)
+	require.Len(t, waitingLobby.Players, 1)
+	assert.True(t, waitingLobby.Players[0].Ready)
+	assert.Nil(t, waitingLobby.Players[0].Placement)
+
+	// Once the legacy columns are gone, the migration does nothing.
+	require.NoError(t, MigrateMemberships(db))
+	var memberships int64
+	require.NoError(t, db.Model(&models.LobbyPlayer{}).Count(&memberships).Error)
+	assert.Equal(t, int64(3), memberships)
+}
+
+func TestMigrateHostsMakesTheCreatorsTheHosts(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+filepath.Join(t.TempDir(), "hosts.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Lobby{}, &models.LobbyPlayer{}))
+	creatorID, hostID := uint(1), uint(2)
+	legacy := models.Lobby{LobbyID: "legacy", Name: "legacy", CreatorID: &creatorID}
+	transferred := models.Lobby{LobbyID: "transferred", Name: "transferred", CreatorID: &creatorID, HostID: &hostID}
+	require.NoError(t, db.Create(&legacy).Error)
+	require.NoError(t, db.Create(&transferred).Error)
+
+	require.NoError(t, MigrateHosts(db))
+
+	var lobbies []models.Lobby
+	require.NoError(t, db.Order("lobby_id").Find(&lobbies).Error)
+	require.Len(t, lobbies, 2)
+	assert.Equal(t, creatorID, *lobbies[0].HostID)
+	assert.Equal(t, hostID, *lobbies[1].HostID)
+}
+
+func TestMigrateHostsFallsBackToThePlayerInTheLowestSeat(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+filepath.Join(t.TempDir(), "hosts.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Lobby{}, &models.LobbyPlayer{}))
+	orphan := models.Lobby{LobbyID: "orphan", Name: "orphan"}
+	empty := models.Lobby{LobbyID: "empty", Name: "empty"}
+	require.NoError(t, db.Create(&orphan).Error)
+	require.NoError(t, db.Create(&empty).Error)
+	leftAt := time.Now().UTC()
+	for _, player := range []models.LobbyPlayer{
+		{LobbyID: "orphan", UserID: 1, Seat: 0, LeftAt: &leftAt},
+		{LobbyID: "orphan", UserID: 3, Seat: 2},
+		{LobbyID: "orphan", UserID: 2, Seat: 1},
+		{LobbyID: "empty", UserID: 4, Seat: 0, LeftAt: &leftAt},
+	} {
+		require.NoError(t, db.Omit("User").Create(&player).Error)
+	}
+
+	require.NoError(t, MigrateHosts(db))
+
+	var orphanLobby, emptyLobby models.Lobby
+	require.NoError(t, db.First(&orphanLobby, "lobby_id = ?", "orphan").Error)
+	require.NoError(t, db.First(&emptyLobby, "lobby_id = ?", "empty").Error)
+	require.NotNil(t, orphanLobby.HostID)
+	assert.Equal(t, uint(2), *orphanLobby.HostID)
+	assert.Nil(t, emptyLobby.HostID)
+}
@@ -2,6 +2,9 @@ package lobby
 
 import (
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
 	"gorm.io/gorm"
@@ -15,37 +18,1058 @@ func NewSQLLobbyRepository(db *gorm.DB) LobbyRepository {
 	return &sqlLobbyRepository{db: db}
 }
 
+// Create stores the lobby together with the memberships in lobby.Players.
 func (r *sqlLobbyRepository) Create(lobby *models.Lobby) error {
-	return r.db.Create(lobby).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, player := range lobby.Players {
+			busy, err := inActiveLobby(tx, player.UserID)
+			if err != nil {
+				return err
+			}
+			if busy {
+				return ErrPlayerInLobby
+			}
+		}
+		return tx.Omit("Players.User").Create(lobby).Error
+	})
+}
+
+// inActiveLobby reports whether the user holds a membership of an active lobby.
+func inActiveLobby(tx *gorm.DB, userID uint) (bool, error) {
+	var memberships int64
+	err := currentMembers(tx).
+		Where("user_id = ?", userID).
+		Where("lobby_id IN (?)", activeLobbyIDs(tx)).
+		Count(&memberships).Error
+	return memberships > 0, err
+}
+
+// activeLobbyIDs is the subquery of the identifiers of the active lobbies.
+func activeLobbyIDs(tx *gorm.DB) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).
+		Model(&models.Lobby{}).
+		Select("lobby_id").
+		Where("status IN ?", models.ActiveLobbyStatuses)
+}
+
+// currentMembers is the query of the memberships that did not end.
+func currentMembers(tx *gorm.DB) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).Model(&models.LobbyPlayer{}).Where("left_at IS NULL")
+}
+
+// withPlayers preloads the current players of the lobbies, in seat order.
+func withPlayers(db *gorm.DB) *gorm.DB {
+	return db.Preload("Players", func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("left_at IS NULL").Order("seat")
+	}).Preload("Players.User")
+}
+
+// withSpectators preloads the current spectators of the lobbies, in arrival order.
+func withSpectators(db *gorm.DB) *gorm.DB {
+	return db.Preload("Spectators", func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("left_at IS NULL").Order("joined_at").Order("id")
+	}).Preload("Spectators.User")
 }
 
 func (r *sqlLobbyRepository) FindByID(lobbyID string) (*models.Lobby, error) {
 	var lobby models.Lobby
-	result := r.db.Preload("Players").Preload("Winner").First(&lobby, "lobby_id = ?", lobbyID)
+	result := withSpectators(withPlayers(r.db)).Preload("Winner").Preload("Timers").First(&lobby, "lobby_id = ?", lobbyID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrLobbyNotFound
+	}
+	return &lobby, result.Error
+}
+
+func (r *sqlLobbyRepository) FindByJoinCode(joinCode string) (*models.Lobby, error) {
+	var lobby models.Lobby
+	result := withSpectators(withPlayers(r.db)).Preload("Winner").Preload("Timers").First(&lobby, "join_code = ?", joinCode)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, ErrLobbyNotFound
 	}
 	return &lobby, result.Error
 }
 
-func (r *sqlLobbyRepository) ListAvailable() []*models.Lobby {
+func (r *sqlLobbyRepository) FindActiveByPlayer(userID uint) (*models.Lobby, error) {
+	var lobby models.Lobby
+	result := withSpectators(withPlayers(r.db)).Preload("Winner").Preload("Timers").
+		Select("lobbies.*").
+		Joins("JOIN lobby_players ON lobby_players.lobby_id = lobbies.lobby_id").
+		Where("lobby_players.user_id = ? AND lobby_players.left_at IS NULL", userID).
+		Where("lobbies.status IN ?", models.ActiveLobbyStatuses).
+		First(&lobby)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrLobbyNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &lobby, nil
+}
+
+// ListAvailable pages through the public lobbies waiting for players, and not locked, with a keyset cursor, so that
+// every page costs the same however deep it is.
+func (r *sqlLobbyRepository) ListAvailable(filter AvailableFilter) ([]*models.Lobby, error) {
+	query := withPlayers(r.db).
+		Where("status = ? AND visibility = ? AND NOT locked", models.LobbyStatusWaiting, models.LobbyVisibilityPublic)
+
+	if filter.NameContains != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '\\'", "%"+escapeLike(strings.ToLower(filter.NameContains))+"%")
+	}
+	if filter.MinFreeSlots > 0 {
+		players := currentMembers(r.db).Select("COUNT(*)").Where("lobby_players.lobby_id = lobbies.lobby_id")
+		query = query.Where("max_players - (?) >= ?", players, filter.MinFreeSlots)
+	}
+	if filter.GameMode != "" {
+		query = query.Where("game_mode = ?", filter.GameMode)
+	}
+	if filter.Region != "" {
+		query = query.Where("region = ?", filter.Region)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedBefore)
+	}
+	if !filter.OnlineAt.IsZero() {
+		disconnected := r.db.Model(&models.Presence{}).Select("1").
+			Where("presences.user_id = lobbies.host_id AND presences.expires_at <= ?", filter.OnlineAt)
+		query = query.Where("NOT EXISTS (?)", disconnected)
+	}
+
+	after := filter.After
+	switch filter.Sort {
+	case SortOldest:
+		query = query.Order("created_at").Order("lobby_id")
+		if after != nil {
+			query = query.Where("created_at > ? OR (created_at = ? AND lobby_id > ?)", after.CreatedAt, after.CreatedAt, after.LobbyID)
+		}
+	case SortByName:
+		query = query.Order("name").Order("lobby_id")
+		if after != nil {
+			query = query.Where("name > ? OR (name = ? AND lobby_id > ?)", after.Name, after.Name, after.LobbyID)
+		}
+	default:
+		query = query.Order("created_at DESC").Order("lobby_id")
+		if after != nil {
+			query = query.Where("created_at < ? OR (created_at = ? AND lobby_id > ?)", after.CreatedAt, after.CreatedAt, after.LobbyID)
+		}
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var lobbies []*models.Lobby
+	err := query.Find(&lobbies).Error
+	return lobbies, err
+}
+
+// escapeLike makes the wildcards of a LIKE pattern match themselves.
+func escapeLike(pattern string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(pattern)
+}
+
+// ListStale returns the WAITING lobbies created before createdBefore, whose creator was last seen before
+// creatorSeenBefore, or whose creator disconnected before creatorDisconnectedBefore. A creator whose connection is
+// not tracked is only judged by when they were last seen.
+func (r *sqlLobbyRepository) ListStale(createdBefore, creatorSeenBefore, creatorDisconnectedBefore time.Time) ([]*models.Lobby, error) {
+	var lobbies []*models.Lobby
+	err := r.db.Select("lobbies.*").
+		Joins("LEFT JOIN users ON users.id = lobbies.creator_id").
+		Joins("LEFT JOIN presences ON presences.user_id = lobbies.creator_id").
+		Where("lobbies.status = ?", models.LobbyStatusWaiting).
+		Where("lobbies.created_at < ? OR users.last_seen_at < ? OR presences.expires_at < ?",
+			createdBefore, creatorSeenBefore, creatorDisconnectedBefore).
+		Find(&lobbies).Error
+	return lobbies, err
+}
+
+func (r *sqlLobbyRepository) ListWaiting() ([]WaitingLobby, error) {
+	var waiting []WaitingLobby
+	err := r.db.Model(&models.Lobby{}).
+		Select("lobbies.game_mode, lobbies.region, lobbies.max_players, COUNT(lobby_players.id) AS players").
+		Joins("LEFT JOIN lobby_players ON lobby_players.lobby_id = lobbies.lobby_id AND lobby_players.left_at IS NULL").
+		Where("lobbies.status = ? AND lobbies.visibility = ? AND NOT lobbies.locked",
+			models.LobbyStatusWaiting, models.LobbyVisibilityPublic).
+		Group("lobbies.lobby_id").
+		Order("lobbies.lobby_id").
+		Scan(&waiting).Error
+	return waiting, err
+}
+
+// ListMatchWaits computes the waits in Go, since SQLite has no type for the timestamps to subtract them.
+func (r *sqlLobbyRepository) ListMatchWaits(since time.Time) ([]MatchWait, error) {
+	var rows []struct {
+		GameMode  string
+		Region    string
+		MatchedAt time.Time
+		JoinedAt  time.Time
+	}
+	err := r.db.Model(&models.Lobby{}).
+		Select("lobbies.game_mode, lobbies.region, lobbies.matched_at, lobby_players.joined_at").
+		Joins("JOIN lobby_players ON lobby_players.lobby_id = lobbies.lobby_id AND lobby_players.left_at IS NULL").
+		Where("lobbies.matched_at >= ?", since).
+		Where("lobbies.visibility = ?", models.LobbyVisibilityPublic).
+		Where("lobbies.status NOT IN ?", []models.LobbyStatus{models.LobbyStatusWaiting, models.LobbyStatusCancelled}).
+		Order("lobbies.matched_at").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	waits := make([]MatchWait, len(rows))
+	for i, row := range rows {
+		waits[i] = MatchWait{GameMode: row.GameMode, Region: row.Region, Wait: max(row.MatchedAt.Sub(row.JoinedAt), 0)}
+	}
+	return waits, nil
+}
+
+// SyncConnections looks only at the players whose presence is tracked: the clients that never sent a heartbeat are
+// never seen as disconnected. A disconnected player is stamped with the expiry of their last heartbeat.
+func (r *sqlLobbyRepository) SyncConnections(at time.Time) ([]string, error) {
+	var lobbyIDs []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		inProgress := tx.Model(&models.Lobby{}).Select("lobby_id").Where("status = ?", models.LobbyStatusInProgress)
+		expired := tx.Model(&models.Presence{}).Select("user_id").Where("expires_at <= ?", at)
+		online := tx.Model(&models.Presence{}).Select("user_id").Where("expires_at > ?", at)
+
+		err := currentMembers(tx).
+			Distinct("lobby_id").
+			Where("lobby_id IN (?)", inProgress).
+			Where("(disconnected_at IS NULL AND user_id IN (?)) OR (disconnected_at IS NOT NULL AND user_id IN (?))",
+				expired, online).
+			Order("lobby_id").
+			Pluck("lobby_id", &lobbyIDs).Error
+		if err != nil || len(lobbyIDs) == 0 {
+			return err
+		}
+
+		err = currentMembers(tx).
+			Where("lobby_id IN ? AND disconnected_at IS NULL AND user_id IN (?)", lobbyIDs, expired).
+			Update("disconnected_at", gorm.Expr("(SELECT expires_at FROM presences WHERE presences.user_id = lobby_players.user_id)")).Error
+		if err != nil {
+			return err
+		}
+		return currentMembers(tx).
+			Where("lobby_id IN ? AND disconnected_at IS NOT NULL AND user_id IN (?)", lobbyIDs, online).
+			Update("disconnected_at", nil).Error
+	})
+	return lobbyIDs, err
+}
+
+func (r *sqlLobbyRepository) AbandonPlayers(lobby *models.Lobby, playerIDs []uint, abandonedAt time.Time) error {
+	return currentMembers(r.db).
+		Where("lobby_id = ? AND user_id IN ? AND abandoned_at IS NULL", lobby.LobbyID, playerIDs).
+		Update("abandoned_at", abandonedAt).Error
+}
+
+// ListFinishedByPlayer returns the finished games the user played until the end, the most recent first.
+func (r *sqlLobbyRepository) ListFinishedByPlayer(userID uint, offset, limit int) ([]*models.Lobby, error) {
 	var lobbies []*models.Lobby
-	r.db.Preload("Players").
-		Where("status = ?", models.LobbyStatusWaiting).
-		Find(&lobbies)
-	return lobbies
+	err := finishedByPlayer(withPlayers(r.db).Preload("Winner"), userID).
+		Select("lobbies.*").
+		Offset(offset).
+		Limit(limit).
+		Find(&lobbies).Error
+	return lobbies, err
+}
+
+// ListResultsByPlayer reads only the placement of the player in each game, so that the whole history of a player
+// stays cheap to load. The placement covers the games won by a team as well as the ones won by a single player.
+func (r *sqlLobbyRepository) ListResultsByPlayer(userID uint) ([]bool, error) {
+	var placements []*int
+	err := finishedByPlayer(r.db.Model(&models.Lobby{}), userID).Pluck("lobby_players.placement", &placements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]bool, len(placements))
+	for i, placement := range placements {
+		results[i] = placement != nil && *placement == 1
+	}
+	return results, nil
+}
+
+// finishedByPlayer narrows the query to the finished lobbies the user played until the end, the most recent first.
+func finishedByPlayer(db *gorm.DB, userID uint) *gorm.DB {
+	return db.Joins("JOIN lobby_players ON lobby_players.lobby_id = lobbies.lobby_id").
+		Where("lobby_players.user_id = ? AND lobby_players.left_at IS NULL", userID).
+		Where("lobbies.status = ?", models.LobbyStatusFinished).
+		Order("lobbies.updated_at DESC").
+		Order("lobbies.lobby_id")
+}
+
+// claimWaiting bumps the version of the lobby only if it still matches the version read by the caller: a concurrent
+// change of the players holds the lobby row until it commits, after which the version does not match anymore.
+func claimWaiting(tx *gorm.DB, lobby *models.Lobby) error {
+	result := tx.Model(&models.Lobby{}).
+		Where("lobby_id = ? AND version = ?", lobby.LobbyID, lobby.Version).
+		Update("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLobbyConflict
+	}
+
+	var current models.Lobby
+	if err := tx.Select("status").First(&current, "lobby_id = ?", lobby.LobbyID).Error; err != nil {
+		return err
+	}
+	if current.Status != models.LobbyStatusWaiting {
+		return ErrLobbyNotWaiting
+	}
+	return nil
+}
+
+func (r *sqlLobbyRepository) AddPlayer(lobby *models.Lobby, player *models.User, capacity int) error {
+	return r.AddPlayers(lobby, []*models.User{player}, capacity)
+}
+
+// AddPlayers claims the lobby before seating the players, so that two concurrent joins can not take the same seat.
+// The capacity and the lobby the players are already in are checked inside the same transaction.
+func (r *sqlLobbyRepository) AddPlayers(lobby *models.Lobby, newPlayers []*models.User, capacity int) error {
+	memberships := make([]models.LobbyPlayer, 0, len(newPlayers))
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := claimWaiting(tx, lobby); err != nil {
+			return err
+		}
+
+		var players []models.LobbyPlayer
+		err := currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).Order("seat").Find(&players).Error
+		if err != nil {
+			return err
+		}
+		if len(players)+len(newPlayers) > capacity {
+			return ErrLobbyFull
+		}
+
+		var current models.Lobby
+		if err := tx.Select("locked").First(&current, "lobby_id = ?", lobby.LobbyID).Error; err != nil {
+			return err
+		}
+		if current.Locked {
+			return ErrLobbyLocked
+		}
+
+		for _, player := range newPlayers {
+			// The player must not be in an active lobby, this one included.
+			busy, err := inActiveLobby(tx, player.ID)
+			if err != nil {
+				return err
+			}
+			if busy {
+				return ErrPlayerInLobby
+			}
+
+			membership := models.LobbyPlayer{LobbyID: lobby.LobbyID, UserID: player.ID, Seat: firstFreeSeat(players)}
+			if lobby.Teams > 0 {
+				team := smallestTeam(players, lobby.Teams)
+				membership.Team = &team
+			}
+			if err := tx.Omit("User").Create(&membership).Error; err != nil {
+				return err
+			}
+			// A spectator who takes a seat stops watching the lobby.
+			err = currentSpectators(tx).Where("lobby_id = ? AND user_id = ?", lobby.LobbyID, player.ID).
+				Update("left_at", tx.NowFunc()).Error
+			if err != nil {
+				return err
+			}
+
+			memberships = append(memberships, membership)
+			players = append(players, membership)
+			slices.SortFunc(players, func(a, b models.LobbyPlayer) int { return a.Seat - b.Seat })
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.Version++
+	for i, player := range newPlayers {
+		memberships[i].User = *player
+		lobby.Players = append(lobby.Players, memberships[i])
+		lobby.Spectators = slices.DeleteFunc(lobby.Spectators, func(spectator models.LobbySpectator) bool {
+			return spectator.UserID == player.ID
+		})
+	}
+	return nil
+}
+
+// currentSpectators is the query of the spectators that did not leave.
+func currentSpectators(tx *gorm.DB) *gorm.DB {
+	return tx.Session(&gorm.Session{NewDB: true}).Model(&models.LobbySpectator{}).Where("left_at IS NULL")
+}
+
+// AddSpectator locks the lobby row with an update before counting the spectators, so that two concurrent spectators
+// can not both take the last place.
+func (r *sqlLobbyRepository) AddSpectator(lobby *models.Lobby, user *models.User, maxSpectators int) error {
+	var spectator models.LobbySpectator
+	added := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Lobby{}).Where("lobby_id = ?", lobby.LobbyID).
+			Update("updated_at", tx.NowFunc())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrLobbyNotFound
+		}
+
+		var players int64
+		err := currentMembers(tx).Where("lobby_id = ? AND user_id = ?", lobby.LobbyID, user.ID).Count(&players).Error
+		if err != nil {
+			return err
+		}
+		if players > 0 {
+			return ErrPlayerInLobby
+		}
+
+		var spectators []models.LobbySpectator
+		if err := currentSpectators(tx).Where("lobby_id = ?", lobby.LobbyID).Find(&spectators).Error; err != nil {
+			return err
+		}
+		if slices.ContainsFunc(spectators, func(spectator models.LobbySpectator) bool {
+			return spectator.UserID == user.ID
+		}) {
+			return nil
+		}
+		if len(spectators) >= maxSpectators {
+			return ErrSpectatorsFull
+		}
+
+		spectator = models.LobbySpectator{LobbyID: lobby.LobbyID, UserID: user.ID}
+		added = true
+		return tx.Omit("User").Create(&spectator).Error
+	})
+	if err != nil || !added {
+		return err
+	}
+
+	spectator.User = *user
+	lobby.Spectators = append(lobby.Spectators, spectator)
+	return nil
+}
+
+// firstFreeSeat returns the lowest seat missing from the players sorted by seat.
+func firstFreeSeat(players []models.LobbyPlayer) int {
+	for seat, player := range players {
+		if seat != player.Seat {
+			return seat
+		}
+	}
+	return len(players)
+}
+
+// smallestTeam returns the team with the fewest players, the lowest one on a tie.
+func smallestTeam(players []models.LobbyPlayer, teams int) int {
+	sizes := make([]int, teams+1)
+	for _, player := range players {
+		if player.Team != nil && *player.Team <= teams {
+			sizes[*player.Team]++
+		}
+	}
+	smallest := 1
+	for team := 2; team <= teams; team++ {
+		if sizes[team] < sizes[smallest] {
+			smallest = team
+		}
+	}
+	return smallest
+}
+
+func (r *sqlLobbyRepository) SwitchTeam(lobby *models.Lobby, userID uint, team, teamSize int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := claimWaiting(tx, lobby); err != nil {
+			return err
+		}
+
+		var teammates int64
+		err := currentMembers(tx).Where("lobby_id = ? AND team = ? AND user_id <> ?", lobby.LobbyID, team, userID).
+			Count(&teammates).Error
+		if err != nil {
+			return err
+		}
+		if int(teammates) >= teamSize {
+			return ErrTeamFull
+		}
+
+		result := currentMembers(tx).Where("lobby_id = ? AND user_id = ?", lobby.LobbyID, userID).Update("team", team)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPlayerNotInLobby
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.Version++
+	for i := range lobby.Players {
+		if lobby.Players[i].UserID == userID {
+			lobby.Players[i].Team = &team
+		}
+	}
+	return nil
+}
+
+func (r *sqlLobbyRepository) SwapSeat(lobby *models.Lobby, userID uint, seat int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := claimWaiting(tx, lobby); err != nil {
+			return err
+		}
+
+		var players []models.LobbyPlayer
+		if err := currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).Find(&players).Error; err != nil {
+			return err
+		}
+		moverIndex := slices.IndexFunc(players, func(p models.LobbyPlayer) bool { return p.UserID == userID })
+		if moverIndex < 0 {
+			return ErrPlayerNotInLobby
+		}
+		mover := players[moverIndex]
+
+		occupantIndex := slices.IndexFunc(players, func(p models.LobbyPlayer) bool { return p.Seat == seat })
+		team := mover.Team
+		if occupantIndex >= 0 {
+			occupant := players[occupantIndex]
+			team = occupant.Team
+			err := tx.Model(&occupant).Updates(map[string]any{"seat": mover.Seat, "team": mover.Team}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&mover).Updates(map[string]any{"seat": seat, "team": team}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.Version++
+	moverIndex := slices.IndexFunc(lobby.Players, func(p models.LobbyPlayer) bool { return p.UserID == userID })
+	if moverIndex < 0 {
+		return nil
+	}
+	mover := &lobby.Players[moverIndex]
+	for i := range lobby.Players {
+		if occupant := &lobby.Players[i]; occupant.Seat == seat && occupant.UserID != userID {
+			occupant.Seat, occupant.Team, mover.Team = mover.Seat, mover.Team, occupant.Team
+		}
+	}
+	mover.Seat = seat
+	slices.SortFunc(lobby.Players, func(a, b models.LobbyPlayer) int { return a.Seat - b.Seat })
+	return nil
+}
+
+// KickPlayer claims the lobby like AddPlayer, so that the kicked player can not be seated by a concurrent change.
+func (r *sqlLobbyRepository) KickPlayer(lobby *models.Lobby, userID uint, bannedUntil time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := claimWaiting(tx, lobby); err != nil {
+			return err
+		}
+
+		result := currentMembers(tx).
+			Where("lobby_id = ? AND user_id = ?", lobby.LobbyID, userID).
+			Updates(map[string]any{"left_at": tx.NowFunc(), "ready": false, "banned_until": bannedUntil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPlayerNotInLobby
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.Version++
+	lobby.Players = slices.DeleteFunc(lobby.Players, func(player models.LobbyPlayer) bool {
+		return player.UserID == userID
+	})
+	return nil
+}
+
+// LeaveWaiting claims the lobby like KickPlayer, so that the seat of the player is freed only once.
+func (r *sqlLobbyRepository) LeaveWaiting(lobby *models.Lobby, userID uint, leftAt time.Time) error {
+	var remaining int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := claimWaiting(tx, lobby); err != nil {
+			return err
+		}
+
+		result := currentMembers(tx).
+			Where("lobby_id = ? AND user_id = ?", lobby.LobbyID, userID).
+			Updates(map[string]any{"left_at": leftAt, "ready": false})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPlayerNotInLobby
+		}
+
+		if err := currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining == 0 {
+			return tx.Model(&models.Lobby{}).Where("lobby_id = ?", lobby.LobbyID).Updates(map[string]any{
+				"status":       models.LobbyStatusCancelled,
+				"close_reason": models.LobbyCloseEmpty,
+				"closed_at":    leftAt,
+			}).Error
+		}
+		if lobby.HostID != nil && *lobby.HostID == userID {
+			return r.passHost(tx, lobby)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.Version++
+	lobby.Players = slices.DeleteFunc(lobby.Players, func(player models.LobbyPlayer) bool {
+		return player.UserID == userID
+	})
+	if remaining == 0 {
+		reason := models.LobbyCloseEmpty
+		lobby.Status = models.LobbyStatusCancelled
+		lobby.CloseReason = &reason
+		lobby.ClosedAt = &leftAt
+	}
+	return nil
+}
+
+// LeaveGame checks that the game is still in progress in the same statement that bumps its version, so that a player
+// can not leave a game that ended in the meantime.
+func (r *sqlLobbyRepository) LeaveGame(lobby *models.Lobby, userID uint, leftAt time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Lobby{}).
+			Where("lobby_id = ? AND status = ?", lobby.LobbyID, models.LobbyStatusInProgress).
+			Update("version", gorm.Expr("version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrLobbyNotInProgress
+		}
+
+		result = currentMembers(tx).
+			Where("lobby_id = ? AND user_id = ?", lobby.LobbyID, userID).
+			Updates(map[string]any{"left_at": leftAt, "abandoned_at": gorm.Expr("COALESCE(abandoned_at, ?)", leftAt)})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPlayerNotInLobby
+		}
+
+		if lobby.HostID != nil && *lobby.HostID == userID {
+			return r.passHost(tx, lobby)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.Version++
+	return nil
+}
+
+func (r *sqlLobbyRepository) BannedUntil(lobbyID string, userID uint) (*time.Time, error) {
+	var memberships []models.LobbyPlayer
+	err := r.db.Where("lobby_id = ? AND user_id = ? AND banned_until IS NOT NULL", lobbyID, userID).
+		Order("banned_until DESC").
+		Limit(1).
+		Find(&memberships).Error
+	if err != nil || len(memberships) == 0 {
+		return nil, err
+	}
+	return memberships[0].BannedUntil, nil
+}
+
+func (r *sqlLobbyRepository) UpdateHost(lobby *models.Lobby, hostID uint) error {
+	if err := r.updateUnchanged(lobby, map[string]any{"host_id": hostID}); err != nil {
+		return err
+	}
+	lobby.HostID = &hostID
+	return nil
+}
+
+func (r *sqlLobbyRepository) SetLocked(lobby *models.Lobby, locked bool) error {
+	if err := r.updateUnchanged(lobby, map[string]any{"locked": locked}); err != nil {
+		return err
+	}
+	lobby.Locked = locked
+	return nil
+}
+
+func (r *sqlLobbyRepository) Rename(lobby *models.Lobby, name string) error {
+	if err := r.updateUnchanged(lobby, map[string]any{"name": name}); err != nil {
+		return err
+	}
+	lobby.Name = name
+	return nil
 }
 
-func (r *sqlLobbyRepository) AddPlayer(lobby *models.Lobby, player *models.User) error {
-	return r.db.Model(lobby).Association("Players").Append(player)
+// updateUnchanged applies the updates, and bumps the version of the lobby, only if its status and version still
+// match the ones read by the caller, like claimWaiting: a concurrent change of the players holds the lobby row until
+// it commits, after which the version does not match anymore.
+func (r *sqlLobbyRepository) updateUnchanged(lobby *models.Lobby, updates map[string]any) error {
+	updates["version"] = gorm.Expr("version + 1")
+	result := r.db.Model(&models.Lobby{}).
+		Where("lobby_id = ? AND status = ? AND version = ?", lobby.LobbyID, lobby.Status, lobby.Version).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLobbyConflict
+	}
+
+	lobby.Version++
+	return nil
+}
+
+func (r *sqlLobbyRepository) AssignTeams(lobby *models.Lobby, teams map[uint]int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for userID, team := range teams {
+			err := currentMembers(tx).
+				Where("lobby_id = ? AND user_id = ?", lobby.LobbyID, userID).
+				Update("team", team).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range lobby.Players {
+		if team, ok := teams[lobby.Players[i].UserID]; ok {
+			lobby.Players[i].Team = &team
+		}
+	}
+	return nil
 }
 
 func (r *sqlLobbyRepository) UpdateStatus(lobby *models.Lobby, status models.LobbyStatus) error {
 	return r.db.Model(lobby).Update("status", status).Error
 }
 
-func (r *sqlLobbyRepository) UpdateWinner(lobby *models.Lobby, winnerID uint) error {
-	return r.db.Model(lobby).Update("winner_id", winnerID).Error
+// FinishWithWinner moves the game in progress to FINISHED and records its winner together with the final placement
+// of every player: the winner is first and everybody else second. The status check and the update are a single
+// statement, so a game can be finished only once: the losers get ErrLobbyNotInProgress.
+func (r *sqlLobbyRepository) FinishWithWinner(lobby *models.Lobby, winnerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := finishInProgress(tx, lobby, "winner_id", winnerID); err != nil {
+			return err
+		}
+		return currentMembers(tx).
+			Where("lobby_id = ?", lobby.LobbyID).
+			Update("placement", gorm.Expr("CASE WHEN user_id = ? THEN 1 ELSE 2 END", winnerID)).Error
+	})
+}
+
+func (r *sqlLobbyRepository) FinishWithWinningTeam(lobby *models.Lobby, team int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := finishInProgress(tx, lobby, "winning_team", team); err != nil {
+			return err
+		}
+		return currentMembers(tx).
+			Where("lobby_id = ?", lobby.LobbyID).
+			Update("placement", gorm.Expr("CASE WHEN team = ? THEN 1 ELSE 2 END", team)).Error
+	})
+}
+
+// finishInProgress moves the lobby from IN_PROGRESS to FINISHED, together with the column that holds the result.
+func (r *sqlLobbyRepository) AbandonGame(lobby *models.Lobby) error {
+	update := r.db.Model(&models.Lobby{}).
+		Where("lobby_id = ? AND status = ?", lobby.LobbyID, models.LobbyStatusInProgress).
+		Update("status", models.LobbyStatusAbandoned)
+	if update.Error != nil {
+		return update.Error
+	}
+	if update.RowsAffected == 0 {
+		return ErrLobbyNotInProgress
+	}
+	return nil
+}
+
+func finishInProgress(tx *gorm.DB, lobby *models.Lobby, resultColumn string, result any) error {
+	update := tx.Model(&models.Lobby{}).
+		Where("lobby_id = ? AND status = ?", lobby.LobbyID, models.LobbyStatusInProgress).
+		Updates(map[string]any{"status": models.LobbyStatusFinished, resultColumn: result})
+	if update.Error != nil {
+		return update.Error
+	}
+	if update.RowsAffected == 0 {
+		return ErrLobbyNotInProgress
+	}
+	return nil
+}
+
+// StartReadyCheck moves the lobby to READY_CHECK and clears the confirmations left by any previous ready check.
+// StartReadyCheck runs after the transaction that filled the lobby, so it checks again in a single statement that the
+// lobby is still the one that was filled: a kick, a leave or the waiting timeout may have changed it in between.
+func (r *sqlLobbyRepository) StartReadyCheck(lobby *models.Lobby, deadline time.Time, matched bool) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{
+			"status":               models.LobbyStatusReadyCheck,
+			"ready_check_deadline": deadline,
+			"version":              gorm.Expr("version + 1"),
+		}
+		if matched {
+			updates["matched_at"] = tx.NowFunc()
+		}
+
+		seated := currentMembers(tx).Select("COUNT(*)").Where("lobby_id = ?", lobby.LobbyID)
+		result := tx.Model(&models.Lobby{}).
+			Where("lobby_id = ? AND status = ? AND version = ?", lobby.LobbyID, models.LobbyStatusWaiting, lobby.Version).
+			Where("max_players <= (?)", seated).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrLobbyConflict
+		}
+		return r.resetReadiness(tx, lobby)
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.Version++
+	return nil
+}
+
+func (r *sqlLobbyRepository) SetPlayerReady(lobby *models.Lobby, player *models.User) error {
+	return currentMembers(r.db).
+		Where("user_id = ? AND lobby_id = ?", player.ID, lobby.LobbyID).
+		Update("ready", true).Error
+}
+
+// CompleteReadyCheck starts the game once every player confirmed. The status check and the update are a single
+// statement, so the ready check ends only once: the losers get ErrReadyCheckOver.
+func (r *sqlLobbyRepository) CompleteReadyCheck(lobby *models.Lobby) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := endReadyCheck(tx, lobby, map[string]any{
+			"status":               models.LobbyStatusInProgress,
+			"ready_check_deadline": nil,
+		})
+		if err != nil {
+			return err
+		}
+		return r.resetReadiness(tx, lobby)
+	})
+}
+
+// FailReadyCheck removes the players that did not confirm in time and moves the lobby back to WAITING. It fails like
+// CompleteReadyCheck when the ready check is already over.
+func (r *sqlLobbyRepository) FailReadyCheck(lobby *models.Lobby, removedPlayerIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := endReadyCheck(tx, lobby, map[string]any{
+			"status":               models.LobbyStatusWaiting,
+			"ready_check_deadline": nil,
+			"version":              gorm.Expr("version + 1"),
+		})
+		if err != nil {
+			return err
+		}
+		if len(removedPlayerIDs) > 0 {
+			err := currentMembers(tx).
+				Where("user_id IN ? AND lobby_id = ?", removedPlayerIDs, lobby.LobbyID).
+				Updates(map[string]any{"left_at": tx.NowFunc(), "ready": false}).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := r.resetReadiness(tx, lobby); err != nil {
+			return err
+		}
+		if lobby.HostID != nil && slices.Contains(removedPlayerIDs, *lobby.HostID) {
+			return r.passHost(tx, lobby)
+		}
+		return nil
+	})
+}
+
+// DeleteUnconfirmed bumps the version of the lobby in the same statement that checks it, so that a concurrent start
+// of a new ready check, or its end, can not be deleted. The confirmations are counted afterwards, within the
+// transaction.
+func (r *sqlLobbyRepository) DeleteUnconfirmed(lobby *models.Lobby) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Lobby{}).
+			Where("lobby_id = ? AND status = ? AND version = ?", lobby.LobbyID, models.LobbyStatusReadyCheck, lobby.Version).
+			Update("version", gorm.Expr("version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrReadyCheckOver
+		}
+
+		var confirmed int64
+		if err := currentMembers(tx).Where("lobby_id = ? AND ready = ?", lobby.LobbyID, true).Count(&confirmed).Error; err != nil {
+			return err
+		}
+		if confirmed > 0 {
+			return ErrReadyCheckOver
+		}
+		return deleteLobby(tx, lobby)
+	})
+}
+
+// endReadyCheck applies the updates to the lobby only if it is still in the ready check.
+func endReadyCheck(tx *gorm.DB, lobby *models.Lobby, updates map[string]any) error {
+	result := tx.Model(&models.Lobby{}).
+		Where("lobby_id = ? AND status = ?", lobby.LobbyID, models.LobbyStatusReadyCheck).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrReadyCheckOver
+	}
+	return nil
+}
+
+// passHost makes the current player with the lowest seat the host of the lobby.
+func (r *sqlLobbyRepository) passHost(tx *gorm.DB, lobby *models.Lobby) error {
+	var players []models.LobbyPlayer
+	err := currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).Order("seat").Limit(1).Find(&players).Error
+	if err != nil {
+		return err
+	}
+	if len(players) == 0 {
+		return nil
+	}
+	return tx.Model(lobby).Update("host_id", players[0].UserID).Error
+}
+
+func (r *sqlLobbyRepository) resetReadiness(tx *gorm.DB, lobby *models.Lobby) error {
+	return currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).Update("ready", false).Error
+}
+
+// StartRematchVote checks and opens the vote in a single statement, so that two players requesting a rematch at the
+// same time can not both open it.
+func (r *sqlLobbyRepository) StartRematchVote(lobby *models.Lobby, playerID uint, startedAt, deadline time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Lobby{}).
+			Where("lobby_id = ? AND rematch_lobby_id IS NULL", lobby.LobbyID).
+			Where("rematch_deadline IS NULL OR rematch_deadline <= ?", startedAt).
+			Update("rematch_deadline", deadline)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var current models.Lobby
+			if err := tx.Select("rematch_lobby_id").First(&current, "lobby_id = ?", lobby.LobbyID).Error; err != nil {
+				return err
+			}
+			if current.RematchLobbyID != nil {
+				return ErrRematchCreated
+			}
+			return ErrRematchVoteRunning
+		}
+
+		return currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).
+			Update("rematch_accepted", gorm.Expr("user_id = ?", playerID)).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.RematchDeadline = &deadline
+	for i := range lobby.Players {
+		lobby.Players[i].RematchAccepted = lobby.Players[i].UserID == playerID
+	}
+	return nil
+}
+
+func (r *sqlLobbyRepository) SetRematchAccepted(lobby *models.Lobby, playerID uint) error {
+	return currentMembers(r.db).
+		Where("user_id = ? AND lobby_id = ?", playerID, lobby.LobbyID).
+		Update("rematch_accepted", true).Error
+}
+
+func (r *sqlLobbyRepository) EndRematchVote(lobby *models.Lobby) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).Update("rematch_accepted", false).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(lobby).Update("rematch_deadline", nil).Error
+	})
+}
+
+// CreateRematch claims the finished lobby before creating the rematch: of two players accepting last at the same
+// time, only the first one creates it.
+func (r *sqlLobbyRepository) CreateRematch(lobby *models.Lobby, rematch *models.Lobby) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Lobby{}).
+			Where("lobby_id = ? AND rematch_lobby_id IS NULL", lobby.LobbyID).
+			Updates(map[string]any{"rematch_lobby_id": rematch.LobbyID, "rematch_deadline": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRematchCreated
+		}
+
+		for _, player := range rematch.Players {
+			busy, err := inActiveLobby(tx, player.UserID)
+			if err != nil {
+				return err
+			}
+			if busy {
+				return ErrPlayerInLobby
+			}
+		}
+		return tx.Omit("Players.User").Create(rematch).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	lobby.RematchLobbyID = &rematch.LobbyID
+	lobby.RematchDeadline = nil
+	return nil
+}
+
+// CloseWaiting cancels the lobby and releases its players, only if the lobby is still WAITING. The status check and
+// the update are a single statement, so concurrent callers cannot both close the lobby: the losers get
+// ErrLobbyNotWaiting.
+func (r *sqlLobbyRepository) CloseWaiting(lobbyID string, reason models.LobbyCloseReason, closedAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Lobby{}).
+			Where("lobby_id = ? AND status = ?", lobbyID, models.LobbyStatusWaiting).
+			Updates(map[string]any{
+				"status":       models.LobbyStatusCancelled,
+				"close_reason": reason,
+				"closed_at":    closedAt,
+				"version":      gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrLobbyNotWaiting
+		}
+
+		return currentMembers(tx).
+			Where("lobby_id = ?", lobbyID).
+			Updates(map[string]any{"left_at": closedAt, "ready": false}).Error
+	})
 }
 
 func (r *sqlLobbyRepository) Delete(lobbyID string) error {
@@ -53,11 +1077,223 @@ func (r *sqlLobbyRepository) Delete(lobbyID string) error {
 	if err := r.db.First(&lobby, "lobby_id = ?", lobbyID).Error; err != nil {
 		return ErrLobbyNotFound
 	}
+	return deleteLobby(r.db, &lobby)
+}
 
-	if err := r.db.Model(&lobby).Association("Players").Clear(); err != nil {
+// deleteLobby keeps the memberships and the spectators of the lobby, but they end with it.
+func deleteLobby(tx *gorm.DB, lobby *models.Lobby) error {
+	if err := currentMembers(tx).Where("lobby_id = ?", lobby.LobbyID).Update("left_at", tx.NowFunc()).Error; err != nil {
 		return ErrLobbyCleanupFailed
 	}
+	if err := currentSpectators(tx).Where("lobby_id = ?", lobby.LobbyID).Update("left_at", tx.NowFunc()).Error; err != nil {
+		return ErrLobbyCleanupFailed
+	}
+
+	tx.Delete(lobby)
+	return nil
+}
+
+// Enqueue checks for the ticket of the user within the transaction, so that two searches of the same user can not
+// both be stored.
+func (r *sqlLobbyRepository) Enqueue(ticket *models.MatchTicket) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var tickets int64
+		if err := tx.Model(&models.MatchTicket{}).Where("user_id = ?", ticket.UserID).Count(&tickets).Error; err != nil {
+			return err
+		}
+		if tickets > 0 {
+			return ErrAlreadySearching
+		}
+		return tx.Omit("User").Create(ticket).Error
+	})
+}
+
+func (r *sqlLobbyRepository) FindTicket(userID uint) (*models.MatchTicket, error) {
+	var ticket models.MatchTicket
+	result := r.db.Preload("User").First(&ticket, "user_id = ?", userID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrTicketNotFound
+	}
+	return &ticket, result.Error
+}
+
+func (r *sqlLobbyRepository) Dequeue(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ? AND pending_match_id IS NULL", userID).Delete(&models.MatchTicket{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+
+		var tickets int64
+		if err := tx.Model(&models.MatchTicket{}).Where("user_id = ?", userID).Count(&tickets).Error; err != nil {
+			return err
+		}
+		if tickets > 0 {
+			return ErrTicketMatched
+		}
+		return ErrTicketNotFound
+	})
+}
+
+// withTickets preloads the tickets of the pending matches and their users, in queue order.
+func withTickets(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tickets", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("queued_at").Order("id")
+	}).Preload("Tickets.User")
+}
+
+// FormMatch picks the tickets before claiming them: when another match claims one of them in between, fewer tickets
+// are claimed and the match is not formed.
+func (r *sqlLobbyRepository) FormMatch(match *models.PendingMatch, size int) error {
+	var tickets []models.MatchTicket
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Preload("User").
+			Where("game_mode = ? AND region = ? AND pending_match_id IS NULL", match.GameMode, match.Region).
+			Order("queued_at").
+			Order("id").
+			Limit(size).
+			Find(&tickets).Error
+		if err != nil {
+			return err
+		}
+		if len(tickets) < size {
+			return ErrNotEnoughTickets
+		}
+
+		if err := tx.Omit("Tickets").Create(match).Error; err != nil {
+			return err
+		}
+		ticketIDs := make([]uint, len(tickets))
+		for i, ticket := range tickets {
+			ticketIDs[i] = ticket.ID
+		}
+		result := tx.Model(&models.MatchTicket{}).
+			Where("id IN ? AND pending_match_id IS NULL", ticketIDs).
+			Update("pending_match_id", match.MatchID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < int64(size) {
+			return ErrNotEnoughTickets
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range tickets {
+		tickets[i].PendingMatchID = &match.MatchID
+	}
+	match.Tickets = tickets
+	return nil
+}
+
+func (r *sqlLobbyRepository) FindMatch(matchID string) (*models.PendingMatch, error) {
+	var match models.PendingMatch
+	result := withTickets(r.db).First(&match, "match_id = ?", matchID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrMatchNotFound
+	}
+	return &match, result.Error
+}
+
+// endPending moves the match out of PENDING with the given changes. The update also serialises the changes to the
+// tickets of the match that follow it with the ones of the concurrent answers.
+func endPending(tx *gorm.DB, match *models.PendingMatch, changes map[string]any) error {
+	result := tx.Model(&models.PendingMatch{}).
+		Where("match_id = ? AND status = ?", match.MatchID, models.PendingMatchPending).
+		Updates(changes)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMatchOver
+	}
+	return nil
+}
+
+func (r *sqlLobbyRepository) AcceptMatch(match *models.PendingMatch, playerID uint, acceptedAt time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The match stays PENDING: only its update time is touched, to lock it.
+		if err := endPending(tx, match, map[string]any{"updated_at": tx.NowFunc()}); err != nil {
+			return err
+		}
+		return tx.Model(&models.MatchTicket{}).
+			Where("pending_match_id = ? AND user_id = ? AND accepted_at IS NULL", match.MatchID, playerID).
+			Update("accepted_at", acceptedAt).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range match.Tickets {
+		if match.Tickets[i].UserID == playerID && match.Tickets[i].AcceptedAt == nil {
+			match.Tickets[i].AcceptedAt = &acceptedAt
+		}
+	}
+	return nil
+}
+
+// CreateMatchLobby claims the match before creating the lobby: of two players accepting last at the same time, only
+// the first one creates it.
+func (r *sqlLobbyRepository) CreateMatchLobby(match *models.PendingMatch, lobby *models.Lobby) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		changes := map[string]any{"status": models.PendingMatchAccepted, "lobby_id": lobby.LobbyID}
+		if err := endPending(tx, match, changes); err != nil {
+			return err
+		}
+
+		for _, player := range lobby.Players {
+			busy, err := inActiveLobby(tx, player.UserID)
+			if err != nil {
+				return err
+			}
+			if busy {
+				return ErrPlayerInLobby
+			}
+		}
+		if err := tx.Where("pending_match_id = ?", match.MatchID).Delete(&models.MatchTicket{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("Players.User").Create(lobby).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	match.Status = models.PendingMatchAccepted
+	match.LobbyID = &lobby.LobbyID
+	match.Tickets = nil
+	return nil
+}
+
+func (r *sqlLobbyRepository) FailMatch(match *models.PendingMatch, status models.PendingMatchStatus,
+	droppedPlayerIDs []uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := endPending(tx, match, map[string]any{"status": status}); err != nil {
+			return err
+		}
+
+		if len(droppedPlayerIDs) > 0 {
+			err := tx.Where("pending_match_id = ? AND user_id IN ?", match.MatchID, droppedPlayerIDs).
+				Delete(&models.MatchTicket{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Model(&models.MatchTicket{}).
+			Where("pending_match_id = ?", match.MatchID).
+			Updates(map[string]any{"pending_match_id": nil, "accepted_at": nil}).Error
+	})
+	if err != nil {
+		return err
+	}
 
-	r.db.Delete(&lobby)
+	match.Status = status
+	match.Tickets = nil
 	return nil
 }
@@ -1,10 +1,17 @@
 package lobby
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -15,6 +22,8 @@ const (
 	fixtureLobbyCondition = "lobby_id = ?"
 )
 
+var fixtureCreatedAt = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
 type LobbySQLRepositoryTestSuite struct {
 	suite.Suite
 	db        *gorm.DB
@@ -34,27 +43,52 @@ func (s *LobbySQLRepositoryTestSuite) TearDownSuite() {
 }
 
 func (s *LobbySQLRepositoryTestSuite) SetupTest() {
-	err := s.db.Migrator().DropTable(&models.User{}, &models.Lobby{})
+	tables := []any{&models.User{}, &models.Lobby{}, &models.LobbyPlayer{}, &models.LobbySpectator{}, &models.LobbyTimer{},
+		&models.Presence{}, &models.PendingMatch{}, &models.MatchTicket{}}
+	err := s.db.Migrator().DropTable(tables...)
 	s.Require().NoError(err)
-	err = s.db.AutoMigrate(&models.User{}, &models.Lobby{})
+	err = s.db.AutoMigrate(tables...)
 	s.Require().NoError(err)
 
 	s.lobbyRepo = NewSQLLobbyRepository(s.db)
 }
 
+// createUserInDB creates the user, seated in the lobby when lobbyID is not nil.
 func (s *LobbySQLRepositoryTestSuite) createUserInDB(username string, lobbyID *string) models.User {
-	user := models.User{Username: username, Password: "password", LobbyID: lobbyID}
+	user := models.User{Username: username, Password: "password"}
 	err := s.db.Create(&user).Error
 	s.Require().NoError(err)
+	if lobbyID != nil {
+		var seats int64
+		s.db.Model(&models.LobbyPlayer{}).Where("lobby_id = ?", *lobbyID).Count(&seats)
+		membership := models.LobbyPlayer{LobbyID: *lobbyID, UserID: user.ID, Seat: int(seats)}
+		s.Require().NoError(s.db.Create(&membership).Error)
+	}
 	return user
 }
 
+// currentLobbyOf returns the lobby of the latest membership of the user that did not end, or nil.
+func (s *LobbySQLRepositoryTestSuite) currentLobbyOf(userID uint) *string {
+	var memberships []models.LobbyPlayer
+	s.db.Where("user_id = ? AND left_at IS NULL", userID).Order("id DESC").Find(&memberships)
+	if len(memberships) == 0 {
+		return nil
+	}
+	return &memberships[0].LobbyID
+}
+
+func (s *LobbySQLRepositoryTestSuite) membership(lobbyID string, userID uint) models.LobbyPlayer {
+	var membership models.LobbyPlayer
+	s.Require().NoError(s.db.First(&membership, "lobby_id = ? AND user_id = ?", lobbyID, userID).Error)
+	return membership
+}
+
 func (s *LobbySQLRepositoryTestSuite) TestCreateSuccess() {
 	creator := s.createUserInDB("creator", nil)
 	lobbyToCreate := &models.Lobby{
 		LobbyID: uuid.New().String(),
 		Name:    fixtureLobbyName,
-		Players: []models.User{creator},
+		Players: []models.LobbyPlayer{{UserID: creator.ID}},
 	}
 
 	err := s.lobbyRepo.Create(lobbyToCreate)
@@ -63,9 +97,82 @@ func (s *LobbySQLRepositoryTestSuite) TestCreateSuccess() {
 	s.NoError(err)
 	s.Equal(lobbyToCreate.Name, addedLobby.Name)
 	s.Equal(lobbyToCreate.LobbyID, addedLobby.LobbyID)
-	var updatedCreator models.User
-	s.db.First(&updatedCreator, creator.ID)
-	s.Equal(lobbyToCreate.LobbyID, *updatedCreator.LobbyID)
+	s.Equal(lobbyToCreate.LobbyID, *s.currentLobbyOf(creator.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateKeepsTheUsersOfThePlayersAsTheyAre() {
+	creator := s.createUserInDB("creator", nil)
+	lobbyToCreate := &models.Lobby{
+		LobbyID: uuid.New().String(),
+		Name:    fixtureLobbyName,
+		Players: []models.LobbyPlayer{{UserID: creator.ID, User: models.User{Username: "renamed"}}},
+	}
+
+	err := s.lobbyRepo.Create(lobbyToCreate)
+
+	s.NoError(err)
+	var users []models.User
+	s.db.Find(&users)
+	s.Require().Len(users, 1)
+	s.Equal("creator", users[0].Username)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateFailsWhenTheCreatorIsInAnActiveLobby() {
+	activeLobby := s.createLobbyInDB("Active", models.LobbyStatusReadyCheck)
+	creator := s.createUserInDB("creator", &activeLobby.LobbyID)
+	lobbyToCreate := &models.Lobby{
+		LobbyID: uuid.New().String(),
+		Name:    fixtureLobbyName,
+		Players: []models.LobbyPlayer{{UserID: creator.ID}},
+	}
+
+	err := s.lobbyRepo.Create(lobbyToCreate)
+
+	s.ErrorIs(err, ErrPlayerInLobby)
+	s.Equal(activeLobby.LobbyID, *s.currentLobbyOf(creator.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateSucceedsWhenTheCreatorsLastGameIsOver() {
+	finishedLobby := s.createLobbyInDB("Finished", models.LobbyStatusFinished)
+	creator := s.createUserInDB("creator", &finishedLobby.LobbyID)
+	lobbyToCreate := &models.Lobby{
+		LobbyID: uuid.New().String(),
+		Name:    fixtureLobbyName,
+		Players: []models.LobbyPlayer{{UserID: creator.ID}},
+	}
+
+	err := s.lobbyRepo.Create(lobbyToCreate)
+
+	s.NoError(err)
+	s.Equal(lobbyToCreate.LobbyID, *s.currentLobbyOf(creator.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFindActiveByPlayerSuccess() {
+	lobby := s.createLobbyInDB("Mine", models.LobbyStatusInProgress)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+
+	foundLobby, err := s.lobbyRepo.FindActiveByPlayer(player.ID)
+
+	s.NoError(err)
+	s.Equal(lobby.LobbyID, foundLobby.LobbyID)
+	s.Len(foundLobby.Players, 1)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFindActiveByPlayerSkipsTheFinishedGames() {
+	lobby := s.createLobbyInDB("Over", models.LobbyStatusFinished)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+
+	_, err := s.lobbyRepo.FindActiveByPlayer(player.ID)
+
+	s.ErrorIs(err, ErrLobbyNotFound)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFindActiveByPlayerWhenThePlayerIsInNoLobby() {
+	player := s.createUserInDB("player1", nil)
+
+	_, err := s.lobbyRepo.FindActiveByPlayer(player.ID)
+
+	s.ErrorIs(err, ErrLobbyNotFound)
 }
 
 func (s *LobbySQLRepositoryTestSuite) TestFindByIdSuccess() {
@@ -77,6 +184,16 @@ func (s *LobbySQLRepositoryTestSuite) TestFindByIdSuccess() {
 	s.Len(foundLobby.Players, 1)
 }
 
+func (s *LobbySQLRepositoryTestSuite) TestFindByIdLoadsTheScheduledTimers() {
+	lobby := s.createLobbyInDB("FindMe", models.LobbyStatusInProgress)
+	timer := models.LobbyTimer{LobbyID: lobby.LobbyID, Kind: models.LobbyTimerGameEnd, FiresAt: time.Now().UTC()}
+	s.Require().NoError(s.db.Create(&timer).Error)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.NoError(err)
+	s.Require().Len(foundLobby.Timers, 1)
+	s.Equal(models.LobbyTimerGameEnd, foundLobby.Timers[0].Kind)
+}
+
 func (s *LobbySQLRepositoryTestSuite) createLobbyInDB(name string, status models.LobbyStatus) models.Lobby {
 	lobby := models.Lobby{
 		LobbyID: uuid.New().String(),
@@ -98,81 +215,1824 @@ func (s *LobbySQLRepositoryTestSuite) TestListAvailableWhenThereAreWaitingLobbie
 	s.createLobbyInDB("Lobby 1", models.LobbyStatusWaiting)
 	s.createLobbyInDB("Lobby 2", models.LobbyStatusWaiting)
 	s.createLobbyInDB("Lobby 3", models.LobbyStatusInProgress)
-	lobbies := s.lobbyRepo.ListAvailable()
+	lobbies, err := s.lobbyRepo.ListAvailable(AvailableFilter{})
+	s.NoError(err)
 	s.Len(lobbies, 2)
 }
 
+func (s *LobbySQLRepositoryTestSuite) TestListAvailableSkipsUnlistedAndPrivateLobbies() {
+	s.createLobbyInDB("Public Lobby", models.LobbyStatusWaiting)
+	for _, visibility := range []models.LobbyVisibility{models.LobbyVisibilityUnlisted, models.LobbyVisibilityPrivate} {
+		hidden := models.Lobby{LobbyID: uuid.New().String(), Name: "Hidden", Visibility: visibility}
+		s.Require().NoError(s.db.Create(&hidden).Error)
+	}
+	lobbies, err := s.lobbyRepo.ListAvailable(AvailableFilter{})
+	s.NoError(err)
+	s.Len(lobbies, 1)
+	s.Equal("Public Lobby", lobbies[0].Name)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListAvailableSkipsLockedLobbies() {
+	s.createLobbyInDB("Open Lobby", models.LobbyStatusWaiting)
+	locked := s.createLobbyInDB("Locked Lobby", models.LobbyStatusWaiting)
+	s.Require().NoError(s.lobbyRepo.SetLocked(&locked, true))
+
+	lobbies, err := s.lobbyRepo.ListAvailable(AvailableFilter{})
+
+	s.NoError(err)
+	s.Require().Len(lobbies, 1)
+	s.Equal("Open Lobby", lobbies[0].Name)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListAvailableSkipsTheLobbiesOfDisconnectedHosts() {
+	at := fixtureCreatedAt
+	later := at.Add(time.Minute)
+	earlier := at.Add(-time.Minute)
+	for _, host := range []struct {
+		name      string
+		expiresAt *time.Time
+	}{
+		{"connected", &later},
+		{"disconnected", &earlier},
+		{"untracked", nil},
+	} {
+		user := s.createUserInDB(host.name, nil)
+		if host.expiresAt != nil {
+			s.Require().NoError(s.db.Create(&models.Presence{UserID: user.ID, ExpiresAt: *host.expiresAt}).Error)
+		}
+		lobby := models.Lobby{LobbyID: host.name, Name: host.name, HostID: &user.ID}
+		s.Require().NoError(s.db.Create(&lobby).Error)
+	}
+
+	lobbies, err := s.lobbyRepo.ListAvailable(AvailableFilter{OnlineAt: at})
+
+	s.NoError(err)
+	s.ElementsMatch([]string{"connected", "untracked"}, lobbyIDs(lobbies))
+}
+
+// createLobbyCreatedAt stores a waiting public lobby created minutesAgo minutes before fixtureCreatedAt.
+func (s *LobbySQLRepositoryTestSuite) createLobbyCreatedAt(lobbyID, name string, minutesAgo int) models.Lobby {
+	lobby := models.Lobby{
+		LobbyID:   lobbyID,
+		Name:      name,
+		CreatedAt: fixtureCreatedAt.Add(-time.Duration(minutesAgo) * time.Minute),
+	}
+	s.Require().NoError(s.db.Create(&lobby).Error)
+	return lobby
+}
+
+func lobbyIDs(lobbies []*models.Lobby) []string {
+	ids := make([]string, len(lobbies))
+	for i, lobby := range lobbies {
+		ids[i] = lobby.LobbyID
+	}
+	return ids
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListAvailablePagesThroughTheNewestLobbies() {
+	s.createLobbyCreatedAt("a", "Alpha", 30)
+	s.createLobbyCreatedAt("b", "Bravo", 10)
+	s.createLobbyCreatedAt("c", "Charlie", 10)
+	s.createLobbyCreatedAt("d", "Delta", 20)
+
+	firstPage, err := s.lobbyRepo.ListAvailable(AvailableFilter{Limit: 2})
+	s.Require().NoError(err)
+	last := firstPage[len(firstPage)-1]
+	secondPage, err := s.lobbyRepo.ListAvailable(AvailableFilter{
+		Limit: 2,
+		After: &AvailableCursor{CreatedAt: last.CreatedAt, Name: last.Name, LobbyID: last.LobbyID},
+	})
+	s.Require().NoError(err)
+
+	s.Equal([]string{"b", "c"}, lobbyIDs(firstPage))
+	s.Equal([]string{"d", "a"}, lobbyIDs(secondPage))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListAvailableSortsByAgeAndName() {
+	s.createLobbyCreatedAt("a", "Charlie", 30)
+	s.createLobbyCreatedAt("b", "Alpha", 10)
+	s.createLobbyCreatedAt("c", "Bravo", 20)
+
+	oldest, err := s.lobbyRepo.ListAvailable(AvailableFilter{Sort: SortOldest})
+	s.NoError(err)
+	byName, err := s.lobbyRepo.ListAvailable(AvailableFilter{Sort: SortByName, After: &AvailableCursor{Name: "Alpha", LobbyID: "b"}})
+	s.NoError(err)
+
+	s.Equal([]string{"a", "c", "b"}, lobbyIDs(oldest))
+	s.Equal([]string{"c", "a"}, lobbyIDs(byName))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListAvailableFiltersTheLobbies() {
+	s.createLobbyCreatedAt("old", "Old friends", 120)
+	s.createLobbyCreatedAt("full", "Friday fun", 5)
+	s.createLobbyCreatedAt("open", "FRIDAY night", 5)
+	s.createLobbyCreatedAt("wild", "100% fun_", 5)
+	fullLobbyID := "full"
+	s.createUserInDB("player1", &fullLobbyID)
+	s.createUserInDB("player2", &fullLobbyID)
+
+	byName, err := s.lobbyRepo.ListAvailable(AvailableFilter{NameContains: "fri", Sort: SortByName})
+	s.NoError(err)
+	withWildcards, err := s.lobbyRepo.ListAvailable(AvailableFilter{NameContains: "0% FUN_"})
+	s.NoError(err)
+	notFull, err := s.lobbyRepo.ListAvailable(AvailableFilter{NameContains: "fri", MinFreeSlots: 1, Sort: SortByName})
+	s.NoError(err)
+	recent, err := s.lobbyRepo.ListAvailable(AvailableFilter{
+		CreatedAfter:  fixtureCreatedAt.Add(-time.Hour),
+		CreatedBefore: fixtureCreatedAt,
+		Sort:          SortByName,
+	})
+	s.NoError(err)
+
+	s.Equal([]string{"open", "full", "old"}, lobbyIDs(byName))
+	s.Equal([]string{"wild"}, lobbyIDs(withWildcards))
+	s.Equal([]string{"open", "old"}, lobbyIDs(notFull))
+	s.Equal([]string{"wild", "open", "full"}, lobbyIDs(recent))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListAvailableFiltersByGameModeRegionAndFreeSlots() {
+	s.createLobbyCreatedAt("duel", "Duel", 5)
+	for _, lobby := range []models.Lobby{
+		{LobbyID: "ffa-eu", Name: "Free for all", GameMode: "FREE_FOR_ALL", Region: "EU", MaxPlayers: 4},
+		{LobbyID: "ffa-na", Name: "Free for all", GameMode: "FREE_FOR_ALL", Region: "NA", MaxPlayers: 4},
+	} {
+		s.Require().NoError(s.db.Create(&lobby).Error)
+	}
+	duelLobbyID, ffaLobbyID := "duel", "ffa-eu"
+	s.createUserInDB("player1", &duelLobbyID)
+	s.createUserInDB("player2", &ffaLobbyID)
+	s.createUserInDB("player3", &ffaLobbyID)
+
+	freeForAll, err := s.lobbyRepo.ListAvailable(AvailableFilter{GameMode: "FREE_FOR_ALL", Sort: SortOldest})
+	s.NoError(err)
+	inNorthAmerica, err := s.lobbyRepo.ListAvailable(AvailableFilter{Region: "NA"})
+	s.NoError(err)
+	withTwoFreeSlots, err := s.lobbyRepo.ListAvailable(AvailableFilter{MinFreeSlots: 2, Sort: SortOldest})
+	s.NoError(err)
+
+	s.Equal([]string{"ffa-eu", "ffa-na"}, lobbyIDs(freeForAll))
+	s.Equal([]string{"ffa-na"}, lobbyIDs(inNorthAmerica))
+	s.Equal([]string{"ffa-eu", "ffa-na"}, lobbyIDs(withTwoFreeSlots))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateStoresTheGameModeAndSettings() {
+	lobby := &models.Lobby{
+		LobbyID:    "ffa",
+		Name:       "Free for all",
+		GameMode:   "FREE_FOR_ALL",
+		Region:     "ASIA",
+		Settings:   map[string]string{"map": "RUINS", "time_limit": "20"},
+		MaxPlayers: 4,
+	}
+	s.Require().NoError(s.lobbyRepo.Create(lobby))
+
+	found, err := s.lobbyRepo.FindByID("ffa")
+
+	s.Require().NoError(err)
+	s.Equal("FREE_FOR_ALL", found.GameMode)
+	s.Equal("ASIA", found.Region)
+	s.Equal(map[string]string{"map": "RUINS", "time_limit": "20"}, found.Settings)
+	s.Equal(4, found.MaxPlayers)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFindByJoinCodeSuccess() {
+	joinCode := "ABC234"
+	lobby := models.Lobby{LobbyID: uuid.New().String(), Name: fixtureLobbyName, JoinCode: &joinCode}
+	s.Require().NoError(s.db.Create(&lobby).Error)
+	s.createUserInDB("player1", &lobby.LobbyID)
+	foundLobby, err := s.lobbyRepo.FindByJoinCode(joinCode)
+	s.NoError(err)
+	s.Equal(lobby.LobbyID, foundLobby.LobbyID)
+	s.Len(foundLobby.Players, 1)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFindByJoinCodeNotFound() {
+	lobby, err := s.lobbyRepo.FindByJoinCode("ZZZZZZ")
+	s.ErrorIs(err, ErrLobbyNotFound)
+	s.Empty(lobby)
+}
+
 func (s *LobbySQLRepositoryTestSuite) TestListAvailableWhenThereAreNotWaitingLobbies() {
 	s.createLobbyInDB("Full Lobby", models.LobbyStatusInProgress)
-	lobbies := s.lobbyRepo.ListAvailable()
+	lobbies, err := s.lobbyRepo.ListAvailable(AvailableFilter{})
+	s.NoError(err)
 	s.Empty(lobbies)
 }
 
 func (s *LobbySQLRepositoryTestSuite) TestAddPlayerSuccess() {
 	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
 	player := s.createUserInDB("new_player", nil)
-	err := s.lobbyRepo.AddPlayer(&lobby, &player)
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 2)
 	s.NoError(err)
-	var updatedPlayer models.User
-	s.db.First(&updatedPlayer, player.ID)
-	s.Equal(lobby.LobbyID, *updatedPlayer.LobbyID)
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(player.ID))
 	s.Len(lobby.Players, 1)
+	s.Equal(uint(1), lobby.Version)
 }
 
-func (s *LobbySQLRepositoryTestSuite) TestUpdateStatusSuccess() {
-	lobby := s.createLobbyInDB("Status Test Lobby", models.LobbyStatusWaiting)
-	err := s.lobbyRepo.UpdateStatus(&lobby, models.LobbyStatusInProgress)
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerTakesTheFirstFreeSeat() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	first := s.createUserInDB("first", &lobby.LobbyID)
+	s.createUserInDB("second", &lobby.LobbyID)
+	leftAt := time.Now().UTC()
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", first.ID).Update("left_at", leftAt).Error)
+	player := s.createUserInDB("new_player", nil)
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 2)
+
 	s.NoError(err)
-	var updatedLobby models.Lobby
-	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
-	s.Equal(models.LobbyStatusInProgress, updatedLobby.Status)
+	membership := s.membership(lobby.LobbyID, player.ID)
+	s.Equal(0, membership.Seat)
+	s.False(membership.JoinedAt.IsZero())
+	s.Require().Len(lobby.Players, 1)
+	s.Equal("new_player", lobby.Players[0].User.Username)
 }
 
-func (s *LobbySQLRepositoryTestSuite) TestUpdateWinnerSuccess() {
-	lobby := s.createLobbyInDB("Winner Test Lobby", models.LobbyStatusInProgress)
-	winner := s.createUserInDB("the_winner", &lobby.LobbyID)
-	err := s.lobbyRepo.UpdateWinner(&lobby, winner.ID)
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerSucceedsWhenThePlayerLeftTheLobbyBefore() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+	leftAt := time.Now().UTC()
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).Update("left_at", leftAt).Error)
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 2)
+
 	s.NoError(err)
-	var updatedLobby models.Lobby
-	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
-	s.Equal(winner.ID, *updatedLobby.WinnerID)
+	var memberships int64
+	s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).Count(&memberships)
+	s.Equal(int64(2), memberships)
 }
 
-func (s *LobbySQLRepositoryTestSuite) TestDeleteSuccess() {
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerFailsWhenTheLobbyIsFull() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.createUserInDB("creator", &lobby.LobbyID)
+	player := s.createUserInDB("new_player", nil)
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 1)
+
+	s.ErrorIs(err, ErrLobbyFull)
+	s.Nil(s.currentLobbyOf(player.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayersSeatsTheWholeGroup() {
+	lobby, users := s.createTeamLobbyInDB("creator")
+	friend := s.createUserInDB("friend", nil)
+	other := s.createUserInDB("other", nil)
+
+	err := s.lobbyRepo.AddPlayers(&lobby, []*models.User{&friend, &other}, 4)
+
+	s.NoError(err)
+	s.Require().Len(lobby.Players, 3)
+	s.Equal(1, s.membership(lobby.LobbyID, friend.ID).Seat)
+	s.Equal(2, *s.membership(lobby.LobbyID, friend.ID).Team)
+	s.Equal(2, s.membership(lobby.LobbyID, other.ID).Seat)
+	s.Equal(1, *s.membership(lobby.LobbyID, other.ID).Team)
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(users[0].ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayersFailsWithoutRoomForTheWholeGroup() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.createUserInDB("creator", &lobby.LobbyID)
+	friend := s.createUserInDB("friend", nil)
+	other := s.createUserInDB("other", nil)
+
+	err := s.lobbyRepo.AddPlayers(&lobby, []*models.User{&friend, &other}, 2)
+
+	s.ErrorIs(err, ErrLobbyFull)
+	s.Nil(s.currentLobbyOf(friend.ID))
+	s.Nil(s.currentLobbyOf(other.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayersSeatsNobodyWhenAPlayerIsInAnotherActiveLobby() {
+	otherLobby := s.createLobbyInDB("Other", models.LobbyStatusWaiting)
+	busy := s.createUserInDB("busy", &otherLobby.LobbyID)
+	friend := s.createUserInDB("friend", nil)
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+
+	err := s.lobbyRepo.AddPlayers(&lobby, []*models.User{&friend, &busy}, 4)
+
+	s.ErrorIs(err, ErrPlayerInLobby)
+	s.Nil(s.currentLobbyOf(friend.ID))
+	s.Empty(lobby.Players)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerFailsWhenTheLobbyIsNotWaiting() {
 	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
-	player1 := s.createUserInDB("player1", &lobby.LobbyID)
-	player2 := s.createUserInDB("player2", &lobby.LobbyID)
-	err := s.lobbyRepo.Delete(lobby.LobbyID)
+	player := s.createUserInDB("new_player", nil)
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 2)
+
+	s.ErrorIs(err, ErrLobbyNotWaiting)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerFailsWhenThePlayerIsAlreadyInTheLobby() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	creator := s.createUserInDB("creator", &lobby.LobbyID)
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &creator, 2)
+
+	s.ErrorIs(err, ErrPlayerInLobby)
+	s.Empty(lobby.Players)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerFailsWhenThePlayerIsInAnotherActiveLobby() {
+	otherLobby := s.createLobbyInDB("Other", models.LobbyStatusWaiting)
+	player := s.createUserInDB("player", &otherLobby.LobbyID)
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 2)
+
+	s.ErrorIs(err, ErrPlayerInLobby)
+	s.Equal(otherLobby.LobbyID, *s.currentLobbyOf(player.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerFailsWhenTheLobbyChangedSinceItWasRead() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	staleLobby := lobby
+	first := s.createUserInDB("first", nil)
+	second := s.createUserInDB("second", nil)
+	s.Require().NoError(s.lobbyRepo.AddPlayer(&lobby, &first, 3))
+
+	err := s.lobbyRepo.AddPlayer(&staleLobby, &second, 3)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+	s.Nil(s.currentLobbyOf(second.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerFailsWhenTheLobbyIsLocked() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.createUserInDB("creator", &lobby.LobbyID)
+	player := s.createUserInDB("player", nil)
+	s.Require().NoError(s.lobbyRepo.SetLocked(&lobby, true))
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 2)
+
+	s.ErrorIs(err, ErrLobbyLocked)
+	s.Nil(s.currentLobbyOf(player.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestKickPlayerRemovesAndBansThePlayer() {
+	lobby, users := s.createTeamLobbyInDB("host", "troll")
+	bannedUntil := fixtureCreatedAt.Add(5 * time.Minute)
+
+	err := s.lobbyRepo.KickPlayer(&lobby, users[1].ID, bannedUntil)
+
 	s.NoError(err)
-	err = s.db.First(&lobby, fixtureLobbyCondition, lobby.LobbyID).Error
-	s.ErrorIs(err, gorm.ErrRecordNotFound)
-	var updatedPlayer1 models.User
-	s.db.First(&updatedPlayer1, player1.ID)
-	s.Empty(updatedPlayer1.LobbyID)
-	var updatedPlayer2 models.User
-	s.db.First(&updatedPlayer2, player2.ID)
-	s.Empty(updatedPlayer2.LobbyID)
+	s.Require().Len(lobby.Players, 1)
+	s.Equal(users[0].ID, lobby.Players[0].UserID)
+	s.Nil(s.currentLobbyOf(users[1].ID))
+	until, err := s.lobbyRepo.BannedUntil(lobby.LobbyID, users[1].ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(until)
+	s.True(bannedUntil.Equal(*until))
 }
 
-func (s *LobbySQLRepositoryTestSuite) TestDeleteWhenAssociationClearFails() {
+func (s *LobbySQLRepositoryTestSuite) TestKickPlayerFailsWhenThePlayerIsNotInTheLobby() {
+	lobby, _ := s.createTeamLobbyInDB("host")
+	stranger := s.createUserInDB("stranger", nil)
+
+	err := s.lobbyRepo.KickPlayer(&lobby, stranger.ID, fixtureCreatedAt)
+
+	s.ErrorIs(err, ErrPlayerNotInLobby)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestKickPlayerFailsWhenTheLobbyChangedSinceItWasRead() {
+	lobby, users := s.createTeamLobbyInDB("host", "troll")
+	stale := lobby
+	s.Require().NoError(s.lobbyRepo.SwitchTeam(&lobby, users[0].ID, 2, 2))
+
+	err := s.lobbyRepo.KickPlayer(&stale, users[1].ID, fixtureCreatedAt)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(users[1].ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestLeaveWaitingPassesTheHostToTheNextSeat() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	host := s.createUserInDB("host", &lobby.LobbyID)
+	nextHost := s.createUserInDB("next", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.UpdateHost(&lobby, host.ID))
+
+	err := s.lobbyRepo.LeaveWaiting(&lobby, host.ID, fixtureCreatedAt)
+
+	s.NoError(err)
+	s.Nil(s.currentLobbyOf(host.ID))
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(models.LobbyStatusWaiting, foundLobby.Status)
+	s.Equal(nextHost.ID, *foundLobby.HostID)
+	s.Require().Len(foundLobby.Players, 1)
+	s.Equal(nextHost.ID, foundLobby.Players[0].UserID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestLeaveWaitingClosesTheLobbyNobodyIsLeftIn() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+
+	err := s.lobbyRepo.LeaveWaiting(&lobby, player.ID, fixtureCreatedAt)
+
+	s.NoError(err)
+	s.Empty(lobby.Players)
+	s.Equal(models.LobbyStatusCancelled, lobby.Status)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(models.LobbyStatusCancelled, foundLobby.Status)
+	s.Equal(models.LobbyCloseEmpty, *foundLobby.CloseReason)
+	s.True(fixtureCreatedAt.Equal(*foundLobby.ClosedAt))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestLeaveWaitingFailsWhenTheLobbyChangedSinceItWasRead() {
+	lobby, users := s.createTeamLobbyInDB("host", "player")
+	stale := lobby
+	s.Require().NoError(s.lobbyRepo.SwitchTeam(&lobby, users[0].ID, 2, 2))
+
+	err := s.lobbyRepo.LeaveWaiting(&stale, users[1].ID, fixtureCreatedAt)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(users[1].ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestLeaveGameRecordsThatThePlayerAbandonedIt() {
 	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
-	s.createUserInDB("a_player", &lobby.LobbyID)
+	host := s.createUserInDB("host", &lobby.LobbyID)
+	nextHost := s.createUserInDB("next", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.UpdateHost(&lobby, host.ID))
 
-	err := s.db.Migrator().DropTable(&models.User{})
-	s.Require().NoError(err, "Dropping user table for test setup should not fail")
+	err := s.lobbyRepo.LeaveGame(&lobby, host.ID, fixtureCreatedAt)
 
-	deleteErr := s.lobbyRepo.Delete(lobby.LobbyID)
-	s.ErrorIs(deleteErr, ErrLobbyCleanupFailed)
+	s.NoError(err)
+	s.Nil(s.currentLobbyOf(host.ID))
+	membership := s.membership(lobby.LobbyID, host.ID)
+	s.True(fixtureCreatedAt.Equal(*membership.AbandonedAt))
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(models.LobbyStatusInProgress, foundLobby.Status)
+	s.Equal(nextHost.ID, *foundLobby.HostID)
+}
 
-	// Verify the lobby was NOT deleted, as the process failed before the final deletion step.
-	var foundLobby models.Lobby
-	findErr := s.db.First(&foundLobby, fixtureLobbyCondition, lobby.LobbyID).Error
-	s.NoError(findErr, "Lobby should still exist because the transaction should have failed")
+func (s *LobbySQLRepositoryTestSuite) TestLeaveGameFailsOnceTheGameIsOver() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+
+	err := s.lobbyRepo.LeaveGame(&lobby, player.ID, fixtureCreatedAt)
+
+	s.ErrorIs(err, ErrLobbyNotInProgress)
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(player.ID))
 }
 
-func (s *LobbySQLRepositoryTestSuite) TestDeleteNotFound() {
-	err := s.lobbyRepo.Delete("non-existent-id")
-	s.ErrorIs(err, ErrLobbyNotFound)
+func (s *LobbySQLRepositoryTestSuite) TestBannedUntilWhenTheUserWasNeverKicked() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+
+	until, err := s.lobbyRepo.BannedUntil(lobby.LobbyID, player.ID)
+
+	s.NoError(err)
+	s.Nil(until)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestUpdateHostRenameAndSetLocked() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+
+	s.NoError(s.lobbyRepo.UpdateHost(&lobby, player.ID))
+	s.NoError(s.lobbyRepo.Rename(&lobby, "Renamed"))
+	s.NoError(s.lobbyRepo.SetLocked(&lobby, true))
+
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(player.ID, *foundLobby.HostID)
+	s.Equal("Renamed", foundLobby.Name)
+	s.True(foundLobby.Locked)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestUpdateHostFailsOnceTheNewHostLeft() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	host := s.createUserInDB("host", &lobby.LobbyID)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.UpdateHost(&lobby, host.ID))
+	stale := lobby
+	s.Require().NoError(s.lobbyRepo.LeaveWaiting(&lobby, player.ID, time.Now().UTC()))
+
+	err := s.lobbyRepo.UpdateHost(&stale, player.ID)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(host.ID, *foundLobby.HostID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestRenameAndSetLockedFailOnceTheStatusChanged() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	err := s.db.Model(&models.Lobby{}).Where(fixtureLobbyCondition, lobby.LobbyID).
+		Update("status", models.LobbyStatusInProgress).Error
+	s.Require().NoError(err)
+
+	s.ErrorIs(s.lobbyRepo.Rename(&lobby, "Renamed"), ErrLobbyConflict)
+	s.ErrorIs(s.lobbyRepo.SetLocked(&lobby, true), ErrLobbyConflict)
+
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(fixtureLobbyName, foundLobby.Name)
+	s.False(foundLobby.Locked)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddSpectatorSuccess() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+	s.createUserInDB("player", &lobby.LobbyID)
+	spectator := s.createUserInDB("spectator", nil)
+
+	err := s.lobbyRepo.AddSpectator(&lobby, &spectator, 1)
+
+	s.NoError(err)
+	s.Require().Len(lobby.Spectators, 1)
+	s.Equal("spectator", lobby.Spectators[0].User.Username)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Require().Len(foundLobby.Spectators, 1)
+	s.Equal("spectator", foundLobby.Spectators[0].User.Username)
+	s.Len(foundLobby.Players, 1)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddSpectatorTwiceIsNotAnError() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	spectator := s.createUserInDB("spectator", nil)
+	s.Require().NoError(s.lobbyRepo.AddSpectator(&lobby, &spectator, 1))
+
+	err := s.lobbyRepo.AddSpectator(&lobby, &spectator, 1)
+
+	s.NoError(err)
+	s.Len(lobby.Spectators, 1)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddSpectatorFailsWhenThereIsNoRoomLeft() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	first := s.createUserInDB("first", nil)
+	second := s.createUserInDB("second", nil)
+	s.Require().NoError(s.lobbyRepo.AddSpectator(&lobby, &first, 1))
+
+	err := s.lobbyRepo.AddSpectator(&lobby, &second, 1)
+
+	s.ErrorIs(err, ErrSpectatorsFull)
+	s.Len(lobby.Spectators, 1)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddSpectatorFailsForAPlayerOfTheLobby() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+
+	err := s.lobbyRepo.AddSpectator(&lobby, &player, 1)
+
+	s.ErrorIs(err, ErrPlayerInLobby)
+	s.Empty(lobby.Spectators)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerEndsTheSpectatorRoleOfThePlayer() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player", nil)
+	s.Require().NoError(s.lobbyRepo.AddSpectator(&lobby, &player, 1))
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 2)
+
+	s.NoError(err)
+	s.Empty(lobby.Spectators)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Empty(foundLobby.Spectators)
+	s.Len(foundLobby.Players, 1)
+}
+
+// createTeamLobbyInDB creates a waiting lobby of two teams, with the players seated in order and alternating teams.
+func (s *LobbySQLRepositoryTestSuite) createTeamLobbyInDB(usernames ...string) (models.Lobby, []models.User) {
+	lobby := models.Lobby{LobbyID: uuid.New().String(), Name: fixtureLobbyName, Status: models.LobbyStatusWaiting, Teams: 2}
+	s.Require().NoError(s.db.Create(&lobby).Error)
+	users := make([]models.User, len(usernames))
+	for i, username := range usernames {
+		users[i] = models.User{Username: username, Password: "password"}
+		s.Require().NoError(s.db.Create(&users[i]).Error)
+		team := i%2 + 1
+		membership := models.LobbyPlayer{LobbyID: lobby.LobbyID, UserID: users[i].ID, Seat: i, Team: &team}
+		s.Require().NoError(s.db.Create(&membership).Error)
+	}
+	found, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	return *found, users
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAddPlayerJoinsTheSmallestTeam() {
+	lobby, users := s.createTeamLobbyInDB("first", "second", "third")
+	player := s.createUserInDB("new_player", nil)
+
+	err := s.lobbyRepo.AddPlayer(&lobby, &player, 4)
+
+	s.NoError(err)
+	s.Equal(2, *s.membership(lobby.LobbyID, player.ID).Team)
+	s.Equal(1, *s.membership(lobby.LobbyID, users[2].ID).Team)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSwitchTeamSuccess() {
+	lobby, users := s.createTeamLobbyInDB("first", "second")
+
+	err := s.lobbyRepo.SwitchTeam(&lobby, users[0].ID, 2, 2)
+
+	s.NoError(err)
+	s.Equal(2, *s.membership(lobby.LobbyID, users[0].ID).Team)
+	s.Equal(2, *lobby.Players[0].Team)
+	s.Equal(uint(1), lobby.Version)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSwitchTeamFailsWhenTheTeamIsFull() {
+	lobby, users := s.createTeamLobbyInDB("first", "second", "third")
+
+	err := s.lobbyRepo.SwitchTeam(&lobby, users[1].ID, 1, 2)
+
+	s.ErrorIs(err, ErrTeamFull)
+	s.Equal(2, *s.membership(lobby.LobbyID, users[1].ID).Team)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSwitchTeamFailsWhenThePlayerIsNotInTheLobby() {
+	lobby, _ := s.createTeamLobbyInDB("first")
+	stranger := s.createUserInDB("stranger", nil)
+
+	err := s.lobbyRepo.SwitchTeam(&lobby, stranger.ID, 2, 2)
+
+	s.ErrorIs(err, ErrPlayerNotInLobby)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSwitchTeamFailsWhenTheLobbyChangedSinceItWasRead() {
+	lobby, users := s.createTeamLobbyInDB("first", "second")
+	staleLobby := lobby
+	s.Require().NoError(s.lobbyRepo.SwitchTeam(&lobby, users[0].ID, 2, 2))
+
+	err := s.lobbyRepo.SwitchTeam(&staleLobby, users[1].ID, 1, 2)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSwapSeatTradesTheSeatAndTheTeamWithTheOccupant() {
+	lobby, users := s.createTeamLobbyInDB("first", "second")
+
+	err := s.lobbyRepo.SwapSeat(&lobby, users[0].ID, 1)
+
+	s.NoError(err)
+	first, second := s.membership(lobby.LobbyID, users[0].ID), s.membership(lobby.LobbyID, users[1].ID)
+	s.Equal(1, first.Seat)
+	s.Equal(2, *first.Team)
+	s.Equal(0, second.Seat)
+	s.Equal(1, *second.Team)
+	s.Require().Len(lobby.Players, 2)
+	s.Equal("second", lobby.Players[0].User.Username)
+	s.Equal(1, *lobby.Players[0].Team)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSwapSeatToAFreeSeatKeepsTheTeam() {
+	lobby, users := s.createTeamLobbyInDB("first", "second")
+
+	err := s.lobbyRepo.SwapSeat(&lobby, users[0].ID, 3)
+
+	s.NoError(err)
+	first := s.membership(lobby.LobbyID, users[0].ID)
+	s.Equal(3, first.Seat)
+	s.Equal(1, *first.Team)
+	s.Equal(1, s.membership(lobby.LobbyID, users[1].ID).Seat)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSwapSeatFailsWhenTheLobbyIsNotWaiting() {
+	lobby, users := s.createTeamLobbyInDB("first", "second")
+	s.Require().NoError(s.db.Model(&lobby).Update("status", models.LobbyStatusInProgress).Error)
+
+	err := s.lobbyRepo.SwapSeat(&lobby, users[0].ID, 1)
+
+	s.ErrorIs(err, ErrLobbyNotWaiting)
+	s.Equal(0, s.membership(lobby.LobbyID, users[0].ID).Seat)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAssignTeams() {
+	lobby, users := s.createTeamLobbyInDB("first", "second")
+
+	err := s.lobbyRepo.AssignTeams(&lobby, map[uint]int{users[0].ID: 2, users[1].ID: 1})
+
+	s.NoError(err)
+	s.Equal(2, *s.membership(lobby.LobbyID, users[0].ID).Team)
+	s.Equal(1, *s.membership(lobby.LobbyID, users[1].ID).Team)
+	s.Equal(2, *lobby.Players[0].Team)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFinishWithWinningTeamRecordsThePlacements() {
+	lobby, users := s.createTeamLobbyInDB("first", "second", "third")
+	s.Require().NoError(s.db.Model(&lobby).Update("status", models.LobbyStatusInProgress).Error)
+
+	err := s.lobbyRepo.FinishWithWinningTeam(&lobby, 1)
+
+	s.NoError(err)
+	s.Equal(1, *s.membership(lobby.LobbyID, users[0].ID).Placement)
+	s.Equal(2, *s.membership(lobby.LobbyID, users[1].ID).Placement)
+	s.Equal(1, *s.membership(lobby.LobbyID, users[2].ID).Placement)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusFinished, updatedLobby.Status)
+	s.Equal(1, *updatedLobby.WinningTeam)
+	s.Nil(updatedLobby.WinnerID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestUpdateStatusSuccess() {
+	lobby := s.createLobbyInDB("Status Test Lobby", models.LobbyStatusWaiting)
+	err := s.lobbyRepo.UpdateStatus(&lobby, models.LobbyStatusInProgress)
+	s.NoError(err)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusInProgress, updatedLobby.Status)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFinishWithWinnerSuccess() {
+	lobby := s.createLobbyInDB("Winner Test Lobby", models.LobbyStatusInProgress)
+	winner := s.createUserInDB("the_winner", &lobby.LobbyID)
+	err := s.lobbyRepo.FinishWithWinner(&lobby, winner.ID)
+	s.NoError(err)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusFinished, updatedLobby.Status)
+	s.Equal(winner.ID, *updatedLobby.WinnerID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFinishWithWinnerRecordsThePlacements() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+	winner := s.createUserInDB("the_winner", &lobby.LobbyID)
+	loser := s.createUserInDB("the_loser", &lobby.LobbyID)
+
+	err := s.lobbyRepo.FinishWithWinner(&lobby, winner.ID)
+
+	s.NoError(err)
+	s.Equal(1, *s.membership(lobby.LobbyID, winner.ID).Placement)
+	s.Equal(2, *s.membership(lobby.LobbyID, loser.ID).Placement)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFinishWithWinnerFinishesTheGameOnlyOnce() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+	first := s.createUserInDB("first", &lobby.LobbyID)
+	second := s.createUserInDB("second", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.FinishWithWinner(&lobby, first.ID))
+
+	err := s.lobbyRepo.FinishWithWinner(&lobby, second.ID)
+
+	s.ErrorIs(err, ErrLobbyNotInProgress)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(first.ID, *updatedLobby.WinnerID)
+	s.Equal(1, *s.membership(lobby.LobbyID, first.ID).Placement)
+	s.Equal(2, *s.membership(lobby.LobbyID, second.ID).Placement)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFinishWithWinningTeamFailsWhenTheGameIsNotInProgress() {
+	lobby, users := s.createTeamLobbyInDB("first", "second")
+
+	err := s.lobbyRepo.FinishWithWinningTeam(&lobby, 1)
+
+	s.ErrorIs(err, ErrLobbyNotInProgress)
+	s.Nil(s.membership(lobby.LobbyID, users[0].ID).Placement)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAbandonGameStopsTheGameInProgress() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+
+	err := s.lobbyRepo.AbandonGame(&lobby)
+
+	s.NoError(err)
+	var stored models.Lobby
+	s.Require().NoError(s.db.First(&stored, fixtureLobbyCondition, lobby.LobbyID).Error)
+	s.Equal(models.LobbyStatusAbandoned, stored.Status)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAbandonGameDoesNotOverwriteAFinishedGame() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+	winner := s.createUserInDB("winner", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.FinishWithWinner(&lobby, winner.ID))
+
+	err := s.lobbyRepo.AbandonGame(&lobby)
+
+	s.ErrorIs(err, ErrLobbyNotInProgress)
+	var stored models.Lobby
+	s.Require().NoError(s.db.First(&stored, fixtureLobbyCondition, lobby.LobbyID).Error)
+	s.Equal(models.LobbyStatusFinished, stored.Status)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartReadyCheckResetsPreviousConfirmations() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 1).Error)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).Update("ready", true).Error)
+	deadline := time.Now().UTC().Add(time.Minute).Truncate(time.Second)
+
+	err := s.lobbyRepo.StartReadyCheck(&lobby, deadline, true)
+
+	s.NoError(err)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusReadyCheck, updatedLobby.Status)
+	s.Require().NotNil(updatedLobby.ReadyCheckDeadline)
+	s.True(deadline.Equal(*updatedLobby.ReadyCheckDeadline))
+	s.False(s.membership(lobby.LobbyID, player.ID).Ready)
+	s.NotNil(updatedLobby.MatchedAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartReadyCheckOfALobbyThatStartedFullIsNotMatched() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 1).Error)
+	s.createUserInDB("player1", &lobby.LobbyID)
+
+	err := s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), false)
+
+	s.NoError(err)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusReadyCheck, updatedLobby.Status)
+	s.Nil(updatedLobby.MatchedAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartReadyCheckFailsWhenTheLobbyChangedSinceItWasFilled() {
+	lobby, users := s.createTeamLobbyInDB("host", "player")
+	stale := lobby
+	s.Require().NoError(s.lobbyRepo.SwitchTeam(&lobby, users[0].ID, 2, 2))
+
+	err := s.lobbyRepo.StartReadyCheck(&stale, time.Now().UTC().Add(time.Minute), true)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusWaiting, updatedLobby.Status)
+	s.Nil(updatedLobby.MatchedAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartReadyCheckFailsWhenAPlayerLeftTheLobby() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 2).Error)
+	s.createUserInDB("player1", &lobby.LobbyID)
+
+	err := s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartReadyCheckFailsOnceTheLobbyIsClosed() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusCancelled)
+	s.createUserInDB("player1", &lobby.LobbyID)
+
+	err := s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true)
+
+	s.ErrorIs(err, ErrLobbyConflict)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusCancelled, updatedLobby.Status)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSetPlayerReadyOnlyForLobbyPlayers() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusReadyCheck)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	outsider := s.createUserInDB("outsider", nil)
+
+	s.NoError(s.lobbyRepo.SetPlayerReady(&lobby, &player))
+	s.NoError(s.lobbyRepo.SetPlayerReady(&lobby, &outsider))
+
+	s.True(s.membership(lobby.LobbyID, player.ID).Ready)
+	var outsiderMemberships int64
+	s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", outsider.ID).Count(&outsiderMemberships)
+	s.Zero(outsiderMemberships)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCompleteReadyCheckStartsTheGame() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 1).Error)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+	s.Require().NoError(s.lobbyRepo.SetPlayerReady(&lobby, &player))
+
+	err := s.lobbyRepo.CompleteReadyCheck(&lobby)
+
+	s.NoError(err)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusInProgress, updatedLobby.Status)
+	s.Nil(updatedLobby.ReadyCheckDeadline)
+	s.False(s.membership(lobby.LobbyID, player.ID).Ready)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCompleteReadyCheckFailsOnceTheReadyCheckFailed() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	afkPlayer := s.createUserInDB("afk", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+	s.Require().NoError(s.lobbyRepo.SetPlayerReady(&lobby, &player))
+	s.Require().NoError(s.lobbyRepo.FailReadyCheck(&lobby, []uint{afkPlayer.ID}))
+
+	err := s.lobbyRepo.CompleteReadyCheck(&lobby)
+
+	s.ErrorIs(err, ErrReadyCheckOver)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusWaiting, updatedLobby.Status)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFailReadyCheckFailsOnceTheGameStarted() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 1).Error)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+	s.Require().NoError(s.lobbyRepo.CompleteReadyCheck(&lobby))
+
+	err := s.lobbyRepo.FailReadyCheck(&lobby, []uint{player.ID})
+
+	s.ErrorIs(err, ErrReadyCheckOver)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Equal(models.LobbyStatusInProgress, updatedLobby.Status)
+	s.Nil(s.membership(lobby.LobbyID, player.ID).LeftAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFailReadyCheckRemovesPlayersAndReopensTheLobby() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	readyPlayer := s.createUserInDB("ready", &lobby.LobbyID)
+	afkPlayer := s.createUserInDB("afk", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+	s.Require().NoError(s.lobbyRepo.SetPlayerReady(&lobby, &readyPlayer))
+
+	err := s.lobbyRepo.FailReadyCheck(&lobby, []uint{afkPlayer.ID})
+
+	s.NoError(err)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(models.LobbyStatusWaiting, foundLobby.Status)
+	s.Nil(foundLobby.ReadyCheckDeadline)
+	s.Require().Len(foundLobby.Players, 1)
+	s.Equal("ready", foundLobby.Players[0].User.Username)
+	s.False(foundLobby.Players[0].Ready)
+	s.Nil(s.currentLobbyOf(afkPlayer.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFailReadyCheckPassesTheHostToTheNextSeat() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusReadyCheck)
+	afkHost := s.createUserInDB("afk", &lobby.LobbyID)
+	s.createUserInDB("leaver", &lobby.LobbyID)
+	nextHost := s.createUserInDB("ready", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.UpdateHost(&lobby, afkHost.ID))
+	var leaver models.User
+	s.Require().NoError(s.db.First(&leaver, "username = ?", "leaver").Error)
+
+	err := s.lobbyRepo.FailReadyCheck(&lobby, []uint{afkHost.ID, leaver.ID})
+
+	s.NoError(err)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(nextHost.ID, *foundLobby.HostID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFailReadyCheckKeepsTheMembershipOfTheRemovedPlayers() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusReadyCheck)
+	afkPlayer := s.createUserInDB("afk", &lobby.LobbyID)
+
+	err := s.lobbyRepo.FailReadyCheck(&lobby, []uint{afkPlayer.ID})
+
+	s.NoError(err)
+	s.NotNil(s.membership(lobby.LobbyID, afkPlayer.ID).LeftAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDeleteUnconfirmedDeletesTheLobby() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 1).Error)
+	afkPlayer := s.createUserInDB("afk", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+
+	err := s.lobbyRepo.DeleteUnconfirmed(&lobby)
+
+	s.NoError(err)
+	err = s.db.First(&lobby, fixtureLobbyCondition, lobby.LobbyID).Error
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	s.NotNil(s.membership(lobby.LobbyID, afkPlayer.ID).LeftAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDeleteUnconfirmedKeepsTheLobbyConfirmedMeanwhile() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 1).Error)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+	s.Require().NoError(s.lobbyRepo.SetPlayerReady(&lobby, &player))
+
+	err := s.lobbyRepo.DeleteUnconfirmed(&lobby)
+
+	s.ErrorIs(err, ErrReadyCheckOver)
+	s.NoError(s.db.First(&models.Lobby{}, fixtureLobbyCondition, lobby.LobbyID).Error)
+	s.Nil(s.membership(lobby.LobbyID, player.ID).LeftAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDeleteUnconfirmedFailsOnceTheLobbyChanged() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Model(&lobby).Update("max_players", 1).Error)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+	expired := lobby
+	s.Require().NoError(s.lobbyRepo.FailReadyCheck(&lobby, nil))
+	s.Require().NoError(s.db.First(&lobby, fixtureLobbyCondition, lobby.LobbyID).Error)
+	s.Require().NoError(s.lobbyRepo.StartReadyCheck(&lobby, time.Now().UTC().Add(time.Minute), true))
+
+	err := s.lobbyRepo.DeleteUnconfirmed(&expired)
+
+	s.ErrorIs(err, ErrReadyCheckOver)
+	var updatedLobby models.Lobby
+	s.Require().NoError(s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID).Error)
+	s.Equal(models.LobbyStatusReadyCheck, updatedLobby.Status)
+	s.Nil(s.membership(lobby.LobbyID, player.ID).LeftAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartRematchVoteAcceptsForTheRequester() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	requester := s.createUserInDB("player1", &lobby.LobbyID)
+	opponent := s.createUserInDB("player2", &lobby.LobbyID)
+	deadline := fixtureCreatedAt.Add(time.Minute)
+
+	err := s.lobbyRepo.StartRematchVote(&lobby, requester.ID, fixtureCreatedAt, deadline)
+
+	s.NoError(err)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Require().NotNil(updatedLobby.RematchDeadline)
+	s.True(deadline.Equal(*updatedLobby.RematchDeadline))
+	s.True(s.membership(lobby.LobbyID, requester.ID).RematchAccepted)
+	s.False(s.membership(lobby.LobbyID, opponent.ID).RematchAccepted)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartRematchVoteFailsWhileAVoteIsRunning() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	requester := s.createUserInDB("player1", &lobby.LobbyID)
+	opponent := s.createUserInDB("player2", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartRematchVote(&lobby, requester.ID, fixtureCreatedAt, fixtureCreatedAt.Add(time.Minute)))
+
+	err := s.lobbyRepo.StartRematchVote(&lobby, opponent.ID, fixtureCreatedAt.Add(time.Second), fixtureCreatedAt.Add(time.Hour))
+
+	s.ErrorIs(err, ErrRematchVoteRunning)
+	s.True(s.membership(lobby.LobbyID, requester.ID).RematchAccepted)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestStartRematchVoteReopensAnExpiredVote() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	requester := s.createUserInDB("player1", &lobby.LobbyID)
+	opponent := s.createUserInDB("player2", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartRematchVote(&lobby, requester.ID, fixtureCreatedAt, fixtureCreatedAt.Add(time.Minute)))
+
+	err := s.lobbyRepo.StartRematchVote(&lobby, opponent.ID, fixtureCreatedAt.Add(time.Minute), fixtureCreatedAt.Add(time.Hour))
+
+	s.NoError(err)
+	s.False(s.membership(lobby.LobbyID, requester.ID).RematchAccepted)
+	s.True(s.membership(lobby.LobbyID, opponent.ID).RematchAccepted)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestEndRematchVoteClearsTheAcceptances() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	requester := s.createUserInDB("player1", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartRematchVote(&lobby, requester.ID, fixtureCreatedAt, fixtureCreatedAt.Add(time.Minute)))
+
+	err := s.lobbyRepo.EndRematchVote(&lobby)
+
+	s.NoError(err)
+	var updatedLobby models.Lobby
+	s.db.First(&updatedLobby, fixtureLobbyCondition, lobby.LobbyID)
+	s.Nil(updatedLobby.RematchDeadline)
+	s.False(s.membership(lobby.LobbyID, requester.ID).RematchAccepted)
+}
+
+func (s *LobbySQLRepositoryTestSuite) rematchOf(lobby models.Lobby, players ...models.User) *models.Lobby {
+	rematch := &models.Lobby{LobbyID: uuid.New().String(), Name: lobby.Name, Status: models.LobbyStatusWaiting}
+	for seat, player := range players {
+		rematch.Players = append(rematch.Players, models.LobbyPlayer{UserID: player.ID, Seat: seat})
+	}
+	return rematch
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateRematchLinksTheLobbies() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	player1 := s.createUserInDB("player1", &lobby.LobbyID)
+	player2 := s.createUserInDB("player2", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.StartRematchVote(&lobby, player1.ID, fixtureCreatedAt, fixtureCreatedAt.Add(time.Minute)))
+	rematch := s.rematchOf(lobby, player1, player2)
+
+	err := s.lobbyRepo.CreateRematch(&lobby, rematch)
+
+	s.NoError(err)
+	s.Equal(rematch.LobbyID, *lobby.RematchLobbyID)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(rematch.LobbyID, *foundLobby.RematchLobbyID)
+	s.Nil(foundLobby.RematchDeadline)
+	s.Equal(rematch.LobbyID, *s.currentLobbyOf(player1.ID))
+	s.Equal(rematch.LobbyID, *s.currentLobbyOf(player2.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateRematchOnlyOnce() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	s.Require().NoError(s.lobbyRepo.CreateRematch(&lobby, s.rematchOf(lobby, player)))
+
+	err := s.lobbyRepo.CreateRematch(&lobby, s.rematchOf(lobby, player))
+
+	s.ErrorIs(err, ErrRematchCreated)
+	var lobbies int64
+	s.db.Model(&models.Lobby{}).Count(&lobbies)
+	s.Equal(int64(2), lobbies)
+	s.ErrorIs(s.lobbyRepo.StartRematchVote(&lobby, player.ID, fixtureCreatedAt, fixtureCreatedAt.Add(time.Minute)),
+		ErrRematchCreated)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateRematchFailsWhenAPlayerIsInAnActiveLobby() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusFinished)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+	activeLobby := s.createLobbyInDB("Active", models.LobbyStatusWaiting)
+	busyPlayer := s.createUserInDB("busy", &activeLobby.LobbyID)
+
+	err := s.lobbyRepo.CreateRematch(&lobby, s.rematchOf(lobby, player, busyPlayer))
+
+	s.ErrorIs(err, ErrPlayerInLobby)
+	s.Nil(lobby.RematchLobbyID)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Nil(foundLobby.RematchLobbyID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCloseWaitingCancelsTheLobbyAndReleasesThePlayers() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	creator := s.createUserInDB("creator", &lobby.LobbyID)
+	closedAt := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	err := s.lobbyRepo.CloseWaiting(lobby.LobbyID, models.LobbyCloseExpired, closedAt)
+
+	s.NoError(err)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(models.LobbyStatusCancelled, foundLobby.Status)
+	s.Equal(models.LobbyCloseExpired, *foundLobby.CloseReason)
+	s.True(closedAt.Equal(*foundLobby.ClosedAt))
+	s.Empty(foundLobby.Players)
+	s.Nil(s.currentLobbyOf(creator.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCloseWaitingClosesTheLobbyOnlyOnce() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	s.Require().NoError(s.lobbyRepo.CloseWaiting(lobby.LobbyID, models.LobbyCloseExpired, time.Now().UTC()))
+
+	err := s.lobbyRepo.CloseWaiting(lobby.LobbyID, models.LobbyCloseCreatorInactive, time.Now().UTC())
+
+	s.ErrorIs(err, ErrLobbyNotWaiting)
+	foundLobby, err := s.lobbyRepo.FindByID(lobby.LobbyID)
+	s.Require().NoError(err)
+	s.Equal(models.LobbyCloseExpired, *foundLobby.CloseReason)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCloseWaitingKeepsTheGamesThatStarted() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+	player := s.createUserInDB("player", &lobby.LobbyID)
+
+	err := s.lobbyRepo.CloseWaiting(lobby.LobbyID, models.LobbyCloseExpired, time.Now().UTC())
+
+	s.ErrorIs(err, ErrLobbyNotWaiting)
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(player.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListStaleFindsOldLobbiesAndInactiveCreators() {
+	cutoff := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	before := cutoff.Add(-time.Minute)
+	after := cutoff.Add(time.Minute)
+	idleCreator := models.User{Username: "idle", Password: "password", LastSeenAt: &before}
+	activeCreator := models.User{Username: "active", Password: "password", LastSeenAt: &after}
+	disconnectedCreator := models.User{Username: "disconnected", Password: "password", LastSeenAt: &after}
+	connectedCreator := models.User{Username: "connected", Password: "password", LastSeenAt: &after}
+	for _, creator := range []*models.User{&idleCreator, &activeCreator, &disconnectedCreator, &connectedCreator} {
+		s.Require().NoError(s.db.Create(creator).Error)
+	}
+	s.Require().NoError(s.db.Create(&models.Presence{UserID: disconnectedCreator.ID, ExpiresAt: before}).Error)
+	s.Require().NoError(s.db.Create(&models.Presence{UserID: connectedCreator.ID, ExpiresAt: after}).Error)
+	for _, lobby := range []models.Lobby{
+		{LobbyID: "old", Name: "old", Status: models.LobbyStatusWaiting, CreatorID: &activeCreator.ID, CreatedAt: before},
+		{LobbyID: "idle", Name: "idle", Status: models.LobbyStatusWaiting, CreatorID: &idleCreator.ID, CreatedAt: after},
+		{LobbyID: "fresh", Name: "fresh", Status: models.LobbyStatusWaiting, CreatorID: &activeCreator.ID, CreatedAt: after},
+		{LobbyID: "gone", Name: "gone", Status: models.LobbyStatusWaiting, CreatorID: &disconnectedCreator.ID, CreatedAt: after},
+		{LobbyID: "here", Name: "here", Status: models.LobbyStatusWaiting, CreatorID: &connectedCreator.ID, CreatedAt: after},
+		{LobbyID: "orphan", Name: "orphan", Status: models.LobbyStatusWaiting, CreatedAt: after},
+		{LobbyID: "started", Name: "started", Status: models.LobbyStatusInProgress, CreatorID: &idleCreator.ID, CreatedAt: before},
+	} {
+		s.Require().NoError(s.db.Create(&lobby).Error)
+	}
+
+	lobbies, err := s.lobbyRepo.ListStale(cutoff, cutoff, cutoff)
+
+	s.NoError(err)
+	var ids []string
+	for _, lobby := range lobbies {
+		ids = append(ids, lobby.LobbyID)
+	}
+	s.ElementsMatch([]string{"old", "idle", "gone"}, ids)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListWaitingCountsThePlayersOfTheOpenLobbies() {
+	full := models.Lobby{LobbyID: "a", Name: "a", GameMode: "TEAM_DEATHMATCH", Region: "NA", MaxPlayers: 4}
+	s.Require().NoError(s.db.Create(&full).Error)
+	s.createUserInDB("player1", &full.LobbyID)
+	s.createUserInDB("player2", &full.LobbyID)
+	empty := models.Lobby{LobbyID: "b", Name: "b"}
+	s.Require().NoError(s.db.Create(&empty).Error)
+	locked := s.createLobbyInDB("locked", models.LobbyStatusWaiting)
+	s.createUserInDB("player3", &locked.LobbyID)
+	s.Require().NoError(s.lobbyRepo.SetLocked(&locked, true))
+	started := s.createLobbyInDB("started", models.LobbyStatusInProgress)
+	s.createUserInDB("player4", &started.LobbyID)
+
+	waiting, err := s.lobbyRepo.ListWaiting()
+
+	s.NoError(err)
+	s.Equal([]WaitingLobby{
+		{GameMode: "TEAM_DEATHMATCH", Region: "NA", Players: 2, MaxPlayers: 4},
+		{GameMode: "DUEL", Region: "EU", Players: 0, MaxPlayers: 2},
+	}, waiting)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListWaitingLeavesOutTheLobbiesThatAreNotPublic() {
+	public := s.createLobbyInDB("public", models.LobbyStatusWaiting)
+	s.createUserInDB("player1", &public.LobbyID)
+	for _, visibility := range []models.LobbyVisibility{models.LobbyVisibilityPrivate, models.LobbyVisibilityUnlisted} {
+		hidden := models.Lobby{LobbyID: string(visibility), Name: string(visibility), Visibility: visibility}
+		s.Require().NoError(s.db.Create(&hidden).Error)
+		s.createUserInDB("player-"+string(visibility), &hidden.LobbyID)
+	}
+
+	waiting, err := s.lobbyRepo.ListWaiting()
+
+	s.NoError(err)
+	s.Equal([]WaitingLobby{{GameMode: "DUEL", Region: "EU", Players: 1, MaxPlayers: 2}}, waiting)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListMatchWaitsMeasuresFromWhenThePlayersJoined() {
+	matchedAt := fixtureCreatedAt
+	since := matchedAt.Add(-time.Hour)
+	game := models.Lobby{LobbyID: "game", Name: "game", Status: models.LobbyStatusInProgress, MatchedAt: &matchedAt}
+	reopened := models.Lobby{LobbyID: "reopened", Name: "reopened", Status: models.LobbyStatusWaiting, MatchedAt: &matchedAt}
+	old := models.Lobby{LobbyID: "old", Name: "old", Status: models.LobbyStatusFinished, MatchedAt: &since}
+	rematch := models.Lobby{LobbyID: "rematch", Name: "rematch", Status: models.LobbyStatusInProgress}
+	for _, lobby := range []*models.Lobby{&game, &reopened, &old, &rematch} {
+		s.Require().NoError(s.db.Create(lobby).Error)
+	}
+	for username, membership := range map[string]struct {
+		lobbyID string
+		joined  time.Duration
+	}{
+		"first":   {game.LobbyID, 2 * time.Minute},
+		"second":  {game.LobbyID, 30 * time.Second},
+		"waiting": {reopened.LobbyID, time.Minute},
+		"earlier": {old.LobbyID, time.Minute},
+		"again":   {rematch.LobbyID, time.Minute},
+	} {
+		player := s.createUserInDB(username, &membership.lobbyID)
+		err := s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).
+			Update("joined_at", matchedAt.Add(-membership.joined)).Error
+		s.Require().NoError(err)
+	}
+	dodger := s.createUserInDB("dodger", &game.LobbyID)
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", dodger.ID).
+		Updates(map[string]any{"joined_at": matchedAt.Add(-time.Hour), "left_at": matchedAt}).Error)
+
+	waits, err := s.lobbyRepo.ListMatchWaits(since.Add(time.Second))
+
+	s.NoError(err)
+	s.ElementsMatch([]MatchWait{
+		{GameMode: "DUEL", Region: "EU", Wait: 2 * time.Minute},
+		{GameMode: "DUEL", Region: "EU", Wait: 30 * time.Second},
+	}, waits)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListMatchWaitsLeavesOutTheLobbiesThatAreNotPublic() {
+	matchedAt := fixtureCreatedAt
+	for _, visibility := range []models.LobbyVisibility{models.LobbyVisibilityPublic, models.LobbyVisibilityPrivate, models.LobbyVisibilityUnlisted} {
+		game := models.Lobby{LobbyID: string(visibility), Name: string(visibility), Status: models.LobbyStatusInProgress,
+			Visibility: visibility, MatchedAt: &matchedAt}
+		s.Require().NoError(s.db.Create(&game).Error)
+		player := s.createUserInDB("player-"+string(visibility), &game.LobbyID)
+		s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).
+			Update("joined_at", matchedAt.Add(-time.Minute)).Error)
+	}
+
+	waits, err := s.lobbyRepo.ListMatchWaits(matchedAt.Add(-time.Hour))
+
+	s.NoError(err)
+	s.Equal([]MatchWait{{GameMode: "DUEL", Region: "EU", Wait: time.Minute}}, waits)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSyncConnectionsMarksTheDisconnectedPlayersOfTheGamesInProgress() {
+	at := fixtureCreatedAt
+	expiredAt := at.Add(-time.Minute)
+	game := s.createLobbyInDB("game", models.LobbyStatusInProgress)
+	waiting := s.createLobbyInDB("waiting", models.LobbyStatusWaiting)
+	gone := s.createUserInDB("gone", &game.LobbyID)
+	connected := s.createUserInDB("connected", &game.LobbyID)
+	untracked := s.createUserInDB("untracked", &game.LobbyID)
+	idle := s.createUserInDB("idle", &waiting.LobbyID)
+	for userID, expiresAt := range map[uint]time.Time{
+		gone.ID: expiredAt, connected.ID: at.Add(time.Minute), idle.ID: expiredAt,
+	} {
+		s.Require().NoError(s.db.Create(&models.Presence{UserID: userID, ExpiresAt: expiresAt}).Error)
+	}
+
+	lobbyIDs, err := s.lobbyRepo.SyncConnections(at)
+
+	s.NoError(err)
+	s.Equal([]string{game.LobbyID}, lobbyIDs)
+	s.Require().NotNil(s.membership(game.LobbyID, gone.ID).DisconnectedAt)
+	s.True(s.membership(game.LobbyID, gone.ID).DisconnectedAt.Equal(expiredAt))
+	s.Nil(s.membership(game.LobbyID, connected.ID).DisconnectedAt)
+	s.Nil(s.membership(game.LobbyID, untracked.ID).DisconnectedAt)
+	s.Nil(s.membership(waiting.LobbyID, idle.ID).DisconnectedAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSyncConnectionsClearsThePlayersThatReconnected() {
+	at := fixtureCreatedAt
+	disconnectedAt := at.Add(-time.Minute)
+	game := s.createLobbyInDB("game", models.LobbyStatusInProgress)
+	player := s.createUserInDB("player", &game.LobbyID)
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).
+		Update("disconnected_at", disconnectedAt).Error)
+	s.Require().NoError(s.db.Create(&models.Presence{UserID: player.ID, ExpiresAt: at.Add(time.Minute)}).Error)
+
+	lobbyIDs, err := s.lobbyRepo.SyncConnections(at)
+
+	s.NoError(err)
+	s.Equal([]string{game.LobbyID}, lobbyIDs)
+	s.Nil(s.membership(game.LobbyID, player.ID).DisconnectedAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestSyncConnectionsWithoutChanges() {
+	at := fixtureCreatedAt
+	disconnectedAt := at.Add(-time.Minute)
+	game := s.createLobbyInDB("game", models.LobbyStatusInProgress)
+	player := s.createUserInDB("player", &game.LobbyID)
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).
+		Update("disconnected_at", disconnectedAt).Error)
+	s.Require().NoError(s.db.Create(&models.Presence{UserID: player.ID, ExpiresAt: disconnectedAt}).Error)
+
+	lobbyIDs, err := s.lobbyRepo.SyncConnections(at)
+
+	s.NoError(err)
+	s.Empty(lobbyIDs)
+	s.True(s.membership(game.LobbyID, player.ID).DisconnectedAt.Equal(disconnectedAt))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAbandonPlayersKeepsTheFirstAbandonment() {
+	at := fixtureCreatedAt
+	game := s.createLobbyInDB("game", models.LobbyStatusInProgress)
+	first := s.createUserInDB("first", &game.LobbyID)
+	second := s.createUserInDB("second", &game.LobbyID)
+	stayed := s.createUserInDB("stayed", &game.LobbyID)
+	s.Require().NoError(s.lobbyRepo.AbandonPlayers(&game, []uint{first.ID}, at))
+
+	err := s.lobbyRepo.AbandonPlayers(&game, []uint{first.ID, second.ID}, at.Add(time.Minute))
+
+	s.NoError(err)
+	s.True(s.membership(game.LobbyID, first.ID).AbandonedAt.Equal(at))
+	s.True(s.membership(game.LobbyID, second.ID).AbandonedAt.Equal(at.Add(time.Minute)))
+	s.Nil(s.membership(game.LobbyID, stayed.ID).AbandonedAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListFinishedByPlayerPagesThroughTheGamesTheUserFinished() {
+	player := s.createUserInDB("player", nil)
+	finishedAt := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	for i, name := range []string{"oldest", "middle", "newest"} {
+		updatedAt := finishedAt.Add(time.Duration(i) * time.Hour)
+		lobby := models.Lobby{LobbyID: name, Name: name, Status: models.LobbyStatusFinished, UpdatedAt: updatedAt,
+			WinnerID: &player.ID}
+		s.Require().NoError(s.db.Create(&lobby).Error)
+		s.Require().NoError(s.db.Create(&models.LobbyPlayer{LobbyID: name, UserID: player.ID}).Error)
+	}
+	ongoing := s.createLobbyInDB("ongoing", models.LobbyStatusInProgress)
+	s.Require().NoError(s.db.Create(&models.LobbyPlayer{LobbyID: ongoing.LobbyID, UserID: player.ID}).Error)
+	left := s.createLobbyInDB("left", models.LobbyStatusFinished)
+	leftAt := finishedAt
+	s.Require().NoError(s.db.Create(&models.LobbyPlayer{LobbyID: left.LobbyID, UserID: player.ID, LeftAt: &leftAt}).Error)
+
+	firstPage, err := s.lobbyRepo.ListFinishedByPlayer(player.ID, 0, 2)
+	s.NoError(err)
+	secondPage, err := s.lobbyRepo.ListFinishedByPlayer(player.ID, 2, 2)
+	s.NoError(err)
+
+	s.Require().Len(firstPage, 2)
+	s.Equal("newest", firstPage[0].LobbyID)
+	s.Equal("middle", firstPage[1].LobbyID)
+	s.Require().Len(secondPage, 1)
+	s.Equal("oldest", secondPage[0].LobbyID)
+	s.Require().Len(secondPage[0].Players, 1)
+	s.Equal("player", secondPage[0].Players[0].User.Username)
+	s.Require().NotNil(secondPage[0].Winner)
+	s.Equal("player", secondPage[0].Winner.Username)
+}
+
+// createGameInDB stores a game between the player and the opponent, last updated at the given time. When there is a
+// winner, the players get their placements.
+func (s *LobbySQLRepositoryTestSuite) createGameInDB(lobbyID string, status models.LobbyStatus, updatedAt time.Time,
+	winner *models.User, player, opponent models.User) {
+	game := models.Lobby{LobbyID: lobbyID, Name: lobbyID, Status: status, UpdatedAt: updatedAt}
+	s.Require().NoError(s.db.Create(&game).Error)
+	for seat, user := range []models.User{player, opponent} {
+		membership := models.LobbyPlayer{LobbyID: lobbyID, UserID: user.ID, Seat: seat}
+		if winner != nil {
+			placement := 2
+			if winner.ID == user.ID {
+				placement = 1
+			}
+			membership.Placement = &placement
+		}
+		s.Require().NoError(s.db.Create(&membership).Error)
+	}
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListResultsByPlayerListsTheFinishedGamesFromTheMostRecent() {
+	player, opponent := s.createUserInDB("player", nil), s.createUserInDB("opponent", nil)
+	finishedAt := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	s.createGameInDB("oldest", models.LobbyStatusFinished, finishedAt, &player, player, opponent)
+	s.createGameInDB("middle", models.LobbyStatusFinished, finishedAt.Add(time.Hour), &opponent, player, opponent)
+	s.createGameInDB("newest", models.LobbyStatusFinished, finishedAt.Add(2*time.Hour), &player, player, opponent)
+	s.createGameInDB("ongoing", models.LobbyStatusInProgress, finishedAt.Add(3*time.Hour), nil, player, opponent)
+
+	results, err := s.lobbyRepo.ListResultsByPlayer(player.ID)
+
+	s.NoError(err)
+	s.Equal([]bool{true, false, true}, results)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListResultsByPlayerCountsTheGamesWonByTheTeamOfThePlayer() {
+	player, opponent := s.createUserInDB("player", nil), s.createUserInDB("opponent", nil)
+	s.createGameInDB("teams", models.LobbyStatusFinished, time.Now(), nil, player, opponent)
+	s.Require().NoError(s.db.Model(&models.Lobby{}).Where("lobby_id = ?", "teams").Update("winning_team", 1).Error)
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).Update("placement", 1).Error)
+
+	results, err := s.lobbyRepo.ListResultsByPlayer(player.ID)
+
+	s.NoError(err)
+	s.Equal([]bool{true}, results)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestListResultsByPlayerSkipsTheGamesThePlayerLeft() {
+	player, opponent := s.createUserInDB("player", nil), s.createUserInDB("opponent", nil)
+	s.createGameInDB("left", models.LobbyStatusFinished, time.Now(), &opponent, player, opponent)
+	s.Require().NoError(s.db.Model(&models.LobbyPlayer{}).Where("user_id = ?", player.ID).Update("left_at", time.Now()).Error)
+
+	results, err := s.lobbyRepo.ListResultsByPlayer(player.ID)
+
+	s.NoError(err)
+	s.Empty(results)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDeleteSuccess() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+	player1 := s.createUserInDB("player1", &lobby.LobbyID)
+	player2 := s.createUserInDB("player2", &lobby.LobbyID)
+	err := s.lobbyRepo.Delete(lobby.LobbyID)
+	s.NoError(err)
+	err = s.db.First(&lobby, fixtureLobbyCondition, lobby.LobbyID).Error
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	s.Empty(s.currentLobbyOf(player1.ID))
+	s.Empty(s.currentLobbyOf(player2.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDeleteKeepsTheMembershipsForTheHistory() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusWaiting)
+	player := s.createUserInDB("player1", &lobby.LobbyID)
+
+	err := s.lobbyRepo.Delete(lobby.LobbyID)
+
+	s.NoError(err)
+	s.NotNil(s.membership(lobby.LobbyID, player.ID).LeftAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDeleteWhenAssociationClearFails() {
+	lobby := s.createLobbyInDB(fixtureLobbyName, models.LobbyStatusInProgress)
+	s.createUserInDB("a_player", &lobby.LobbyID)
+
+	err := s.db.Migrator().DropTable(&models.LobbyPlayer{})
+	s.Require().NoError(err, "Dropping the lobby players table for test setup should not fail")
+
+	deleteErr := s.lobbyRepo.Delete(lobby.LobbyID)
+	s.ErrorIs(deleteErr, ErrLobbyCleanupFailed)
+
+	// Verify the lobby was NOT deleted, as the process failed before the final deletion step.
+	var foundLobby models.Lobby
+	findErr := s.db.First(&foundLobby, fixtureLobbyCondition, lobby.LobbyID).Error
+	s.NoError(findErr, "Lobby should still exist because the transaction should have failed")
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDeleteNotFound() {
+	err := s.lobbyRepo.Delete("non-existent-id")
+	s.ErrorIs(err, ErrLobbyNotFound)
+}
+
+// createTicketInDB puts the user in the queue of the duel in Europe, since the given time.
+func (s *LobbySQLRepositoryTestSuite) createTicketInDB(user models.User, queuedAt time.Time) models.MatchTicket {
+	ticket := models.MatchTicket{UserID: user.ID, GameMode: "DUEL", Region: "EU", QueuedAt: queuedAt}
+	s.Require().NoError(s.db.Create(&ticket).Error)
+	return ticket
+}
+
+func (s *LobbySQLRepositoryTestSuite) pendingMatch() *models.PendingMatch {
+	return &models.PendingMatch{
+		MatchID:  uuid.New().String(),
+		GameMode: "DUEL",
+		Region:   "EU",
+		Status:   models.PendingMatchPending,
+		Deadline: fixtureCreatedAt.Add(time.Minute),
+	}
+}
+
+// formedMatch pairs the tickets of the two players into a pending match.
+func (s *LobbySQLRepositoryTestSuite) formedMatch(player1, player2 models.User) *models.PendingMatch {
+	s.createTicketInDB(player1, fixtureCreatedAt)
+	s.createTicketInDB(player2, fixtureCreatedAt.Add(time.Second))
+	match := s.pendingMatch()
+	s.Require().NoError(s.lobbyRepo.FormMatch(match, 2))
+	return match
+}
+
+func (s *LobbySQLRepositoryTestSuite) matchLobbyOf(players ...models.User) *models.Lobby {
+	lobby := &models.Lobby{LobbyID: uuid.New().String(), Name: fixtureLobbyName, Status: models.LobbyStatusInProgress}
+	for i, player := range players {
+		lobby.Players = append(lobby.Players, models.LobbyPlayer{UserID: player.ID, User: player, Seat: i})
+	}
+	return lobby
+}
+
+func (s *LobbySQLRepositoryTestSuite) ticketOf(userID uint) *models.MatchTicket {
+	ticket, err := s.lobbyRepo.FindTicket(userID)
+	s.Require().NoError(err)
+	return ticket
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestEnqueueFailsWhenTheUserIsAlreadySearching() {
+	player := s.createUserInDB("player1", nil)
+	s.createTicketInDB(player, fixtureCreatedAt)
+
+	err := s.lobbyRepo.Enqueue(&models.MatchTicket{UserID: player.ID, GameMode: "TEAMS", Region: "NA", QueuedAt: fixtureCreatedAt})
+
+	s.ErrorIs(err, ErrAlreadySearching)
+	s.Equal("DUEL", s.ticketOf(player.ID).GameMode)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFindTicketNotFound() {
+	ticket, err := s.lobbyRepo.FindTicket(42)
+
+	s.ErrorIs(err, ErrTicketNotFound)
+	s.Nil(ticket)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDequeueDeletesTheTicket() {
+	player := s.createUserInDB("player1", nil)
+	s.createTicketInDB(player, fixtureCreatedAt)
+
+	err := s.lobbyRepo.Dequeue(player.ID)
+
+	s.NoError(err)
+	_, err = s.lobbyRepo.FindTicket(player.ID)
+	s.ErrorIs(err, ErrTicketNotFound)
+	s.ErrorIs(s.lobbyRepo.Dequeue(player.ID), ErrTicketNotFound)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestDequeueFailsWhileTheTicketIsPartOfAMatch() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+
+	err := s.lobbyRepo.Dequeue(player1.ID)
+
+	s.ErrorIs(err, ErrTicketMatched)
+	s.Equal(match.MatchID, *s.ticketOf(player1.ID).PendingMatchID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFormMatchPairsTheTicketsThatWaitedTheLongest() {
+	latecomer := s.createUserInDB("latecomer", nil)
+	s.createTicketInDB(latecomer, fixtureCreatedAt.Add(time.Minute))
+	player1 := s.createUserInDB("player1", nil)
+	s.createTicketInDB(player1, fixtureCreatedAt)
+	player2 := s.createUserInDB("player2", nil)
+	s.createTicketInDB(player2, fixtureCreatedAt.Add(time.Second))
+	elsewhere := s.createUserInDB("elsewhere", nil)
+	s.Require().NoError(s.db.Create(&models.MatchTicket{UserID: elsewhere.ID, GameMode: "DUEL", Region: "NA",
+		QueuedAt: fixtureCreatedAt.Add(-time.Hour)}).Error)
+	match := s.pendingMatch()
+
+	err := s.lobbyRepo.FormMatch(match, 2)
+
+	s.NoError(err)
+	s.Require().Len(match.Tickets, 2)
+	s.Equal("player1", match.Tickets[0].User.Username)
+	s.Equal("player2", match.Tickets[1].User.Username)
+	foundMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Equal(models.PendingMatchPending, foundMatch.Status)
+	s.Require().Len(foundMatch.Tickets, 2)
+	s.Equal(player1.ID, foundMatch.Tickets[0].UserID)
+	s.Equal(player2.ID, foundMatch.Tickets[1].UserID)
+	s.Nil(s.ticketOf(latecomer.ID).PendingMatchID)
+	s.Nil(s.ticketOf(elsewhere.ID).PendingMatchID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFormMatchFailsWithoutEnoughTickets() {
+	player := s.createUserInDB("player1", nil)
+	s.createTicketInDB(player, fixtureCreatedAt)
+	match := s.pendingMatch()
+
+	err := s.lobbyRepo.FormMatch(match, 2)
+
+	s.ErrorIs(err, ErrNotEnoughTickets)
+	_, err = s.lobbyRepo.FindMatch(match.MatchID)
+	s.ErrorIs(err, ErrMatchNotFound)
+	s.Nil(s.ticketOf(player.ID).PendingMatchID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFormMatchLeavesOutTheTicketsOfAnotherMatch() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	s.formedMatch(player1, player2)
+	player3 := s.createUserInDB("player3", nil)
+	s.createTicketInDB(player3, fixtureCreatedAt.Add(time.Minute))
+
+	err := s.lobbyRepo.FormMatch(s.pendingMatch(), 2)
+
+	s.ErrorIs(err, ErrNotEnoughTickets)
+	s.Nil(s.ticketOf(player3.ID).PendingMatchID)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAcceptMatchRecordsTheAcceptanceOfThePlayer() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+	acceptedAt := fixtureCreatedAt.Add(10 * time.Second)
+
+	err := s.lobbyRepo.AcceptMatch(match, player2.ID, acceptedAt)
+
+	s.NoError(err)
+	s.Nil(match.Tickets[0].AcceptedAt)
+	s.Equal(acceptedAt, *match.Tickets[1].AcceptedAt)
+	s.Nil(s.ticketOf(player1.ID).AcceptedAt)
+	s.True(acceptedAt.Equal(*s.ticketOf(player2.ID).AcceptedAt))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestAcceptMatchFailsOnceTheMatchIsOver() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+	staleMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.lobbyRepo.FailMatch(match, models.PendingMatchDeclined, []uint{player1.ID}))
+
+	err = s.lobbyRepo.AcceptMatch(staleMatch, player2.ID, fixtureCreatedAt)
+
+	s.ErrorIs(err, ErrMatchOver)
+	s.Nil(s.ticketOf(player2.ID).AcceptedAt)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateMatchLobbyStoresTheLobbyAndDeletesTheTickets() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+	lobby := s.matchLobbyOf(player1, player2)
+
+	err := s.lobbyRepo.CreateMatchLobby(match, lobby)
+
+	s.NoError(err)
+	s.Equal(models.PendingMatchAccepted, match.Status)
+	s.Equal(lobby.LobbyID, *match.LobbyID)
+	foundMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Equal(models.PendingMatchAccepted, foundMatch.Status)
+	s.Equal(lobby.LobbyID, *foundMatch.LobbyID)
+	s.Empty(foundMatch.Tickets)
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(player1.ID))
+	s.Equal(lobby.LobbyID, *s.currentLobbyOf(player2.ID))
+	_, err = s.lobbyRepo.FindTicket(player1.ID)
+	s.ErrorIs(err, ErrTicketNotFound)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateMatchLobbyOnlyOnce() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+	staleMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.lobbyRepo.CreateMatchLobby(match, s.matchLobbyOf(player1, player2)))
+
+	err = s.lobbyRepo.CreateMatchLobby(staleMatch, s.matchLobbyOf(player1, player2))
+
+	s.ErrorIs(err, ErrMatchOver)
+	var lobbies int64
+	s.db.Model(&models.Lobby{}).Count(&lobbies)
+	s.Equal(int64(1), lobbies)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestCreateMatchLobbyFailsWhenAPlayerIsInAnActiveLobby() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+	activeLobby := s.createLobbyInDB("Active", models.LobbyStatusWaiting)
+	s.Require().NoError(s.db.Create(&models.LobbyPlayer{LobbyID: activeLobby.LobbyID, UserID: player2.ID}).Error)
+
+	err := s.lobbyRepo.CreateMatchLobby(match, s.matchLobbyOf(player1, player2))
+
+	s.ErrorIs(err, ErrPlayerInLobby)
+	foundMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Equal(models.PendingMatchPending, foundMatch.Status)
+	s.Len(foundMatch.Tickets, 2)
+	s.Nil(s.currentLobbyOf(player1.ID))
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFailMatchPutsTheOtherTicketsBackInTheQueue() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+	s.Require().NoError(s.lobbyRepo.AcceptMatch(match, player1.ID, fixtureCreatedAt))
+
+	err := s.lobbyRepo.FailMatch(match, models.PendingMatchDeclined, []uint{player2.ID})
+
+	s.NoError(err)
+	s.Equal(models.PendingMatchDeclined, match.Status)
+	foundMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Equal(models.PendingMatchDeclined, foundMatch.Status)
+	s.Empty(foundMatch.Tickets)
+	ticket := s.ticketOf(player1.ID)
+	s.Nil(ticket.PendingMatchID)
+	s.Nil(ticket.AcceptedAt)
+	s.True(fixtureCreatedAt.Equal(ticket.QueuedAt))
+	_, err = s.lobbyRepo.FindTicket(player2.ID)
+	s.ErrorIs(err, ErrTicketNotFound)
+}
+
+func (s *LobbySQLRepositoryTestSuite) TestFailMatchOnlyOnce() {
+	player1 := s.createUserInDB("player1", nil)
+	player2 := s.createUserInDB("player2", nil)
+	match := s.formedMatch(player1, player2)
+	staleMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.lobbyRepo.FailMatch(match, models.PendingMatchExpired, nil))
+
+	err = s.lobbyRepo.FailMatch(staleMatch, models.PendingMatchDeclined, []uint{player1.ID})
+
+	s.ErrorIs(err, ErrMatchOver)
+	s.Nil(s.ticketOf(player1.ID).PendingMatchID)
+	foundMatch, err := s.lobbyRepo.FindMatch(match.MatchID)
+	s.Require().NoError(err)
+	s.Equal(models.PendingMatchExpired, foundMatch.Status)
+}
+
+// TestAddPlayerWithParallelJoins runs on a database file rather than in memory, since the writers of an in-memory
+// database do not wait for each other but fail right away.
+func TestAddPlayerWithParallelJoins(t *testing.T) {
+	const joiners = 20
+	dsn := "file:" + filepath.Join(t.TempDir(), "lobbies.db") + "?_busy_timeout=10000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.User{}, &models.Lobby{}, &models.LobbyPlayer{}, &models.LobbySpectator{}, &models.LobbyTimer{}))
+	repo := NewSQLLobbyRepository(db)
+
+	lobby := models.Lobby{LobbyID: uuid.New().String(), Name: fixtureLobbyName, Status: models.LobbyStatusWaiting}
+	require.NoError(t, db.Create(&lobby).Error)
+	creator := models.User{Username: "creator", Password: "password"}
+	require.NoError(t, db.Create(&creator).Error)
+	require.NoError(t, db.Create(&models.LobbyPlayer{LobbyID: lobby.LobbyID, UserID: creator.ID}).Error)
+	players := make([]models.User, joiners)
+	for i := range players {
+		players[i] = models.User{Username: fmt.Sprintf("player%d", i), Password: "password"}
+		require.NoError(t, db.Create(&players[i]).Error)
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, joiners)
+	for i := range players {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			// Every joiner reads the lobby before joining it, as the lobby service does.
+			found, err := repo.FindByID(lobby.LobbyID)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = repo.AddPlayer(found, &players[i], 2)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	joined := 0
+	for _, err := range errs {
+		if err == nil {
+			joined++
+			continue
+		}
+		assert.True(t, errors.Is(err, ErrLobbyConflict) || errors.Is(err, ErrLobbyFull), "unexpected error: %v", err)
+	}
+	assert.Equal(t, 1, joined)
+	var seated int64
+	require.NoError(t, db.Model(&models.LobbyPlayer{}).Where("lobby_id = ?", lobby.LobbyID).Count(&seated).Error)
+	assert.Equal(t, int64(2), seated)
 }
 
 func TestLobbyRepository(t *testing.T) {
@@ -0,0 +1,38 @@
+package party
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+)
+
+var (
+	ErrPartyNotFound  = errors.New("party not found in the database")
+	ErrInviteNotFound = errors.New("party invite not found in the database")
+	ErrAlreadyInParty = errors.New("user is already in a party")
+	ErrPartyFull      = errors.New("party is full")
+	ErrNotInParty     = errors.New("user is not in the party")
+)
+
+type PartyRepository interface {
+	// Create stores the party with its leader as the only member. It fails with ErrAlreadyInParty when the leader
+	// belongs to another party.
+	Create(party *models.Party) error
+	FindByID(partyID uint) (*models.Party, error)
+	// FindByMember returns the party the user belongs to, or ErrPartyNotFound.
+	FindByMember(userID uint) (*models.Party, error)
+	// AddMember adds the user to the party, as long as it has less than maxSize members. It fails with
+	// ErrAlreadyInParty when the user belongs to a party, this one included.
+	AddMember(party *models.Party, user *models.User, maxSize int) error
+	// RemoveMember removes the user from the party. A leader that leaves is replaced by the member that joined first,
+	// and the last member that leaves disbands the party.
+	RemoveMember(party *models.Party, userID uint) error
+	CreateInvite(invite *models.PartyInvite) error
+	FindInvite(inviteID uint) (*models.PartyInvite, error)
+	// FindPendingInvite returns the invite for the user to the party that is still pending at the given time.
+	FindPendingInvite(partyID, inviteeID uint, now time.Time) (*models.PartyInvite, error)
+	// ListPendingInvites returns the invites addressed to the user that are still pending at the given time.
+	ListPendingInvites(inviteeID uint, now time.Time) []*models.PartyInvite
+	UpdateInviteStatus(invite *models.PartyInvite, status models.InviteStatus) error
+}
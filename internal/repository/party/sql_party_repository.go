@@ -0,0 +1,181 @@
+package party
+
+import (
+	"errors"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+)
+
+type sqlPartyRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLPartyRepository(db *gorm.DB) PartyRepository {
+	return &sqlPartyRepository{db: db}
+}
+
+func (r *sqlPartyRepository) Create(party *models.Party) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		busy, err := inParty(tx, party.LeaderID)
+		if err != nil {
+			return err
+		}
+		if busy {
+			return ErrAlreadyInParty
+		}
+
+		party.Members = []models.PartyMember{{UserID: party.LeaderID, User: party.Leader, JoinedAt: tx.NowFunc()}}
+		return tx.Omit("Leader", "Members.User").Create(party).Error
+	})
+}
+
+// inParty reports whether the user belongs to a party.
+func inParty(tx *gorm.DB, userID uint) (bool, error) {
+	var memberships int64
+	err := tx.Model(&models.PartyMember{}).Where("user_id = ?", userID).Count(&memberships).Error
+	return memberships > 0, err
+}
+
+// withMembers preloads the leader and the members of the parties, in order of arrival.
+func withMembers(db *gorm.DB) *gorm.DB {
+	return db.Preload("Leader").Preload("Members", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("joined_at").Order("id")
+	}).Preload("Members.User")
+}
+
+func (r *sqlPartyRepository) FindByID(partyID uint) (*models.Party, error) {
+	var party models.Party
+	result := withMembers(r.db).First(&party, partyID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrPartyNotFound
+	}
+	return &party, result.Error
+}
+
+func (r *sqlPartyRepository) FindByMember(userID uint) (*models.Party, error) {
+	var membership models.PartyMember
+	result := r.db.First(&membership, "user_id = ?", userID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrPartyNotFound
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.FindByID(membership.PartyID)
+}
+
+// AddMember locks the party row with an update before counting the members, so that two users can not both take
+// the last place.
+func (r *sqlPartyRepository) AddMember(party *models.Party, user *models.User, maxSize int) error {
+	membership := models.PartyMember{PartyID: party.ID, UserID: user.ID}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Party{}).Where("id = ?", party.ID).Update("updated_at", tx.NowFunc())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPartyNotFound
+		}
+
+		busy, err := inParty(tx, user.ID)
+		if err != nil {
+			return err
+		}
+		if busy {
+			return ErrAlreadyInParty
+		}
+
+		var members int64
+		if err := tx.Model(&models.PartyMember{}).Where("party_id = ?", party.ID).Count(&members).Error; err != nil {
+			return err
+		}
+		if members >= int64(maxSize) {
+			return ErrPartyFull
+		}
+
+		membership.JoinedAt = tx.NowFunc()
+		return tx.Omit("User").Create(&membership).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	membership.User = *user
+	party.Members = append(party.Members, membership)
+	return nil
+}
+
+func (r *sqlPartyRepository) RemoveMember(party *models.Party, userID uint) error {
+	var remaining []models.PartyMember
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("party_id = ? AND user_id = ?", party.ID, userID).Delete(&models.PartyMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotInParty
+		}
+
+		err := tx.Preload("User").Where("party_id = ?", party.ID).Order("joined_at").Order("id").Find(&remaining).Error
+		if err != nil {
+			return err
+		}
+		if len(remaining) == 0 {
+			return tx.Delete(&models.Party{}, party.ID).Error
+		}
+		if party.LeaderID == userID {
+			return tx.Model(&models.Party{}).Where("id = ?", party.ID).Update("leader_id", remaining[0].UserID).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	party.Members = remaining
+	if len(remaining) > 0 && party.LeaderID == userID {
+		party.LeaderID = remaining[0].UserID
+		party.Leader = remaining[0].User
+	}
+	return nil
+}
+
+func (r *sqlPartyRepository) CreateInvite(invite *models.PartyInvite) error {
+	return r.db.Omit("Inviter", "Invitee").Create(invite).Error
+}
+
+func (r *sqlPartyRepository) FindInvite(inviteID uint) (*models.PartyInvite, error) {
+	var invite models.PartyInvite
+	result := r.db.Preload("Inviter").Preload("Invitee").First(&invite, inviteID)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrInviteNotFound
+	}
+	return &invite, result.Error
+}
+
+func (r *sqlPartyRepository) FindPendingInvite(partyID, inviteeID uint, now time.Time) (*models.PartyInvite, error) {
+	var invite models.PartyInvite
+	result := r.db.
+		Where("party_id = ? AND invitee_id = ? AND status = ? AND expires_at > ?",
+			partyID, inviteeID, models.InviteStatusPending, now).
+		First(&invite)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, ErrInviteNotFound
+	}
+	return &invite, result.Error
+}
+
+func (r *sqlPartyRepository) ListPendingInvites(inviteeID uint, now time.Time) []*models.PartyInvite {
+	var invites []*models.PartyInvite
+	r.db.Preload("Inviter").Preload("Invitee").
+		Where("invitee_id = ? AND status = ? AND expires_at > ?", inviteeID, models.InviteStatusPending, now).
+		Order("created_at").
+		Find(&invites)
+	return invites
+}
+
+func (r *sqlPartyRepository) UpdateInviteStatus(invite *models.PartyInvite, status models.InviteStatus) error {
+	return r.db.Model(invite).Update("status", status).Error
+}
@@ -0,0 +1,232 @@
+package party
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type PartySQLRepositoryTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	partyRepo PartyRepository
+	leader    models.User
+	friend    models.User
+	now       time.Time
+}
+
+func (s *PartySQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *PartySQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *PartySQLRepositoryTestSuite) SetupTest() {
+	err := s.db.Migrator().DropTable(&models.User{}, &models.Party{}, &models.PartyMember{}, &models.PartyInvite{})
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(&models.User{}, &models.Party{}, &models.PartyMember{}, &models.PartyInvite{})
+	s.Require().NoError(err)
+
+	s.leader = s.createUserInDB("leader")
+	s.friend = s.createUserInDB("friend")
+	s.now = time.Now().UTC()
+
+	s.partyRepo = NewSQLPartyRepository(s.db)
+}
+
+func (s *PartySQLRepositoryTestSuite) createUserInDB(username string) models.User {
+	user := models.User{Username: username, Password: "password"}
+	s.Require().NoError(s.db.Create(&user).Error)
+	return user
+}
+
+func (s *PartySQLRepositoryTestSuite) createPartyInDB(leader models.User, members ...models.User) *models.Party {
+	party := &models.Party{LeaderID: leader.ID, Leader: leader}
+	s.Require().NoError(s.partyRepo.Create(party))
+	for _, member := range members {
+		s.Require().NoError(s.partyRepo.AddMember(party, &member, 10))
+	}
+	return party
+}
+
+func (s *PartySQLRepositoryTestSuite) TestCreateMakesTheLeaderTheFirstMember() {
+	party := &models.Party{LeaderID: s.leader.ID, Leader: s.leader}
+
+	err := s.partyRepo.Create(party)
+
+	s.NoError(err)
+	found, err := s.partyRepo.FindByID(party.ID)
+	s.Require().NoError(err)
+	s.Equal("leader", found.Leader.Username)
+	s.Require().Len(found.Members, 1)
+	s.Equal("leader", found.Members[0].User.Username)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestCreateFailsWhenTheLeaderIsInAnotherParty() {
+	s.createPartyInDB(s.friend, s.leader)
+
+	err := s.partyRepo.Create(&models.Party{LeaderID: s.leader.ID})
+
+	s.ErrorIs(err, ErrAlreadyInParty)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestFindByMember() {
+	party := s.createPartyInDB(s.leader, s.friend)
+
+	found, err := s.partyRepo.FindByMember(s.friend.ID)
+
+	s.NoError(err)
+	s.Equal(party.ID, found.ID)
+	s.Require().Len(found.Members, 2)
+	s.Equal("leader", found.Members[0].User.Username)
+	s.Equal("friend", found.Members[1].User.Username)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestFindByMemberWhenTheUserIsNotInAParty() {
+	_, err := s.partyRepo.FindByMember(s.friend.ID)
+
+	s.ErrorIs(err, ErrPartyNotFound)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestAddMemberFailsWhenThePartyIsFull() {
+	party := s.createPartyInDB(s.leader)
+
+	err := s.partyRepo.AddMember(party, &s.friend, 1)
+
+	s.ErrorIs(err, ErrPartyFull)
+	_, err = s.partyRepo.FindByMember(s.friend.ID)
+	s.ErrorIs(err, ErrPartyNotFound)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestAddMemberFailsWhenTheUserIsInAParty() {
+	party := s.createPartyInDB(s.leader)
+	s.createPartyInDB(s.friend)
+
+	err := s.partyRepo.AddMember(party, &s.friend, 4)
+
+	s.ErrorIs(err, ErrAlreadyInParty)
+	s.Len(party.Members, 1)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestAddMemberFailsWhenThePartyWasDisbanded() {
+	party := s.createPartyInDB(s.leader)
+	s.Require().NoError(s.partyRepo.RemoveMember(party, s.leader.ID))
+
+	err := s.partyRepo.AddMember(party, &s.friend, 4)
+
+	s.ErrorIs(err, ErrPartyNotFound)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestRemoveMemberPassesTheLeadershipToTheOldestMember() {
+	other := s.createUserInDB("other")
+	party := s.createPartyInDB(s.leader, s.friend, other)
+
+	err := s.partyRepo.RemoveMember(party, s.leader.ID)
+
+	s.NoError(err)
+	s.Equal(s.friend.ID, party.LeaderID)
+	found, err := s.partyRepo.FindByID(party.ID)
+	s.Require().NoError(err)
+	s.Equal("friend", found.Leader.Username)
+	s.Len(found.Members, 2)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestRemoveMemberKeepsTheLeader() {
+	party := s.createPartyInDB(s.leader, s.friend)
+
+	err := s.partyRepo.RemoveMember(party, s.friend.ID)
+
+	s.NoError(err)
+	found, err := s.partyRepo.FindByID(party.ID)
+	s.Require().NoError(err)
+	s.Equal(s.leader.ID, found.LeaderID)
+	s.Len(found.Members, 1)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestRemoveTheLastMemberDisbandsTheParty() {
+	party := s.createPartyInDB(s.leader)
+
+	err := s.partyRepo.RemoveMember(party, s.leader.ID)
+
+	s.NoError(err)
+	_, err = s.partyRepo.FindByID(party.ID)
+	s.ErrorIs(err, ErrPartyNotFound)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestRemoveMemberFailsWhenTheUserIsNotInTheParty() {
+	party := s.createPartyInDB(s.leader)
+
+	err := s.partyRepo.RemoveMember(party, s.friend.ID)
+
+	s.ErrorIs(err, ErrNotInParty)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestPendingInvites() {
+	party := s.createPartyInDB(s.leader)
+	pending := &models.PartyInvite{
+		PartyID:   party.ID,
+		InviterID: s.leader.ID,
+		InviteeID: s.friend.ID,
+		Status:    models.InviteStatusPending,
+		ExpiresAt: s.now.Add(time.Minute),
+	}
+	s.Require().NoError(s.partyRepo.CreateInvite(pending))
+	expired := &models.PartyInvite{
+		PartyID:   party.ID,
+		InviterID: s.leader.ID,
+		InviteeID: s.friend.ID,
+		Status:    models.InviteStatusPending,
+		ExpiresAt: s.now.Add(-time.Minute),
+	}
+	s.Require().NoError(s.partyRepo.CreateInvite(expired))
+
+	invites := s.partyRepo.ListPendingInvites(s.friend.ID, s.now)
+	found, err := s.partyRepo.FindPendingInvite(party.ID, s.friend.ID, s.now)
+
+	s.Require().Len(invites, 1)
+	s.Equal(pending.ID, invites[0].ID)
+	s.Equal("leader", invites[0].Inviter.Username)
+	s.NoError(err)
+	s.Equal(pending.ID, found.ID)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestUpdateInviteStatus() {
+	party := s.createPartyInDB(s.leader)
+	invite := &models.PartyInvite{
+		PartyID:   party.ID,
+		InviterID: s.leader.ID,
+		InviteeID: s.friend.ID,
+		Status:    models.InviteStatusPending,
+		ExpiresAt: s.now.Add(time.Minute),
+	}
+	s.Require().NoError(s.partyRepo.CreateInvite(invite))
+
+	err := s.partyRepo.UpdateInviteStatus(invite, models.InviteStatusDeclined)
+
+	s.NoError(err)
+	found, err := s.partyRepo.FindInvite(invite.ID)
+	s.Require().NoError(err)
+	s.Equal(models.InviteStatusDeclined, found.Status)
+	_, err = s.partyRepo.FindPendingInvite(party.ID, s.friend.ID, s.now)
+	s.ErrorIs(err, ErrInviteNotFound)
+}
+
+func (s *PartySQLRepositoryTestSuite) TestFindInviteNotFound() {
+	_, err := s.partyRepo.FindInvite(42)
+
+	s.ErrorIs(err, ErrInviteNotFound)
+}
+
+func TestPartyRepository(t *testing.T) {
+	suite.Run(t, new(PartySQLRepositoryTestSuite))
+}
@@ -0,0 +1,17 @@
+package presence
+
+import (
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+)
+
+type PresenceRepository interface {
+	// Heartbeat records that the user is connected at heartbeatAt and until expiresAt, creating their presence on
+	// the first heartbeat.
+	Heartbeat(userID uint, heartbeatAt, expiresAt time.Time) error
+	// FindByUsers returns the presence of the given users. The users that never sent a heartbeat are left out.
+	FindByUsers(userIDs []uint) ([]*models.Presence, error)
+	// CountOnline returns how many users are online at the given time.
+	CountOnline(at time.Time) (int64, error)
+}
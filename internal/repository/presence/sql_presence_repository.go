@@ -0,0 +1,40 @@
+package presence
+
+import (
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlPresenceRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLPresenceRepository(db *gorm.DB) PresenceRepository {
+	return &sqlPresenceRepository{db: db}
+}
+
+func (r *sqlPresenceRepository) Heartbeat(userID uint, heartbeatAt, expiresAt time.Time) error {
+	presence := models.Presence{UserID: userID, LastHeartbeatAt: heartbeatAt, ExpiresAt: expiresAt}
+	return r.db.Omit("User").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_heartbeat_at", "expires_at"}),
+	}).Create(&presence).Error
+}
+
+func (r *sqlPresenceRepository) FindByUsers(userIDs []uint) ([]*models.Presence, error) {
+	var presences []*models.Presence
+	if len(userIDs) == 0 {
+		return presences, nil
+	}
+	err := r.db.Where("user_id IN ?", userIDs).Find(&presences).Error
+	return presences, err
+}
+
+func (r *sqlPresenceRepository) CountOnline(at time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Presence{}).Where("expires_at > ?", at).Count(&count).Error
+	return count, err
+}
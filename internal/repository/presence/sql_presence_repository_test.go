@@ -0,0 +1,107 @@
+package presence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+type PresenceSQLRepositoryTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	presenceRepo PresenceRepository
+	alice        models.User
+	bob          models.User
+}
+
+func (s *PresenceSQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *PresenceSQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *PresenceSQLRepositoryTestSuite) SetupTest() {
+	err := s.db.Migrator().DropTable(&models.User{}, &models.Presence{})
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(&models.User{}, &models.Presence{})
+	s.Require().NoError(err)
+
+	s.alice = s.createUserInDB("alice")
+	s.bob = s.createUserInDB("bob")
+
+	s.presenceRepo = NewSQLPresenceRepository(s.db)
+}
+
+func (s *PresenceSQLRepositoryTestSuite) createUserInDB(username string) models.User {
+	user := models.User{Username: username, Password: "password"}
+	s.Require().NoError(s.db.Create(&user).Error)
+	return user
+}
+
+func (s *PresenceSQLRepositoryTestSuite) TestHeartbeatCreatesThePresence() {
+	err := s.presenceRepo.Heartbeat(s.alice.ID, fixtureNow, fixtureNow.Add(time.Minute))
+
+	s.NoError(err)
+	presences, err := s.presenceRepo.FindByUsers([]uint{s.alice.ID})
+	s.Require().NoError(err)
+	s.Require().Len(presences, 1)
+	s.True(presences[0].LastHeartbeatAt.Equal(fixtureNow))
+	s.True(presences[0].ExpiresAt.Equal(fixtureNow.Add(time.Minute)))
+}
+
+func (s *PresenceSQLRepositoryTestSuite) TestHeartbeatExtendsThePresence() {
+	s.Require().NoError(s.presenceRepo.Heartbeat(s.alice.ID, fixtureNow, fixtureNow.Add(time.Minute)))
+	later := fixtureNow.Add(30 * time.Second)
+
+	err := s.presenceRepo.Heartbeat(s.alice.ID, later, later.Add(time.Minute))
+
+	s.NoError(err)
+	presences, err := s.presenceRepo.FindByUsers([]uint{s.alice.ID})
+	s.Require().NoError(err)
+	s.Require().Len(presences, 1)
+	s.True(presences[0].LastHeartbeatAt.Equal(later))
+	s.True(presences[0].ExpiresAt.Equal(later.Add(time.Minute)))
+}
+
+func (s *PresenceSQLRepositoryTestSuite) TestFindByUsersLeavesOutTheUntrackedUsers() {
+	s.Require().NoError(s.presenceRepo.Heartbeat(s.alice.ID, fixtureNow, fixtureNow.Add(time.Minute)))
+
+	presences, err := s.presenceRepo.FindByUsers([]uint{s.alice.ID, s.bob.ID})
+
+	s.NoError(err)
+	s.Require().Len(presences, 1)
+	s.Equal(s.alice.ID, presences[0].UserID)
+}
+
+func (s *PresenceSQLRepositoryTestSuite) TestFindByUsersWithoutUsers() {
+	presences, err := s.presenceRepo.FindByUsers(nil)
+
+	s.NoError(err)
+	s.Empty(presences)
+}
+
+func (s *PresenceSQLRepositoryTestSuite) TestCountOnlineSkipsTheExpiredPresences() {
+	s.Require().NoError(s.presenceRepo.Heartbeat(s.alice.ID, fixtureNow, fixtureNow.Add(time.Minute)))
+	s.Require().NoError(s.presenceRepo.Heartbeat(s.bob.ID, fixtureNow.Add(-2*time.Minute), fixtureNow.Add(-time.Minute)))
+
+	count, err := s.presenceRepo.CountOnline(fixtureNow)
+
+	s.NoError(err)
+	s.Equal(int64(1), count)
+}
+
+func TestPresenceRepository(t *testing.T) {
+	suite.Run(t, new(PresenceSQLRepositoryTestSuite))
+}
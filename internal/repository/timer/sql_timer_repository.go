@@ -0,0 +1,38 @@
+package timer
+
+import (
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlTimerRepository struct {
+	db *gorm.DB
+}
+
+func NewSQLTimerRepository(db *gorm.DB) TimerRepository {
+	return &sqlTimerRepository{db: db}
+}
+
+func (r *sqlTimerRepository) Save(timer *models.LobbyTimer) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "lobby_id"}, {Name: "kind"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fires_at", "updated_at"}),
+	}).Create(timer).Error
+}
+
+func (r *sqlTimerRepository) Delete(timer *models.LobbyTimer) error {
+	return r.db.
+		Where("lobby_id = ? AND kind = ? AND fires_at = ?", timer.LobbyID, timer.Kind, timer.FiresAt).
+		Delete(&models.LobbyTimer{}).Error
+}
+
+func (r *sqlTimerRepository) DeleteByKind(lobbyID string, kind models.LobbyTimerKind) error {
+	return r.db.Where("lobby_id = ? AND kind = ?", lobbyID, kind).Delete(&models.LobbyTimer{}).Error
+}
+
+func (r *sqlTimerRepository) ListAll() ([]*models.LobbyTimer, error) {
+	var timers []*models.LobbyTimer
+	err := r.db.Order("fires_at").Find(&timers).Error
+	return timers, err
+}
@@ -0,0 +1,112 @@
+package timer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const fixtureLobbyID = "lobby-123"
+
+type TimerSQLRepositoryTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	timerRepo TimerRepository
+	now       time.Time
+}
+
+func (s *TimerSQLRepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *TimerSQLRepositoryTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *TimerSQLRepositoryTestSuite) SetupTest() {
+	err := s.db.Migrator().DropTable(&models.LobbyTimer{})
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(&models.LobbyTimer{})
+	s.Require().NoError(err)
+
+	s.now = time.Now().UTC()
+	s.timerRepo = NewSQLTimerRepository(s.db)
+}
+
+func (s *TimerSQLRepositoryTestSuite) TestSaveCreatesTheTimer() {
+	timer := &models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerWaiting, FiresAt: s.now}
+
+	err := s.timerRepo.Save(timer)
+
+	s.NoError(err)
+	timers, err := s.timerRepo.ListAll()
+	s.Require().NoError(err)
+	s.Require().Len(timers, 1)
+	s.Equal(models.LobbyTimerWaiting, timers[0].Kind)
+}
+
+func (s *TimerSQLRepositoryTestSuite) TestSaveMovesTheTimerOfTheSameKind() {
+	s.Require().NoError(s.timerRepo.Save(&models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerWaiting, FiresAt: s.now}))
+	s.Require().NoError(s.timerRepo.Save(&models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerGameEnd, FiresAt: s.now}))
+
+	later := s.now.Add(time.Minute)
+	err := s.timerRepo.Save(&models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerWaiting, FiresAt: later})
+
+	s.NoError(err)
+	timers, err := s.timerRepo.ListAll()
+	s.Require().NoError(err)
+	s.Require().Len(timers, 2)
+	s.Equal(models.LobbyTimerGameEnd, timers[0].Kind)
+	s.Equal(models.LobbyTimerWaiting, timers[1].Kind)
+	s.True(later.Equal(timers[1].FiresAt))
+}
+
+func (s *TimerSQLRepositoryTestSuite) TestDeleteSkipsMovedTimers() {
+	fired := &models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerReadyCheck, FiresAt: s.now}
+	s.Require().NoError(s.timerRepo.Save(fired))
+	s.Require().NoError(s.timerRepo.Save(&models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerReadyCheck, FiresAt: s.now.Add(time.Minute)}))
+
+	err := s.timerRepo.Delete(fired)
+
+	s.NoError(err)
+	timers, err := s.timerRepo.ListAll()
+	s.Require().NoError(err)
+	s.Len(timers, 1)
+}
+
+func (s *TimerSQLRepositoryTestSuite) TestDeleteRemovesTheFiredTimer() {
+	fired := &models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerReadyCheck, FiresAt: s.now}
+	s.Require().NoError(s.timerRepo.Save(fired))
+
+	err := s.timerRepo.Delete(fired)
+
+	s.NoError(err)
+	timers, err := s.timerRepo.ListAll()
+	s.Require().NoError(err)
+	s.Empty(timers)
+}
+
+func (s *TimerSQLRepositoryTestSuite) TestDeleteByKind() {
+	s.Require().NoError(s.timerRepo.Save(&models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerGameEnd, FiresAt: s.now}))
+	s.Require().NoError(s.timerRepo.Save(&models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerResultReport, FiresAt: s.now}))
+
+	err := s.timerRepo.DeleteByKind(fixtureLobbyID, models.LobbyTimerGameEnd)
+
+	s.NoError(err)
+	timers, err := s.timerRepo.ListAll()
+	s.Require().NoError(err)
+	s.Require().Len(timers, 1)
+	s.Equal(models.LobbyTimerResultReport, timers[0].Kind)
+}
+
+func TestTimerRepository(t *testing.T) {
+	suite.Run(t, new(TimerSQLRepositoryTestSuite))
+}
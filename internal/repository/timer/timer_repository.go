@@ -0,0 +1,14 @@
+package timer
+
+import (
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+)
+
+type TimerRepository interface {
+	// Save creates the timer, or moves the timer of the same lobby and kind to the new deadline.
+	Save(timer *models.LobbyTimer) error
+	// Delete removes the timer only if it has not been moved to another deadline in the meantime.
+	Delete(timer *models.LobbyTimer) error
+	DeleteByKind(lobbyID string, kind models.LobbyTimerKind) error
+	ListAll() ([]*models.LobbyTimer, error)
+}
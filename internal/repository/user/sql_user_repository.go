@@ -2,6 +2,7 @@ package user
 
 import (
 	"errors"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
 	"gorm.io/gorm"
@@ -46,3 +47,11 @@ func (r *sqlUserRepository) FindByID(id uint) (*models.User, error) {
 
 	return &retrievedUser, nil
 }
+
+func (r *sqlUserRepository) UpdatePassword(user *models.User, hashedPassword string) error {
+	return r.db.Model(user).Update("password", hashedPassword).Error
+}
+
+func (r *sqlUserRepository) UpdateLastSeen(username string, seenAt time.Time) error {
+	return r.db.Model(&models.User{}).Where("username = ?", username).Update("last_seen_at", seenAt).Error
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"testing"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
 	"github.com/stretchr/testify/suite"
@@ -90,6 +91,27 @@ func (s *SQLUserRepositoryTestSuite) TestFindByIDWhenUserIsMissingShouldReturnEr
 	s.ErrorIs(err, ErrUserNotFound)
 }
 
+func (s *SQLUserRepositoryTestSuite) TestUpdatePasswordSuccess() {
+	user := models.User{Username: UserFixtureUsername, Password: UserFixturePassword}
+	s.db.Create(&user)
+	err := s.repository.UpdatePassword(&user, "new-hash")
+	s.NoError(err)
+	var updatedUser models.User
+	s.db.First(&updatedUser, user.ID)
+	s.Equal("new-hash", updatedUser.Password)
+}
+
+func (s *SQLUserRepositoryTestSuite) TestUpdateLastSeenSuccess() {
+	user := models.User{Username: UserFixtureUsername, Password: UserFixturePassword}
+	s.db.Create(&user)
+	seenAt := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	err := s.repository.UpdateLastSeen(UserFixtureUsername, seenAt)
+	s.NoError(err)
+	var updatedUser models.User
+	s.db.First(&updatedUser, user.ID)
+	s.True(seenAt.Equal(*updatedUser.LastSeenAt))
+}
+
 func TestSQLUserRepository(t *testing.T) {
 	suite.Run(t, new(SQLUserRepositoryTestSuite))
 }
@@ -2,6 +2,7 @@ package user
 
 import (
 	"errors"
+	"time"
 
 	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
 )
@@ -15,4 +16,6 @@ type UserRepository interface {
 	Create(user *models.User) error
 	FindByUsername(username string) (*models.User, error)
 	FindByID(id uint) (*models.User, error)
+	UpdatePassword(user *models.User, hashedPassword string) error
+	UpdateLastSeen(username string, seenAt time.Time) error
 }
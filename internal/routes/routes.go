@@ -7,15 +7,37 @@ import (
 )
 
 type RoutesManager struct {
-	userHandler    *handlers.UserHandler
-	lobbyHandler   *handlers.LobbyHandler
-	authMiddleware *middleware.AuthMiddleware
+	userHandler        *handlers.UserHandler
+	lobbyHandler       *handlers.LobbyHandler
+	statsHandler       *handlers.StatsHandler
+	leaderboardHandler *handlers.LeaderboardHandler
+	chatHandler        *handlers.ChatHandler
+	partyHandler       *handlers.PartyHandler
+	friendHandler      *handlers.FriendHandler
+	presenceHandler    *handlers.PresenceHandler
+	authMiddleware     *middleware.AuthMiddleware
 }
 
 func NewRoutes(userHandler *handlers.UserHandler,
 	lobbyHandler *handlers.LobbyHandler,
+	statsHandler *handlers.StatsHandler,
+	leaderboardHandler *handlers.LeaderboardHandler,
+	chatHandler *handlers.ChatHandler,
+	partyHandler *handlers.PartyHandler,
+	friendHandler *handlers.FriendHandler,
+	presenceHandler *handlers.PresenceHandler,
 	authMiddleware *middleware.AuthMiddleware) *RoutesManager {
-	return &RoutesManager{userHandler: userHandler, lobbyHandler: lobbyHandler, authMiddleware: authMiddleware}
+	return &RoutesManager{
+		userHandler:        userHandler,
+		lobbyHandler:       lobbyHandler,
+		statsHandler:       statsHandler,
+		leaderboardHandler: leaderboardHandler,
+		chatHandler:        chatHandler,
+		partyHandler:       partyHandler,
+		friendHandler:      friendHandler,
+		presenceHandler:    presenceHandler,
+		authMiddleware:     authMiddleware,
+	}
 }
 
 func (m *RoutesManager) InitializeRoutes(router *gin.Engine) {
@@ -36,8 +58,41 @@ func (m *RoutesManager) InitializeRoutes(router *gin.Engine) {
 	protected.Use(middleware.EnsureLoggedIn())
 	{
 		protected.POST("/lobbies/create", m.lobbyHandler.CreateLobby)
+		protected.POST("/lobbies/join-by-code", m.lobbyHandler.JoinLobbyByCode)
 		protected.POST("/lobbies/:lobby_id/join", m.lobbyHandler.JoinLobby)
+		protected.POST("/lobbies/:lobby_id/spectate", m.lobbyHandler.SpectateLobby)
+		protected.POST("/lobbies/:lobby_id/invite", m.lobbyHandler.InviteToLobby)
+		protected.POST("/lobbies/:lobby_id/ready", m.lobbyHandler.SetReady)
+		protected.POST("/lobbies/:lobby_id/decline", m.lobbyHandler.DeclineReadyCheck)
+		protected.POST("/lobbies/:lobby_id/leave", m.lobbyHandler.LeaveLobby)
+		protected.POST("/lobbies/:lobby_id/rematch", m.lobbyHandler.RequestRematch)
+		protected.POST("/lobbies/:lobby_id/rematch/respond", m.lobbyHandler.RespondRematch)
+		protected.POST("/lobbies/:lobby_id/team", m.lobbyHandler.SwitchTeam)
+		protected.POST("/lobbies/:lobby_id/seat", m.lobbyHandler.SwapSeat)
+		protected.POST("/lobbies/:lobby_id/kick", m.lobbyHandler.KickPlayer)
+		protected.POST("/lobbies/:lobby_id/host", m.lobbyHandler.TransferHost)
+		protected.POST("/lobbies/:lobby_id/lock", m.lobbyHandler.SetLobbyLocked)
+		protected.POST("/lobbies/:lobby_id/name", m.lobbyHandler.RenameLobby)
 		protected.GET("/lobbies/:lobby_id", m.lobbyHandler.GetLobbyPage)
+		protected.POST("/lobbies/:lobby_id/messages", m.chatHandler.SendMessage)
+		protected.GET("/lobbies/:lobby_id/messages", m.chatHandler.StreamMessages)
+		protected.GET("/matches", m.lobbyHandler.GetMatchesPage)
+		protected.POST("/invites/:invite_id/accept", m.lobbyHandler.AcceptInvite)
+		protected.POST("/invites/:invite_id/decline", m.lobbyHandler.DeclineInvite)
+		protected.POST("/matchmaking/search", m.lobbyHandler.SearchMatch)
+		protected.POST("/matchmaking/cancel", m.lobbyHandler.CancelSearch)
+		protected.POST("/matchmaking/:match_id/accept", m.lobbyHandler.AcceptMatch)
+		protected.POST("/matchmaking/:match_id/decline", m.lobbyHandler.DeclineMatch)
+		protected.POST("/parties/create", m.partyHandler.CreateParty)
+		protected.POST("/parties/:party_id/invite", m.partyHandler.InviteToParty)
+		protected.POST("/parties/:party_id/leave", m.partyHandler.LeaveParty)
+		protected.POST("/party-invites/:invite_id/accept", m.partyHandler.AcceptPartyInvite)
+		protected.POST("/party-invites/:invite_id/decline", m.partyHandler.DeclinePartyInvite)
+		protected.POST("/friends/request", m.friendHandler.SendFriendRequest)
+		protected.POST("/friends/block", m.friendHandler.BlockUser)
+		protected.POST("/friends/:username/remove", m.friendHandler.RemoveFriend)
+		protected.POST("/friend-requests/:request_id/accept", m.friendHandler.AcceptFriendRequest)
+		protected.POST("/presence/heartbeat", m.presenceHandler.Heartbeat)
 
 		protected.PUT("/api/v1/lobbies/:lobby_id/finish", m.lobbyHandler.FinishLobby)
 
@@ -45,4 +100,6 @@ func (m *RoutesManager) InitializeRoutes(router *gin.Engine) {
 	}
 
 	router.GET("/", m.userHandler.ShowIndexPage)
+	router.GET("/users/:username", m.statsHandler.ShowProfilePage)
+	router.GET("/leaderboard", m.leaderboardHandler.ShowLeaderboardPage)
 }
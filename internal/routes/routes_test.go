@@ -17,6 +17,12 @@ func TestInitializeRoutes(t *testing.T) {
 	manager := NewRoutes(
 		&handlers.UserHandler{},
 		&handlers.LobbyHandler{},
+		&handlers.StatsHandler{},
+		&handlers.LeaderboardHandler{},
+		&handlers.ChatHandler{},
+		&handlers.PartyHandler{},
+		&handlers.FriendHandler{},
+		&handlers.PresenceHandler{},
 		&middleware.AuthMiddleware{},
 	)
 	manager.InitializeRoutes(router)
@@ -30,11 +36,46 @@ func TestInitializeRoutes(t *testing.T) {
 		{http.MethodGet, "/user/login"},
 		{http.MethodPost, "/user/login"},
 		{http.MethodPost, "/lobbies/create"},
+		{http.MethodPost, "/lobbies/join-by-code"},
 		{http.MethodPost, "/lobbies/:lobby_id/join"},
+		{http.MethodPost, "/lobbies/:lobby_id/spectate"},
+		{http.MethodPost, "/lobbies/:lobby_id/invite"},
+		{http.MethodPost, "/lobbies/:lobby_id/ready"},
+		{http.MethodPost, "/lobbies/:lobby_id/decline"},
+		{http.MethodPost, "/lobbies/:lobby_id/leave"},
+		{http.MethodPost, "/lobbies/:lobby_id/rematch"},
+		{http.MethodPost, "/lobbies/:lobby_id/rematch/respond"},
+		{http.MethodPost, "/lobbies/:lobby_id/team"},
+		{http.MethodPost, "/lobbies/:lobby_id/seat"},
+		{http.MethodPost, "/lobbies/:lobby_id/kick"},
+		{http.MethodPost, "/lobbies/:lobby_id/host"},
+		{http.MethodPost, "/lobbies/:lobby_id/lock"},
+		{http.MethodPost, "/lobbies/:lobby_id/name"},
 		{http.MethodGet, "/lobbies/:lobby_id"},
+		{http.MethodPost, "/lobbies/:lobby_id/messages"},
+		{http.MethodGet, "/lobbies/:lobby_id/messages"},
+		{http.MethodGet, "/matches"},
+		{http.MethodPost, "/invites/:invite_id/accept"},
+		{http.MethodPost, "/invites/:invite_id/decline"},
+		{http.MethodPost, "/matchmaking/search"},
+		{http.MethodPost, "/matchmaking/cancel"},
+		{http.MethodPost, "/matchmaking/:match_id/accept"},
+		{http.MethodPost, "/matchmaking/:match_id/decline"},
+		{http.MethodPost, "/parties/create"},
+		{http.MethodPost, "/parties/:party_id/invite"},
+		{http.MethodPost, "/parties/:party_id/leave"},
+		{http.MethodPost, "/party-invites/:invite_id/accept"},
+		{http.MethodPost, "/party-invites/:invite_id/decline"},
+		{http.MethodPost, "/friends/request"},
+		{http.MethodPost, "/friends/block"},
+		{http.MethodPost, "/friends/:username/remove"},
+		{http.MethodPost, "/friend-requests/:request_id/accept"},
+		{http.MethodPost, "/presence/heartbeat"},
 		{http.MethodPut, "/api/v1/lobbies/:lobby_id/finish"},
 		{http.MethodGet, "/user/logout"},
 		{http.MethodGet, "/"},
+		{http.MethodGet, "/users/:username"},
+		{http.MethodGet, "/leaderboard"},
 	}
 
 	registeredRoutes := router.Routes()
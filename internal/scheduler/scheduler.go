@@ -0,0 +1,154 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	timerrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/timer"
+)
+
+// Handler is run when a timer of the kind it is registered for fires. Handlers must be idempotent: a timer that
+// fired right before a restart may fire again once the scheduler is started.
+type Handler func(lobbyID string)
+
+// Scheduler owns the per-lobby deadlines. Timers are persisted, so that they survive restarts.
+type Scheduler interface {
+	Handle(kind models.LobbyTimerKind, handler Handler)
+	// Schedule arms the timer of the given kind for the lobby, replacing the previous one if any.
+	Schedule(lobbyID string, kind models.LobbyTimerKind, firesAt time.Time) error
+	Cancel(lobbyID string, kind models.LobbyTimerKind) error
+	// Start arms the persisted timers. The ones whose deadline already passed fire immediately.
+	// Every armed timer is stopped when the context is done.
+	Start(ctx context.Context) error
+}
+
+type stopper interface {
+	Stop() bool
+}
+
+// package-level variable used for test purpose only.
+var afterFunc = func(d time.Duration, f func()) stopper { return time.AfterFunc(d, f) }
+
+type timerKey struct {
+	lobbyID string
+	kind    models.LobbyTimerKind
+}
+
+type armedTimer struct {
+	firesAt time.Time
+	stopper stopper
+}
+
+type lobbyScheduler struct {
+	timerRepo timerrepo.TimerRepository
+
+	mu       sync.Mutex
+	handlers map[models.LobbyTimerKind]Handler
+	armed    map[timerKey]armedTimer
+}
+
+func NewScheduler(timerRepo timerrepo.TimerRepository) Scheduler {
+	return &lobbyScheduler{
+		timerRepo: timerRepo,
+		handlers:  make(map[models.LobbyTimerKind]Handler),
+		armed:     make(map[timerKey]armedTimer),
+	}
+}
+
+func (s *lobbyScheduler) Handle(kind models.LobbyTimerKind, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = handler
+}
+
+func (s *lobbyScheduler) Schedule(lobbyID string, kind models.LobbyTimerKind, firesAt time.Time) error {
+	timer := &models.LobbyTimer{LobbyID: lobbyID, Kind: kind, FiresAt: firesAt.UTC()}
+	if err := s.timerRepo.Save(timer); err != nil {
+		return fmt.Errorf("failed to persist the %s timer of lobby %s: %w", kind, lobbyID, err)
+	}
+	s.arm(timer)
+	return nil
+}
+
+func (s *lobbyScheduler) Cancel(lobbyID string, kind models.LobbyTimerKind) error {
+	s.mu.Lock()
+	key := timerKey{lobbyID: lobbyID, kind: kind}
+	if armed, ok := s.armed[key]; ok {
+		armed.stopper.Stop()
+		delete(s.armed, key)
+	}
+	s.mu.Unlock()
+
+	if err := s.timerRepo.DeleteByKind(lobbyID, kind); err != nil {
+		return fmt.Errorf("failed to delete the %s timer of lobby %s: %w", kind, lobbyID, err)
+	}
+	return nil
+}
+
+func (s *lobbyScheduler) Start(ctx context.Context) error {
+	timers, err := s.timerRepo.ListAll()
+	if err != nil {
+		return fmt.Errorf("failed to load the lobby timers: %w", err)
+	}
+	for _, timer := range timers {
+		s.arm(timer)
+	}
+	log.Printf("Scheduler started with %d pending lobby timers", len(timers))
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for key, armed := range s.armed {
+			armed.stopper.Stop()
+			delete(s.armed, key)
+		}
+	}()
+	return nil
+}
+
+// arm replaces the in-memory timer of the same lobby and kind, if any.
+func (s *lobbyScheduler) arm(timer *models.LobbyTimer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := timerKey{lobbyID: timer.LobbyID, kind: timer.Kind}
+	if armed, ok := s.armed[key]; ok {
+		armed.stopper.Stop()
+	}
+	fired := *timer
+	s.armed[key] = armedTimer{
+		firesAt: timer.FiresAt,
+		stopper: afterFunc(time.Until(timer.FiresAt), func() { s.fire(&fired) }),
+	}
+}
+
+// fire runs the handler, then deletes the persisted timer: a crash in between makes the timer fire again after
+// the restart, rather than being lost.
+func (s *lobbyScheduler) fire(timer *models.LobbyTimer) {
+	s.mu.Lock()
+	key := timerKey{lobbyID: timer.LobbyID, kind: timer.Kind}
+	armed, ok := s.armed[key]
+	// The timer has been cancelled or moved to another deadline after it was started.
+	if !ok || !armed.firesAt.Equal(timer.FiresAt) {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.armed, key)
+	handler := s.handlers[timer.Kind]
+	s.mu.Unlock()
+
+	if handler == nil {
+		log.Printf("No handler registered for the %s timer of lobby %s", timer.Kind, timer.LobbyID)
+	} else {
+		handler(timer.LobbyID)
+	}
+
+	if err := s.timerRepo.Delete(timer); err != nil {
+		log.Printf("Failed to delete the %s timer of lobby %s: %v", timer.Kind, timer.LobbyID, err)
+	}
+}
@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const fixtureLobbyID = "lobby-123"
+
+type MockTimerRepository struct {
+	mock.Mock
+}
+
+func (m *MockTimerRepository) Save(timer *models.LobbyTimer) error {
+	args := m.Called(timer)
+	return args.Error(0)
+}
+
+func (m *MockTimerRepository) Delete(timer *models.LobbyTimer) error {
+	args := m.Called(timer)
+	return args.Error(0)
+}
+
+func (m *MockTimerRepository) DeleteByKind(lobbyID string, kind models.LobbyTimerKind) error {
+	args := m.Called(lobbyID, kind)
+	return args.Error(0)
+}
+
+func (m *MockTimerRepository) ListAll() ([]*models.LobbyTimer, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LobbyTimer), args.Error(1)
+}
+
+// fakeTimer stands for a time.Timer that only fires when the test says so.
+type fakeTimer struct {
+	delay   time.Duration
+	f       func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.stopped = true
+	return true
+}
+
+type SchedulerTestSuite struct {
+	suite.Suite
+	timerRepo         *MockTimerRepository
+	scheduler         Scheduler
+	timers            []*fakeTimer
+	originalAfterFunc func(time.Duration, func()) stopper
+}
+
+func (s *SchedulerTestSuite) SetupTest() {
+	s.timerRepo = new(MockTimerRepository)
+	s.scheduler = NewScheduler(s.timerRepo)
+
+	s.timers = nil
+	s.originalAfterFunc = afterFunc
+	afterFunc = func(d time.Duration, f func()) stopper {
+		timer := &fakeTimer{delay: d, f: f}
+		s.timers = append(s.timers, timer)
+		return timer
+	}
+}
+
+func (s *SchedulerTestSuite) TearDownTest() {
+	afterFunc = s.originalAfterFunc
+}
+
+func (s *SchedulerTestSuite) TestScheduledTimerRunsTheHandlerAndIsDeleted() {
+	firesAt := time.Now().Add(time.Minute)
+	var fired []string
+	s.scheduler.Handle(models.LobbyTimerGameEnd, func(lobbyID string) { fired = append(fired, lobbyID) })
+	s.timerRepo.On("Save", mock.AnythingOfType("*models.LobbyTimer")).Return(nil)
+	s.timerRepo.On("Delete", mock.MatchedBy(func(t *models.LobbyTimer) bool {
+		return t.LobbyID == fixtureLobbyID && t.Kind == models.LobbyTimerGameEnd
+	})).Return(nil)
+
+	err := s.scheduler.Schedule(fixtureLobbyID, models.LobbyTimerGameEnd, firesAt)
+	s.Require().NoError(err)
+	s.Require().Len(s.timers, 1)
+	s.InDelta(time.Minute, s.timers[0].delay, float64(time.Second))
+	s.timers[0].f()
+
+	s.Equal([]string{fixtureLobbyID}, fired)
+	s.timerRepo.AssertExpectations(s.T())
+}
+
+func (s *SchedulerTestSuite) TestScheduleFailsWhenTheTimerCanNotBePersisted() {
+	s.timerRepo.On("Save", mock.AnythingOfType("*models.LobbyTimer")).Return(errors.New("db error"))
+
+	err := s.scheduler.Schedule(fixtureLobbyID, models.LobbyTimerGameEnd, time.Now())
+
+	s.Error(err)
+	s.Empty(s.timers)
+}
+
+func (s *SchedulerTestSuite) TestRescheduleReplacesThePreviousTimer() {
+	var fired int
+	s.scheduler.Handle(models.LobbyTimerWaiting, func(lobbyID string) { fired++ })
+	s.timerRepo.On("Save", mock.AnythingOfType("*models.LobbyTimer")).Return(nil)
+	s.timerRepo.On("Delete", mock.AnythingOfType("*models.LobbyTimer")).Return(nil)
+
+	s.Require().NoError(s.scheduler.Schedule(fixtureLobbyID, models.LobbyTimerWaiting, time.Now().Add(time.Minute)))
+	s.Require().NoError(s.scheduler.Schedule(fixtureLobbyID, models.LobbyTimerWaiting, time.Now().Add(time.Hour)))
+	s.Require().Len(s.timers, 2)
+	s.True(s.timers[0].stopped)
+
+	// A stale callback that was already running when the timer got replaced must not run the handler.
+	s.timers[0].f()
+	s.Equal(0, fired)
+	s.timers[1].f()
+	s.Equal(1, fired)
+}
+
+func (s *SchedulerTestSuite) TestCancelStopsAndDeletesTheTimer() {
+	var fired int
+	s.scheduler.Handle(models.LobbyTimerReadyCheck, func(lobbyID string) { fired++ })
+	s.timerRepo.On("Save", mock.AnythingOfType("*models.LobbyTimer")).Return(nil)
+	s.timerRepo.On("DeleteByKind", fixtureLobbyID, models.LobbyTimerReadyCheck).Return(nil)
+
+	s.Require().NoError(s.scheduler.Schedule(fixtureLobbyID, models.LobbyTimerReadyCheck, time.Now().Add(time.Minute)))
+	err := s.scheduler.Cancel(fixtureLobbyID, models.LobbyTimerReadyCheck)
+
+	s.NoError(err)
+	s.True(s.timers[0].stopped)
+	s.timers[0].f()
+	s.Equal(0, fired)
+	s.timerRepo.AssertNotCalled(s.T(), "Delete", mock.Anything)
+}
+
+func (s *SchedulerTestSuite) TestStartArmsThePersistedTimers() {
+	overdue := &models.LobbyTimer{LobbyID: fixtureLobbyID, Kind: models.LobbyTimerResultReport, FiresAt: time.Now().Add(-time.Minute)}
+	pending := &models.LobbyTimer{LobbyID: "lobby-456", Kind: models.LobbyTimerGameEnd, FiresAt: time.Now().Add(time.Minute)}
+	var fired []string
+	s.scheduler.Handle(models.LobbyTimerResultReport, func(lobbyID string) { fired = append(fired, lobbyID) })
+	s.timerRepo.On("ListAll").Return([]*models.LobbyTimer{overdue, pending}, nil)
+	s.timerRepo.On("Delete", mock.AnythingOfType("*models.LobbyTimer")).Return(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := s.scheduler.Start(ctx)
+
+	s.NoError(err)
+	s.Require().Len(s.timers, 2)
+	s.LessOrEqual(s.timers[0].delay, time.Duration(0))
+	s.Greater(s.timers[1].delay, time.Duration(0))
+	s.timers[0].f()
+	s.Equal([]string{fixtureLobbyID}, fired)
+}
+
+func (s *SchedulerTestSuite) TestStartFailsWhenTimersCanNotBeLoaded() {
+	s.timerRepo.On("ListAll").Return(nil, errors.New("db error"))
+
+	err := s.scheduler.Start(context.Background())
+
+	s.Error(err)
+	s.Empty(s.timers)
+}
+
+func (s *SchedulerTestSuite) TestTimerWithoutHandlerIsStillDeleted() {
+	s.timerRepo.On("Save", mock.AnythingOfType("*models.LobbyTimer")).Return(nil)
+	s.timerRepo.On("Delete", mock.AnythingOfType("*models.LobbyTimer")).Return(nil)
+
+	s.Require().NoError(s.scheduler.Schedule(fixtureLobbyID, models.LobbyTimerWaiting, time.Now()))
+	s.timers[0].f()
+
+	s.timerRepo.AssertCalled(s.T(), "Delete", mock.AnythingOfType("*models.LobbyTimer"))
+}
+
+func TestScheduler(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
@@ -0,0 +1,118 @@
+package season
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
+)
+
+// Config tells the rotator how long a season lasts and how often to check whether it ended.
+type Config struct {
+	Length   time.Duration
+	Interval time.Duration
+}
+
+// Rotator periodically archives the standings of the ended seasons and opens the next season.
+//
+// Several replicas can run a rotator on the same database: a season is archived, and the next one is opened, only by
+// the replica whose write wins.
+type Rotator interface {
+	// Start runs a first pass immediately, then one every interval until the context is done.
+	Start(ctx context.Context)
+}
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+type seasonRotator struct {
+	leaderboardRepo leaderboardrepo.LeaderboardRepository
+	cfg             Config
+}
+
+func NewRotator(leaderboardRepo leaderboardrepo.LeaderboardRepository, cfg Config) Rotator {
+	return &seasonRotator{
+		leaderboardRepo: leaderboardRepo,
+		cfg:             cfg,
+	}
+}
+
+func (r *seasonRotator) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			r.rotate()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (r *seasonRotator) rotate() {
+	rotatedAt := now()
+
+	ended, err := r.leaderboardRepo.ListUnarchivedSeasons(rotatedAt)
+	if err != nil {
+		log.Printf("Failed to list the ended seasons: %v", err)
+		return
+	}
+	for _, season := range ended {
+		err := r.leaderboardRepo.ArchiveSeason(season.ID, rotatedAt)
+		if errors.Is(err, leaderboardrepo.ErrSeasonArchived) {
+			// Another replica archived the season since it was listed.
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to archive season %d: %v", season.Number, err)
+			continue
+		}
+		log.Printf("Archived the standings of season %d", season.Number)
+	}
+
+	_, err = r.leaderboardRepo.FindSeasonAt(rotatedAt)
+	if errors.Is(err, leaderboardrepo.ErrSeasonNotFound) {
+		r.open(rotatedAt)
+	} else if err != nil {
+		log.Printf("Failed to find the current season: %v", err)
+	}
+}
+
+// open starts the season running at the given time. Seasons follow each other without gaps: if the server was down
+// for longer than a season, the seasons that nobody played are skipped, but the next one keeps the same schedule.
+func (r *seasonRotator) open(at time.Time) {
+	next := &models.Season{Number: 1, StartsAt: at}
+
+	latest, err := r.leaderboardRepo.LatestSeason()
+	switch {
+	case err == nil:
+		next.Number = latest.Number + 1
+		next.StartsAt = latest.EndsAt
+		if skipped := at.Sub(latest.EndsAt) / r.cfg.Length; skipped > 0 {
+			next.StartsAt = next.StartsAt.Add(skipped * r.cfg.Length)
+		}
+	case !errors.Is(err, leaderboardrepo.ErrSeasonNotFound):
+		log.Printf("Failed to find the latest season: %v", err)
+		return
+	}
+	next.EndsAt = next.StartsAt.Add(r.cfg.Length)
+
+	err = r.leaderboardRepo.CreateSeason(next)
+	if errors.Is(err, leaderboardrepo.ErrSeasonExists) {
+		// Another replica opened the season first.
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to open season %d: %v", next.Number, err)
+		return
+	}
+	log.Printf("Opened season %d, ending at %s", next.Number, next.EndsAt.Format(time.RFC3339))
+}
@@ -0,0 +1,113 @@
+package season
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	leaderboardrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/leaderboard"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var fixtureConfig = Config{Length: 7 * 24 * time.Hour, Interval: time.Minute}
+
+type RotatorTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	leaderboardRepo leaderboardrepo.LeaderboardRepository
+	rotator         *seasonRotator
+	originalNow     func() time.Time
+}
+
+func (s *RotatorTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *RotatorTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *RotatorTestSuite) SetupTest() {
+	tables := []any{&models.User{}, &models.Season{}, &models.Standing{}, &models.ArchivedStanding{}}
+	s.Require().NoError(s.db.Migrator().DropTable(tables...))
+	s.Require().NoError(s.db.AutoMigrate(tables...))
+
+	s.leaderboardRepo = leaderboardrepo.NewSQLLeaderboardRepository(s.db)
+	s.rotator = NewRotator(s.leaderboardRepo, fixtureConfig).(*seasonRotator)
+
+	s.originalNow = now
+	now = func() time.Time { return fixtureNow }
+}
+
+func (s *RotatorTestSuite) TearDownTest() {
+	now = s.originalNow
+}
+
+func (s *RotatorTestSuite) TestRotateOpensTheFirstSeason() {
+	s.rotator.rotate()
+
+	season, err := s.leaderboardRepo.FindSeasonAt(fixtureNow)
+	s.Require().NoError(err)
+	s.Equal(1, season.Number)
+	s.Equal(fixtureNow, season.StartsAt.UTC())
+	s.Equal(fixtureNow.Add(fixtureConfig.Length), season.EndsAt.UTC())
+}
+
+func (s *RotatorTestSuite) TestRotateKeepsTheRunningSeason() {
+	s.rotator.rotate()
+	now = func() time.Time { return fixtureNow.Add(time.Hour) }
+
+	s.rotator.rotate()
+
+	seasons, err := s.leaderboardRepo.ListSeasons()
+	s.NoError(err)
+	s.Len(seasons, 1)
+}
+
+func (s *RotatorTestSuite) TestRotateArchivesTheEndedSeasonAndOpensTheNextOne() {
+	s.rotator.rotate()
+	user := models.User{Username: "player", Password: "password"}
+	opponent := models.User{Username: "opponent", Password: "password"}
+	s.Require().NoError(s.db.Create(&user).Error)
+	s.Require().NoError(s.db.Create(&opponent).Error)
+	s.Require().NoError(s.leaderboardRepo.RecordGame([]uint{user.ID}, []uint{opponent.ID}, fixtureNow))
+	endsAt := fixtureNow.Add(fixtureConfig.Length)
+	now = func() time.Time { return endsAt.Add(time.Minute) }
+
+	s.rotator.rotate()
+
+	seasons, err := s.leaderboardRepo.ListSeasons()
+	s.NoError(err)
+	s.Require().Len(seasons, 2)
+	s.Equal(2, seasons[0].Number)
+	s.Equal(endsAt, seasons[0].StartsAt.UTC())
+	s.Nil(seasons[0].ArchivedAt)
+	s.NotNil(seasons[1].ArchivedAt)
+	entry, err := s.leaderboardRepo.FindEntry(seasons[1], models.RankingByWins, user.ID)
+	s.NoError(err)
+	s.Equal(1, entry.Rank)
+}
+
+func (s *RotatorTestSuite) TestRotateSkipsTheSeasonsNobodyPlayed() {
+	s.rotator.rotate()
+	now = func() time.Time { return fixtureNow.Add(3*fixtureConfig.Length + time.Hour) }
+
+	s.rotator.rotate()
+
+	latest, err := s.leaderboardRepo.LatestSeason()
+	s.NoError(err)
+	s.Equal(2, latest.Number)
+	s.Equal(fixtureNow.Add(3*fixtureConfig.Length), latest.StartsAt.UTC())
+}
+
+func TestRotator(t *testing.T) {
+	suite.Run(t, new(RotatorTestSuite))
+}
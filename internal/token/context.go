@@ -0,0 +1,17 @@
+package token
+
+import "context"
+
+type tokenKey struct{}
+
+// NewContext returns a copy of the context that carries the token of the signed-in user, so that the requests made
+// on their behalf can authenticate them.
+func NewContext(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// FromContext returns the token carried by the context, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	return token, ok
+}
@@ -0,0 +1,81 @@
+package watchdog
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/scheduler"
+)
+
+// Config tells the watchdog how often to look at the connections of the players.
+type Config struct {
+	Interval time.Duration
+}
+
+// Watchdog periodically records which players of the games in progress disconnected or came back, from their
+// heartbeats.
+//
+// The watchdog only fires the RECONNECT timer of the lobbies whose players changed: the lobby service runs the grace
+// periods and applies the disconnect policy. Several replicas can run a watchdog on the same database, since the
+// timer handler is idempotent.
+type Watchdog interface {
+	// Start runs a first pass immediately, then one every interval until the context is done.
+	Start(ctx context.Context)
+}
+
+// package-level variable used for test purpose only.
+var now = func() time.Time { return time.Now().UTC() }
+
+type connectionWatchdog struct {
+	lobbyRepo lobbyrepo.LobbyRepository
+	scheduler scheduler.Scheduler
+	cfg       Config
+}
+
+func NewWatchdog(lobbyRepo lobbyrepo.LobbyRepository, lobbyScheduler scheduler.Scheduler, cfg Config) Watchdog {
+	return &connectionWatchdog{
+		lobbyRepo: lobbyRepo,
+		scheduler: lobbyScheduler,
+		cfg:       cfg,
+	}
+}
+
+func (w *connectionWatchdog) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			w.check()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// check syncs the connections of the players and returns how many lobbies it fired the RECONNECT timer of.
+func (w *connectionWatchdog) check() int {
+	checkedAt := now()
+	lobbyIDs, err := w.lobbyRepo.SyncConnections(checkedAt)
+	if err != nil {
+		log.Printf("Failed to sync the connections of the players: %v", err)
+		return 0
+	}
+
+	fired := 0
+	for _, lobbyID := range lobbyIDs {
+		if err := w.scheduler.Schedule(lobbyID, models.LobbyTimerReconnect, checkedAt); err != nil {
+			log.Printf("Failed to check the disconnected players of lobby %s: %v", lobbyID, err)
+			continue
+		}
+		fired++
+	}
+	return fired
+}
@@ -0,0 +1,163 @@
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NicoPolazzi/multiplayer-queue/internal/models"
+	lobbyrepo "github.com/NicoPolazzi/multiplayer-queue/internal/repository/lobby"
+	"github.com/NicoPolazzi/multiplayer-queue/internal/scheduler"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+var fixtureNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var fixtureConfig = Config{Interval: 5 * time.Second}
+
+type MockScheduler struct {
+	mock.Mock
+}
+
+func (m *MockScheduler) Handle(kind models.LobbyTimerKind, handler scheduler.Handler) {
+	m.Called(kind, handler)
+}
+
+func (m *MockScheduler) Schedule(lobbyID string, kind models.LobbyTimerKind, firesAt time.Time) error {
+	args := m.Called(lobbyID, kind, firesAt)
+	return args.Error(0)
+}
+
+func (m *MockScheduler) Cancel(lobbyID string, kind models.LobbyTimerKind) error {
+	args := m.Called(lobbyID, kind)
+	return args.Error(0)
+}
+
+func (m *MockScheduler) Start(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+type WatchdogTestSuite struct {
+	suite.Suite
+	db          *gorm.DB
+	lobbyRepo   lobbyrepo.LobbyRepository
+	scheduler   *MockScheduler
+	watchdog    *connectionWatchdog
+	originalNow func() time.Time
+}
+
+func (s *WatchdogTestSuite) SetupSuite() {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	s.Require().NoError(err, "Failed to connect to the database")
+	s.db = db
+}
+
+func (s *WatchdogTestSuite) TearDownSuite() {
+	db, _ := s.db.DB()
+	err := db.Close()
+	s.Require().NoError(err, "Failed to close the database connection")
+}
+
+func (s *WatchdogTestSuite) SetupTest() {
+	tables := []any{&models.User{}, &models.Lobby{}, &models.LobbyPlayer{}, &models.LobbySpectator{}, &models.LobbyTimer{},
+		&models.Presence{}}
+	err := s.db.Migrator().DropTable(tables...)
+	s.Require().NoError(err)
+	err = s.db.AutoMigrate(tables...)
+	s.Require().NoError(err)
+
+	s.lobbyRepo = lobbyrepo.NewSQLLobbyRepository(s.db)
+	s.scheduler = new(MockScheduler)
+	s.watchdog = NewWatchdog(s.lobbyRepo, s.scheduler, fixtureConfig).(*connectionWatchdog)
+
+	s.originalNow = now
+	now = func() time.Time { return fixtureNow }
+}
+
+func (s *WatchdogTestSuite) TearDownTest() {
+	now = s.originalNow
+}
+
+// createGame stores a lobby with a single player, whose heartbeats expire at expiresAt.
+func (s *WatchdogTestSuite) createGame(lobbyID string, status models.LobbyStatus, expiresAt time.Time) *models.User {
+	player := models.User{Username: "player-" + lobbyID, Password: "password"}
+	s.Require().NoError(s.db.Create(&player).Error)
+	lobby := models.Lobby{
+		LobbyID: lobbyID,
+		Name:    lobbyID,
+		Status:  status,
+		Players: []models.LobbyPlayer{{UserID: player.ID}},
+	}
+	s.Require().NoError(s.db.Create(&lobby).Error)
+	s.Require().NoError(s.db.Create(&models.Presence{UserID: player.ID, ExpiresAt: expiresAt}).Error)
+	return &player
+}
+
+func (s *WatchdogTestSuite) disconnectedAt(lobbyID string) *time.Time {
+	var membership models.LobbyPlayer
+	s.Require().NoError(s.db.First(&membership, "lobby_id = ?", lobbyID).Error)
+	return membership.DisconnectedAt
+}
+
+func (s *WatchdogTestSuite) TestCheckFiresTheTimerOfTheGamesWithDisconnectedPlayers() {
+	expiredAt := fixtureNow.Add(-10 * time.Second)
+	s.createGame("gone", models.LobbyStatusInProgress, expiredAt)
+	s.createGame("here", models.LobbyStatusInProgress, fixtureNow.Add(time.Minute))
+	s.createGame("waiting", models.LobbyStatusWaiting, expiredAt)
+	s.scheduler.On("Schedule", "gone", models.LobbyTimerReconnect, fixtureNow).Return(nil)
+
+	fired := s.watchdog.check()
+
+	s.Equal(1, fired)
+	s.Require().NotNil(s.disconnectedAt("gone"))
+	s.True(s.disconnectedAt("gone").Equal(expiredAt))
+	s.Nil(s.disconnectedAt("here"))
+	s.Nil(s.disconnectedAt("waiting"))
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func (s *WatchdogTestSuite) TestCheckFiresTheTimerOnceThePlayerReconnects() {
+	s.createGame("blip", models.LobbyStatusInProgress, fixtureNow.Add(-10*time.Second))
+	s.scheduler.On("Schedule", "blip", models.LobbyTimerReconnect, mock.AnythingOfType("time.Time")).Return(nil)
+	s.watchdog.check()
+	s.Require().NoError(s.db.Model(&models.Presence{}).Where("1 = 1").Update("expires_at", fixtureNow.Add(time.Minute)).Error)
+
+	fired := s.watchdog.check()
+
+	s.Equal(1, fired)
+	s.Nil(s.disconnectedAt("blip"))
+	s.scheduler.AssertNumberOfCalls(s.T(), "Schedule", 2)
+}
+
+func (s *WatchdogTestSuite) TestCheckDoesNothingWhenNoConnectionChanged() {
+	s.createGame("gone", models.LobbyStatusInProgress, fixtureNow.Add(-10*time.Second))
+	s.scheduler.On("Schedule", "gone", models.LobbyTimerReconnect, mock.AnythingOfType("time.Time")).Return(nil).Once()
+	s.watchdog.check()
+
+	fired := s.watchdog.check()
+
+	s.Zero(fired)
+	s.scheduler.AssertNumberOfCalls(s.T(), "Schedule", 1)
+}
+
+func (s *WatchdogTestSuite) TestCheckKeepsGoingWhenATimerCannotBeScheduled() {
+	expiredAt := fixtureNow.Add(-10 * time.Second)
+	s.createGame("first", models.LobbyStatusInProgress, expiredAt)
+	s.createGame("second", models.LobbyStatusInProgress, expiredAt)
+	s.scheduler.On("Schedule", "first", models.LobbyTimerReconnect, fixtureNow).Return(errors.New("db error"))
+	s.scheduler.On("Schedule", "second", models.LobbyTimerReconnect, fixtureNow).Return(nil)
+
+	fired := s.watchdog.check()
+
+	s.Equal(1, fired)
+	s.scheduler.AssertExpectations(s.T())
+}
+
+func TestWatchdog(t *testing.T) {
+	suite.Run(t, new(WatchdogTestSuite))
+}